@@ -0,0 +1,79 @@
+// Package barcode - проверка штрихкодов товаров.
+package barcode
+
+import (
+	"fmt"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	ean13Length      = 13
+	upcaLength       = 12
+	code128MaxLength = 48
+)
+
+// Validate - проверка штрихкода code для символики symbology, включая контрольную цифру для EAN-13 и UPC-A.
+func Validate(symbology, code string) error {
+	switch symbology {
+	case models.SymbologyEAN13:
+		return validateGTIN(code, ean13Length)
+	case models.SymbologyUPCA:
+		return validateGTIN(code, upcaLength)
+	case models.SymbologyCode128:
+		return validateCode128(code)
+	default:
+		return fmt.Errorf("неизвестная символика штрихкода %s", symbology)
+	}
+}
+
+// CheckDigit - расчет контрольной цифры GTIN (EAN-13, UPC-A) по цифрам без контрольной.
+// Веса 3 и 1 чередуются справа налево, начиная с 3.
+func CheckDigit(digits string) (int, error) {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if d < '0' || d > '9' {
+			return 0, fmt.Errorf("штрихкод должен состоять только из цифр")
+		}
+
+		weight := 1
+		if (len(digits)-1-i)%2 == 0 {
+			weight = 3
+		}
+		sum += int(d-'0') * weight
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+func validateGTIN(code string, length int) error {
+	if len(code) != length {
+		return fmt.Errorf("штрихкод %s должен содержать %d цифр", code, length)
+	}
+
+	check, err := CheckDigit(code[:length-1])
+	if err != nil {
+		return fmt.Errorf("штрихкод %s: %w", code, err)
+	}
+
+	if int(code[length-1]-'0') != check {
+		return fmt.Errorf("неверная контрольная цифра штрихкода %s, ожидается %d", code, check)
+	}
+
+	return nil
+}
+
+func validateCode128(code string) error {
+	if code == "" || len(code) > code128MaxLength {
+		return fmt.Errorf("штрихкод Code128 должен содержать от 1 до %d символов", code128MaxLength)
+	}
+
+	for _, r := range code {
+		if r < ' ' || r > '~' {
+			return fmt.Errorf("штрихкод Code128 %q содержит недопустимый символ %q", code, r)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package barcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func TestValidate_OK(t *testing.T) {
+	cases := []struct {
+		symbology string
+		code      string
+	}{
+		{models.SymbologyEAN13, "4006381333931"},
+		{models.SymbologyEAN13, "4601234567893"},
+		{models.SymbologyUPCA, "036000291452"},
+		{models.SymbologyCode128, "SKU-0001/A"},
+	}
+
+	for _, tc := range cases {
+		assert.NoError(t, Validate(tc.symbology, tc.code), tc.code)
+	}
+}
+
+func TestValidate_ErrWrongCheckDigit(t *testing.T) {
+	err := Validate(models.SymbologyEAN13, "4006381333932")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "контрольная цифра")
+}
+
+func TestValidate_ErrWrongLength(t *testing.T) {
+	err := Validate(models.SymbologyUPCA, "03600029145")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "12 цифр")
+}
+
+func TestValidate_ErrNotDigits(t *testing.T) {
+	err := Validate(models.SymbologyEAN13, "40063813339A1")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "только из цифр")
+}
+
+func TestValidate_ErrCode128InvalidChar(t *testing.T) {
+	err := Validate(models.SymbologyCode128, "SKU\tTAB")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "недопустимый символ")
+}
+
+func TestValidate_ErrUnknownSymbology(t *testing.T) {
+	err := Validate("qr", "anything")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "неизвестная символика")
+}
@@ -1,11 +1,27 @@
 package config
 
+import (
+	"strings"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
 type Config struct {
 	HTTPPort  string `mapstructure:"HTTP_PORT"`
 	LogLevel  string `mapstructure:"LOG_LEVEL"`
 	JWTSecret string `mapstructure:"JWT_SECRET"`
 
-	DB DBConfig `mapstructure:",squash"`
+	DB           DBConfig          `mapstructure:",squash"`
+	Reservations ReservationConfig `mapstructure:",squash"`
+	Alerts       AlertConfig       `mapstructure:",squash"`
+	Purchasing   PurchaseConfig    `mapstructure:",squash"`
+	Counting     CountConfig       `mapstructure:",squash"`
+	WriteOffs    WriteOffConfig    `mapstructure:",squash"`
+	Approvals    ApprovalConfig    `mapstructure:",squash"`
+	Analysis     AnalysisConfig    `mapstructure:",squash"`
+	Forecast     ForecastConfig    `mapstructure:",squash"`
+	Dashboard    DashboardConfig   `mapstructure:",squash"`
 }
 
 type DBConfig struct {
@@ -13,3 +29,65 @@ type DBConfig struct {
 	MaxOpenConns int    `mapstructure:"DB_MAX_OPEN_CONNS"`
 	MaxIdleConns int    `mapstructure:"DB_MAX_IDLE_CONNS"`
 }
+
+type ReservationConfig struct {
+	DefaultTTL    time.Duration `mapstructure:"RESERVATION_DEFAULT_TTL"`
+	SweepInterval time.Duration `mapstructure:"RESERVATION_SWEEP_INTERVAL"`
+}
+
+type AlertConfig struct {
+	CheckInterval time.Duration `mapstructure:"STOCK_ALERT_CHECK_INTERVAL"`
+}
+
+type PurchaseConfig struct {
+	OverReceiptTolerance int `mapstructure:"PO_OVER_RECEIPT_TOLERANCE"`
+}
+
+type CountConfig struct {
+	RecountThreshold int `mapstructure:"COUNT_RECOUNT_THRESHOLD"`
+}
+
+type WriteOffConfig struct {
+	ApprovalQty   int    `mapstructure:"WRITE_OFF_APPROVAL_QTY"`
+	ApprovalValue string `mapstructure:"WRITE_OFF_APPROVAL_VALUE"`
+}
+
+type ApprovalConfig struct {
+	Operations          string `mapstructure:"APPROVAL_OPERATIONS"`
+	AdjustmentThreshold int    `mapstructure:"APPROVAL_ADJUSTMENT_THRESHOLD"`
+}
+
+type AnalysisConfig struct {
+	Interval     time.Duration `mapstructure:"ANALYSIS_INTERVAL"`
+	PeriodMonths int           `mapstructure:"ANALYSIS_PERIOD_MONTHS"`
+	SlowDays     int           `mapstructure:"ANALYSIS_SLOW_DAYS"`
+	DeadDays     int           `mapstructure:"ANALYSIS_DEAD_DAYS"`
+}
+
+type ForecastConfig struct {
+	HistoryMonths   int     `mapstructure:"FORECAST_HISTORY_MONTHS"`
+	ServiceLevel    float64 `mapstructure:"FORECAST_SERVICE_LEVEL"`
+	DefaultLeadDays int     `mapstructure:"FORECAST_DEFAULT_LEAD_DAYS"`
+}
+
+type DashboardConfig struct {
+	CacheTTL time.Duration `mapstructure:"DASHBOARD_CACHE_TTL"`
+}
+
+// ApprovalAmount - порог стоимости списания в базовой валюте, значение проверяется в GetConfig.
+func (c WriteOffConfig) ApprovalAmount() models.Money {
+	amount, _ := models.ParseMoney(c.ApprovalValue)
+	return amount
+}
+
+// OperationList - список операций, требующих согласования, из значения через запятую.
+func (c ApprovalConfig) OperationList() []string {
+	var ops []string
+	for _, op := range strings.Split(c.Operations, ",") {
+		if op = strings.TrimSpace(op); op != "" {
+			ops = append(ops, op)
+		}
+	}
+
+	return ops
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/wb-go/wbf/config"
 	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
 )
 
 func GetConfig() (*Config, error) {
@@ -21,10 +23,52 @@ func GetConfig() (*Config, error) {
 	cfg.SetDefault("DB_MAX_OPEN_CONNS", 10)
 	cfg.SetDefault("DB_MAX_IDLE_CONNS", 2)
 
+	cfg.SetDefault("RESERVATION_DEFAULT_TTL", "24h")
+	cfg.SetDefault("RESERVATION_SWEEP_INTERVAL", "1m")
+
+	cfg.SetDefault("STOCK_ALERT_CHECK_INTERVAL", "5m")
+
+	cfg.SetDefault("PO_OVER_RECEIPT_TOLERANCE", 10)
+
+	cfg.SetDefault("COUNT_RECOUNT_THRESHOLD", 5)
+
+	cfg.SetDefault("WRITE_OFF_APPROVAL_QTY", 20)
+	cfg.SetDefault("WRITE_OFF_APPROVAL_VALUE", "10000")
+
+	cfg.SetDefault("APPROVAL_OPERATIONS", "item_delete,item_adjustment,user_role")
+	cfg.SetDefault("APPROVAL_ADJUSTMENT_THRESHOLD", 100)
+
+	cfg.SetDefault("ANALYSIS_INTERVAL", "24h")
+	cfg.SetDefault("ANALYSIS_PERIOD_MONTHS", 12)
+	cfg.SetDefault("ANALYSIS_SLOW_DAYS", 90)
+	cfg.SetDefault("ANALYSIS_DEAD_DAYS", 180)
+
+	cfg.SetDefault("FORECAST_HISTORY_MONTHS", 24)
+	cfg.SetDefault("FORECAST_SERVICE_LEVEL", 0.95)
+	cfg.SetDefault("FORECAST_DEFAULT_LEAD_DAYS", 14)
+
+	cfg.SetDefault("DASHBOARD_CACHE_TTL", "30s")
+
 	var c Config
 	if err := cfg.Unmarshal(&c); err != nil {
 		return nil, fmt.Errorf("cfg.Unmarshal: %w", err)
 	}
 
+	if c.Reservations.SweepInterval <= 0 {
+		return nil, fmt.Errorf("некорректный RESERVATION_SWEEP_INTERVAL %s: должен быть положительным", c.Reservations.SweepInterval)
+	}
+	if c.Alerts.CheckInterval <= 0 {
+		return nil, fmt.Errorf("некорректный STOCK_ALERT_CHECK_INTERVAL %s: должен быть положительным", c.Alerts.CheckInterval)
+	}
+	if c.Analysis.Interval <= 0 {
+		return nil, fmt.Errorf("некорректный ANALYSIS_INTERVAL %s: должен быть положительным", c.Analysis.Interval)
+	}
+	if amount, err := models.ParseMoney(c.WriteOffs.ApprovalValue); err != nil || amount < 0 {
+		return nil, fmt.Errorf("некорректный WRITE_OFF_APPROVAL_VALUE %q: должен быть неотрицательной суммой", c.WriteOffs.ApprovalValue)
+	}
+	if c.Forecast.ServiceLevel <= 0 || c.Forecast.ServiceLevel >= 1 {
+		return nil, fmt.Errorf("некорректный FORECAST_SERVICE_LEVEL %v: должен быть строго между 0 и 1", c.Forecast.ServiceLevel)
+	}
+
 	return &c, nil
 }
@@ -10,8 +10,34 @@ import (
 	"github.com/sunr3d/warehouse-control/internal/infra/postgres"
 	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
 	"github.com/sunr3d/warehouse-control/internal/server"
+	"github.com/sunr3d/warehouse-control/internal/services/alertsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/analysissvc"
+	"github.com/sunr3d/warehouse-control/internal/services/approvalsvc"
 	"github.com/sunr3d/warehouse-control/internal/services/authsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/categorysvc"
+	"github.com/sunr3d/warehouse-control/internal/services/countsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/currencysvc"
+	"github.com/sunr3d/warehouse-control/internal/services/dashboardsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/forecastsvc"
 	"github.com/sunr3d/warehouse-control/internal/services/inventorysvc"
+	"github.com/sunr3d/warehouse-control/internal/services/kitsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/labelsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/locationsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/lotsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/outboundsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/purchasesvc"
+	"github.com/sunr3d/warehouse-control/internal/services/replenishmentsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/reportsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/reservationsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/returnsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/serialsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/stockstatussvc"
+	"github.com/sunr3d/warehouse-control/internal/services/suppliersvc"
+	"github.com/sunr3d/warehouse-control/internal/services/unitsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/usersvc"
+	"github.com/sunr3d/warehouse-control/internal/services/valuationsvc"
+	"github.com/sunr3d/warehouse-control/internal/services/writeoffsvc"
+	"github.com/sunr3d/warehouse-control/models"
 )
 
 func RunApp(ctx context.Context, cfg *config.Config) error {
@@ -27,11 +53,46 @@ func RunApp(ctx context.Context, cfg *config.Config) error {
 	}(repo)
 
 	// Сервисный слой (Application / Use Cases layer)
+	approvalPolicy := models.ApprovalPolicy{
+		Operations:          cfg.Approvals.OperationList(),
+		AdjustmentThreshold: cfg.Approvals.AdjustmentThreshold,
+	}
 	authSvc := authsvc.New(repo, cfg.JWTSecret)
-	invSvc := inventorysvc.New(repo)
+	alertSvc := alertsvc.New(repo)
+	stockChecker := alertsvc.NewChecker(alertSvc, cfg.Alerts.CheckInterval)
+	invSvc := inventorysvc.New(repo, stockChecker, approvalPolicy)
+	resSvc := reservationsvc.New(repo, cfg.Reservations.DefaultTTL)
+	lotSvc := lotsvc.New(repo, stockChecker)
+	serialSvc := serialsvc.New(repo)
+	locSvc := locationsvc.New(repo)
+	labelSvc := labelsvc.New(repo)
+	catSvc := categorysvc.New(repo)
+	unitSvc := unitsvc.New(repo)
+	supplierSvc := suppliersvc.New(repo)
+	purchaseSvc := purchasesvc.New(repo, stockChecker, cfg.Purchasing.OverReceiptTolerance)
+	outboundSvc := outboundsvc.New(repo, stockChecker)
+	replenishmentSvc := replenishmentsvc.New(repo)
+	countSvc := countsvc.New(repo, stockChecker, cfg.Counting.RecountThreshold)
+	returnSvc := returnsvc.New(repo, stockChecker)
+	stockStatusSvc := stockstatussvc.New(repo, stockChecker)
+	writeOffSvc := writeoffsvc.New(repo, stockChecker, cfg.WriteOffs.ApprovalQty, cfg.WriteOffs.ApprovalAmount())
+	approvalSvc := approvalsvc.New(repo, stockChecker)
+	userSvc := usersvc.New(repo, approvalPolicy)
+	valuationSvc := valuationsvc.New(repo)
+	currencySvc := currencysvc.New(repo)
+	kitSvc := kitsvc.New(repo, stockChecker)
+	analysisSvc := analysissvc.New(repo, cfg.Analysis.PeriodMonths, cfg.Analysis.SlowDays, cfg.Analysis.DeadDays)
+	dashboardSvc := dashboardsvc.New(repo, cfg.Dashboard.CacheTTL)
+	reportSvc := reportsvc.New(repo)
+	forecastSvc := forecastsvc.New(repo, stockChecker, cfg.Forecast.HistoryMonths, cfg.Forecast.ServiceLevel, cfg.Forecast.DefaultLeadDays)
+
+	// Фоновые процессы
+	go reservationsvc.NewSweeper(resSvc, cfg.Reservations.SweepInterval).Run(ctx)
+	go stockChecker.Run(ctx)
+	go analysissvc.NewRunner(analysisSvc, cfg.Analysis.Interval).Run(ctx)
 
 	// Слой представления (Presentation layer)
-	h := httphandlers.New(authSvc, invSvc)
+	h := httphandlers.New(authSvc, invSvc, resSvc, lotSvc, serialSvc, locSvc, labelSvc, catSvc, unitSvc, alertSvc, supplierSvc, purchaseSvc, outboundSvc, replenishmentSvc, countSvc, returnSvc, stockStatusSvc, writeOffSvc, approvalSvc, userSvc, valuationSvc, currencySvc, kitSvc, analysisSvc, forecastSvc, dashboardSvc, reportSvc)
 	engine := h.RegisterHandlers()
 
 	// Сервер
@@ -0,0 +1,184 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// setStockLevel - handler для задания минимума, точки заказа и максимума item на весь склад или на ячейку.
+func (h *handler) setStockLevel(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setStockLevel: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req stockLevelReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setStockLevel: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	level := &models.StockLevel{
+		ItemID:       itemID,
+		LocationID:   req.LocationID,
+		MinQty:       req.MinQty,
+		ReorderPoint: req.ReorderPoint,
+		MaxQty:       req.MaxQty,
+	}
+
+	if err := h.alertSvc.SetStockLevel(c.Request.Context(), level); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "некорректные"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("setStockLevel: не удалось сохранить уровни запаса")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось сохранить уровни запаса"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Int("reorder_point", level.ReorderPoint).
+		Msg("setStockLevel: уровни запаса успешно сохранены")
+
+	c.JSON(http.StatusOK, ginext.H{
+		"item_id":       itemID,
+		"location_id":   level.LocationID,
+		"min_qty":       level.MinQty,
+		"reorder_point": level.ReorderPoint,
+		"max_qty":       level.MaxQty,
+	})
+}
+
+// getLowStockReport - handler для отчета по items, доступный остаток которых достиг точки заказа.
+func (h *handler) getLowStockReport(c *ginext.Context) {
+	positions, err := h.alertSvc.GetLowStock(c.Request.Context())
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("getLowStockReport: не удалось получить отчет")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить отчет"})
+		return
+	}
+
+	resp := make([]lowStockResp, 0, len(positions))
+	for _, pos := range positions {
+		resp = append(resp, lowStockResp{
+			ItemID:         pos.ItemID,
+			ItemName:       pos.ItemName,
+			LocationID:     pos.LocationID,
+			LocationCode:   pos.LocationCode,
+			Quantity:       pos.Quantity,
+			Reserved:       pos.Reserved,
+			Available:      pos.Available(),
+			MinQty:         pos.MinQty,
+			ReorderPoint:   pos.ReorderPoint,
+			MaxQty:         pos.MaxQty,
+			BelowMin:       pos.Available() < pos.MinQty,
+			SuggestedOrder: pos.SuggestedOrder(),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getStockAlerts - handler для получения оповещений о низком остатке, опционально по статусу.
+func (h *handler) getStockAlerts(c *ginext.Context) {
+	alerts, err := h.alertSvc.GetAlerts(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		if strings.Contains(err.Error(), "некорректный") {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Msg("getStockAlerts: не удалось получить оповещения")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить оповещения"})
+		return
+	}
+
+	resp := make([]stockAlertResp, 0, len(alerts))
+	for _, alert := range alerts {
+		resp = append(resp, stockAlertResp{
+			ID:             alert.ID,
+			ItemID:         alert.ItemID,
+			ItemName:       alert.ItemName,
+			LocationID:     alert.LocationID,
+			LocationCode:   alert.LocationCode,
+			Available:      alert.Available,
+			ReorderPoint:   alert.ReorderPoint,
+			Status:         alert.Status,
+			AcknowledgedBy: alert.AcknowledgedBy,
+			AcknowledgedAt: formatOptionalTime(alert.AcknowledgedAt),
+			ResolvedAt:     formatOptionalTime(alert.ResolvedAt),
+			CreatedAt:      alert.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// acknowledgeStockAlert - handler для подтверждения оповещения о низком остатке.
+func (h *handler) acknowledgeStockAlert(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("acknowledgeStockAlert: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.alertSvc.AcknowledgeAlert(c.Request.Context(), userID, id); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найдено"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "нельзя"):
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Int("alert_id", id).
+				Msg("acknowledgeStockAlert: не удалось подтвердить оповещение")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось подтвердить оповещение"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("alert_id", id).
+		Msg("acknowledgeStockAlert: оповещение подтверждено")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": models.AlertAcknowledged})
+}
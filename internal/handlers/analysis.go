@@ -0,0 +1,77 @@
+package httphandlers
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// getABCReport - handler для отчета ABC/XYZ по последнему анализу запаса.
+// Фильтры abc_class, xyz_class и movement_status ограничивают список items, сводка считается по всем.
+func (h *handler) getABCReport(c *ginext.Context) {
+	filter := models.AnalysisFilter{
+		ABCClass:       c.Query("abc_class"),
+		XYZClass:       c.Query("xyz_class"),
+		MovementStatus: c.Query("movement_status"),
+	}
+
+	report, err := h.analysisSvc.GetABCReport(c.Request.Context(), filter)
+	if err != nil {
+		if strings.Contains(err.Error(), "некорректн") {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Msg("getABCReport: не удалось получить отчет")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить отчет"})
+		return
+	}
+
+	now := time.Now()
+	resp := abcReportResp{
+		AnalyzedAt: formatOptionalTime(report.AnalyzedAt),
+		PeriodFrom: formatOptionalDate(report.PeriodFrom),
+		PeriodTo:   formatOptionalDate(report.PeriodTo),
+		Classes:    make([]abcClassResp, 0, len(report.Classes)),
+		Matrix:     report.Matrix,
+		Items:      make([]itemAnalysisResp, 0, len(report.Items)),
+	}
+	for _, class := range report.Classes {
+		resp.Classes = append(resp.Classes, abcClassResp{
+			Class:      class.Class,
+			Items:      class.Items,
+			Value:      class.Value,
+			ValueShare: roundShare(class.ValueShare),
+		})
+	}
+	for _, a := range report.Items {
+		resp.Items = append(resp.Items, itemAnalysisResp{
+			ItemID:            a.ItemID,
+			Name:              a.ItemName,
+			ABCClass:          a.ABCClass,
+			XYZClass:          a.XYZClass,
+			ConsumptionQty:    a.ConsumptionQty,
+			ConsumptionValue:  a.ConsumptionValue,
+			ValueShare:        roundShare(a.ValueShare),
+			DemandCV:          a.DemandCV,
+			OnHand:            a.OnHand,
+			LastMovementAt:    a.LastMovementAt.Format(time.RFC3339),
+			DaysSinceMovement: a.DaysSinceMovement(now),
+			MovementStatus:    a.MovementStatus,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// roundShare - доля в процентах с точностью до сотых.
+func roundShare(share float64) float64 {
+	return math.Round(share*100) / 100
+}
@@ -0,0 +1,164 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// getChangeRequests - handler для получения запросов на согласование, опционально по статусу.
+func (h *handler) getChangeRequests(c *ginext.Context) {
+	crs, err := h.approvalSvc.GetChangeRequests(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		approvalError(c, "getChangeRequests", "не удалось получить запросы на согласование", err)
+		return
+	}
+
+	resp := make([]changeRequestResp, 0, len(crs))
+	for _, cr := range crs {
+		resp = append(resp, toChangeRequestResp(cr))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getChangeRequest - handler для получения запроса на согласование.
+func (h *handler) getChangeRequest(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getChangeRequest: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	cr, err := h.approvalSvc.GetChangeRequest(c.Request.Context(), id)
+	if err != nil {
+		approvalError(c, "getChangeRequest", "не удалось получить запрос на согласование", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toChangeRequestResp(*cr))
+}
+
+// approveChangeRequest - handler для согласования запроса и выполнения операции вторым пользователем.
+func (h *handler) approveChangeRequest(c *ginext.Context) {
+	h.decideChangeRequest(c, "approveChangeRequest", models.ChangeRequestApproved)
+}
+
+// rejectChangeRequest - handler для отклонения запроса на согласование.
+func (h *handler) rejectChangeRequest(c *ginext.Context) {
+	h.decideChangeRequest(c, "rejectChangeRequest", models.ChangeRequestRejected)
+}
+
+// decideChangeRequest - общая часть согласования и отклонения запроса.
+func (h *handler) decideChangeRequest(c *ginext.Context, op, status string) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg(op + ": некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req changeRequestDecisionReq
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			zlog.Logger.Warn().
+				Err(err).
+				Msg(op + ": некорректный JSON запрос")
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+			return
+		}
+	}
+
+	if status == models.ChangeRequestApproved {
+		err = h.approvalSvc.ApproveChangeRequest(c.Request.Context(), userID, id, req.Note)
+	} else {
+		err = h.approvalSvc.RejectChangeRequest(c.Request.Context(), userID, id, req.Note)
+	}
+	if err != nil {
+		approvalError(c, op, "не удалось принять решение по запросу", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("change_request_id", id).
+		Str("status", status).
+		Msg(op + ": решение по запросу принято")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": status})
+}
+
+// cancelChangeRequest - handler для отмены запроса его автором.
+func (h *handler) cancelChangeRequest(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("cancelChangeRequest: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.approvalSvc.CancelChangeRequest(c.Request.Context(), userID, id); err != nil {
+		approvalError(c, "cancelChangeRequest", "не удалось отменить запрос", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("change_request_id", id).
+		Msg("cancelChangeRequest: запрос отменен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": models.ChangeRequestCancelled})
+}
+
+// approvalError - ответ на ошибку операции с запросами на согласование.
+func approvalError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"), strings.Contains(err.Error(), "уже существует"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
+
+func toChangeRequestResp(cr models.ChangeRequest) changeRequestResp {
+	return changeRequestResp{
+		ID:           cr.ID,
+		Operation:    cr.Operation,
+		TargetID:     cr.TargetID,
+		Payload:      cr.Payload,
+		Summary:      cr.Summary,
+		Status:       cr.Status,
+		RequestedBy:  cr.RequestedBy,
+		DecidedBy:    cr.DecidedBy,
+		DecisionNote: cr.DecisionNote,
+		DecidedAt:    formatOptionalTime(cr.DecidedAt),
+		CreatedAt:    cr.CreatedAt.Format(time.RFC3339),
+	}
+}
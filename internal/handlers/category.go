@@ -0,0 +1,312 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createCategory - handler для создания категории.
+func (h *handler) createCategory(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req categoryReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createCategory: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	cat := &models.Category{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	}
+
+	id, err := h.catSvc.CreateCategory(c.Request.Context(), cat)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найдена"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "уже существует"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Str("category_name", cat.Name).
+				Msg("createCategory: категория уже существует")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "некорректное"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Str("category_name", cat.Name).
+				Msg("createCategory: не удалось создать категорию")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось создать категорию"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("category_id", id).
+		Str("category_name", cat.Name).
+		Msg("createCategory: категория успешно создана")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id})
+}
+
+// getCategories - handler для получения дерева категорий с количеством items.
+func (h *handler) getCategories(c *ginext.Context) {
+	tree, err := h.catSvc.GetCategoryTree(c.Request.Context())
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("getCategories: не удалось получить категории")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить категории"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toCategoryResps(tree))
+}
+
+// moveCategory - handler для перемещения категории в другую родительскую категорию.
+func (h *handler) moveCategory(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("moveCategory: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req moveCategoryReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("moveCategory: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if err := h.catSvc.MoveCategory(c.Request.Context(), id, req.ParentID); err != nil {
+		categoryError(c, "moveCategory", userID, id, err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("category_id", id).
+		Msg("moveCategory: категория успешно перемещена")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "parent_id": req.ParentID})
+}
+
+// mergeCategory - handler для объединения категории с целевой.
+func (h *handler) mergeCategory(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("mergeCategory: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req mergeCategoryReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("mergeCategory: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	moved, err := h.catSvc.MergeCategory(c.Request.Context(), userID, id, req.TargetID)
+	if err != nil {
+		categoryError(c, "mergeCategory", userID, id, err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("category_id", id).
+		Int("target_id", req.TargetID).
+		Int("items_moved", moved).
+		Msg("mergeCategory: категории успешно объединены")
+
+	c.JSON(http.StatusOK, ginext.H{"target_id": req.TargetID, "items_moved": moved})
+}
+
+// createCategoryAttribute - handler для добавления атрибута в схему категории.
+func (h *handler) createCategoryAttribute(c *ginext.Context) {
+	categoryID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createCategoryAttribute: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req attributeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createCategoryAttribute: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	def := &models.AttributeDefinition{
+		CategoryID:    categoryID,
+		Code:          req.Code,
+		Name:          req.Name,
+		Type:          req.Type,
+		Required:      req.Required,
+		AllowedValues: req.AllowedValues,
+		Unit:          req.Unit,
+	}
+
+	id, err := h.catSvc.CreateAttribute(c.Request.Context(), def)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найдена"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "уже существует"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("category_id", categoryID).
+				Str("attr_code", def.Code).
+				Msg("createCategoryAttribute: атрибут уже существует")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "некорректн"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Int("category_id", categoryID).
+				Str("attr_code", def.Code).
+				Msg("createCategoryAttribute: не удалось создать атрибут")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось создать атрибут"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("category_id", categoryID).
+		Int("attribute_id", id).
+		Str("attr_code", def.Code).
+		Msg("createCategoryAttribute: атрибут успешно создан")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id, "code": def.Code})
+}
+
+// getCategoryAttributes - handler для получения схемы атрибутов категории вместе с унаследованными.
+func (h *handler) getCategoryAttributes(c *ginext.Context) {
+	categoryID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getCategoryAttributes: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	defs, err := h.catSvc.GetCategoryAttributes(c.Request.Context(), categoryID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", categoryID).
+			Msg("getCategoryAttributes: не удалось получить атрибуты категории")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить атрибуты категории"})
+		return
+	}
+
+	resp := make([]attributeResp, 0, len(defs))
+	for _, def := range defs {
+		allowed := def.AllowedValues
+		if allowed == nil {
+			allowed = []string{}
+		}
+		resp = append(resp, attributeResp{
+			ID:            def.ID,
+			CategoryID:    def.CategoryID,
+			Code:          def.Code,
+			Name:          def.Name,
+			Type:          def.Type,
+			Required:      def.Required,
+			AllowedValues: allowed,
+			Unit:          def.Unit,
+			Inherited:     def.CategoryID != categoryID,
+			CreatedAt:     def.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// categoryError - ответ на ошибку изменения дерева категорий.
+func categoryError(c *ginext.Context, op string, userID, id int, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найдена"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"), strings.Contains(err.Error(), "уже существует"):
+		zlog.Logger.Warn().
+			Err(err).
+			Int("user_id", userID).
+			Int("category_id", id).
+			Msg(op + ": конфликт в дереве категорий")
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("category_id", id).
+			Msg(op + ": не удалось изменить дерево категорий")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось изменить дерево категорий"})
+	}
+}
+
+func toCategoryResps(categories []models.Category) []categoryResp {
+	resp := make([]categoryResp, 0, len(categories))
+	for _, cat := range categories {
+		resp = append(resp, categoryResp{
+			ID:         cat.ID,
+			ParentID:   cat.ParentID,
+			Name:       cat.Name,
+			ItemCount:  cat.ItemCount,
+			TotalCount: cat.TotalCount,
+			Children:   toCategoryResps(cat.Children),
+			CreatedAt:  cat.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:  cat.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,288 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createCountSession - handler для открытия сессии инвентаризации.
+func (h *handler) createCountSession(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req countSessionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createCountSession: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	session := &models.CountSession{
+		LocationID:       req.LocationID,
+		CategoryID:       req.CategoryID,
+		Blind:            req.Blind,
+		RecountThreshold: -1,
+	}
+	if req.RecountThreshold != nil {
+		session.RecountThreshold = *req.RecountThreshold
+	}
+
+	id, number, err := h.countSvc.CreateSession(c.Request.Context(), userID, session)
+	if err != nil {
+		countError(c, "createCountSession", "не удалось открыть сессию", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("session_id", id).
+		Str("session_number", number).
+		Msg("createCountSession: сессия инвентаризации открыта")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id, "number": number, "status": models.CountOpen})
+}
+
+// getCountSessions - handler для получения сессий инвентаризации, опционально по статусу.
+func (h *handler) getCountSessions(c *ginext.Context) {
+	sessions, err := h.countSvc.GetSessions(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		countError(c, "getCountSessions", "не удалось получить сессии", err)
+		return
+	}
+
+	resp := make([]countSessionResp, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, toCountSessionResp(session))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getCountSession - handler для получения сессии со строками подсчета.
+// В слепой сессии ожидаемый остаток и расхождения скрыты, пока идет подсчет.
+func (h *handler) getCountSession(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getCountSession: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	session, err := h.countSvc.GetSession(c.Request.Context(), id)
+	if err != nil {
+		countError(c, "getCountSession", "не удалось получить сессию", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCountSessionResp(*session))
+}
+
+// recordCounts - handler для записи результатов подсчета.
+func (h *handler) recordCounts(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("recordCounts: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req countEntriesReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("recordCounts: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	counts := make([]models.CountEntry, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		quantity, ok := h.baseQuantity(c, "recordCounts", line.ItemID, *line.Quantity, line.Unit)
+		if !ok {
+			return
+		}
+		counts = append(counts, models.CountEntry{ItemID: line.ItemID, Quantity: quantity})
+	}
+
+	if err := h.countSvc.RecordCounts(c.Request.Context(), userID, id, counts); err != nil {
+		countError(c, "recordCounts", "не удалось записать подсчет", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("session_id", id).
+		Int("lines", len(counts)).
+		Msg("recordCounts: подсчет записан")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "counted": len(counts)})
+}
+
+// submitCountSession - handler для завершения подсчета с запросом пересчета крупных расхождений.
+func (h *handler) submitCountSession(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("submitCountSession: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	status, recount, err := h.countSvc.SubmitSession(c.Request.Context(), id)
+	if err != nil {
+		countError(c, "submitCountSession", "не удалось завершить подсчет", err)
+		return
+	}
+
+	// Пересчет тоже слепой: счетчику отдаются только items без количеств.
+	items := make([]countRecountResp, 0, len(recount))
+	for _, line := range recount {
+		items = append(items, countRecountResp{ItemID: line.ItemID, ItemName: line.ItemName})
+	}
+
+	zlog.Logger.Info().
+		Int("session_id", id).
+		Int("recount", len(items)).
+		Str("status", status).
+		Msg("submitCountSession: подсчет завершен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": status, "recount": items})
+}
+
+// approveCountSession - handler для согласования сессии с корректировкой остатков.
+func (h *handler) approveCountSession(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("approveCountSession: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	adjusted, err := h.countSvc.ApproveSession(c.Request.Context(), userID, id)
+	if err != nil {
+		countError(c, "approveCountSession", "не удалось согласовать сессию", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("session_id", id).
+		Int("adjusted", adjusted).
+		Msg("approveCountSession: сессия согласована")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": models.CountApproved, "adjusted": adjusted})
+}
+
+// cancelCountSession - handler для отмены сессии без корректировок.
+func (h *handler) cancelCountSession(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("cancelCountSession: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.countSvc.CancelSession(c.Request.Context(), id); err != nil {
+		countError(c, "cancelCountSession", "не удалось отменить сессию", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("session_id", id).
+		Msg("cancelCountSession: сессия отменена")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": models.CountCancelled})
+}
+
+// countError - ответ на ошибку операции с сессиями инвентаризации.
+func countError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
+
+func toCountSessionResp(session models.CountSession) countSessionResp {
+	hidden := session.HidesExpected()
+
+	var lines []countLineResp
+	for _, line := range session.Lines {
+		resp := countLineResp{
+			ItemID:          line.ItemID,
+			ItemName:        line.ItemName,
+			CountedQty:      line.CountedQty,
+			Round:           line.Round,
+			RecountRequired: line.RecountRequired,
+			CountedBy:       line.CountedBy,
+			CountedAt:       formatOptionalTime(line.CountedAt),
+		}
+		if !hidden {
+			expected, held, variance := line.ExpectedQty, line.HeldQty, line.Variance()
+			resp.ExpectedQty = &expected
+			resp.HeldQty = &held
+			resp.FirstCountedQty = line.FirstCountedQty
+			if line.CountedQty != nil {
+				resp.Variance = &variance
+			}
+		}
+
+		lines = append(lines, resp)
+	}
+
+	return countSessionResp{
+		ID:               session.ID,
+		Number:           session.Number,
+		LocationID:       session.LocationID,
+		CategoryID:       session.CategoryID,
+		Blind:            session.Blind,
+		RecountThreshold: session.RecountThreshold,
+		Status:           session.Status,
+		ItemsTotal:       session.ItemsTotal,
+		ItemsCounted:     session.ItemsCounted,
+		Lines:            lines,
+		ApprovedBy:       session.ApprovedBy,
+		ApprovedAt:       formatOptionalTime(session.ApprovedAt),
+		CreatedAt:        session.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        session.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,226 @@
+package httphandlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// getCurrencies - handler для получения списка валют.
+func (h *handler) getCurrencies(c *ginext.Context) {
+	currencies, err := h.currencySvc.GetCurrencies(c.Request.Context())
+	if err != nil {
+		currencyError(c, "getCurrencies", "не удалось получить валюты", err)
+		return
+	}
+
+	resp := make([]currencyResp, 0, len(currencies))
+	for _, cur := range currencies {
+		resp = append(resp, currencyResp{
+			Code:      cur.Code,
+			Name:      cur.Name,
+			Base:      cur.Base,
+			CreatedAt: cur.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// createCurrency - handler для добавления валюты.
+func (h *handler) createCurrency(c *ginext.Context) {
+	var req currencyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createCurrency: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	cur := &models.Currency{Code: req.Code, Name: req.Name}
+	if err := h.currencySvc.CreateCurrency(c.Request.Context(), cur); err != nil {
+		currencyError(c, "createCurrency", "не удалось добавить валюту", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Str("currency", cur.Code).
+		Msg("createCurrency: валюта добавлена")
+
+	c.JSON(http.StatusCreated, ginext.H{"code": cur.Code})
+}
+
+// getExchangeRates - handler для получения курсов валют, опционально по валюте и периоду.
+func (h *handler) getExchangeRates(c *ginext.Context) {
+	from, err := parseOptionalDate(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+	to, err := parseOptionalDate(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+
+	filter := models.ExchangeRateFilter{
+		Currency: c.Query("currency"),
+		From:     from,
+		To:       to,
+	}
+	rates, err := h.currencySvc.GetRates(c.Request.Context(), filter)
+	if err != nil {
+		currencyError(c, "getExchangeRates", "не удалось получить курсы", err)
+		return
+	}
+
+	resp := make([]exchangeRateResp, 0, len(rates))
+	for _, rate := range rates {
+		resp = append(resp, exchangeRateResp{
+			ID:        rate.ID,
+			Currency:  rate.Currency,
+			Date:      rate.Date.Format(dateLayout),
+			Rate:      rate.Rate,
+			CreatedBy: rate.CreatedBy,
+			UpdatedAt: rate.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// setExchangeRate - handler для задания курса валюты на дату.
+func (h *handler) setExchangeRate(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req exchangeRateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setExchangeRate: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: дата курса должна быть в формате YYYY-MM-DD"})
+		return
+	}
+
+	rate := &models.ExchangeRate{Currency: req.Currency, Date: date, Rate: req.Rate}
+	id, err := h.currencySvc.SetRate(c.Request.Context(), userID, rate)
+	if err != nil {
+		currencyError(c, "setExchangeRate", "не удалось сохранить курс", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Str("currency", rate.Currency).
+		Str("date", req.Date).
+		Str("rate", rate.Rate.String()).
+		Msg("setExchangeRate: курс сохранен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "currency": rate.Currency, "date": req.Date, "rate": rate.Rate})
+}
+
+// importExchangeRates - handler для загрузки курсов из CSV файла (поле file формы multipart).
+func (h *handler) importExchangeRates(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	// Запас в 1 МБ на заголовки и границы multipart.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.ExchangeRateImportMaxSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("importExchangeRates: не удалось получить файл")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: нужен CSV файл в поле file размером до 1 МБ"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("importExchangeRates: не удалось открыть файл")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось прочитать файл"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, models.ExchangeRateImportMaxSize+1))
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("importExchangeRates: не удалось прочитать файл")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось прочитать файл"})
+		return
+	}
+
+	imported, err := h.currencySvc.ImportRates(c.Request.Context(), userID, data)
+	if err != nil {
+		currencyError(c, "importExchangeRates", "не удалось загрузить курсы", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("imported", imported).
+		Msg("importExchangeRates: курсы загружены")
+
+	c.JSON(http.StatusOK, ginext.H{"imported": imported})
+}
+
+// deleteExchangeRate - handler для удаления курса валюты.
+func (h *handler) deleteExchangeRate(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("deleteExchangeRate: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if err := h.currencySvc.DeleteRate(c.Request.Context(), id); err != nil {
+		currencyError(c, "deleteExchangeRate", "не удалось удалить курс", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("rate_id", id).
+		Msg("deleteExchangeRate: курс удален")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "message": "курс успешно удален"})
+}
+
+// currencyError - ответ на ошибку операции с валютами и курсами.
+func currencyError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"),
+		strings.Contains(err.Error(), "уже существует"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
@@ -0,0 +1,75 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// getDashboard - handler для сводных показателей склада.
+// Для viewer стоимость запаса, активные пользователи и последние изменения не возвращаются.
+func (h *handler) getDashboard(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+
+	dashboard, err := h.dashboardSvc.GetDashboard(c.Request.Context(), claims.Role)
+	if err != nil {
+		if strings.Contains(err.Error(), "нельзя") {
+			c.JSON(http.StatusForbidden, ginext.H{"error": err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", claims.UserID).
+			Msg("getDashboard: не удалось получить показатели")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить показатели"})
+		return
+	}
+
+	resp := dashboardResp{
+		GeneratedAt: dashboard.GeneratedAt.Format(time.RFC3339),
+		SKUs:        dashboard.SKUs,
+		Units:       dashboard.Units,
+		StockOuts:   dashboard.StockOuts,
+		LowStock:    dashboard.LowStock,
+		Today: dashboardFlowResp{
+			InboundQty:        dashboard.Today.InboundQty,
+			InboundMovements:  dashboard.Today.InboundMovements,
+			OutboundQty:       dashboard.Today.OutboundQty,
+			OutboundMovements: dashboard.Today.OutboundMovements,
+		},
+		TopMovers: make([]topMoverResp, 0, len(dashboard.TopMovers)),
+	}
+	for _, m := range dashboard.TopMovers {
+		resp.TopMovers = append(resp.TopMovers, topMoverResp{ItemID: m.ItemID, Name: m.ItemName, Quantity: m.Quantity})
+	}
+
+	if dashboard.Full {
+		resp.Value = &dashboard.Value
+		users := make([]activeUserResp, 0, len(dashboard.ActiveUsers))
+		for _, u := range dashboard.ActiveUsers {
+			users = append(users, activeUserResp{UserID: u.UserID, Username: u.Username, Changes: u.Changes})
+		}
+		changes := make([]recentChangeResp, 0, len(dashboard.RecentChanges))
+		for _, change := range dashboard.RecentChanges {
+			changes = append(changes, recentChangeResp{
+				ID:        change.ID,
+				ItemID:    change.ItemID,
+				ItemName:  change.ItemName,
+				UserID:    change.UserID,
+				Username:  change.Username,
+				Operation: change.Operation,
+				Details:   change.Details,
+				ChangedAt: change.ChangedAt.Format(time.RFC3339),
+			})
+		}
+		resp.ActiveUsers, resp.RecentChanges = &users, &changes
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,137 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	defaultForecastHorizon = 3
+)
+
+// getForecast - handler для прогноза месячного спроса item на horizon месяцев, по умолчанию 3,
+// с рекомендованными страховым запасом и точкой заказа.
+func (h *handler) getForecast(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getForecast: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	horizon := defaultForecastHorizon
+	if horizonStr := c.Query("horizon"); horizonStr != "" {
+		parsed, err := strconv.Atoi(horizonStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: horizon должно быть числом месяцев"})
+			return
+		}
+		horizon = parsed
+	}
+
+	forecast, err := h.forecastSvc.GetForecast(c.Request.Context(), itemID, horizon)
+	if err != nil {
+		forecastError(c, "getForecast", itemID, err, "не удалось рассчитать прогноз")
+		return
+	}
+
+	resp := forecastResp{
+		ItemID:       forecast.ItemID,
+		Name:         forecast.ItemName,
+		Method:       forecast.Method,
+		MAE:          forecast.MAE,
+		RMSE:         forecast.RMSE,
+		LeadTimeDays: forecast.LeadTimeDays,
+		ServiceLevel: forecast.ServiceLevel,
+		History:      make([]forecastPeriodResp, 0, len(forecast.History)),
+		Periods:      make([]forecastPeriodResp, 0, len(forecast.Periods)),
+		Recommendation: stockRecommendationResp{
+			DailyDemand:  forecast.Recommendation.DailyDemand,
+			SafetyStock:  forecast.Recommendation.SafetyStock,
+			ReorderPoint: forecast.Recommendation.ReorderPoint,
+			MaxQty:       forecast.Recommendation.MaxQty,
+		},
+	}
+	for _, p := range forecast.History {
+		resp.History = append(resp.History, forecastPeriodResp{
+			Month:    p.Month.Format(monthLayout),
+			Quantity: float64(p.Quantity),
+		})
+	}
+	for _, p := range forecast.Periods {
+		resp.Periods = append(resp.Periods, forecastPeriodResp{
+			Month:    p.Month.Format(monthLayout),
+			Quantity: p.Quantity,
+		})
+	}
+	if forecast.Current != nil {
+		resp.Current = &stockLevelResp{
+			MinQty:       forecast.Current.MinQty,
+			ReorderPoint: forecast.Current.ReorderPoint,
+			MaxQty:       forecast.Current.MaxQty,
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// applyForecast - handler для замены уровней запаса item рекомендованными по прогнозу.
+func (h *handler) applyForecast(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("applyForecast: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	level, err := h.forecastSvc.ApplyRecommendation(c.Request.Context(), itemID)
+	if err != nil {
+		forecastError(c, "applyForecast", itemID, err, "не удалось применить рекомендацию")
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Int("min_qty", level.MinQty).
+		Int("reorder_point", level.ReorderPoint).
+		Int("max_qty", level.MaxQty).
+		Msg("applyForecast: уровни запаса заменены рекомендованными")
+
+	c.JSON(http.StatusOK, ginext.H{
+		"item_id":       itemID,
+		"min_qty":       level.MinQty,
+		"reorder_point": level.ReorderPoint,
+		"max_qty":       level.MaxQty,
+	})
+}
+
+// forecastError - ответ на ошибку прогноза спроса.
+func forecastError(c *ginext.Context, op string, itemID int, err error, msg string) {
+	switch {
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
@@ -9,12 +9,93 @@ import (
 )
 
 type handler struct {
-	authSvc services.AuthService
-	invSvc  services.InventoryService
+	authSvc          services.AuthService
+	invSvc           services.InventoryService
+	resSvc           services.ReservationService
+	lotSvc           services.LotService
+	serialSvc        services.SerialService
+	locSvc           services.LocationService
+	labelSvc         services.LabelService
+	catSvc           services.CategoryService
+	unitSvc          services.UnitService
+	alertSvc         services.AlertService
+	supplierSvc      services.SupplierService
+	purchaseSvc      services.PurchaseService
+	outboundSvc      services.OutboundService
+	replenishmentSvc services.ReplenishmentService
+	countSvc         services.CountService
+	returnSvc        services.ReturnService
+	stockStatusSvc   services.StockStatusService
+	writeOffSvc      services.WriteOffService
+	approvalSvc      services.ApprovalService
+	userSvc          services.UserService
+	valuationSvc     services.ValuationService
+	currencySvc      services.CurrencyService
+	kitSvc           services.KitService
+	analysisSvc      services.AnalysisService
+	forecastSvc      services.ForecastService
+	dashboardSvc     services.DashboardService
+	reportSvc        services.ReportService
 }
 
-func New(authSvc services.AuthService, invSvc services.InventoryService) *handler {
-	return &handler{authSvc: authSvc, invSvc: invSvc}
+func New(
+	authSvc services.AuthService,
+	invSvc services.InventoryService,
+	resSvc services.ReservationService,
+	lotSvc services.LotService,
+	serialSvc services.SerialService,
+	locSvc services.LocationService,
+	labelSvc services.LabelService,
+	catSvc services.CategoryService,
+	unitSvc services.UnitService,
+	alertSvc services.AlertService,
+	supplierSvc services.SupplierService,
+	purchaseSvc services.PurchaseService,
+	outboundSvc services.OutboundService,
+	replenishmentSvc services.ReplenishmentService,
+	countSvc services.CountService,
+	returnSvc services.ReturnService,
+	stockStatusSvc services.StockStatusService,
+	writeOffSvc services.WriteOffService,
+	approvalSvc services.ApprovalService,
+	userSvc services.UserService,
+	valuationSvc services.ValuationService,
+	currencySvc services.CurrencyService,
+	kitSvc services.KitService,
+	analysisSvc services.AnalysisService,
+	forecastSvc services.ForecastService,
+	dashboardSvc services.DashboardService,
+	reportSvc services.ReportService,
+) *handler {
+	return &handler{
+		authSvc:          authSvc,
+		invSvc:           invSvc,
+		resSvc:           resSvc,
+		lotSvc:           lotSvc,
+		serialSvc:        serialSvc,
+		locSvc:           locSvc,
+		labelSvc:         labelSvc,
+		catSvc:           catSvc,
+		unitSvc:          unitSvc,
+		alertSvc:         alertSvc,
+		supplierSvc:      supplierSvc,
+		purchaseSvc:      purchaseSvc,
+		outboundSvc:      outboundSvc,
+		replenishmentSvc: replenishmentSvc,
+		countSvc:         countSvc,
+		returnSvc:        returnSvc,
+		stockStatusSvc:   stockStatusSvc,
+		writeOffSvc:      writeOffSvc,
+		approvalSvc:      approvalSvc,
+		userSvc:          userSvc,
+		valuationSvc:     valuationSvc,
+		currencySvc:      currencySvc,
+		kitSvc:           kitSvc,
+		analysisSvc:      analysisSvc,
+		forecastSvc:      forecastSvc,
+		dashboardSvc:     dashboardSvc,
+		reportSvc:        reportSvc,
+	}
 }
 
 func (h *handler) RegisterHandlers() *ginext.Engine {
@@ -39,6 +120,23 @@ func (h *handler) RegisterHandlers() *ginext.Engine {
 		models.RoleViewer,
 	), h.getItems)
 
+	protected.GET("/by-barcode/:code", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getItemByBarcode)
+
+	protected.POST("/labels", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createItemLabelSheet)
+
+	protected.GET("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getItem)
+
 	protected.GET("/:id/history", middleware.RBACMiddleware(
 		models.RoleAdmin,
 		models.RoleManager,
@@ -58,5 +156,578 @@ func (h *handler) RegisterHandlers() *ginext.Engine {
 		models.RoleAdmin,
 	), h.deleteItem)
 
+	protected.GET("/:id/reservations", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getItemReservations)
+
+	protected.POST("/:id/reservations", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createReservation)
+
+	protected.GET("/:id/lots", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getItemLots)
+
+	protected.POST("/:id/lots", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createLot)
+
+	protected.POST("/:id/issue", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.issueItem)
+
+	protected.GET("/:id/serials", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getItemSerials)
+
+	protected.POST("/:id/serials", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.registerSerials)
+
+	protected.PUT("/:id/units", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.setItemUnits)
+
+	protected.PUT("/:id/stock-levels", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.setStockLevel)
+
+	protected.GET("/:id/forecast", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getForecast)
+
+	protected.POST("/:id/forecast/apply", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.applyForecast)
+
+	protected.PUT("/:id/pick-location", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.setItemPickLocation)
+
+	protected.GET("/:id/stock", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getItemStock)
+
+	protected.POST("/:id/stock-status", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.changeStockStatus)
+
+	protected.GET("/:id/suppliers", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getItemSuppliers)
+
+	protected.GET("/:id/label", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getItemLabel)
+
+	protected.GET("/:id/costs", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getItemCosts)
+
+	protected.PUT("/:id/valuation-method", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.setItemValuationMethod)
+
+	protected.PUT("/:id/kit", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.setKit)
+
+	protected.DELETE("/:id/kit", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.deleteKit)
+
+	protected.POST("/:id/assemble", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.assembleKit)
+
+	protected.POST("/:id/disassemble", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.disassembleKit)
+
+	protected.GET("/:id/assemblies", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getKitAssemblies)
+
+	reservations := router.Group("/reservations")
+	reservations.Use(middleware.AuthMiddleware(h.authSvc))
+
+	reservations.POST("/:id/release", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.releaseReservation)
+
+	reservations.POST("/:id/confirm", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.confirmReservation)
+
+	serials := router.Group("/serials")
+	serials.Use(middleware.AuthMiddleware(h.authSvc))
+
+	serials.GET("/:serial", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getSerial)
+
+	serials.POST("/:serial/move", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.moveSerial)
+
+	locations := router.Group("/locations")
+	locations.Use(middleware.AuthMiddleware(h.authSvc))
+
+	locations.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getLocations)
+
+	locations.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createLocation)
+
+	locations.GET("/:id/label", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getLocationLabel)
+
+	categories := router.Group("/categories")
+	categories.Use(middleware.AuthMiddleware(h.authSvc))
+
+	categories.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getCategories)
+
+	categories.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createCategory)
+
+	categories.POST("/:id/move", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.moveCategory)
+
+	categories.POST("/:id/merge", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.mergeCategory)
+
+	categories.GET("/:id/attributes", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getCategoryAttributes)
+
+	categories.POST("/:id/attributes", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.createCategoryAttribute)
+
+	suppliers := router.Group("/suppliers")
+	suppliers.Use(middleware.AuthMiddleware(h.authSvc))
+
+	suppliers.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getSuppliers)
+
+	suppliers.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createSupplier)
+
+	suppliers.GET("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getSupplier)
+
+	suppliers.PUT("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.updateSupplier)
+
+	suppliers.DELETE("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.deleteSupplier)
+
+	suppliers.PUT("/:id/items/:item_id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.setSupplierItem)
+
+	suppliers.DELETE("/:id/items/:item_id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.removeSupplierItem)
+
+	purchaseOrders := router.Group("/purchase-orders")
+	purchaseOrders.Use(middleware.AuthMiddleware(h.authSvc))
+
+	purchaseOrders.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getPurchaseOrders)
+
+	purchaseOrders.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createPurchaseOrder)
+
+	purchaseOrders.GET("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getPurchaseOrder)
+
+	purchaseOrders.DELETE("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.deletePurchaseOrder)
+
+	purchaseOrders.POST("/:id/send", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.sendPurchaseOrder)
+
+	purchaseOrders.POST("/:id/receipts", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.receivePurchaseOrder)
+
+	purchaseOrders.POST("/:id/close", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.closePurchaseOrder)
+
+	replenishment := router.Group("/replenishment")
+	replenishment.Use(middleware.AuthMiddleware(h.authSvc))
+
+	replenishment.GET("/suggestions", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getReplenishmentSuggestions)
+
+	replenishment.POST("/purchase-orders", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createReplenishmentOrders)
+
+	outboundOrders := router.Group("/outbound-orders")
+	outboundOrders.Use(middleware.AuthMiddleware(h.authSvc))
+
+	outboundOrders.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getOutboundOrders)
+
+	outboundOrders.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createOutboundOrder)
+
+	outboundOrders.GET("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getOutboundOrder)
+
+	outboundOrders.POST("/:id/allocate", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.allocateOutboundOrder)
+
+	outboundOrders.POST("/:id/pick-list", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getPickList)
+
+	outboundOrders.POST("/:id/pack", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.packOutboundOrder)
+
+	outboundOrders.POST("/:id/ship", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.shipOutboundOrder)
+
+	outboundOrders.POST("/:id/cancel", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.cancelOutboundOrder)
+
+	returns := router.Group("/returns")
+	returns.Use(middleware.AuthMiddleware(h.authSvc))
+
+	returns.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getReturns)
+
+	returns.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createReturn)
+
+	returns.GET("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getReturn)
+
+	returns.POST("/:id/receive", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.receiveReturn)
+
+	returns.POST("/:id/cancel", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.cancelReturn)
+
+	writeOffs := router.Group("/write-offs")
+	writeOffs.Use(middleware.AuthMiddleware(h.authSvc))
+
+	writeOffs.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getWriteOffs)
+
+	writeOffs.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createWriteOff)
+
+	writeOffs.GET("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getWriteOff)
+
+	writeOffs.POST("/:id/approve", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.approveWriteOff)
+
+	writeOffs.POST("/:id/reject", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.rejectWriteOff)
+
+	writeOffs.POST("/:id/evidence", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.uploadWriteOffEvidence)
+
+	writeOffs.GET("/:id/evidence/:evidence_id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getWriteOffEvidence)
+
+	approvals := router.Group("/approvals")
+	approvals.Use(middleware.AuthMiddleware(h.authSvc))
+
+	approvals.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getChangeRequests)
+
+	approvals.GET("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getChangeRequest)
+
+	approvals.POST("/:id/approve", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.approveChangeRequest)
+
+	approvals.POST("/:id/reject", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.rejectChangeRequest)
+
+	approvals.POST("/:id/cancel", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.cancelChangeRequest)
+
+	users := router.Group("/users")
+	users.Use(middleware.AuthMiddleware(h.authSvc))
+
+	users.PUT("/:id/role", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.changeUserRole)
+
+	countSessions := router.Group("/count-sessions")
+	countSessions.Use(middleware.AuthMiddleware(h.authSvc))
+
+	countSessions.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getCountSessions)
+
+	countSessions.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.createCountSession)
+
+	countSessions.GET("/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getCountSession)
+
+	countSessions.POST("/:id/counts", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.recordCounts)
+
+	countSessions.POST("/:id/submit", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.submitCountSession)
+
+	countSessions.POST("/:id/approve", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.approveCountSession)
+
+	countSessions.POST("/:id/cancel", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.cancelCountSession)
+
+	reports := router.Group("/reports")
+	reports.Use(middleware.AuthMiddleware(h.authSvc))
+
+	reports.GET("/expiring-lots", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getExpiringLots)
+
+	reports.GET("/low-stock", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getLowStockReport)
+
+	reports.GET("/returns", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getReturnsReport)
+
+	reports.GET("/valuation", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getValuationReport)
+
+	reports.GET("/abc", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getABCReport)
+
+	reports.GET("/movements", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getMovementsReport)
+
+	reports.GET("/user-activity", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getUserActivityReport)
+
+	reports.GET("/balances", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getBalancesReport)
+
+	valuation := router.Group("/valuation")
+	valuation.Use(middleware.AuthMiddleware(h.authSvc))
+
+	valuation.GET("/method", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getDefaultValuationMethod)
+
+	valuation.PUT("/method", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.setDefaultValuationMethod)
+
+	currencies := router.Group("/currencies")
+	currencies.Use(middleware.AuthMiddleware(h.authSvc))
+
+	currencies.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getCurrencies)
+
+	currencies.POST("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.createCurrency)
+
+	currencies.GET("/rates", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getExchangeRates)
+
+	currencies.PUT("/rates", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.setExchangeRate)
+
+	currencies.POST("/rates/import", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.importExchangeRates)
+
+	currencies.DELETE("/rates/:id", middleware.RBACMiddleware(
+		models.RoleAdmin,
+	), h.deleteExchangeRate)
+
+	alerts := router.Group("/alerts")
+	alerts.Use(middleware.AuthMiddleware(h.authSvc))
+
+	alerts.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.getStockAlerts)
+
+	alerts.POST("/:id/acknowledge", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+	), h.acknowledgeStockAlert)
+
+	dashboard := router.Group("/dashboard")
+	dashboard.Use(middleware.AuthMiddleware(h.authSvc))
+
+	dashboard.GET("", middleware.RBACMiddleware(
+		models.RoleAdmin,
+		models.RoleManager,
+		models.RoleViewer,
+	), h.getDashboard)
+
 	return router
 }
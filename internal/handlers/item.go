@@ -9,9 +9,13 @@ import (
 	"github.com/wb-go/wbf/ginext"
 	"github.com/wb-go/wbf/zlog"
 
+	"github.com/sunr3d/warehouse-control/internal/uom"
 	"github.com/sunr3d/warehouse-control/models"
 )
 
+// attrQueryPrefix - префикс query параметров фильтрации items по атрибутам, например attr.color=black.
+const attrQueryPrefix = "attr."
+
 // createItem - handler для создания нового item.
 func (h *handler) createItem(c *ginext.Context) {
 	userClaims, _ := c.Get("user")
@@ -30,22 +34,61 @@ func (h *handler) createItem(c *ginext.Context) {
 	zlog.Logger.Info().
 		Int("user_id", userID).
 		Str("item_name", req.Name).
-		Int("quantity", req.Quantity).
+		Float64("quantity", req.Quantity).
 		Msg("createItem: попытка создания нового item")
 
+	// Уровни упаковки задаются после создания, поэтому количество принимается только в базовой единице.
+	baseUnit := req.BaseUnit
+	if baseUnit == "" {
+		baseUnit = models.DefaultBaseUnit
+	}
+	quantity, err := uom.ToBase(baseUnit, nil, req.Quantity, req.Unit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+
 	item := &models.Item{
-		Name:        req.Name,
-		Description: req.Description,
-		Quantity:    req.Quantity,
+		Name:         req.Name,
+		Description:  req.Description,
+		Quantity:     quantity,
+		BaseUnit:     req.BaseUnit,
+		Serialized:   req.Serialized,
+		SKU:          req.SKU,
+		Barcodes:     toBarcodes(req.Barcodes),
+		CategoryID:   req.CategoryID,
+		Attributes:   req.Attributes,
+		UnitCost:     req.UnitCost,
+		CostCurrency: req.Currency,
 	}
 
 	id, err := h.invSvc.AddItem(c.Request.Context(), userID, item)
 	if err != nil {
+		if strings.Contains(err.Error(), "quantity") ||
+			strings.Contains(err.Error(), "некорректный") ||
+			strings.Contains(err.Error(), "категория") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Str("item_name", req.Name).
+				Msg("createItem: некорректные данные item")
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "уже существует") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Str("item_name", req.Name).
+				Msg("createItem: sku или штрихкод уже существует")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+			return
+		}
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
 			Str("item_name", req.Name).
-			Int("quantity", req.Quantity).
+			Float64("quantity", req.Quantity).
 			Msg("createItem: не удалось создать item")
 		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось создать item"})
 		return
@@ -55,7 +98,7 @@ func (h *handler) createItem(c *ginext.Context) {
 		Int("user_id", userID).
 		Int("item_id", id).
 		Str("item_name", req.Name).
-		Int("quantity", req.Quantity).
+		Float64("quantity", req.Quantity).
 		Msg("createItem: item успешно создан")
 
 	c.JSON(http.StatusCreated, ginext.H{"id": id})
@@ -67,12 +110,41 @@ func (h *handler) getItems(c *ginext.Context) {
 	claims := userClaims.(*models.JWTClaims)
 	userID := claims.UserID
 
+	var filter models.ItemFilter
+	if categoryStr := c.Query("category_id"); categoryStr != "" {
+		categoryID, err := parseID(categoryStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: category_id должно быть положительным числом"})
+			return
+		}
+		filter.CategoryID = &categoryID
+	}
+	for key, values := range c.Request.URL.Query() {
+		code, ok := strings.CutPrefix(key, attrQueryPrefix)
+		if !ok || code == "" {
+			continue
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string]string)
+		}
+		filter.Attributes[code] = values[0]
+	}
+	filter.StockStatus = c.Query("stock_status")
+	filter.ABCClass = c.Query("abc_class")
+	filter.XYZClass = c.Query("xyz_class")
+	filter.MovementStatus = c.Query("movement_status")
+	unit := c.Query("unit")
+
 	zlog.Logger.Info().
 		Int("user_id", userID).
 		Msg("getItems: попытка получить все items")
 
-	items, err := h.invSvc.GetInventory(c.Request.Context())
+	items, err := h.invSvc.GetInventory(c.Request.Context(), filter)
 	if err != nil {
+		if strings.Contains(err.Error(), "некорректн") {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
@@ -88,19 +160,64 @@ func (h *handler) getItems(c *ginext.Context) {
 
 	var resp []itemResp
 	for _, item := range items {
-		resp = append(resp, itemResp{
-			ID:          item.ID,
-			Quantity:    item.Quantity,
-			Name:        item.Name,
-			Description: item.Description,
-			CreatedAt:   item.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   item.UpdatedAt.Format(time.RFC3339),
-		})
+		resp = append(resp, toItemResp(item, unit))
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
+// getItem - handler для получения item по id, для комплекта - с составом и количеством к сборке.
+func (h *handler) getItem(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getItem: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	item, err := h.invSvc.GetItem(c.Request.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", id).
+			Msg("getItem: не удалось получить item")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toItemResp(*item, c.Query("unit")))
+}
+
+// getItemByBarcode - handler для поиска item по отсканированному штрихкоду или sku.
+func (h *handler) getItemByBarcode(c *ginext.Context) {
+	code := c.Param("code")
+
+	item, err := h.invSvc.GetItemByBarcode(c.Request.Context(), code)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "не может быть пустым"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Str("code", code).
+				Msg("getItemByBarcode: не удалось получить item")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить item"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toItemResp(*item, c.Query("unit")))
+}
+
 // updateItem - handler для обновления item.
 func (h *handler) updateItem(c *ginext.Context) {
 	id, err := parseID(c.Param("id"))
@@ -129,18 +246,32 @@ func (h *handler) updateItem(c *ginext.Context) {
 		Int("user_id", userID).
 		Int("item_id", id).
 		Str("item_name", req.Name).
-		Int("quantity", req.Quantity).
+		Float64("quantity", req.Quantity).
 		Msg("updateItem: попытка обновления item")
 
+	quantity, ok := h.baseQuantity(c, "updateItem", id, req.Quantity, req.Unit)
+	if !ok {
+		return
+	}
+
 	item := &models.Item{
 		Name:        req.Name,
 		Description: req.Description,
-		Quantity:    req.Quantity,
+		Quantity:    quantity,
+		Serialized:  req.Serialized,
+		SKU:         req.SKU,
+		Barcodes:    toBarcodes(req.Barcodes),
+		CategoryID:  req.CategoryID,
+		Attributes:  req.Attributes,
 		UpdatedAt:   time.Now(),
 	}
 
-	err = h.invSvc.UpdateItem(c.Request.Context(), userID, id, item)
+	cr, err := h.invSvc.UpdateItem(c.Request.Context(), userID, id, item)
 	if err != nil {
+		if strings.Contains(err.Error(), "категория") {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
 		if strings.Contains(err.Error(), "не найден") {
 			zlog.Logger.Warn().
 				Err(err).
@@ -150,6 +281,32 @@ func (h *handler) updateItem(c *ginext.Context) {
 			c.JSON(http.StatusNotFound, ginext.H{"error": "item с id " + strconv.Itoa(id) + " не найден"})
 			return
 		}
+		if strings.Contains(err.Error(), "серийного item") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", id).
+				Msg("updateItem: quantity серийного item не совпадает с серийными номерами")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "уже существует") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", id).
+				Msg("updateItem: sku или штрихкод уже существует")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "нельзя") {
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "некорректный") {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
@@ -159,11 +316,26 @@ func (h *handler) updateItem(c *ginext.Context) {
 		return
 	}
 
+	if cr != nil {
+		zlog.Logger.Info().
+			Int("user_id", userID).
+			Int("item_id", id).
+			Int("change_request_id", cr.ID).
+			Msg("updateItem: изменение item ожидает согласования")
+		c.JSON(http.StatusAccepted, ginext.H{
+			"id":                id,
+			"change_request_id": cr.ID,
+			"status":            cr.Status,
+			"message":           "изменение item ожидает согласования",
+		})
+		return
+	}
+
 	zlog.Logger.Info().
 		Int("user_id", userID).
 		Int("item_id", id).
 		Str("item_name", req.Name).
-		Int("quantity", req.Quantity).
+		Float64("quantity", req.Quantity).
 		Msg("updateItem: item успешно обновлен")
 
 	c.JSON(http.StatusOK, ginext.H{"id": id, "message": "item успешно обновлен"})
@@ -184,7 +356,7 @@ func (h *handler) deleteItem(c *ginext.Context) {
 	claims := userClaims.(*models.JWTClaims)
 	userID := claims.UserID
 
-	err = h.invSvc.DeleteItem(c.Request.Context(), userID, id)
+	cr, err := h.invSvc.DeleteItem(c.Request.Context(), userID, id)
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			zlog.Logger.Warn().
@@ -195,6 +367,10 @@ func (h *handler) deleteItem(c *ginext.Context) {
 			c.JSON(http.StatusNotFound, ginext.H{"error": "item с id " + strconv.Itoa(id) + " не найден"})
 			return
 		}
+		if strings.Contains(err.Error(), "нельзя") {
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+			return
+		}
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
@@ -204,6 +380,21 @@ func (h *handler) deleteItem(c *ginext.Context) {
 		return
 	}
 
+	if cr != nil {
+		zlog.Logger.Info().
+			Int("user_id", userID).
+			Int("item_id", id).
+			Int("change_request_id", cr.ID).
+			Msg("deleteItem: удаление item ожидает согласования")
+		c.JSON(http.StatusAccepted, ginext.H{
+			"id":                id,
+			"change_request_id": cr.ID,
+			"status":            cr.Status,
+			"message":           "удаление item ожидает согласования",
+		})
+		return
+	}
+
 	zlog.Logger.Info().
 		Int("user_id", userID).
 		Int("item_id", id).
@@ -211,3 +402,56 @@ func (h *handler) deleteItem(c *ginext.Context) {
 
 	c.JSON(http.StatusOK, ginext.H{"id": id, "message": "item успешно удален"})
 }
+
+func toItemResp(item models.Item, unit string) itemResp {
+	barcodes := make([]barcodeResp, 0, len(item.Barcodes))
+	for _, b := range item.Barcodes {
+		barcodes = append(barcodes, barcodeResp{Code: b.Code, Symbology: b.Symbology})
+	}
+
+	return itemResp{
+		ID:            item.ID,
+		Quantity:      item.Quantity,
+		Reserved:      item.Reserved,
+		Available:     item.Available(),
+		OnHand:        item.OnHand(),
+		StockStatuses: toStockStatuses(item),
+		BaseUnit:      item.BaseUnit,
+		Units:         toItemUnitResps(item.Units),
+		UnitQuantity:  toUnitQuantity(item, unit),
+		Serialized:    item.Serialized,
+		SKU:           item.SKU,
+		Barcodes:      barcodes,
+		CategoryID:    item.CategoryID,
+		Attributes:    item.Attributes,
+		Name:          item.Name,
+		Description:   item.Description,
+		CreatedAt:     item.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     item.UpdatedAt.Format(time.RFC3339),
+		Kit:           toKitResp(item.Kit),
+
+		ABCClass:          item.ABCClass,
+		XYZClass:          item.XYZClass,
+		MovementStatus:    item.MovementStatus,
+		DaysSinceMovement: toDaysSinceMovement(item.LastMovementAt),
+	}
+}
+
+// toDaysSinceMovement - дни без движения по последнему анализу, nil до первого анализа.
+func toDaysSinceMovement(lastMovementAt *time.Time) *int {
+	if lastMovementAt == nil {
+		return nil
+	}
+	days := models.DaysSince(*lastMovementAt, time.Now())
+
+	return &days
+}
+
+func toBarcodes(reqs []barcodeReq) []models.Barcode {
+	barcodes := make([]models.Barcode, 0, len(reqs))
+	for _, b := range reqs {
+		barcodes = append(barcodes, models.Barcode{Code: b.Code, Symbology: b.Symbology})
+	}
+
+	return barcodes
+}
@@ -71,6 +71,9 @@ func (h *handler) getItemHistory(c *ginext.Context) {
 		if item.NewValue != nil {
 			itemHist.NewValue = *item.NewValue
 		}
+		if item.Details != nil {
+			itemHist.Details = *item.Details
+		}
 
 		resp.Items = append(resp.Items, itemHist)
 	}
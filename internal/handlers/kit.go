@@ -0,0 +1,232 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// setKit - handler для задания состава комплекта.
+func (h *handler) setKit(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setKit: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	var req kitReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setKit: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	kit := &models.Kit{ItemID: itemID, Components: make([]models.KitComponent, 0, len(req.Components))}
+	for _, component := range req.Components {
+		quantity, ok := h.baseQuantity(c, "setKit", component.ItemID, component.Quantity, component.Unit)
+		if !ok {
+			return
+		}
+		kit.Components = append(kit.Components, models.KitComponent{ItemID: component.ItemID, Quantity: quantity})
+	}
+
+	saved, err := h.kitSvc.SetKit(c.Request.Context(), kit)
+	if err != nil {
+		kitError(c, "setKit", itemID, err, "не удалось сохранить состав комплекта")
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("item_id", itemID).
+		Int("components", len(saved.Components)).
+		Msg("setKit: состав комплекта сохранен")
+
+	c.JSON(http.StatusOK, toKitResp(saved))
+}
+
+// deleteKit - handler для удаления состава комплекта.
+func (h *handler) deleteKit(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("deleteKit: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if err := h.kitSvc.DeleteKit(c.Request.Context(), itemID); err != nil {
+		kitError(c, "deleteKit", itemID, err, "не удалось удалить состав комплекта")
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("item_id", itemID).
+		Msg("deleteKit: состав комплекта удален")
+
+	c.JSON(http.StatusOK, ginext.H{"item_id": itemID, "message": "состав комплекта успешно удален"})
+}
+
+// assembleKit - handler для сборки комплектов из компонентов.
+func (h *handler) assembleKit(c *ginext.Context) {
+	h.runKitAssembly(c, "assembleKit", models.KitAssemble)
+}
+
+// disassembleKit - handler для разборки комплектов на компоненты.
+func (h *handler) disassembleKit(c *ginext.Context) {
+	h.runKitAssembly(c, "disassembleKit", models.KitDisassemble)
+}
+
+// runKitAssembly - общая часть сборки и разборки комплектов.
+func (h *handler) runKitAssembly(c *ginext.Context, op, operation string) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg(op + ": некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req kitAssemblyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg(op + ": некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	quantity, ok := h.baseQuantity(c, op, itemID, req.Quantity, req.Unit)
+	if !ok {
+		return
+	}
+
+	assembly := &models.KitAssembly{
+		KitID:     itemID,
+		Operation: operation,
+		Quantity:  quantity,
+		Note:      req.Note,
+	}
+	if err := h.kitSvc.AssembleKit(c.Request.Context(), userID, assembly); err != nil {
+		kitError(c, op, itemID, err, "не удалось выполнить операцию с комплектом")
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Str("number", assembly.Number).
+		Str("operation", operation).
+		Int("quantity", quantity).
+		Msg(op + ": операция с комплектом выполнена")
+
+	c.JSON(http.StatusCreated, toKitAssemblyResp(*assembly))
+}
+
+// getKitAssemblies - handler для получения документов сборки и разборки комплекта.
+func (h *handler) getKitAssemblies(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getKitAssemblies: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	assemblies, err := h.kitSvc.GetAssemblies(c.Request.Context(), itemID)
+	if err != nil {
+		kitError(c, "getKitAssemblies", itemID, err, "не удалось получить документы сборки")
+		return
+	}
+
+	resp := make([]kitAssemblyResp, 0, len(assemblies))
+	for _, assembly := range assemblies {
+		resp = append(resp, toKitAssemblyResp(assembly))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// kitError - ответ на ошибку операции с комплектом.
+func kitError(c *ginext.Context, op string, itemID int, err error, msg string) {
+	switch {
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"), strings.Contains(err.Error(), "недостаточно"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
+
+// toKitResp - состав комплекта для ответа, nil для item без состава.
+func toKitResp(kit *models.Kit) *kitResp {
+	if kit == nil {
+		return nil
+	}
+
+	components := make([]kitComponentResp, 0, len(kit.Components))
+	for _, component := range kit.Components {
+		components = append(components, kitComponentResp{
+			ItemID:    component.ItemID,
+			Name:      component.ItemName,
+			BaseUnit:  component.BaseUnit,
+			Quantity:  component.Quantity,
+			Available: component.Available,
+		})
+	}
+
+	return &kitResp{
+		ItemID:           kit.ItemID,
+		AvailableToBuild: kit.AvailableToBuild(),
+		Components:       components,
+	}
+}
+
+func toKitAssemblyResp(assembly models.KitAssembly) kitAssemblyResp {
+	lines := make([]kitAssemblyLineResp, 0, len(assembly.Lines))
+	for _, line := range assembly.Lines {
+		lines = append(lines, kitAssemblyLineResp{
+			ItemID:   line.ItemID,
+			Name:     line.ItemName,
+			Quantity: line.Quantity,
+			UnitCost: line.UnitCost,
+		})
+	}
+
+	return kitAssemblyResp{
+		ID:        assembly.ID,
+		Number:    assembly.Number,
+		KitID:     assembly.KitID,
+		KitName:   assembly.KitName,
+		Operation: assembly.Operation,
+		Quantity:  assembly.Quantity,
+		UnitCost:  assembly.UnitCost,
+		Note:      assembly.Note,
+		CreatedBy: assembly.CreatedBy,
+		Lines:     lines,
+		CreatedAt: assembly.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,149 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/label"
+)
+
+const (
+	defaultLabelLayout = "a4-21"
+	labelSheetFilename = "labels.pdf"
+)
+
+// getItemLabel - handler для получения этикетки item в виде PNG или SVG.
+func (h *handler) getItemLabel(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getItemLabel: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	kind, format := labelParams(c)
+
+	data, err := h.labelSvc.ItemLabel(c.Request.Context(), itemID, kind, format)
+	if err != nil {
+		labelError(c, "getItemLabel", err)
+		return
+	}
+
+	c.Data(http.StatusOK, label.ContentType(format), data)
+}
+
+// getLocationLabel - handler для получения этикетки складской ячейки в виде PNG или SVG.
+func (h *handler) getLocationLabel(c *ginext.Context) {
+	locationID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getLocationLabel: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	kind, format := labelParams(c)
+
+	data, err := h.labelSvc.LocationLabel(c.Request.Context(), locationID, kind, format)
+	if err != nil {
+		labelError(c, "getLocationLabel", err)
+		return
+	}
+
+	c.Data(http.StatusOK, label.ContentType(format), data)
+}
+
+// createItemLabelSheet - handler для формирования PDF листа этикеток выбранных items.
+func (h *handler) createItemLabelSheet(c *ginext.Context) {
+	var req labelSheetReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createItemLabelSheet: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	layout, ok := sheetLayout(req)
+	if !ok {
+		c.JSON(http.StatusBadRequest, ginext.H{
+			"error": "некорректный запрос: неизвестная раскладка " + req.Layout + ", доступны " + strings.Join(label.LayoutNames(), ", "),
+		})
+		return
+	}
+
+	kind := req.Type
+	if kind == "" {
+		kind = label.KindCode128
+	}
+
+	data, err := h.labelSvc.ItemLabelSheet(c.Request.Context(), req.ItemIDs, kind, layout)
+	if err != nil {
+		labelError(c, "createItemLabelSheet", err)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="`+labelSheetFilename+`"`)
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// labelError - ответ на ошибку генерации этикетки.
+func labelError(c *ginext.Context, op string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "неизвестн"),
+		strings.Contains(err.Error(), "некорректн"),
+		strings.Contains(err.Error(), "не удалось закодировать"),
+		strings.Contains(err.Error(), "не может быть пустым"),
+		strings.Contains(err.Error(), "не более"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": не удалось сформировать этикетку")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось сформировать этикетку"})
+	}
+}
+
+// labelParams - тип штрихкода и формат этикетки из query, по умолчанию Code128 в PNG.
+func labelParams(c *ginext.Context) (string, string) {
+	kind := c.DefaultQuery("type", label.KindCode128)
+	format := c.DefaultQuery("format", label.FormatPNG)
+
+	return kind, format
+}
+
+// sheetLayout - раскладка листа: пользовательская, если задана, иначе стандартная по имени.
+func sheetLayout(req labelSheetReq) (label.Layout, bool) {
+	if req.CustomLayout != nil {
+		l := req.CustomLayout
+		return label.Layout{
+			Name:        "custom",
+			PageWidth:   l.PageWidth,
+			PageHeight:  l.PageHeight,
+			Columns:     l.Columns,
+			Rows:        l.Rows,
+			LabelWidth:  l.LabelWidth,
+			LabelHeight: l.LabelHeight,
+			MarginLeft:  l.MarginLeft,
+			MarginTop:   l.MarginTop,
+			GapX:        l.GapX,
+			GapY:        l.GapY,
+		}, true
+	}
+
+	name := req.Layout
+	if name == "" {
+		name = defaultLabelLayout
+	}
+	layout, ok := label.Layouts[name]
+
+	return layout, ok
+}
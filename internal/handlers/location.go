@@ -0,0 +1,134 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createLocation - handler для создания складской ячейки.
+func (h *handler) createLocation(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req locationReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createLocation: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	loc := &models.Location{
+		Code: req.Code,
+		Name: req.Name,
+	}
+
+	id, err := h.locSvc.CreateLocation(c.Request.Context(), loc)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "уже существует"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Str("code", loc.Code).
+				Msg("createLocation: ячейка уже существует")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "некорректный"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Str("code", loc.Code).
+				Msg("createLocation: не удалось создать ячейку")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось создать ячейку"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("location_id", id).
+		Str("code", loc.Code).
+		Msg("createLocation: ячейка успешно создана")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id, "code": loc.Code})
+}
+
+// getLocations - handler для получения всех складских ячеек.
+func (h *handler) getLocations(c *ginext.Context) {
+	locations, err := h.locSvc.GetLocations(c.Request.Context())
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("getLocations: не удалось получить ячейки")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить ячейки"})
+		return
+	}
+
+	resp := make([]locationResp, 0, len(locations))
+	for _, loc := range locations {
+		resp = append(resp, locationResp{
+			ID:        loc.ID,
+			Code:      loc.Code,
+			Name:      loc.Name,
+			CreatedAt: loc.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// setItemPickLocation - handler для назначения item ячейки подбора, location_id null снимает назначение.
+func (h *handler) setItemPickLocation(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setItemPickLocation: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req pickLocationReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setItemPickLocation: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if err := h.locSvc.SetItemPickLocation(c.Request.Context(), itemID, req.LocationID); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("setItemPickLocation: не удалось назначить ячейку подбора")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось назначить ячейку подбора"})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Msg("setItemPickLocation: ячейка подбора назначена")
+
+	c.JSON(http.StatusOK, ginext.H{"item_id": itemID, "location_id": req.LocationID})
+}
@@ -0,0 +1,275 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	defaultExpiringDays = 30
+)
+
+// createLot - handler для приемки новой партии item.
+func (h *handler) createLot(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createLot: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req lotReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createLot: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	manufacturedAt, err := parseOptionalDate(req.ManufacturedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+	expiresAt, err := parseOptionalDate(req.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Str("lot_number", req.LotNumber).
+		Float64("quantity", req.Quantity).
+		Str("unit", req.Unit).
+		Msg("createLot: попытка приемки партии")
+
+	quantity, ok := h.baseQuantity(c, "createLot", itemID, req.Quantity, req.Unit)
+	if !ok {
+		return
+	}
+
+	lot := &models.Lot{
+		ItemID:         itemID,
+		LotNumber:      req.LotNumber,
+		ManufacturedAt: manufacturedAt,
+		ExpiresAt:      expiresAt,
+		Quantity:       quantity,
+		UnitCost:       req.UnitCost,
+		CostCurrency:   req.Currency,
+	}
+
+	id, err := h.lotSvc.ReceiveLot(c.Request.Context(), userID, lot)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "некорректн"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		case strings.Contains(err.Error(), "не найден"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("createLot: item не найден")
+			c.JSON(http.StatusNotFound, ginext.H{"error": "item с id " + strconv.Itoa(itemID) + " не найден"})
+		case strings.Contains(err.Error(), "уже существует"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("createLot: партия уже существует")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "expires_at"), strings.Contains(err.Error(), "lot_number"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("createLot: не удалось принять партию")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось принять партию"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Int("lot_id", id).
+		Msg("createLot: партия успешно принята")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id})
+}
+
+// getItemLots - handler для получения партий item.
+func (h *handler) getItemLots(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getItemLots: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	lots, err := h.lotSvc.GetItemLots(c.Request.Context(), itemID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("getItemLots: не удалось получить партии")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить партии"})
+		return
+	}
+
+	resp := make([]lotResp, 0, len(lots))
+	for _, lot := range lots {
+		resp = append(resp, toLotResp(lot))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// issueItem - handler для списания item из партий по FEFO.
+func (h *handler) issueItem(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("issueItem: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req issueReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("issueItem: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Float64("quantity", req.Quantity).
+		Str("unit", req.Unit).
+		Msg("issueItem: попытка списания item")
+
+	quantity, ok := h.baseQuantity(c, "issueItem", itemID, req.Quantity, req.Unit)
+	if !ok {
+		return
+	}
+
+	picks, cost, err := h.lotSvc.Issue(c.Request.Context(), userID, itemID, quantity)
+	if err != nil {
+		if strings.Contains(err.Error(), "недостаточно") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("issueItem: недостаточно остатка")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "серийного item") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("issueItem: остаток серийного item списывается только по серийным номерам")
+			c.JSON(http.StatusConflict, ginext.H{"error": "остаток серийного item списывается только по серийным номерам"})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("issueItem: не удалось списать item")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось списать item"})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Int("lots_count", len(picks)).
+		Str("cost", cost.String()).
+		Msg("issueItem: item успешно списан")
+
+	resp := issueResp{ItemID: itemID, Cost: cost}
+	for _, pick := range picks {
+		resp.Lots = append(resp.Lots, lotPickResp{
+			LotID:     pick.LotID,
+			LotNumber: pick.LotNumber,
+			Quantity:  pick.Quantity,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getExpiringLots - handler для отчета по партиям, срок годности которых скоро истекает.
+func (h *handler) getExpiringLots(c *ginext.Context) {
+	days := defaultExpiringDays
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: days должно быть неотрицательным числом"})
+			return
+		}
+		days = parsed
+	}
+
+	lots, err := h.lotSvc.GetExpiringLots(c.Request.Context(), days)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("days", days).
+			Msg("getExpiringLots: не удалось получить отчет")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить отчет"})
+		return
+	}
+
+	resp := make([]expiringLotResp, 0, len(lots))
+	for _, lot := range lots {
+		resp = append(resp, expiringLotResp{
+			lotResp:  toLotResp(lot.Lot),
+			ItemName: lot.ItemName,
+			DaysLeft: lot.DaysLeft,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func toLotResp(lot models.Lot) lotResp {
+	return lotResp{
+		ID:             lot.ID,
+		ItemID:         lot.ItemID,
+		LotNumber:      lot.LotNumber,
+		ManufacturedAt: formatOptionalDate(lot.ManufacturedAt),
+		ExpiresAt:      formatOptionalDate(lot.ExpiresAt),
+		Quantity:       lot.Quantity,
+		CreatedAt:      lot.CreatedAt.Format(time.RFC3339),
+	}
+}
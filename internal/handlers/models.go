@@ -1,5 +1,12 @@
 package httphandlers
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
 type loginReq struct {
 	Username string `json:"username" binding:"required,min=3,max=255"`
 	Password string `json:"password" binding:"required,min=8,max=255"`
@@ -11,18 +18,78 @@ type loginResp struct {
 }
 
 type itemReq struct {
-	Name        string `json:"name" binding:"required,min=3,max=255"`
-	Description string `json:"description" binding:"max=1000"`
-	Quantity    int    `json:"quantity" binding:"required,min=0"`
+	Name        string         `json:"name" binding:"required,min=3,max=255"`
+	Description string         `json:"description" binding:"max=1000"`
+	Quantity    float64        `json:"quantity" binding:"min=0"`
+	Unit        string         `json:"unit" binding:"max=16"`
+	BaseUnit    string         `json:"base_unit" binding:"max=16"`
+	Serialized  bool           `json:"serialized"`
+	SKU         string         `json:"sku" binding:"max=64"`
+	Barcodes    []barcodeReq   `json:"barcodes" binding:"dive"`
+	CategoryID  *int           `json:"category_id" binding:"omitempty,min=1"`
+	Attributes  map[string]any `json:"attributes"`
+	UnitCost    *models.Money  `json:"unit_cost" binding:"omitempty,min=0"`
+	Currency    string         `json:"currency"`
+}
+
+type barcodeReq struct {
+	Code      string `json:"code" binding:"required,max=64"`
+	Symbology string `json:"symbology" binding:"required,oneof=ean13 upca code128"`
+}
+
+type barcodeResp struct {
+	Code      string `json:"code"`
+	Symbology string `json:"symbology"`
 }
 
 type itemResp struct {
-	ID          int    `json:"id"`
-	Quantity    int    `json:"quantity"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID            int               `json:"id"`
+	Quantity      int               `json:"quantity"`
+	Reserved      int               `json:"reserved"`
+	Available     int               `json:"available"`
+	OnHand        int               `json:"on_hand"`
+	StockStatuses map[string]int    `json:"stock_statuses"`
+	BaseUnit      string            `json:"base_unit"`
+	Units         []itemUnitResp    `json:"units"`
+	UnitQuantity  *unitQuantityResp `json:"unit_quantity,omitempty"`
+	Serialized    bool              `json:"serialized"`
+	SKU           string            `json:"sku,omitempty"`
+	Barcodes      []barcodeResp     `json:"barcodes"`
+	CategoryID    *int              `json:"category_id"`
+	Attributes    map[string]any    `json:"attributes"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	CreatedAt     string            `json:"created_at"`
+	UpdatedAt     string            `json:"updated_at"`
+	Kit           *kitResp          `json:"kit,omitempty"`
+
+	ABCClass          string `json:"abc_class,omitempty"`
+	XYZClass          string `json:"xyz_class,omitempty"`
+	MovementStatus    string `json:"movement_status,omitempty"`
+	DaysSinceMovement *int   `json:"days_since_movement,omitempty"`
+}
+
+type itemUnitsReq struct {
+	BaseUnit string        `json:"base_unit" binding:"max=16"`
+	Units    []itemUnitReq `json:"units" binding:"max=20,dive"`
+}
+
+type itemUnitReq struct {
+	Code   string  `json:"code" binding:"required,max=16"`
+	Factor float64 `json:"factor" binding:"required,gt=0"`
+	Of     string  `json:"of" binding:"max=16"`
+}
+
+type itemUnitResp struct {
+	Code   string  `json:"code"`
+	Factor float64 `json:"factor"`
+}
+
+type unitQuantityResp struct {
+	Unit      string  `json:"unit"`
+	Quantity  float64 `json:"quantity"`
+	Reserved  float64 `json:"reserved"`
+	Available float64 `json:"available"`
 }
 
 type getItemHistoryResp struct {
@@ -35,5 +102,922 @@ type itemHistoryResp struct {
 	Operation string `json:"operation"`
 	OldValue  string `json:"old_value,omitempty"`
 	NewValue  string `json:"new_value,omitempty"`
+	Details   string `json:"details,omitempty"`
 	ChangedAt string `json:"changed_at"`
 }
+
+type reservationReq struct {
+	Quantity  float64    `json:"quantity" binding:"required,gt=0"`
+	Unit      string     `json:"unit" binding:"max=16"`
+	OrderRef  string     `json:"order_ref" binding:"max=255"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type reservationResp struct {
+	ID        int    `json:"id"`
+	ItemID    int    `json:"item_id"`
+	UserID    int    `json:"user_id"`
+	Quantity  int    `json:"quantity"`
+	OrderRef  string `json:"order_ref,omitempty"`
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type lotReq struct {
+	LotNumber      string        `json:"lot_number" binding:"required,max=100"`
+	ManufacturedAt string        `json:"manufactured_at"`
+	ExpiresAt      string        `json:"expires_at"`
+	Quantity       float64       `json:"quantity" binding:"required,gt=0"`
+	Unit           string        `json:"unit" binding:"max=16"`
+	UnitCost       *models.Money `json:"unit_cost" binding:"omitempty,min=0"`
+	Currency       string        `json:"currency"`
+}
+
+type lotResp struct {
+	ID             int    `json:"id"`
+	ItemID         int    `json:"item_id"`
+	LotNumber      string `json:"lot_number"`
+	ManufacturedAt string `json:"manufactured_at,omitempty"`
+	ExpiresAt      string `json:"expires_at,omitempty"`
+	Quantity       int    `json:"quantity"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type issueReq struct {
+	Quantity float64 `json:"quantity" binding:"required,gt=0"`
+	Unit     string  `json:"unit" binding:"max=16"`
+}
+
+type lotPickResp struct {
+	LotID     int    `json:"lot_id"`
+	LotNumber string `json:"lot_number"`
+	Quantity  int    `json:"quantity"`
+}
+
+type issueResp struct {
+	ItemID int           `json:"item_id"`
+	Lots   []lotPickResp `json:"lots"`
+	Cost   models.Money  `json:"cost"`
+}
+
+type expiringLotResp struct {
+	lotResp
+	ItemName string `json:"item_name"`
+	DaysLeft int    `json:"days_left"`
+}
+
+type registerSerialsReq struct {
+	SerialNumbers []string `json:"serial_numbers" binding:"required,min=1,dive,required,max=100"`
+}
+
+type moveSerialReq struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type serialResp struct {
+	ID           int    `json:"id"`
+	ItemID       int    `json:"item_id"`
+	SerialNumber string `json:"serial_number"`
+	Status       string `json:"status"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type locationReq struct {
+	Code string `json:"code" binding:"required,max=32"`
+	Name string `json:"name" binding:"max=255"`
+}
+
+type pickLocationReq struct {
+	LocationID *int `json:"location_id" binding:"omitempty,min=1"`
+}
+
+type locationResp struct {
+	ID        int    `json:"id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+}
+
+type labelSheetReq struct {
+	ItemIDs      []int           `json:"item_ids" binding:"required,min=1,dive,min=1"`
+	Type         string          `json:"type"`
+	Layout       string          `json:"layout"`
+	CustomLayout *labelLayoutReq `json:"custom_layout"`
+}
+
+type labelLayoutReq struct {
+	PageWidth   float64 `json:"page_width"`
+	PageHeight  float64 `json:"page_height"`
+	Columns     int     `json:"columns"`
+	Rows        int     `json:"rows"`
+	LabelWidth  float64 `json:"label_width"`
+	LabelHeight float64 `json:"label_height"`
+	MarginLeft  float64 `json:"margin_left"`
+	MarginTop   float64 `json:"margin_top"`
+	GapX        float64 `json:"gap_x"`
+	GapY        float64 `json:"gap_y"`
+}
+
+type categoryReq struct {
+	Name     string `json:"name" binding:"required,max=255"`
+	ParentID *int   `json:"parent_id" binding:"omitempty,min=1"`
+}
+
+type moveCategoryReq struct {
+	ParentID *int `json:"parent_id" binding:"omitempty,min=1"`
+}
+
+type mergeCategoryReq struct {
+	TargetID int `json:"target_id" binding:"required,min=1"`
+}
+
+type attributeReq struct {
+	Code          string   `json:"code" binding:"required,max=64"`
+	Name          string   `json:"name" binding:"required,max=255"`
+	Type          string   `json:"type" binding:"required,oneof=string number boolean enum"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values" binding:"max=100"`
+	Unit          string   `json:"unit" binding:"max=32"`
+}
+
+type attributeResp struct {
+	ID            int      `json:"id"`
+	CategoryID    int      `json:"category_id"`
+	Code          string   `json:"code"`
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values"`
+	Unit          string   `json:"unit,omitempty"`
+	Inherited     bool     `json:"inherited"`
+	CreatedAt     string   `json:"created_at"`
+}
+
+type categoryResp struct {
+	ID         int            `json:"id"`
+	ParentID   *int           `json:"parent_id"`
+	Name       string         `json:"name"`
+	ItemCount  int            `json:"item_count"`
+	TotalCount int            `json:"total_item_count"`
+	Children   []categoryResp `json:"children"`
+	CreatedAt  string         `json:"created_at"`
+	UpdatedAt  string         `json:"updated_at"`
+}
+
+type stockLevelReq struct {
+	LocationID   *int `json:"location_id" binding:"omitempty,min=1"`
+	MinQty       int  `json:"min_qty" binding:"min=0"`
+	ReorderPoint int  `json:"reorder_point" binding:"min=0"`
+	MaxQty       int  `json:"max_qty" binding:"required,min=1"`
+}
+
+type lowStockResp struct {
+	ItemID         int    `json:"item_id"`
+	ItemName       string `json:"item_name"`
+	LocationID     *int   `json:"location_id,omitempty"`
+	LocationCode   string `json:"location_code,omitempty"`
+	Quantity       int    `json:"quantity"`
+	Reserved       int    `json:"reserved"`
+	Available      int    `json:"available"`
+	MinQty         int    `json:"min_qty"`
+	ReorderPoint   int    `json:"reorder_point"`
+	MaxQty         int    `json:"max_qty"`
+	BelowMin       bool   `json:"below_min"`
+	SuggestedOrder int    `json:"suggested_order"`
+}
+
+type stockAlertResp struct {
+	ID             int    `json:"id"`
+	ItemID         int    `json:"item_id"`
+	ItemName       string `json:"item_name"`
+	LocationID     *int   `json:"location_id,omitempty"`
+	LocationCode   string `json:"location_code,omitempty"`
+	Available      int    `json:"available"`
+	ReorderPoint   int    `json:"reorder_point"`
+	Status         string `json:"status"`
+	AcknowledgedBy *int   `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt string `json:"acknowledged_at,omitempty"`
+	ResolvedAt     string `json:"resolved_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type supplierReq struct {
+	Name         string `json:"name" binding:"required"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	LeadTimeDays int    `json:"lead_time_days" binding:"min=0"`
+	Status       string `json:"status"`
+	Currency     string `json:"currency"`
+}
+
+type supplierResp struct {
+	ID           int                `json:"id"`
+	Name         string             `json:"name"`
+	ContactName  string             `json:"contact_name"`
+	Email        string             `json:"email"`
+	Phone        string             `json:"phone"`
+	Address      string             `json:"address"`
+	LeadTimeDays int                `json:"lead_time_days"`
+	Status       string             `json:"status"`
+	Currency     string             `json:"currency"`
+	CreatedAt    string             `json:"created_at"`
+	UpdatedAt    string             `json:"updated_at"`
+	Items        []itemSupplierResp `json:"items,omitempty"`
+}
+
+type itemSupplierReq struct {
+	SupplierSKU string       `json:"supplier_sku"`
+	UnitCost    models.Money `json:"unit_cost" binding:"min=0"`
+	MinOrderQty int          `json:"min_order_qty" binding:"min=0"`
+	Preferred   bool         `json:"preferred"`
+}
+
+type itemSupplierResp struct {
+	ItemID       int          `json:"item_id"`
+	ItemName     string       `json:"item_name"`
+	SupplierID   int          `json:"supplier_id"`
+	SupplierName string       `json:"supplier_name"`
+	SupplierSKU  string       `json:"supplier_sku"`
+	UnitCost     models.Money `json:"unit_cost"`
+	Currency     string       `json:"currency"`
+	MinOrderQty  int          `json:"min_order_qty"`
+	Preferred    bool         `json:"preferred"`
+	LeadTimeDays int          `json:"lead_time_days"`
+	UpdatedAt    string       `json:"updated_at"`
+}
+
+type purchaseOrderLineReq struct {
+	ItemID   int          `json:"item_id" binding:"required,min=1"`
+	Quantity float64      `json:"quantity" binding:"required,gt=0"`
+	Unit     string       `json:"unit" binding:"max=16"`
+	UnitCost models.Money `json:"unit_cost" binding:"min=0"`
+}
+
+type purchaseOrderReq struct {
+	SupplierID int                    `json:"supplier_id" binding:"required,min=1"`
+	Currency   string                 `json:"currency"`
+	ExpectedAt string                 `json:"expected_at"`
+	Notes      string                 `json:"notes"`
+	Lines      []purchaseOrderLineReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type purchaseReceiptLineReq struct {
+	ItemID   int      `json:"item_id" binding:"required,min=1"`
+	Quantity float64  `json:"quantity" binding:"required,gt=0"`
+	Unit     string   `json:"unit" binding:"max=16"`
+	Serials  []string `json:"serials" binding:"dive,max=100"`
+}
+
+type purchaseReceiptReq struct {
+	Note  string                   `json:"note"`
+	Lines []purchaseReceiptLineReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type purchaseOrderLineResp struct {
+	ID          int          `json:"id"`
+	ItemID      int          `json:"item_id"`
+	ItemName    string       `json:"item_name"`
+	OrderedQty  int          `json:"ordered_qty"`
+	ReceivedQty int          `json:"received_qty"`
+	Outstanding int          `json:"outstanding"`
+	UnitCost    models.Money `json:"unit_cost"`
+}
+
+type purchaseReceiptResp struct {
+	ID           int         `json:"id"`
+	LineID       int         `json:"line_id"`
+	ItemID       int         `json:"item_id"`
+	Quantity     int         `json:"quantity"`
+	ExchangeRate models.Rate `json:"exchange_rate"`
+	ReceivedBy   *int        `json:"received_by,omitempty"`
+	Note         string      `json:"note,omitempty"`
+	ReceivedAt   string      `json:"received_at"`
+}
+
+type purchaseOrderResp struct {
+	ID           int                     `json:"id"`
+	Number       string                  `json:"number"`
+	SupplierID   int                     `json:"supplier_id"`
+	SupplierName string                  `json:"supplier_name"`
+	Status       string                  `json:"status"`
+	Currency     string                  `json:"currency"`
+	ExpectedAt   string                  `json:"expected_at,omitempty"`
+	Notes        string                  `json:"notes,omitempty"`
+	Total        models.Money            `json:"total"`
+	Lines        []purchaseOrderLineResp `json:"lines"`
+	Receipts     []purchaseReceiptResp   `json:"receipts,omitempty"`
+	SentAt       string                  `json:"sent_at,omitempty"`
+	ClosedAt     string                  `json:"closed_at,omitempty"`
+	CreatedAt    string                  `json:"created_at"`
+	UpdatedAt    string                  `json:"updated_at"`
+}
+
+type outboundOrderLineReq struct {
+	ItemID   int     `json:"item_id" binding:"required,min=1"`
+	Quantity float64 `json:"quantity" binding:"required,gt=0"`
+	Unit     string  `json:"unit" binding:"max=16"`
+}
+
+type outboundOrderReq struct {
+	CustomerRef string                 `json:"customer_ref" binding:"max=255"`
+	ShipTo      string                 `json:"ship_to"`
+	Lines       []outboundOrderLineReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type outboundQuantityReq struct {
+	ItemID   int      `json:"item_id" binding:"required,min=1"`
+	Quantity float64  `json:"quantity" binding:"required,gt=0"`
+	Unit     string   `json:"unit" binding:"max=16"`
+	Serials  []string `json:"serials" binding:"dive,max=100"`
+}
+
+type outboundPackReq struct {
+	Lines []outboundQuantityReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type outboundShipReq struct {
+	TrackingRef string                `json:"tracking_ref" binding:"max=100"`
+	Lines       []outboundQuantityReq `json:"lines" binding:"dive"`
+}
+
+type outboundCancelReq struct {
+	Note string `json:"note"`
+}
+
+type outboundOrderLineResp struct {
+	ID           int    `json:"id"`
+	ItemID       int    `json:"item_id"`
+	ItemName     string `json:"item_name"`
+	OrderedQty   int    `json:"ordered_qty"`
+	AllocatedQty int    `json:"allocated_qty"`
+	PackedQty    int    `json:"packed_qty"`
+	ShippedQty   int    `json:"shipped_qty"`
+}
+
+type shipmentLineResp struct {
+	LineID   int `json:"line_id"`
+	ItemID   int `json:"item_id"`
+	Quantity int `json:"quantity"`
+}
+
+type shipmentResp struct {
+	ID          int                `json:"id"`
+	TrackingRef string             `json:"tracking_ref,omitempty"`
+	ShippedBy   *int               `json:"shipped_by,omitempty"`
+	Lines       []shipmentLineResp `json:"lines"`
+	ShippedAt   string             `json:"shipped_at"`
+}
+
+type outboundOrderEventResp struct {
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status,omitempty"`
+	UserID     *int   `json:"user_id,omitempty"`
+	Note       string `json:"note,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type outboundOrderResp struct {
+	ID          int                      `json:"id"`
+	Number      string                   `json:"number"`
+	CustomerRef string                   `json:"customer_ref,omitempty"`
+	ShipTo      string                   `json:"ship_to,omitempty"`
+	Status      string                   `json:"status"`
+	Lines       []outboundOrderLineResp  `json:"lines"`
+	Shipments   []shipmentResp           `json:"shipments,omitempty"`
+	History     []outboundOrderEventResp `json:"history,omitempty"`
+	CreatedAt   string                   `json:"created_at"`
+	UpdatedAt   string                   `json:"updated_at"`
+}
+
+type pickListItemResp struct {
+	ItemID   int    `json:"item_id"`
+	ItemName string `json:"item_name"`
+	SKU      string `json:"sku,omitempty"`
+	Quantity int    `json:"quantity"`
+}
+
+type pickListGroupResp struct {
+	LocationID   *int               `json:"location_id"`
+	LocationCode string             `json:"location_code,omitempty"`
+	Items        []pickListItemResp `json:"items"`
+}
+
+type replenishmentLineReq struct {
+	SupplierID int `json:"supplier_id" binding:"required,min=1"`
+	ItemID     int `json:"item_id" binding:"required,min=1"`
+	Quantity   int `json:"quantity" binding:"min=0"`
+}
+
+type replenishmentOrdersReq struct {
+	Lines []replenishmentLineReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type replenishmentLineResp struct {
+	ItemID       int          `json:"item_id"`
+	ItemName     string       `json:"item_name"`
+	Available    int          `json:"available"`
+	OnOrder      int          `json:"on_order"`
+	ReorderPoint int          `json:"reorder_point"`
+	MaxQty       int          `json:"max_qty"`
+	Quantity     int          `json:"suggested_qty"`
+	MinOrderQty  int          `json:"min_order_qty,omitempty"`
+	UnitCost     models.Money `json:"unit_cost"`
+}
+
+type replenishmentSuggestionResp struct {
+	SupplierID   int                     `json:"supplier_id"`
+	SupplierName string                  `json:"supplier_name,omitempty"`
+	Currency     string                  `json:"currency,omitempty"`
+	LeadTimeDays int                     `json:"lead_time_days,omitempty"`
+	ExpectedAt   string                  `json:"expected_at,omitempty"`
+	Total        models.Money            `json:"total"`
+	Lines        []replenishmentLineResp `json:"lines"`
+}
+
+type countSessionReq struct {
+	LocationID       *int `json:"location_id" binding:"omitempty,min=1"`
+	CategoryID       *int `json:"category_id" binding:"omitempty,min=1"`
+	Blind            bool `json:"blind"`
+	RecountThreshold *int `json:"recount_threshold" binding:"omitempty,min=0"`
+}
+
+type countEntryReq struct {
+	ItemID   int      `json:"item_id" binding:"required,min=1"`
+	Quantity *float64 `json:"quantity" binding:"required,min=0"`
+	Unit     string   `json:"unit" binding:"max=16"`
+}
+
+type countEntriesReq struct {
+	Lines []countEntryReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type countLineResp struct {
+	ItemID          int    `json:"item_id"`
+	ItemName        string `json:"item_name"`
+	ExpectedQty     *int   `json:"expected_qty,omitempty"`
+	HeldQty         *int   `json:"held_qty,omitempty"`
+	FirstCountedQty *int   `json:"first_counted_qty,omitempty"`
+	CountedQty      *int   `json:"counted_qty"`
+	Variance        *int   `json:"variance,omitempty"`
+	Round           int    `json:"round"`
+	RecountRequired bool   `json:"recount_required"`
+	CountedBy       *int   `json:"counted_by,omitempty"`
+	CountedAt       string `json:"counted_at,omitempty"`
+}
+
+type countRecountResp struct {
+	ItemID   int    `json:"item_id"`
+	ItemName string `json:"item_name"`
+}
+
+type countSessionResp struct {
+	ID               int             `json:"id"`
+	Number           string          `json:"number"`
+	LocationID       *int            `json:"location_id,omitempty"`
+	CategoryID       *int            `json:"category_id,omitempty"`
+	Blind            bool            `json:"blind"`
+	RecountThreshold int             `json:"recount_threshold"`
+	Status           string          `json:"status"`
+	ItemsTotal       int             `json:"items_total"`
+	ItemsCounted     int             `json:"items_counted"`
+	Lines            []countLineResp `json:"lines,omitempty"`
+	ApprovedBy       *int            `json:"approved_by,omitempty"`
+	ApprovedAt       string          `json:"approved_at,omitempty"`
+	CreatedAt        string          `json:"created_at"`
+	UpdatedAt        string          `json:"updated_at"`
+}
+
+type returnLineReq struct {
+	ItemID   int     `json:"item_id" binding:"required,min=1"`
+	Quantity float64 `json:"quantity" binding:"required,gt=0"`
+	Unit     string  `json:"unit" binding:"max=16"`
+	Reason   string  `json:"reason" binding:"required,max=20"`
+}
+
+type returnReq struct {
+	OrderID int             `json:"order_id" binding:"required,min=1"`
+	Note    string          `json:"note"`
+	Lines   []returnLineReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type returnInspectionReq struct {
+	ItemID      int      `json:"item_id" binding:"required,min=1"`
+	Quantity    float64  `json:"quantity" binding:"required,gt=0"`
+	Unit        string   `json:"unit" binding:"max=16"`
+	Disposition string   `json:"disposition" binding:"required,max=20"`
+	Serials     []string `json:"serials" binding:"dive,max=100"`
+	Note        string   `json:"note"`
+}
+
+type returnReceiveReq struct {
+	Lines []returnInspectionReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type returnLineResp struct {
+	ID            int    `json:"id"`
+	OrderLineID   int    `json:"order_line_id"`
+	ItemID        int    `json:"item_id"`
+	ItemName      string `json:"item_name"`
+	Reason        string `json:"reason"`
+	AuthorizedQty int    `json:"authorized_qty"`
+	ReceivedQty   int    `json:"received_qty"`
+}
+
+type returnReceiptResp struct {
+	ID          int      `json:"id"`
+	LineID      int      `json:"line_id"`
+	ItemID      int      `json:"item_id"`
+	Quantity    int      `json:"quantity"`
+	Disposition string   `json:"disposition"`
+	Serials     []string `json:"serials,omitempty"`
+	Note        string   `json:"note,omitempty"`
+	ReceivedBy  *int     `json:"received_by,omitempty"`
+	ReceivedAt  string   `json:"received_at"`
+}
+
+type returnResp struct {
+	ID          int                 `json:"id"`
+	Number      string              `json:"number"`
+	OrderID     int                 `json:"order_id"`
+	OrderNumber string              `json:"order_number"`
+	Status      string              `json:"status"`
+	Note        string              `json:"note,omitempty"`
+	Lines       []returnLineResp    `json:"lines"`
+	Receipts    []returnReceiptResp `json:"receipts,omitempty"`
+	CreatedAt   string              `json:"created_at"`
+	UpdatedAt   string              `json:"updated_at"`
+}
+
+type returnReasonStatResp struct {
+	Reason        string  `json:"reason"`
+	Returns       int     `json:"returns"`
+	AuthorizedQty int     `json:"authorized_qty"`
+	ReceivedQty   int     `json:"received_qty"`
+	Restocked     int     `json:"restocked"`
+	Refurbished   int     `json:"refurbished"`
+	Quarantined   int     `json:"quarantined"`
+	Scrapped      int     `json:"scrapped"`
+	SharePct      float64 `json:"share_pct"`
+}
+
+type stockStatusChangeReq struct {
+	FromStatus string  `json:"from_status" binding:"required,max=20"`
+	ToStatus   string  `json:"to_status" binding:"required,max=20"`
+	Quantity   float64 `json:"quantity" binding:"required,gt=0"`
+	Unit       string  `json:"unit" binding:"max=16"`
+	LocationID *int    `json:"location_id" binding:"omitempty,min=1"`
+	Reason     string  `json:"reason" binding:"required,max=255"`
+}
+
+type heldStockResp struct {
+	Status       string `json:"status"`
+	LocationID   *int   `json:"location_id,omitempty"`
+	LocationCode string `json:"location_code,omitempty"`
+	Quantity     int    `json:"quantity"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type stockStatusChangeResp struct {
+	ID         int    `json:"id"`
+	LocationID *int   `json:"location_id,omitempty"`
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	Quantity   int    `json:"quantity"`
+	Reason     string `json:"reason"`
+	UserID     *int   `json:"user_id,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type itemStockResp struct {
+	ItemID    int                     `json:"item_id"`
+	Available int                     `json:"available"`
+	Held      []heldStockResp         `json:"held"`
+	Changes   []stockStatusChangeResp `json:"changes"`
+}
+
+type writeOffLineReq struct {
+	ItemID     int      `json:"item_id" binding:"required,min=1"`
+	Quantity   float64  `json:"quantity" binding:"required,gt=0"`
+	Unit       string   `json:"unit" binding:"max=16"`
+	LocationID *int     `json:"location_id" binding:"omitempty,min=1"`
+	Serials    []string `json:"serials" binding:"dive,max=100"`
+}
+
+type writeOffReq struct {
+	Reason       string            `json:"reason" binding:"required,max=20"`
+	SourceStatus string            `json:"source_status" binding:"max=20"`
+	Note         string            `json:"note"`
+	Lines        []writeOffLineReq `json:"lines" binding:"required,min=1,dive"`
+}
+
+type writeOffDecisionReq struct {
+	Note string `json:"note"`
+}
+
+type writeOffLineResp struct {
+	ID         int          `json:"id"`
+	ItemID     int          `json:"item_id"`
+	ItemName   string       `json:"item_name"`
+	LocationID *int         `json:"location_id,omitempty"`
+	Quantity   int          `json:"quantity"`
+	UnitCost   models.Money `json:"unit_cost"`
+	Value      models.Money `json:"value"`
+	Serials    []string     `json:"serials,omitempty"`
+}
+
+type writeOffEvidenceResp struct {
+	ID          int    `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+	UploadedBy  *int   `json:"uploaded_by,omitempty"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type writeOffResp struct {
+	ID           int                    `json:"id"`
+	Number       string                 `json:"number"`
+	Reason       string                 `json:"reason"`
+	SourceStatus string                 `json:"source_status"`
+	Status       string                 `json:"status"`
+	Note         string                 `json:"note,omitempty"`
+	TotalQty     int                    `json:"total_qty"`
+	TotalValue   models.Money           `json:"total_value"`
+	Currency     string                 `json:"currency"`
+	CreatedBy    int                    `json:"created_by"`
+	DecidedBy    *int                   `json:"decided_by,omitempty"`
+	DecisionNote string                 `json:"decision_note,omitempty"`
+	Lines        []writeOffLineResp     `json:"lines"`
+	Evidence     []writeOffEvidenceResp `json:"evidence,omitempty"`
+	DecidedAt    string                 `json:"decided_at,omitempty"`
+	PostedAt     string                 `json:"posted_at,omitempty"`
+	CreatedAt    string                 `json:"created_at"`
+}
+
+type changeRequestDecisionReq struct {
+	Note string `json:"note"`
+}
+
+type changeRequestResp struct {
+	ID           int             `json:"id"`
+	Operation    string          `json:"operation"`
+	TargetID     int             `json:"target_id"`
+	Payload      json.RawMessage `json:"payload"`
+	Summary      string          `json:"summary"`
+	Status       string          `json:"status"`
+	RequestedBy  int             `json:"requested_by"`
+	DecidedBy    *int            `json:"decided_by,omitempty"`
+	DecisionNote string          `json:"decision_note,omitempty"`
+	DecidedAt    string          `json:"decided_at,omitempty"`
+	CreatedAt    string          `json:"created_at"`
+}
+
+type userRoleReq struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type valuationMethodReq struct {
+	Method string `json:"method"`
+}
+
+type costLayerResp struct {
+	ID           int          `json:"id"`
+	ReceivedQty  int          `json:"received_qty"`
+	RemainingQty int          `json:"remaining_qty"`
+	UnitCost     models.Money `json:"unit_cost"`
+	Source       string       `json:"source"`
+	ReceivedAt   string       `json:"received_at"`
+}
+
+type costMovementResp struct {
+	ID           int          `json:"id"`
+	Type         string       `json:"type"`
+	Quantity     int          `json:"quantity"`
+	UnitCost     models.Money `json:"unit_cost"`
+	TotalCost    models.Money `json:"total_cost"`
+	Method       string       `json:"method"`
+	Source       string       `json:"source"`
+	BalanceQty   int          `json:"balance_qty"`
+	BalanceValue models.Money `json:"balance_value"`
+	CreatedBy    *int         `json:"created_by,omitempty"`
+	CreatedAt    string       `json:"created_at"`
+}
+
+type itemCostResp struct {
+	ItemID       int                `json:"item_id"`
+	Currency     string             `json:"currency"`
+	Method       string             `json:"method"`
+	ItemMethod   string             `json:"item_method,omitempty"`
+	BalanceQty   int                `json:"balance_qty"`
+	BalanceValue models.Money       `json:"balance_value"`
+	AvgCost      models.Money       `json:"avg_cost"`
+	Layers       []costLayerResp    `json:"layers"`
+	Movements    []costMovementResp `json:"movements"`
+}
+
+type categoryValuationResp struct {
+	CategoryID   *int         `json:"category_id"`
+	CategoryName string       `json:"category_name,omitempty"`
+	Items        int          `json:"items"`
+	Quantity     int          `json:"quantity"`
+	Value        models.Money `json:"value"`
+}
+
+type valuationReportResp struct {
+	AsOf       string                  `json:"as_of"`
+	Currency   string                  `json:"currency"`
+	Rate       models.Rate             `json:"rate"`
+	Items      int                     `json:"items"`
+	Quantity   int                     `json:"quantity"`
+	Value      models.Money            `json:"value"`
+	Categories []categoryValuationResp `json:"categories"`
+}
+
+type currencyReq struct {
+	Code string `json:"code" binding:"required"`
+	Name string `json:"name" binding:"required"`
+}
+
+type currencyResp struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Base      bool   `json:"base"`
+	CreatedAt string `json:"created_at"`
+}
+
+type exchangeRateReq struct {
+	Currency string      `json:"currency" binding:"required"`
+	Date     string      `json:"date" binding:"required"`
+	Rate     models.Rate `json:"rate" binding:"required"`
+}
+
+type exchangeRateResp struct {
+	ID        int         `json:"id"`
+	Currency  string      `json:"currency"`
+	Date      string      `json:"date"`
+	Rate      models.Rate `json:"rate"`
+	CreatedBy *int        `json:"created_by,omitempty"`
+	UpdatedAt string      `json:"updated_at"`
+}
+
+type kitComponentReq struct {
+	ItemID   int     `json:"item_id" binding:"required,min=1"`
+	Quantity float64 `json:"quantity" binding:"required,gt=0"`
+	Unit     string  `json:"unit" binding:"max=16"`
+}
+
+type kitReq struct {
+	Components []kitComponentReq `json:"components" binding:"required,min=1,max=100,dive"`
+}
+
+type kitComponentResp struct {
+	ItemID    int    `json:"item_id"`
+	Name      string `json:"name"`
+	BaseUnit  string `json:"base_unit"`
+	Quantity  int    `json:"quantity"`
+	Available int    `json:"available"`
+}
+
+type kitResp struct {
+	ItemID           int                `json:"item_id"`
+	AvailableToBuild int                `json:"available_to_build"`
+	Components       []kitComponentResp `json:"components"`
+}
+
+type kitAssemblyReq struct {
+	Quantity float64 `json:"quantity" binding:"required,gt=0"`
+	Unit     string  `json:"unit" binding:"max=16"`
+	Note     string  `json:"note"`
+}
+
+type kitAssemblyLineResp struct {
+	ItemID   int          `json:"item_id"`
+	Name     string       `json:"name"`
+	Quantity int          `json:"quantity"`
+	UnitCost models.Money `json:"unit_cost"`
+}
+
+type kitAssemblyResp struct {
+	ID        int                   `json:"id"`
+	Number    string                `json:"number"`
+	KitID     int                   `json:"kit_id"`
+	KitName   string                `json:"kit_name,omitempty"`
+	Operation string                `json:"operation"`
+	Quantity  int                   `json:"quantity"`
+	UnitCost  models.Money          `json:"unit_cost"`
+	Note      string                `json:"note,omitempty"`
+	CreatedBy int                   `json:"created_by"`
+	Lines     []kitAssemblyLineResp `json:"lines"`
+	CreatedAt string                `json:"created_at"`
+}
+
+type abcClassResp struct {
+	Class      string       `json:"class"`
+	Items      int          `json:"items"`
+	Value      models.Money `json:"value"`
+	ValueShare float64      `json:"value_share"`
+}
+
+type itemAnalysisResp struct {
+	ItemID            int          `json:"item_id"`
+	Name              string       `json:"name"`
+	ABCClass          string       `json:"abc_class"`
+	XYZClass          string       `json:"xyz_class"`
+	ConsumptionQty    int          `json:"consumption_qty"`
+	ConsumptionValue  models.Money `json:"consumption_value"`
+	ValueShare        float64      `json:"value_share"`
+	DemandCV          *float64     `json:"demand_cv"`
+	OnHand            int          `json:"on_hand"`
+	LastMovementAt    string       `json:"last_movement_at"`
+	DaysSinceMovement int          `json:"days_since_movement"`
+	MovementStatus    string       `json:"movement_status,omitempty"`
+}
+
+type abcReportResp struct {
+	AnalyzedAt string             `json:"analyzed_at,omitempty"`
+	PeriodFrom string             `json:"period_from,omitempty"`
+	PeriodTo   string             `json:"period_to,omitempty"`
+	Classes    []abcClassResp     `json:"classes"`
+	Matrix     map[string]int     `json:"matrix"`
+	Items      []itemAnalysisResp `json:"items"`
+}
+
+type forecastPeriodResp struct {
+	Month    string  `json:"month"`
+	Quantity float64 `json:"quantity"`
+}
+
+type stockRecommendationResp struct {
+	DailyDemand  float64 `json:"daily_demand"`
+	SafetyStock  int     `json:"safety_stock"`
+	ReorderPoint int     `json:"reorder_point"`
+	MaxQty       int     `json:"max_qty"`
+}
+
+type stockLevelResp struct {
+	MinQty       int `json:"min_qty"`
+	ReorderPoint int `json:"reorder_point"`
+	MaxQty       int `json:"max_qty"`
+}
+
+type forecastResp struct {
+	ItemID         int                     `json:"item_id"`
+	Name           string                  `json:"name"`
+	Method         string                  `json:"method"`
+	MAE            float64                 `json:"mae"`
+	RMSE           float64                 `json:"rmse"`
+	LeadTimeDays   int                     `json:"lead_time_days"`
+	ServiceLevel   float64                 `json:"service_level"`
+	History        []forecastPeriodResp    `json:"history"`
+	Periods        []forecastPeriodResp    `json:"periods"`
+	Recommendation stockRecommendationResp `json:"recommendation"`
+	Current        *stockLevelResp         `json:"current,omitempty"`
+}
+
+type dashboardFlowResp struct {
+	InboundQty        int `json:"inbound_qty"`
+	InboundMovements  int `json:"inbound_movements"`
+	OutboundQty       int `json:"outbound_qty"`
+	OutboundMovements int `json:"outbound_movements"`
+}
+
+type topMoverResp struct {
+	ItemID   int    `json:"item_id"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+type activeUserResp struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Changes  int    `json:"changes"`
+}
+
+type recentChangeResp struct {
+	ID        int     `json:"id"`
+	ItemID    int     `json:"item_id"`
+	ItemName  string  `json:"item_name"`
+	UserID    int     `json:"user_id"`
+	Username  string  `json:"username"`
+	Operation string  `json:"operation"`
+	Details   *string `json:"details,omitempty"`
+	ChangedAt string  `json:"changed_at"`
+}
+
+type dashboardResp struct {
+	GeneratedAt   string              `json:"generated_at"`
+	SKUs          int                 `json:"skus"`
+	Units         int                 `json:"units"`
+	Value         *models.Money       `json:"value,omitempty"`
+	StockOuts     int                 `json:"stock_outs"`
+	LowStock      int                 `json:"low_stock"`
+	Today         dashboardFlowResp   `json:"today"`
+	TopMovers     []topMoverResp      `json:"top_movers"`
+	ActiveUsers   *[]activeUserResp   `json:"active_users,omitempty"`
+	RecentChanges *[]recentChangeResp `json:"recent_changes,omitempty"`
+}
@@ -0,0 +1,397 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createOutboundOrder - handler для создания заказа на отгрузку.
+func (h *handler) createOutboundOrder(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req outboundOrderReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createOutboundOrder: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	order := &models.OutboundOrder{
+		CustomerRef: req.CustomerRef,
+		ShipTo:      req.ShipTo,
+		Lines:       make([]models.OutboundOrderLine, 0, len(req.Lines)),
+	}
+	for _, line := range req.Lines {
+		quantity, ok := h.baseQuantity(c, "createOutboundOrder", line.ItemID, line.Quantity, line.Unit)
+		if !ok {
+			return
+		}
+		order.Lines = append(order.Lines, models.OutboundOrderLine{
+			ItemID:     line.ItemID,
+			OrderedQty: quantity,
+		})
+	}
+
+	id, number, err := h.outboundSvc.CreateOutboundOrder(c.Request.Context(), userID, order)
+	if err != nil {
+		outboundError(c, "createOutboundOrder", "не удалось создать заказ", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("order_id", id).
+		Str("order_number", number).
+		Msg("createOutboundOrder: заказ успешно создан")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id, "number": number, "status": models.OutboundNew})
+}
+
+// getOutboundOrders - handler для получения заказов на отгрузку, опционально по статусу.
+func (h *handler) getOutboundOrders(c *ginext.Context) {
+	orders, err := h.outboundSvc.GetOutboundOrders(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		outboundError(c, "getOutboundOrders", "не удалось получить заказы", err)
+		return
+	}
+
+	resp := make([]outboundOrderResp, 0, len(orders))
+	for _, order := range orders {
+		resp = append(resp, toOutboundOrderResp(order))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getOutboundOrder - handler для получения заказа на отгрузку с отгрузками и историей статусов.
+func (h *handler) getOutboundOrder(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getOutboundOrder: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	order, err := h.outboundSvc.GetOutboundOrder(c.Request.Context(), id)
+	if err != nil {
+		outboundError(c, "getOutboundOrder", "не удалось получить заказ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOutboundOrderResp(*order))
+}
+
+// allocateOutboundOrder - handler для резервирования остатка под заказ.
+func (h *handler) allocateOutboundOrder(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("allocateOutboundOrder: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	status, allocated, err := h.outboundSvc.AllocateOutboundOrder(c.Request.Context(), userID, id)
+	if err != nil {
+		outboundError(c, "allocateOutboundOrder", "не удалось разместить заказ", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("order_id", id).
+		Int("allocated", allocated).
+		Str("status", status).
+		Msg("allocateOutboundOrder: заказ размещен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": status, "allocated": allocated})
+}
+
+// getPickList - handler для формирования листа подбора, сгруппированного по ячейкам.
+func (h *handler) getPickList(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getPickList: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	entries, err := h.outboundSvc.StartPicking(c.Request.Context(), userID, id)
+	if err != nil {
+		outboundError(c, "getPickList", "не удалось сформировать лист подбора", err)
+		return
+	}
+
+	// Записи приходят отсортированными по коду ячейки, без ячейки - в конце.
+	var groups []pickListGroupResp
+	for _, entry := range entries {
+		n := len(groups)
+		if n == 0 || groups[n-1].LocationCode != entry.LocationCode {
+			groups = append(groups, pickListGroupResp{
+				LocationID:   entry.LocationID,
+				LocationCode: entry.LocationCode,
+			})
+			n++
+		}
+		groups[n-1].Items = append(groups[n-1].Items, pickListItemResp{
+			ItemID:   entry.ItemID,
+			ItemName: entry.ItemName,
+			SKU:      entry.SKU,
+			Quantity: entry.Quantity,
+		})
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("order_id", id).
+		Int("locations", len(groups)).
+		Msg("getPickList: лист подбора сформирован")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": models.OutboundPicking, "locations": groups})
+}
+
+// packOutboundOrder - handler для подтверждения упаковки.
+func (h *handler) packOutboundOrder(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("packOutboundOrder: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req outboundPackReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("packOutboundOrder: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	lines, ok := h.outboundQuantities(c, "packOutboundOrder", req.Lines)
+	if !ok {
+		return
+	}
+
+	status, err := h.outboundSvc.PackOutboundOrder(c.Request.Context(), userID, id, lines)
+	if err != nil {
+		outboundError(c, "packOutboundOrder", "не удалось упаковать заказ", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("order_id", id).
+		Int("lines", len(lines)).
+		Str("status", status).
+		Msg("packOutboundOrder: упаковка подтверждена")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": status})
+}
+
+// shipOutboundOrder - handler для отгрузки упакованного товара, без строк отгружается все упакованное.
+func (h *handler) shipOutboundOrder(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("shipOutboundOrder: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req outboundShipReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("shipOutboundOrder: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	lines, ok := h.outboundQuantities(c, "shipOutboundOrder", req.Lines)
+	if !ok {
+		return
+	}
+
+	shipmentID, status, err := h.outboundSvc.ShipOutboundOrder(c.Request.Context(), userID, id, req.TrackingRef, lines)
+	if err != nil {
+		outboundError(c, "shipOutboundOrder", "не удалось отгрузить заказ", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("order_id", id).
+		Int("shipment_id", shipmentID).
+		Str("status", status).
+		Msg("shipOutboundOrder: заказ отгружен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "shipment_id": shipmentID, "status": status})
+}
+
+// cancelOutboundOrder - handler для отмены заказа со снятием резервов.
+func (h *handler) cancelOutboundOrder(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("cancelOutboundOrder: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req outboundCancelReq
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			zlog.Logger.Warn().
+				Err(err).
+				Msg("cancelOutboundOrder: некорректный JSON запрос")
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+			return
+		}
+	}
+
+	if err := h.outboundSvc.CancelOutboundOrder(c.Request.Context(), userID, id, req.Note); err != nil {
+		outboundError(c, "cancelOutboundOrder", "не удалось отменить заказ", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("order_id", id).
+		Msg("cancelOutboundOrder: заказ отменен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": models.OutboundCancelled})
+}
+
+// outboundQuantities - перевод строк упаковки или отгрузки в базовые единицы items.
+func (h *handler) outboundQuantities(c *ginext.Context, op string, req []outboundQuantityReq) ([]models.OutboundQuantity, bool) {
+	lines := make([]models.OutboundQuantity, 0, len(req))
+	for _, line := range req {
+		quantity, ok := h.baseQuantity(c, op, line.ItemID, line.Quantity, line.Unit)
+		if !ok {
+			return nil, false
+		}
+		lines = append(lines, models.OutboundQuantity{
+			ItemID:   line.ItemID,
+			Quantity: quantity,
+			Serials:  line.Serials,
+		})
+	}
+
+	return lines, true
+}
+
+// outboundError - ответ на ошибку операции с заказами на отгрузку.
+func outboundError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"),
+		strings.Contains(err.Error(), "недостаточно"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
+
+func toOutboundOrderResp(order models.OutboundOrder) outboundOrderResp {
+	lines := make([]outboundOrderLineResp, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		lines = append(lines, outboundOrderLineResp{
+			ID:           line.ID,
+			ItemID:       line.ItemID,
+			ItemName:     line.ItemName,
+			OrderedQty:   line.OrderedQty,
+			AllocatedQty: line.AllocatedQty,
+			PackedQty:    line.PackedQty,
+			ShippedQty:   line.ShippedQty,
+		})
+	}
+
+	var shipments []shipmentResp
+	for _, shipment := range order.Shipments {
+		shipmentLines := make([]shipmentLineResp, 0, len(shipment.Lines))
+		for _, line := range shipment.Lines {
+			shipmentLines = append(shipmentLines, shipmentLineResp{
+				LineID:   line.LineID,
+				ItemID:   line.ItemID,
+				Quantity: line.Quantity,
+			})
+		}
+		shipments = append(shipments, shipmentResp{
+			ID:          shipment.ID,
+			TrackingRef: shipment.TrackingRef,
+			ShippedBy:   shipment.ShippedBy,
+			Lines:       shipmentLines,
+			ShippedAt:   shipment.ShippedAt.Format(time.RFC3339),
+		})
+	}
+
+	var history []outboundOrderEventResp
+	for _, event := range order.History {
+		history = append(history, outboundOrderEventResp{
+			FromStatus: event.FromStatus,
+			ToStatus:   event.ToStatus,
+			UserID:     event.UserID,
+			Note:       event.Note,
+			CreatedAt:  event.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return outboundOrderResp{
+		ID:          order.ID,
+		Number:      order.Number,
+		CustomerRef: order.CustomerRef,
+		ShipTo:      order.ShipTo,
+		Status:      order.Status,
+		Lines:       lines,
+		Shipments:   shipments,
+		History:     history,
+		CreatedAt:   order.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   order.UpdatedAt.Format(time.RFC3339),
+	}
+}
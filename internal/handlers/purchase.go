@@ -0,0 +1,300 @@
+package httphandlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createPurchaseOrder - handler для создания черновика заказа на закупку.
+func (h *handler) createPurchaseOrder(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req purchaseOrderReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createPurchaseOrder: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	expectedAt, err := parseOptionalDate(req.ExpectedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+
+	po := &models.PurchaseOrder{
+		SupplierID: req.SupplierID,
+		ExpectedAt: expectedAt,
+		Notes:      strings.TrimSpace(req.Notes),
+		Currency:   req.Currency,
+		Lines:      make([]models.PurchaseOrderLine, 0, len(req.Lines)),
+	}
+	for _, line := range req.Lines {
+		quantity, ok := h.baseQuantity(c, "createPurchaseOrder", line.ItemID, line.Quantity, line.Unit)
+		if !ok {
+			return
+		}
+		po.Lines = append(po.Lines, models.PurchaseOrderLine{
+			ItemID:     line.ItemID,
+			OrderedQty: quantity,
+			UnitCost:   line.UnitCost,
+		})
+	}
+
+	id, number, err := h.purchaseSvc.CreatePurchaseOrder(c.Request.Context(), userID, po)
+	if err != nil {
+		purchaseError(c, "createPurchaseOrder", "не удалось создать заказ", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("po_id", id).
+		Str("po_number", number).
+		Int("supplier_id", po.SupplierID).
+		Msg("createPurchaseOrder: заказ успешно создан")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id, "number": number, "status": models.PODraft})
+}
+
+// getPurchaseOrders - handler для получения заказов на закупку, опционально по статусу и поставщику.
+func (h *handler) getPurchaseOrders(c *ginext.Context) {
+	filter := models.PurchaseOrderFilter{Status: c.Query("status")}
+	if supplierID := c.Query("supplier_id"); supplierID != "" {
+		id, err := parseID(supplierID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: supplier_id " + err.Error()})
+			return
+		}
+		filter.SupplierID = &id
+	}
+
+	orders, err := h.purchaseSvc.GetPurchaseOrders(c.Request.Context(), filter)
+	if err != nil {
+		purchaseError(c, "getPurchaseOrders", "не удалось получить заказы", err)
+		return
+	}
+
+	resp := make([]purchaseOrderResp, 0, len(orders))
+	for _, po := range orders {
+		resp = append(resp, toPurchaseOrderResp(po))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getPurchaseOrder - handler для получения заказа на закупку со строками и приемками.
+func (h *handler) getPurchaseOrder(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getPurchaseOrder: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	po, err := h.purchaseSvc.GetPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		purchaseError(c, "getPurchaseOrder", "не удалось получить заказ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPurchaseOrderResp(*po))
+}
+
+// sendPurchaseOrder - handler для отправки черновика заказа поставщику.
+func (h *handler) sendPurchaseOrder(c *ginext.Context) {
+	h.changePurchaseOrderStatus(c, "sendPurchaseOrder", models.POSent, h.purchaseSvc.SendPurchaseOrder)
+}
+
+// closePurchaseOrder - handler для закрытия заказа, в том числе с недопоставкой.
+func (h *handler) closePurchaseOrder(c *ginext.Context) {
+	h.changePurchaseOrderStatus(c, "closePurchaseOrder", models.POClosed, h.purchaseSvc.ClosePurchaseOrder)
+}
+
+// deletePurchaseOrder - handler для удаления черновика заказа.
+func (h *handler) deletePurchaseOrder(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("deletePurchaseOrder: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.purchaseSvc.DeletePurchaseOrder(c.Request.Context(), id); err != nil {
+		purchaseError(c, "deletePurchaseOrder", "не удалось удалить заказ", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("po_id", id).
+		Msg("deletePurchaseOrder: заказ успешно удален")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "message": "заказ успешно удален"})
+}
+
+// receivePurchaseOrder - handler для приемки товара по заказу на закупку.
+func (h *handler) receivePurchaseOrder(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("receivePurchaseOrder: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req purchaseReceiptReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("receivePurchaseOrder: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	lines := make([]models.ReceiptLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		quantity, ok := h.baseQuantity(c, "receivePurchaseOrder", line.ItemID, line.Quantity, line.Unit)
+		if !ok {
+			return
+		}
+		lines = append(lines, models.ReceiptLine{
+			ItemID:   line.ItemID,
+			Quantity: quantity,
+			Serials:  line.Serials,
+		})
+	}
+
+	status, err := h.purchaseSvc.ReceivePurchaseOrder(c.Request.Context(), userID, id, lines, req.Note)
+	if err != nil {
+		purchaseError(c, "receivePurchaseOrder", "не удалось принять товар по заказу", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("po_id", id).
+		Int("lines", len(lines)).
+		Str("status", status).
+		Msg("receivePurchaseOrder: товар принят по заказу")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": status})
+}
+
+// changePurchaseOrderStatus - общий код handlers смены статуса заказа.
+func (h *handler) changePurchaseOrderStatus(c *ginext.Context, op, status string, change func(ctx context.Context, id int) error) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg(op + ": некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := change(c.Request.Context(), id); err != nil {
+		purchaseError(c, op, "не удалось изменить статус заказа", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("po_id", id).
+		Str("status", status).
+		Msg(op + ": статус заказа изменен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": status})
+}
+
+// purchaseError - ответ на ошибку операции с заказами на закупку.
+func purchaseError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"),
+		strings.Contains(err.Error(), "уже существует"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
+
+func toPurchaseOrderResp(po models.PurchaseOrder) purchaseOrderResp {
+	lines := make([]purchaseOrderLineResp, 0, len(po.Lines))
+	for _, line := range po.Lines {
+		lines = append(lines, purchaseOrderLineResp{
+			ID:          line.ID,
+			ItemID:      line.ItemID,
+			ItemName:    line.ItemName,
+			OrderedQty:  line.OrderedQty,
+			ReceivedQty: line.ReceivedQty,
+			Outstanding: line.Outstanding(),
+			UnitCost:    line.UnitCost,
+		})
+	}
+
+	var receipts []purchaseReceiptResp
+	for _, receipt := range po.Receipts {
+		receipts = append(receipts, purchaseReceiptResp{
+			ID:           receipt.ID,
+			LineID:       receipt.LineID,
+			ItemID:       receipt.ItemID,
+			Quantity:     receipt.Quantity,
+			ExchangeRate: receipt.ExchangeRate,
+			ReceivedBy:   receipt.ReceivedBy,
+			Note:         receipt.Note,
+			ReceivedAt:   receipt.ReceivedAt.Format(time.RFC3339),
+		})
+	}
+
+	return purchaseOrderResp{
+		ID:           po.ID,
+		Number:       po.Number,
+		SupplierID:   po.SupplierID,
+		SupplierName: po.SupplierName,
+		Status:       po.Status,
+		Currency:     po.Currency,
+		ExpectedAt:   formatOptionalDate(po.ExpectedAt),
+		Notes:        po.Notes,
+		Total:        po.Total(),
+		Lines:        lines,
+		Receipts:     receipts,
+		SentAt:       formatOptionalTime(po.SentAt),
+		ClosedAt:     formatOptionalTime(po.ClosedAt),
+		CreatedAt:    po.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    po.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,113 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// getReplenishmentSuggestions - handler для получения предложений к заказу по поставщикам.
+func (h *handler) getReplenishmentSuggestions(c *ginext.Context) {
+	suggestions, err := h.replenishmentSvc.GetSuggestions(c.Request.Context())
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("getReplenishmentSuggestions: не удалось рассчитать предложения к заказу")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось рассчитать предложения к заказу"})
+		return
+	}
+
+	resp := make([]replenishmentSuggestionResp, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		lines := make([]replenishmentLineResp, 0, len(suggestion.Lines))
+		for _, line := range suggestion.Lines {
+			lines = append(lines, replenishmentLineResp{
+				ItemID:       line.ItemID,
+				ItemName:     line.ItemName,
+				Available:    line.Available,
+				OnOrder:      line.OnOrder,
+				ReorderPoint: line.ReorderPoint,
+				MaxQty:       line.MaxQty,
+				Quantity:     line.Quantity,
+				MinOrderQty:  line.MinOrderQty,
+				UnitCost:     line.UnitCost,
+			})
+		}
+
+		item := replenishmentSuggestionResp{
+			SupplierID:   suggestion.SupplierID,
+			SupplierName: suggestion.SupplierName,
+			Currency:     suggestion.Currency,
+			Total:        suggestion.Total(),
+			Lines:        lines,
+		}
+		if suggestion.SupplierID != 0 {
+			item.LeadTimeDays = suggestion.LeadTimeDays
+			item.ExpectedAt = formatOptionalDate(&suggestion.ExpectedAt)
+		}
+
+		resp = append(resp, item)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// createReplenishmentOrders - handler для создания черновиков заказов на закупку по принятым предложениям.
+func (h *handler) createReplenishmentOrders(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req replenishmentOrdersReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createReplenishmentOrders: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	accepted := make([]models.AcceptedSuggestion, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		accepted = append(accepted, models.AcceptedSuggestion{
+			SupplierID: line.SupplierID,
+			ItemID:     line.ItemID,
+			Quantity:   line.Quantity,
+		})
+	}
+
+	orders, err := h.replenishmentSvc.CreatePurchaseOrders(c.Request.Context(), userID, accepted)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "некорректн"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Msg("createReplenishmentOrders: не удалось создать заказы")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось создать заказы"})
+		}
+		return
+	}
+
+	resp := make([]purchaseOrderResp, 0, len(orders))
+	for _, po := range orders {
+		zlog.Logger.Info().
+			Int("user_id", userID).
+			Int("po_id", po.ID).
+			Str("po_number", po.Number).
+			Int("supplier_id", po.SupplierID).
+			Msg("createReplenishmentOrders: заказ создан по предложениям пополнения")
+
+		resp = append(resp, toPurchaseOrderResp(po))
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
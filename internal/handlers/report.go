@@ -0,0 +1,112 @@
+package httphandlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/report"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// reportWriteFunc - метод сервиса отчетов, записывающий отчет в w.
+type reportWriteFunc func(ctx context.Context, filter models.ReportFilter, format string, w io.Writer) error
+
+// getMovementsReport - handler для отчета по стоимостным движениям за период,
+// опционально по item и автору движения.
+func (h *handler) getMovementsReport(c *ginext.Context) {
+	h.streamReport(c, "getMovementsReport", "movements", h.reportSvc.WriteMovements)
+}
+
+// getUserActivityReport - handler для отчета по изменениям items пользователями за период,
+// опционально по item и пользователю.
+func (h *handler) getUserActivityReport(c *ginext.Context) {
+	h.streamReport(c, "getUserActivityReport", "user_activity", h.reportSvc.WriteUserActivity)
+}
+
+// getBalancesReport - handler для оборотной ведомости за период, опционально по item.
+func (h *handler) getBalancesReport(c *ginext.Context) {
+	h.streamReport(c, "getBalancesReport", "balances", h.reportSvc.WriteBalances)
+}
+
+// streamReport - разбор параметров периода и потоковая выдача отчета файлом в формате format.
+// Формат по умолчанию - json. После начала записи ошибка уже не может быть передана клиенту
+// и только логируется, ответ при этом обрывается.
+func (h *handler) streamReport(c *ginext.Context, op, name string, write reportWriteFunc) {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+
+	format := c.DefaultQuery("format", report.FormatJSON)
+	if err := report.ValidateFormat(format); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("%s_%s_%s.%s", name, filter.From.Format(dateLayout), filter.To.Format(dateLayout), format)
+	c.Header("Content-Type", report.ContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	if err := write(c.Request.Context(), filter, format, c.Writer); err != nil {
+		if c.Writer.Written() {
+			zlog.Logger.Error().
+				Err(err).
+				Str("report", name).
+				Msg(op + ": отчет прерван после начала записи")
+			return
+		}
+
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		if strings.Contains(err.Error(), "некорректн") {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": не удалось сформировать отчет")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось сформировать отчет"})
+	}
+}
+
+// parseReportFilter - разбор параметров отчета: обязательные from и to, необязательные item_id и user_id.
+func parseReportFilter(c *ginext.Context) (models.ReportFilter, error) {
+	var filter models.ReportFilter
+
+	from, err := parseOptionalDate(c.Query("from"))
+	if err != nil {
+		return filter, err
+	}
+	to, err := parseOptionalDate(c.Query("to"))
+	if err != nil {
+		return filter, err
+	}
+	if from == nil || to == nil {
+		return filter, fmt.Errorf("нужно указать период from и to")
+	}
+	filter.From, filter.To = *from, *to
+
+	if itemIDStr := c.Query("item_id"); itemIDStr != "" {
+		itemID, err := parseID(itemIDStr)
+		if err != nil {
+			return filter, fmt.Errorf("item_id: %w", err)
+		}
+		filter.ItemID = &itemID
+	}
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := parseID(userIDStr)
+		if err != nil {
+			return filter, fmt.Errorf("user_id: %w", err)
+		}
+		filter.UserID = &userID
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,262 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createReservation - handler для резервирования остатка item под заказ.
+func (h *handler) createReservation(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createReservation: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req reservationReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createReservation: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Float64("quantity", req.Quantity).
+		Str("unit", req.Unit).
+		Msg("createReservation: попытка резервирования item")
+
+	quantity, ok := h.baseQuantity(c, "createReservation", itemID, req.Quantity, req.Unit)
+	if !ok {
+		return
+	}
+
+	res := &models.Reservation{
+		ItemID:   itemID,
+		Quantity: quantity,
+		OrderRef: req.OrderRef,
+	}
+	if req.ExpiresAt != nil {
+		res.ExpiresAt = *req.ExpiresAt
+	}
+
+	id, err := h.resSvc.Reserve(c.Request.Context(), userID, res)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("createReservation: item не найден")
+			c.JSON(http.StatusNotFound, ginext.H{"error": "item с id " + strconv.Itoa(itemID) + " не найден"})
+		case strings.Contains(err.Error(), "недостаточно"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("createReservation: недостаточно доступного остатка")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "expires_at"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("createReservation: некорректный срок резерва")
+			c.JSON(http.StatusBadRequest, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("createReservation: не удалось создать резерв")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось создать резерв"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Int("reservation_id", id).
+		Msg("createReservation: резерв успешно создан")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id, "expires_at": res.ExpiresAt.Format(time.RFC3339)})
+}
+
+// getItemReservations - handler для получения резервов конкретного item.
+func (h *handler) getItemReservations(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getItemReservations: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	reservations, err := h.resSvc.GetItemReservations(c.Request.Context(), itemID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("getItemReservations: не удалось получить резервы")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить резервы"})
+		return
+	}
+
+	resp := make([]reservationResp, 0, len(reservations))
+	for _, res := range reservations {
+		resp = append(resp, toReservationResp(res))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// releaseReservation - handler для снятия резерва.
+func (h *handler) releaseReservation(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("releaseReservation: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.resSvc.Release(c.Request.Context(), id); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("reservation_id", id).
+				Msg("releaseReservation: активный резерв не найден")
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "нельзя") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("reservation_id", id).
+				Msg("releaseReservation: резерв принадлежит заказу на отгрузку")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("reservation_id", id).
+			Msg("releaseReservation: не удалось снять резерв")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось снять резерв"})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("reservation_id", id).
+		Msg("releaseReservation: резерв успешно снят")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "message": "резерв успешно снят"})
+}
+
+// confirmReservation - handler для подтверждения резерва со списанием остатка.
+func (h *handler) confirmReservation(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("confirmReservation: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.resSvc.Confirm(c.Request.Context(), userID, id); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("reservation_id", id).
+				Msg("confirmReservation: активный резерв не найден")
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "нельзя") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("reservation_id", id).
+				Msg("confirmReservation: нельзя подтвердить резерв")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "серийного item") {
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("reservation_id", id).
+				Msg("confirmReservation: остаток серийного item списывается только по серийным номерам")
+			c.JSON(http.StatusConflict, ginext.H{"error": "остаток серийного item списывается только по серийным номерам"})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("reservation_id", id).
+			Msg("confirmReservation: не удалось подтвердить резерв")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось подтвердить резерв"})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("reservation_id", id).
+		Msg("confirmReservation: резерв успешно подтвержден")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "message": "резерв успешно подтвержден"})
+}
+
+func toReservationResp(res models.Reservation) reservationResp {
+	// У резервов заказов на отгрузку срока нет.
+	var expiresAt *time.Time
+	if !res.ExpiresAt.IsZero() {
+		expiresAt = &res.ExpiresAt
+	}
+
+	return reservationResp{
+		ID:        res.ID,
+		ItemID:    res.ItemID,
+		UserID:    res.UserID,
+		Quantity:  res.Quantity,
+		OrderRef:  res.OrderRef,
+		Status:    res.Status,
+		ExpiresAt: formatOptionalTime(expiresAt),
+		CreatedAt: res.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: res.UpdatedAt.Format(time.RFC3339),
+	}
+}
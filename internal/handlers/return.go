@@ -0,0 +1,296 @@
+package httphandlers
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createReturn - handler для оформления возврата по заказу на отгрузку.
+func (h *handler) createReturn(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req returnReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createReturn: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	rma := &models.ReturnAuthorization{
+		OrderID: req.OrderID,
+		Note:    req.Note,
+		Lines:   make([]models.ReturnLine, 0, len(req.Lines)),
+	}
+	for _, line := range req.Lines {
+		quantity, ok := h.baseQuantity(c, "createReturn", line.ItemID, line.Quantity, line.Unit)
+		if !ok {
+			return
+		}
+		rma.Lines = append(rma.Lines, models.ReturnLine{
+			ItemID:        line.ItemID,
+			Reason:        line.Reason,
+			AuthorizedQty: quantity,
+		})
+	}
+
+	id, number, err := h.returnSvc.CreateReturn(c.Request.Context(), userID, rma)
+	if err != nil {
+		returnError(c, "createReturn", "не удалось оформить возврат", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("rma_id", id).
+		Str("rma_number", number).
+		Int("order_id", req.OrderID).
+		Msg("createReturn: возврат оформлен")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id, "number": number, "status": models.ReturnAuthorized})
+}
+
+// getReturns - handler для получения возвратов, опционально по статусу и заказу.
+func (h *handler) getReturns(c *ginext.Context) {
+	var orderID int
+	if orderIDStr := c.Query("order_id"); orderIDStr != "" {
+		parsed, err := parseID(orderIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
+		orderID = parsed
+	}
+
+	rmas, err := h.returnSvc.GetReturns(c.Request.Context(), c.Query("status"), orderID)
+	if err != nil {
+		returnError(c, "getReturns", "не удалось получить возвраты", err)
+		return
+	}
+
+	resp := make([]returnResp, 0, len(rmas))
+	for _, rma := range rmas {
+		resp = append(resp, toReturnResp(rma))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getReturn - handler для получения возврата со строками и приемками.
+func (h *handler) getReturn(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getReturn: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	rma, err := h.returnSvc.GetReturn(c.Request.Context(), id)
+	if err != nil {
+		returnError(c, "getReturn", "не удалось получить возврат", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReturnResp(*rma))
+}
+
+// receiveReturn - handler для приемки возвращенного товара с результатом осмотра.
+func (h *handler) receiveReturn(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("receiveReturn: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req returnReceiveReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("receiveReturn: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	inspections := make([]models.ReturnInspection, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		quantity, ok := h.baseQuantity(c, "receiveReturn", line.ItemID, line.Quantity, line.Unit)
+		if !ok {
+			return
+		}
+		inspections = append(inspections, models.ReturnInspection{
+			ItemID:      line.ItemID,
+			Quantity:    quantity,
+			Disposition: line.Disposition,
+			Serials:     line.Serials,
+			Note:        line.Note,
+		})
+	}
+
+	status, err := h.returnSvc.ReceiveReturn(c.Request.Context(), userID, id, inspections)
+	if err != nil {
+		returnError(c, "receiveReturn", "не удалось принять возврат", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("rma_id", id).
+		Int("lines", len(inspections)).
+		Str("status", status).
+		Msg("receiveReturn: возврат принят")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": status})
+}
+
+// cancelReturn - handler для отмены возврата, по которому еще ничего не принято.
+func (h *handler) cancelReturn(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("cancelReturn: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.returnSvc.CancelReturn(c.Request.Context(), userID, id); err != nil {
+		returnError(c, "cancelReturn", "не удалось отменить возврат", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("rma_id", id).
+		Msg("cancelReturn: возврат отменен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": models.ReturnCancelled})
+}
+
+// getReturnsReport - handler для отчета о возвратах по причинам за период.
+func (h *handler) getReturnsReport(c *ginext.Context) {
+	from, err := parseOptionalDate(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+	to, err := parseOptionalDate(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+
+	stats, err := h.returnSvc.GetReturnReasonStats(c.Request.Context(), from, to)
+	if err != nil {
+		returnError(c, "getReturnsReport", "не удалось получить отчет", err)
+		return
+	}
+
+	var total int
+	for _, stat := range stats {
+		total += stat.AuthorizedQty
+	}
+
+	resp := make([]returnReasonStatResp, 0, len(stats))
+	for _, stat := range stats {
+		var share float64
+		if total > 0 {
+			share = math.Round(float64(stat.AuthorizedQty)*1000/float64(total)) / 10
+		}
+		resp = append(resp, returnReasonStatResp{
+			Reason:        stat.Reason,
+			Returns:       stat.Returns,
+			AuthorizedQty: stat.AuthorizedQty,
+			ReceivedQty:   stat.ReceivedQty,
+			Restocked:     stat.Restocked,
+			Refurbished:   stat.Refurbished,
+			Quarantined:   stat.Quarantined,
+			Scrapped:      stat.Scrapped,
+			SharePct:      share,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// returnError - ответ на ошибку операции с возвратами.
+func returnError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
+
+func toReturnResp(rma models.ReturnAuthorization) returnResp {
+	lines := make([]returnLineResp, 0, len(rma.Lines))
+	for _, line := range rma.Lines {
+		lines = append(lines, returnLineResp{
+			ID:            line.ID,
+			OrderLineID:   line.OrderLineID,
+			ItemID:        line.ItemID,
+			ItemName:      line.ItemName,
+			Reason:        line.Reason,
+			AuthorizedQty: line.AuthorizedQty,
+			ReceivedQty:   line.ReceivedQty,
+		})
+	}
+
+	var receipts []returnReceiptResp
+	for _, receipt := range rma.Receipts {
+		receipts = append(receipts, returnReceiptResp{
+			ID:          receipt.ID,
+			LineID:      receipt.LineID,
+			ItemID:      receipt.ItemID,
+			Quantity:    receipt.Quantity,
+			Disposition: receipt.Disposition,
+			Serials:     receipt.Serials,
+			Note:        receipt.Note,
+			ReceivedBy:  receipt.ReceivedBy,
+			ReceivedAt:  receipt.ReceivedAt.Format(time.RFC3339),
+		})
+	}
+
+	return returnResp{
+		ID:          rma.ID,
+		Number:      rma.Number,
+		OrderID:     rma.OrderID,
+		OrderNumber: rma.OrderNumber,
+		Status:      rma.Status,
+		Note:        rma.Note,
+		Lines:       lines,
+		Receipts:    receipts,
+		CreatedAt:   rma.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   rma.UpdatedAt.Format(time.RFC3339),
+	}
+}
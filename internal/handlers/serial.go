@@ -0,0 +1,203 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// registerSerials - handler для регистрации серийных номеров item.
+func (h *handler) registerSerials(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("registerSerials: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req registerSerialsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("registerSerials: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Int("serials_count", len(req.SerialNumbers)).
+		Msg("registerSerials: попытка регистрации серийных номеров")
+
+	err = h.serialSvc.RegisterSerials(c.Request.Context(), userID, itemID, req.SerialNumbers)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("registerSerials: item не найден")
+			c.JSON(http.StatusNotFound, ginext.H{"error": "item с id " + strconv.Itoa(itemID) + " не найден"})
+		case strings.Contains(err.Error(), "уже существует"), strings.Contains(err.Error(), "не является серийным"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("registerSerials: конфликт серийных номеров")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "серийный номер"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("registerSerials: не удалось зарегистрировать серийные номера")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось зарегистрировать серийные номера"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Int("serials_count", len(req.SerialNumbers)).
+		Msg("registerSerials: серийные номера успешно зарегистрированы")
+
+	c.JSON(http.StatusCreated, ginext.H{"item_id": itemID, "registered": len(req.SerialNumbers)})
+}
+
+// getItemSerials - handler для получения серийных номеров item.
+func (h *handler) getItemSerials(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getItemSerials: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	serials, err := h.serialSvc.GetItemSerials(c.Request.Context(), itemID, c.Query("status"))
+	if err != nil {
+		if strings.Contains(err.Error(), "неизвестный статус") {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("getItemSerials: не удалось получить серийные номера")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить серийные номера"})
+		return
+	}
+
+	resp := make([]serialResp, 0, len(serials))
+	for _, serial := range serials {
+		resp = append(resp, toSerialResp(serial))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getSerial - handler для поиска серийного номера.
+func (h *handler) getSerial(c *ginext.Context) {
+	serialNumber := c.Param("serial")
+
+	serial, err := h.serialSvc.GetSerial(c.Request.Context(), serialNumber)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Str("serial_number", serialNumber).
+			Msg("getSerial: не удалось получить серийный номер")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить серийный номер"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSerialResp(*serial))
+}
+
+// moveSerial - handler для перевода серийного номера в новый статус.
+func (h *handler) moveSerial(c *ginext.Context) {
+	serialNumber := c.Param("serial")
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req moveSerialReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("moveSerial: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Str("serial_number", serialNumber).
+		Str("status", req.Status).
+		Msg("moveSerial: попытка изменения статуса серийного номера")
+
+	if err := h.serialSvc.MoveSerial(c.Request.Context(), userID, serialNumber, req.Status); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "неизвестный статус"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		case strings.Contains(err.Error(), "недопустим"), strings.Contains(err.Error(), "уже изменен"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Str("serial_number", serialNumber).
+				Msg("moveSerial: переход недопустим")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Str("serial_number", serialNumber).
+				Msg("moveSerial: не удалось изменить статус серийного номера")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось изменить статус серийного номера"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Str("serial_number", serialNumber).
+		Str("status", req.Status).
+		Msg("moveSerial: статус серийного номера успешно изменен")
+
+	c.JSON(http.StatusOK, ginext.H{"serial_number": serialNumber, "status": req.Status})
+}
+
+func toSerialResp(serial models.Serial) serialResp {
+	return serialResp{
+		ID:           serial.ID,
+		ItemID:       serial.ItemID,
+		SerialNumber: serial.SerialNumber,
+		Status:       serial.Status,
+		CreatedAt:    serial.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    serial.UpdatedAt.Format(time.RFC3339),
+	}
+}
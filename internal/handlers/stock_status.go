@@ -0,0 +1,154 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// changeStockStatus - handler для перевода запаса item между статусами.
+func (h *handler) changeStockStatus(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("changeStockStatus: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req stockStatusChangeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("changeStockStatus: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	quantity, ok := h.baseQuantity(c, "changeStockStatus", itemID, req.Quantity, req.Unit)
+	if !ok {
+		return
+	}
+
+	change := &models.StockStatusChange{
+		ItemID:     itemID,
+		LocationID: req.LocationID,
+		FromStatus: req.FromStatus,
+		ToStatus:   req.ToStatus,
+		Quantity:   quantity,
+		Reason:     req.Reason,
+	}
+	if err := h.stockStatusSvc.ChangeStockStatus(c.Request.Context(), userID, change); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "нельзя"), strings.Contains(err.Error(), "недостаточно"):
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "некорректн"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("changeStockStatus: не удалось сменить статус запаса")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось сменить статус запаса"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Str("from_status", change.FromStatus).
+		Str("to_status", change.ToStatus).
+		Int("quantity", quantity).
+		Msg("changeStockStatus: статус запаса изменен")
+
+	c.JSON(http.StatusOK, ginext.H{
+		"item_id":     itemID,
+		"from_status": change.FromStatus,
+		"to_status":   change.ToStatus,
+		"quantity":    quantity,
+	})
+}
+
+// getItemStock - handler для получения запаса item по статусам и журнала смены статусов.
+func (h *handler) getItemStock(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getItemStock: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	stock, err := h.stockStatusSvc.GetItemStock(c.Request.Context(), itemID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+			return
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("getItemStock: не удалось получить запас по статусам")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось получить запас по статусам"})
+		return
+	}
+
+	held := make([]heldStockResp, 0, len(stock.Held))
+	for _, qty := range stock.Held {
+		held = append(held, heldStockResp{
+			Status:       qty.Status,
+			LocationID:   qty.LocationID,
+			LocationCode: qty.LocationCode,
+			Quantity:     qty.Quantity,
+			UpdatedAt:    qty.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	changes := make([]stockStatusChangeResp, 0, len(stock.Changes))
+	for _, change := range stock.Changes {
+		changes = append(changes, stockStatusChangeResp{
+			ID:         change.ID,
+			LocationID: change.LocationID,
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Quantity:   change.Quantity,
+			Reason:     change.Reason,
+			UserID:     change.UserID,
+			CreatedAt:  change.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, itemStockResp{
+		ItemID:    stock.ItemID,
+		Available: stock.Available,
+		Held:      held,
+		Changes:   changes,
+	})
+}
+
+// toStockStatuses - запас item по статусам, статусы без запаса не выводятся.
+func toStockStatuses(item models.Item) map[string]int {
+	statuses := map[string]int{models.StockAvailable: item.Quantity}
+	for status, quantity := range item.Held {
+		if quantity > 0 {
+			statuses[status] = quantity
+		}
+	}
+
+	return statuses
+}
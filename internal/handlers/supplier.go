@@ -0,0 +1,349 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createSupplier - handler для создания поставщика.
+func (h *handler) createSupplier(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req supplierReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createSupplier: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	sup := toSupplier(req)
+
+	id, err := h.supplierSvc.CreateSupplier(c.Request.Context(), sup)
+	if err != nil {
+		supplierError(c, "createSupplier", "не удалось создать поставщика", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("supplier_id", id).
+		Str("supplier_name", sup.Name).
+		Msg("createSupplier: поставщик успешно создан")
+
+	c.JSON(http.StatusCreated, ginext.H{"id": id, "name": sup.Name, "status": sup.Status})
+}
+
+// getSuppliers - handler для получения поставщиков, опционально по статусу.
+func (h *handler) getSuppliers(c *ginext.Context) {
+	suppliers, err := h.supplierSvc.GetSuppliers(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		supplierError(c, "getSuppliers", "не удалось получить поставщиков", err)
+		return
+	}
+
+	resp := make([]supplierResp, 0, len(suppliers))
+	for _, sup := range suppliers {
+		resp = append(resp, toSupplierResp(sup))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getSupplier - handler для получения поставщика вместе с поставляемыми items.
+func (h *handler) getSupplier(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getSupplier: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	sup, err := h.supplierSvc.GetSupplier(c.Request.Context(), id)
+	if err != nil {
+		supplierError(c, "getSupplier", "не удалось получить поставщика", err)
+		return
+	}
+
+	links, err := h.supplierSvc.GetSupplierItems(c.Request.Context(), id)
+	if err != nil {
+		supplierError(c, "getSupplier", "не удалось получить поставщика", err)
+		return
+	}
+
+	resp := toSupplierResp(*sup)
+	resp.Items = toItemSupplierResps(links)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// updateSupplier - handler для обновления карточки поставщика.
+func (h *handler) updateSupplier(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("updateSupplier: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req supplierReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("updateSupplier: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	sup := toSupplier(req)
+	sup.ID = id
+
+	if err := h.supplierSvc.UpdateSupplier(c.Request.Context(), sup); err != nil {
+		supplierError(c, "updateSupplier", "не удалось обновить поставщика", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("supplier_id", id).
+		Str("status", sup.Status).
+		Msg("updateSupplier: поставщик успешно обновлен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "message": "поставщик успешно обновлен"})
+}
+
+// deleteSupplier - handler для удаления поставщика.
+func (h *handler) deleteSupplier(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("deleteSupplier: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.supplierSvc.DeleteSupplier(c.Request.Context(), id); err != nil {
+		supplierError(c, "deleteSupplier", "не удалось удалить поставщика", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("supplier_id", id).
+		Msg("deleteSupplier: поставщик успешно удален")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "message": "поставщик успешно удален"})
+}
+
+// setSupplierItem - handler для привязки item к поставщику с условиями поставки.
+func (h *handler) setSupplierItem(c *ginext.Context) {
+	supplierID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setSupplierItem: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+	itemID, err := parseID(c.Param("item_id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setSupplierItem: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req itemSupplierReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setSupplierItem: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	link := &models.ItemSupplier{
+		ItemID:      itemID,
+		SupplierID:  supplierID,
+		SupplierSKU: req.SupplierSKU,
+		UnitCost:    req.UnitCost,
+		MinOrderQty: req.MinOrderQty,
+		Preferred:   req.Preferred,
+	}
+	if link.MinOrderQty == 0 {
+		link.MinOrderQty = 1
+	}
+
+	if err := h.supplierSvc.SetItemSupplier(c.Request.Context(), link); err != nil {
+		supplierError(c, "setSupplierItem", "не удалось привязать item к поставщику", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("supplier_id", supplierID).
+		Int("item_id", itemID).
+		Bool("preferred", link.Preferred).
+		Msg("setSupplierItem: item привязан к поставщику")
+
+	c.JSON(http.StatusOK, ginext.H{
+		"item_id":       itemID,
+		"supplier_id":   supplierID,
+		"supplier_sku":  link.SupplierSKU,
+		"unit_cost":     link.UnitCost,
+		"min_order_qty": link.MinOrderQty,
+		"preferred":     link.Preferred,
+	})
+}
+
+// removeSupplierItem - handler для отвязки item от поставщика.
+func (h *handler) removeSupplierItem(c *ginext.Context) {
+	supplierID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("removeSupplierItem: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+	itemID, err := parseID(c.Param("item_id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("removeSupplierItem: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	if err := h.supplierSvc.RemoveItemSupplier(c.Request.Context(), itemID, supplierID); err != nil {
+		supplierError(c, "removeSupplierItem", "не удалось отвязать item от поставщика", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("supplier_id", supplierID).
+		Int("item_id", itemID).
+		Msg("removeSupplierItem: item отвязан от поставщика")
+
+	c.JSON(http.StatusOK, ginext.H{"item_id": itemID, "supplier_id": supplierID, "message": "item отвязан от поставщика"})
+}
+
+// getItemSuppliers - handler для получения поставщиков item.
+func (h *handler) getItemSuppliers(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getItemSuppliers: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	links, err := h.supplierSvc.GetItemSuppliers(c.Request.Context(), itemID)
+	if err != nil {
+		supplierError(c, "getItemSuppliers", "не удалось получить поставщиков item", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toItemSupplierResps(links))
+}
+
+// supplierError - ответ на ошибку операции с поставщиками.
+func supplierError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "уже существует"),
+		strings.Contains(err.Error(), "нельзя"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
+
+func toSupplier(req supplierReq) *models.Supplier {
+	return &models.Supplier{
+		Name:         req.Name,
+		ContactName:  req.ContactName,
+		Email:        req.Email,
+		Phone:        req.Phone,
+		Address:      req.Address,
+		LeadTimeDays: req.LeadTimeDays,
+		Status:       req.Status,
+		Currency:     req.Currency,
+	}
+}
+
+func toSupplierResp(sup models.Supplier) supplierResp {
+	return supplierResp{
+		ID:           sup.ID,
+		Name:         sup.Name,
+		ContactName:  sup.ContactName,
+		Email:        sup.Email,
+		Phone:        sup.Phone,
+		Address:      sup.Address,
+		LeadTimeDays: sup.LeadTimeDays,
+		Status:       sup.Status,
+		Currency:     sup.Currency,
+		CreatedAt:    sup.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    sup.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toItemSupplierResps(links []models.ItemSupplier) []itemSupplierResp {
+	resp := make([]itemSupplierResp, 0, len(links))
+	for _, link := range links {
+		resp = append(resp, itemSupplierResp{
+			ItemID:       link.ItemID,
+			ItemName:     link.ItemName,
+			SupplierID:   link.SupplierID,
+			SupplierName: link.SupplierName,
+			SupplierSKU:  link.SupplierSKU,
+			UnitCost:     link.UnitCost,
+			Currency:     link.Currency,
+			MinOrderQty:  link.MinOrderQty,
+			Preferred:    link.Preferred,
+			LeadTimeDays: link.LeadTimeDays,
+			UpdatedAt:    link.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return resp
+}
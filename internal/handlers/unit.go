@@ -0,0 +1,141 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/uom"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// setItemUnits - handler для замены базовой единицы и уровней упаковки item.
+func (h *handler) setItemUnits(c *ginext.Context) {
+	itemID, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setItemUnits: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req itemUnitsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setItemUnits: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	units := make([]models.ItemUnit, 0, len(req.Units))
+	for _, u := range req.Units {
+		units = append(units, models.ItemUnit{Code: u.Code, Factor: u.Factor, Of: u.Of})
+	}
+
+	resolved, err := h.unitSvc.SetItemUnits(c.Request.Context(), userID, itemID, req.BaseUnit, units)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "некорректн"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		case strings.Contains(err.Error(), "нельзя"):
+			zlog.Logger.Warn().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("setItemUnits: смена базовой единицы при ненулевом остатке")
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("user_id", userID).
+				Int("item_id", itemID).
+				Msg("setItemUnits: не удалось сохранить единицы измерения")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось сохранить единицы измерения"})
+		}
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Int("units_count", len(resolved)).
+		Msg("setItemUnits: единицы измерения успешно сохранены")
+
+	baseUnit := strings.TrimSpace(req.BaseUnit)
+	if baseUnit == "" {
+		baseUnit = models.DefaultBaseUnit
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"item_id": itemID, "base_unit": baseUnit, "units": toItemUnitResps(resolved)})
+}
+
+// baseQuantity - пересчет количества из запроса в базовые единицы item.
+// При ошибке отправляет ответ и возвращает false.
+func (h *handler) baseQuantity(c *ginext.Context, op string, itemID int, qty float64, unit string) (int, bool) {
+	var (
+		quantity int
+		err      error
+	)
+	if unit == "" {
+		quantity, err = uom.ToBase("", nil, qty, "")
+	} else {
+		quantity, err = h.unitSvc.ToBaseQuantity(c.Request.Context(), itemID, qty, unit)
+	}
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "некорректн"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		default:
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", itemID).
+				Msg(op + ": не удалось пересчитать количество в базовые единицы")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось пересчитать количество"})
+		}
+		return 0, false
+	}
+
+	return quantity, true
+}
+
+// toUnitQuantity - остатки item в единицах unit, nil если единица не определена для item.
+func toUnitQuantity(item models.Item, unit string) *unitQuantityResp {
+	if unit == "" {
+		return nil
+	}
+
+	quantity, err := uom.FromBase(item.BaseUnit, item.Units, item.Quantity, unit)
+	if err != nil {
+		return nil
+	}
+	reserved, _ := uom.FromBase(item.BaseUnit, item.Units, item.Reserved, unit)
+	available, _ := uom.FromBase(item.BaseUnit, item.Units, item.Available(), unit)
+
+	return &unitQuantityResp{
+		Unit:      unit,
+		Quantity:  quantity,
+		Reserved:  reserved,
+		Available: available,
+	}
+}
+
+func toItemUnitResps(units []models.ItemUnit) []itemUnitResp {
+	resp := make([]itemUnitResp, 0, len(units))
+	for _, u := range units {
+		resp = append(resp, itemUnitResp{Code: u.Code, Factor: u.Factor})
+	}
+
+	return resp
+}
@@ -0,0 +1,66 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// changeUserRole - handler для смены роли пользователя.
+// Если смена роли требует согласования, создается запрос и возвращается 202.
+func (h *handler) changeUserRole(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("changeUserRole: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req userRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("changeUserRole: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	cr, err := h.userSvc.ChangeUserRole(c.Request.Context(), userID, id, req.Role)
+	if err != nil {
+		approvalError(c, "changeUserRole", "не удалось изменить роль пользователя", err)
+		return
+	}
+
+	if cr != nil {
+		zlog.Logger.Info().
+			Int("user_id", userID).
+			Int("target_user_id", id).
+			Int("change_request_id", cr.ID).
+			Str("role", req.Role).
+			Msg("changeUserRole: смена роли ожидает согласования")
+		c.JSON(http.StatusAccepted, ginext.H{
+			"id":                id,
+			"change_request_id": cr.ID,
+			"status":            cr.Status,
+			"message":           "смена роли ожидает согласования",
+		})
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("target_user_id", id).
+		Str("role", req.Role).
+		Msg("changeUserRole: роль пользователя изменена")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "role": req.Role})
+}
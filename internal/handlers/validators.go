@@ -3,6 +3,12 @@ package httphandlers
 import (
 	"fmt"
 	"strconv"
+	"time"
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	monthLayout = "2006-01"
 )
 
 func parseID(idStr string) (int, error) {
@@ -21,3 +27,34 @@ func parseID(idStr string) (int, error) {
 
 	return id, nil
 }
+
+// parseOptionalDate - разбор даты в формате YYYY-MM-DD, пустая строка означает отсутствие даты.
+func parseOptionalDate(dateStr string) (*time.Time, error) {
+	if dateStr == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(dateLayout, dateStr)
+	if err != nil {
+		return nil, fmt.Errorf("некорректный формат даты %q, ожидается YYYY-MM-DD", dateStr)
+	}
+
+	return &date, nil
+}
+
+// formatOptionalDate - форматирование необязательной даты в YYYY-MM-DD.
+func formatOptionalDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+
+	return date.Format(dateLayout)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,201 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// getDefaultValuationMethod - handler для получения метода оценки запасов по умолчанию.
+func (h *handler) getDefaultValuationMethod(c *ginext.Context) {
+	method, err := h.valuationSvc.GetDefaultMethod(c.Request.Context())
+	if err != nil {
+		valuationError(c, "getDefaultValuationMethod", "не удалось получить метод оценки", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"method": method})
+}
+
+// setDefaultValuationMethod - handler для смены метода оценки запасов по умолчанию.
+func (h *handler) setDefaultValuationMethod(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req valuationMethodReq
+	if err := c.ShouldBindJSON(&req); err != nil || req.Method == "" {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setDefaultValuationMethod: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if err := h.valuationSvc.SetDefaultMethod(c.Request.Context(), req.Method); err != nil {
+		valuationError(c, "setDefaultValuationMethod", "не удалось сменить метод оценки", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Str("method", req.Method).
+		Msg("setDefaultValuationMethod: метод оценки по умолчанию изменен")
+
+	c.JSON(http.StatusOK, ginext.H{"method": req.Method})
+}
+
+// setItemValuationMethod - handler для назначения метода оценки item, пустой метод - метод по умолчанию.
+func (h *handler) setItemValuationMethod(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setItemValuationMethod: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req valuationMethodReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("setItemValuationMethod: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if err := h.valuationSvc.SetItemMethod(c.Request.Context(), userID, id, req.Method); err != nil {
+		valuationError(c, "setItemValuationMethod", "не удалось назначить метод оценки", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("item_id", id).
+		Str("method", req.Method).
+		Msg("setItemValuationMethod: метод оценки item изменен")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "method": req.Method})
+}
+
+// getItemCosts - handler для получения стоимостного учета item: слоев себестоимости и журнала движений.
+func (h *handler) getItemCosts(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getItemCosts: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	cost, err := h.valuationSvc.GetItemCost(c.Request.Context(), id)
+	if err != nil {
+		valuationError(c, "getItemCosts", "не удалось получить себестоимость item", err)
+		return
+	}
+
+	resp := itemCostResp{
+		ItemID:       cost.ItemID,
+		Currency:     cost.Currency,
+		Method:       cost.Method,
+		ItemMethod:   cost.ItemMethod,
+		BalanceQty:   cost.BalanceQty,
+		BalanceValue: cost.BalanceValue,
+		AvgCost:      cost.AvgCost(),
+		Layers:       make([]costLayerResp, 0, len(cost.Layers)),
+		Movements:    make([]costMovementResp, 0, len(cost.Movements)),
+	}
+	for _, layer := range cost.Layers {
+		resp.Layers = append(resp.Layers, costLayerResp{
+			ID:           layer.ID,
+			ReceivedQty:  layer.ReceivedQty,
+			RemainingQty: layer.RemainingQty,
+			UnitCost:     layer.UnitCost,
+			Source:       layer.Source,
+			ReceivedAt:   layer.ReceivedAt.Format(time.RFC3339),
+		})
+	}
+	for _, m := range cost.Movements {
+		resp.Movements = append(resp.Movements, costMovementResp{
+			ID:           m.ID,
+			Type:         m.Type,
+			Quantity:     m.Quantity,
+			UnitCost:     m.UnitCost,
+			TotalCost:    m.TotalCost,
+			Method:       m.Method,
+			Source:       m.Source,
+			BalanceQty:   m.BalanceQty,
+			BalanceValue: m.BalanceValue,
+			CreatedBy:    m.CreatedBy,
+			CreatedAt:    m.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getValuationReport - handler для отчета о стоимости запаса на дату as_of, по умолчанию на текущий момент,
+// в валюте currency, по умолчанию в базовой.
+func (h *handler) getValuationReport(c *ginext.Context) {
+	asOf, err := parseOptionalDate(c.Query("as_of"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+		return
+	}
+
+	report, err := h.valuationSvc.GetValuation(c.Request.Context(), asOf, c.Query("currency"))
+	if err != nil {
+		valuationError(c, "getValuationReport", "не удалось получить отчет", err)
+		return
+	}
+
+	resp := valuationReportResp{
+		AsOf:       report.AsOf.Format(time.RFC3339),
+		Currency:   report.Currency,
+		Rate:       report.Rate,
+		Items:      report.Items,
+		Quantity:   report.Quantity,
+		Value:      report.Value,
+		Categories: make([]categoryValuationResp, 0, len(report.Categories)),
+	}
+	if asOf != nil {
+		resp.AsOf = formatOptionalDate(asOf)
+	}
+	for _, category := range report.Categories {
+		resp.Categories = append(resp.Categories, categoryValuationResp{
+			CategoryID:   category.CategoryID,
+			CategoryName: category.CategoryName,
+			Items:        category.Items,
+			Quantity:     category.Quantity,
+			Value:        category.Value,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// valuationError - ответ на ошибку операции со стоимостью запасов.
+func valuationError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
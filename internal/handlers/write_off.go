@@ -0,0 +1,328 @@
+package httphandlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// createWriteOff - handler для оформления списания.
+// Списание в пределах порогов проводится сразу, иначе ждет согласования.
+func (h *handler) createWriteOff(c *ginext.Context) {
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req writeOffReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("createWriteOff: некорректный JSON запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	wo := &models.WriteOff{
+		Reason:       req.Reason,
+		SourceStatus: req.SourceStatus,
+		Note:         req.Note,
+		Lines:        make([]models.WriteOffLine, 0, len(req.Lines)),
+	}
+	for _, line := range req.Lines {
+		quantity, ok := h.baseQuantity(c, "createWriteOff", line.ItemID, line.Quantity, line.Unit)
+		if !ok {
+			return
+		}
+		wo.Lines = append(wo.Lines, models.WriteOffLine{
+			ItemID:     line.ItemID,
+			LocationID: line.LocationID,
+			Quantity:   quantity,
+			Serials:    line.Serials,
+		})
+	}
+
+	id, number, err := h.writeOffSvc.CreateWriteOff(c.Request.Context(), userID, wo)
+	if err != nil {
+		writeOffError(c, "createWriteOff", "не удалось оформить списание", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("write_off_id", id).
+		Str("wo_number", number).
+		Str("status", wo.Status).
+		Str("total_value", wo.TotalValue().String()).
+		Msg("createWriteOff: списание оформлено")
+
+	c.JSON(http.StatusCreated, ginext.H{
+		"id":          id,
+		"number":      number,
+		"status":      wo.Status,
+		"total_qty":   wo.TotalQty(),
+		"total_value": wo.TotalValue(),
+	})
+}
+
+// getWriteOffs - handler для получения списаний, опционально по статусу.
+func (h *handler) getWriteOffs(c *ginext.Context) {
+	wos, err := h.writeOffSvc.GetWriteOffs(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		writeOffError(c, "getWriteOffs", "не удалось получить списания", err)
+		return
+	}
+
+	resp := make([]writeOffResp, 0, len(wos))
+	for _, wo := range wos {
+		resp = append(resp, toWriteOffResp(wo))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getWriteOff - handler для получения списания со строками и подтверждающими файлами.
+func (h *handler) getWriteOff(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getWriteOff: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	wo, err := h.writeOffSvc.GetWriteOff(c.Request.Context(), id)
+	if err != nil {
+		writeOffError(c, "getWriteOff", "не удалось получить списание", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWriteOffResp(*wo))
+}
+
+// approveWriteOff - handler для согласования и проведения списания вторым администратором.
+func (h *handler) approveWriteOff(c *ginext.Context) {
+	h.decideWriteOff(c, "approveWriteOff", models.WriteOffPosted)
+}
+
+// rejectWriteOff - handler для отклонения списания.
+func (h *handler) rejectWriteOff(c *ginext.Context) {
+	h.decideWriteOff(c, "rejectWriteOff", models.WriteOffRejected)
+}
+
+// decideWriteOff - общая часть согласования и отклонения списания.
+func (h *handler) decideWriteOff(c *ginext.Context, op, status string) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg(op + ": некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	var req writeOffDecisionReq
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			zlog.Logger.Warn().
+				Err(err).
+				Msg(op + ": некорректный JSON запрос")
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+			return
+		}
+	}
+
+	if status == models.WriteOffPosted {
+		err = h.writeOffSvc.ApproveWriteOff(c.Request.Context(), userID, id, req.Note)
+	} else {
+		err = h.writeOffSvc.RejectWriteOff(c.Request.Context(), userID, id, req.Note)
+	}
+	if err != nil {
+		writeOffError(c, op, "не удалось принять решение по списанию", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("write_off_id", id).
+		Str("status", status).
+		Msg(op + ": решение по списанию принято")
+
+	c.JSON(http.StatusOK, ginext.H{"id": id, "status": status})
+}
+
+// uploadWriteOffEvidence - handler для прикрепления подтверждающего файла (поле file формы multipart).
+func (h *handler) uploadWriteOffEvidence(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("uploadWriteOffEvidence: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	userClaims, _ := c.Get("user")
+	claims := userClaims.(*models.JWTClaims)
+	userID := claims.UserID
+
+	// Запас в 1 МБ на заголовки и границы multipart.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.WriteOffEvidenceMaxSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("uploadWriteOffEvidence: не удалось получить файл")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: нужен файл в поле file размером до 5 МБ"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("uploadWriteOffEvidence: не удалось открыть файл")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось прочитать файл"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, models.WriteOffEvidenceMaxSize+1))
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("uploadWriteOffEvidence: не удалось прочитать файл")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "не удалось прочитать файл"})
+		return
+	}
+
+	evidence := &models.WriteOffEvidence{
+		WriteOffID: id,
+		FileName:   header.Filename,
+		Data:       data,
+	}
+	evidenceID, err := h.writeOffSvc.AddEvidence(c.Request.Context(), userID, evidence)
+	if err != nil {
+		writeOffError(c, "uploadWriteOffEvidence", "не удалось сохранить файл", err)
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("user_id", userID).
+		Int("write_off_id", id).
+		Int("evidence_id", evidenceID).
+		Int("size", len(data)).
+		Msg("uploadWriteOffEvidence: файл прикреплен")
+
+	c.JSON(http.StatusCreated, ginext.H{
+		"id":           evidenceID,
+		"file_name":    evidence.FileName,
+		"content_type": evidence.ContentType,
+		"size":         len(data),
+	})
+}
+
+// getWriteOffEvidence - handler для скачивания подтверждающего файла списания.
+func (h *handler) getWriteOffEvidence(c *ginext.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getWriteOffEvidence: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+	evidenceID, err := parseID(c.Param("evidence_id"))
+	if err != nil {
+		zlog.Logger.Warn().
+			Err(err).
+			Msg("getWriteOffEvidence: некорректный запрос")
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	evidence, err := h.writeOffSvc.GetEvidence(c.Request.Context(), id, evidenceID)
+	if err != nil {
+		writeOffError(c, "getWriteOffEvidence", "не удалось получить файл", err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": evidence.FileName}))
+	c.Data(http.StatusOK, evidence.ContentType, evidence.Data)
+}
+
+// writeOffError - ответ на ошибку операции со списаниями.
+func writeOffError(c *ginext.Context, op, msg string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "нельзя"), strings.Contains(err.Error(), "недостаточно"):
+		c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос: " + err.Error()})
+	default:
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": " + msg)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": msg})
+	}
+}
+
+func toWriteOffResp(wo models.WriteOff) writeOffResp {
+	lines := make([]writeOffLineResp, 0, len(wo.Lines))
+	for _, line := range wo.Lines {
+		lines = append(lines, writeOffLineResp{
+			ID:         line.ID,
+			ItemID:     line.ItemID,
+			ItemName:   line.ItemName,
+			LocationID: line.LocationID,
+			Quantity:   line.Quantity,
+			UnitCost:   line.UnitCost,
+			Value:      line.TotalCost,
+			Serials:    line.Serials,
+		})
+	}
+
+	var evidence []writeOffEvidenceResp
+	for _, file := range wo.Evidence {
+		evidence = append(evidence, writeOffEvidenceResp{
+			ID:          file.ID,
+			FileName:    file.FileName,
+			ContentType: file.ContentType,
+			Size:        file.Size,
+			UploadedBy:  file.UploadedBy,
+			CreatedAt:   file.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return writeOffResp{
+		ID:           wo.ID,
+		Number:       wo.Number,
+		Reason:       wo.Reason,
+		SourceStatus: wo.SourceStatus,
+		Status:       wo.Status,
+		Note:         wo.Note,
+		TotalQty:     wo.TotalQty(),
+		TotalValue:   wo.TotalValue(),
+		Currency:     wo.Currency,
+		CreatedBy:    wo.CreatedBy,
+		DecidedBy:    wo.DecidedBy,
+		DecisionNote: wo.DecisionNote,
+		Lines:        lines,
+		Evidence:     evidence,
+		DecidedAt:    formatOptionalTime(wo.DecidedAt),
+		PostedAt:     formatOptionalTime(wo.PostedAt),
+		CreatedAt:    wo.CreatedAt.Format(time.RFC3339),
+	}
+}
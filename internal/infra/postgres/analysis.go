@@ -0,0 +1,262 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	// Весь запас item вместе с запасом в статусах и время последнего движения по стоимостному журналу,
+	// для item без движений - время создания.
+	qListItemMovements = `
+	SELECT i.id, i.item_name, i.quantity + COALESCE((
+		SELECT SUM(s.quantity)
+		FROM item_stock_statuses s
+		WHERE s.item_id = i.id
+	), 0), i.created_at, COALESCE((
+		SELECT MAX(m.created_at)
+		FROM cost_movements m
+		WHERE m.item_id = i.id
+	), i.created_at)
+	FROM items i
+	ORDER BY i.id`
+
+	qListMonthlyConsumption = `
+	SELECT item_id, date_trunc('month', created_at), SUM(quantity), SUM(total_cost)
+	FROM cost_movements
+	WHERE movement_type = 'issue' AND created_at >= $1 AND created_at < $2
+	GROUP BY item_id, date_trunc('month', created_at)
+	ORDER BY item_id, date_trunc('month', created_at)`
+
+	qDeleteItemAnalysis = `
+	DELETE FROM item_analysis`
+
+	qCreateItemAnalysis = `
+	INSERT INTO item_analysis (item_id, abc_class, xyz_class, consumption_qty, consumption_value, value_share,
+		demand_cv, on_hand, last_movement_at, movement_status, period_from, period_to, analyzed_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12, $13)`
+
+	qListItemAnalysis = `
+	SELECT a.item_id, i.item_name, a.abc_class, a.xyz_class, a.consumption_qty, a.consumption_value, a.value_share,
+		a.demand_cv, a.on_hand, a.last_movement_at, COALESCE(a.movement_status, ''), a.period_from, a.period_to,
+		a.analyzed_at
+	FROM item_analysis a
+	JOIN items i ON i.id = a.item_id
+	ORDER BY a.consumption_value DESC, a.item_id`
+)
+
+var _ infra.AnalysisRepo = (*analysisRepo)(nil)
+
+type analysisRepo struct {
+	db *dbpg.DB
+}
+
+// ListItemDemand - метод для получения исходных данных анализа всех items:
+// запас, время последнего движения и расход по месяцам в периоде [from, to).
+func (r *analysisRepo) ListItemDemand(ctx context.Context, from, to time.Time) ([]models.ItemDemand, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, qListItemMovements)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListItemDemand: не удалось выполнить запрос ListItemMovements")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListItemMovements: %w", err)
+	}
+	defer rows.Close()
+
+	var demands []models.ItemDemand
+	index := make(map[int]int)
+	for rows.Next() {
+		var d models.ItemDemand
+		if err := rows.Scan(&d.ItemID, &d.ItemName, &d.OnHand, &d.CreatedAt, &d.LastMovementAt); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListItemDemand: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		index[d.ItemID] = len(demands)
+		demands = append(demands, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListItemDemand: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+	rows.Close()
+
+	periods, err := r.db.QueryWithRetry(ctx, strategy, qListMonthlyConsumption, from, to)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListItemDemand: не удалось выполнить запрос ListMonthlyConsumption")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListMonthlyConsumption: %w", err)
+	}
+	defer periods.Close()
+
+	for periods.Next() {
+		var itemID int
+		var p models.DemandPeriod
+		if err := periods.Scan(&itemID, &p.Month, &p.Quantity, &p.Value); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListItemDemand: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		if i, ok := index[itemID]; ok {
+			demands[i].Periods = append(demands[i].Periods, p)
+		}
+	}
+
+	if err := periods.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListItemDemand: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return demands, nil
+}
+
+// SaveItemAnalysis - метод для замены результатов анализа целиком в одной транзакции.
+func (r *analysisRepo) SaveItemAnalysis(ctx context.Context, results []models.ItemAnalysis) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("SaveItemAnalysis: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, qDeleteItemAnalysis); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("SaveItemAnalysis: не удалось удалить прежние результаты")
+
+		return fmt.Errorf("не удалось удалить прежние результаты анализа: %w", err)
+	}
+
+	for _, a := range results {
+		var cv sql.NullFloat64
+		if a.DemandCV != nil {
+			cv = sql.NullFloat64{Float64: *a.DemandCV, Valid: true}
+		}
+		if _, err := tx.ExecContext(
+			ctx,
+			qCreateItemAnalysis,
+			a.ItemID,
+			a.ABCClass,
+			a.XYZClass,
+			a.ConsumptionQty,
+			a.ConsumptionValue,
+			a.ValueShare,
+			cv,
+			a.OnHand,
+			a.LastMovementAt,
+			a.MovementStatus,
+			a.PeriodFrom,
+			a.PeriodTo,
+			a.AnalyzedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", a.ItemID).
+				Msg("SaveItemAnalysis: не удалось сохранить результат анализа")
+
+			return fmt.Errorf("не удалось сохранить результат анализа: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("SaveItemAnalysis: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// ListItemAnalysis - метод для получения результатов последнего анализа, по убыванию стоимости расхода.
+func (r *analysisRepo) ListItemAnalysis(ctx context.Context) ([]models.ItemAnalysis, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, qListItemAnalysis)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListItemAnalysis: не удалось выполнить запрос ListItemAnalysis")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListItemAnalysis: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.ItemAnalysis
+	for rows.Next() {
+		var a models.ItemAnalysis
+		var cv sql.NullFloat64
+		if err := rows.Scan(
+			&a.ItemID,
+			&a.ItemName,
+			&a.ABCClass,
+			&a.XYZClass,
+			&a.ConsumptionQty,
+			&a.ConsumptionValue,
+			&a.ValueShare,
+			&cv,
+			&a.OnHand,
+			&a.LastMovementAt,
+			&a.MovementStatus,
+			&a.PeriodFrom,
+			&a.PeriodTo,
+			&a.AnalyzedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListItemAnalysis: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		if cv.Valid {
+			a.DemandCV = &cv.Float64
+		}
+
+		results = append(results, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListItemAnalysis: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return results, nil
+}
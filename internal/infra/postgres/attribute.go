@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qCreateAttributeDefinition = `
+	INSERT INTO attribute_definitions (category_id, attr_code, attr_name, attr_type, required, allowed_values, unit)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id`
+
+	// Схема категории включает атрибуты всех ее предков, начиная с корня.
+	qListCategoryAttributes = `
+	WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id, 0 AS depth FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id, c.parent_id, a.depth + 1 FROM categories c JOIN ancestors a ON c.id = a.parent_id
+	)
+	SELECT d.id, d.category_id, d.attr_code, d.attr_name, d.attr_type, d.required, d.allowed_values, d.unit, d.created_at
+	FROM attribute_definitions d
+	JOIN ancestors a ON a.id = d.category_id
+	ORDER BY a.depth DESC, d.attr_code`
+
+	// Атрибуты всех подкатегорий на любой глубине, без самой категории.
+	qListSubcategoryAttributes = `
+	WITH RECURSIVE descendants AS (
+		SELECT id FROM categories WHERE parent_id = $1
+		UNION ALL
+		SELECT c.id FROM categories c JOIN descendants d ON c.parent_id = d.id
+	)
+	SELECT a.id, a.category_id, a.attr_code, a.attr_name, a.attr_type, a.required, a.allowed_values, a.unit, a.created_at
+	FROM attribute_definitions a
+	JOIN descendants d ON d.id = a.category_id
+	ORDER BY a.attr_code, a.category_id`
+
+	attributeCategoryConstraint = "attribute_definitions_category_id_fkey"
+)
+
+var _ infra.AttributeRepo = (*attributeRepo)(nil)
+
+type attributeRepo struct {
+	db *dbpg.DB
+}
+
+// CreateAttributeDefinition - метод для добавления атрибута в схему категории.
+func (r *attributeRepo) CreateAttributeDefinition(ctx context.Context, def *models.AttributeDefinition) (int, error) {
+	allowed := "[]"
+	if len(def.AllowedValues) > 0 {
+		data, err := json.Marshal(def.AllowedValues)
+		if err != nil {
+			return 0, fmt.Errorf("не удалось сериализовать допустимые значения: %w", err)
+		}
+		allowed = string(data)
+	}
+
+	var id int
+	if err := r.db.Master.QueryRowContext(
+		ctx,
+		qCreateAttributeDefinition,
+		def.CategoryID,
+		def.Code,
+		def.Name,
+		def.Type,
+		def.Required,
+		allowed,
+		def.Unit,
+	).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("атрибут %s уже существует в категории %d", def.Code, def.CategoryID)
+		}
+		if isForeignKeyViolationOn(err, attributeCategoryConstraint) {
+			return 0, fmt.Errorf("категория с id %d не найдена", def.CategoryID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", def.CategoryID).
+			Str("attr_code", def.Code).
+			Msg("CreateAttributeDefinition: не удалось создать атрибут")
+
+		return 0, fmt.Errorf("не удалось создать атрибут: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetCategoryAttributes - метод для получения схемы атрибутов категории с учетом наследования от предков.
+func (r *attributeRepo) GetCategoryAttributes(ctx context.Context, categoryID int) ([]models.AttributeDefinition, error) {
+	return r.listAttributes(ctx, "GetCategoryAttributes", qListCategoryAttributes, categoryID)
+}
+
+// GetSubcategoryAttributes - метод для получения атрибутов, определенных в подкатегориях категории на любой глубине.
+func (r *attributeRepo) GetSubcategoryAttributes(ctx context.Context, categoryID int) ([]models.AttributeDefinition, error) {
+	return r.listAttributes(ctx, "GetSubcategoryAttributes", qListSubcategoryAttributes, categoryID)
+}
+
+// listAttributes - выборка описаний атрибутов запросом query по категории.
+func (r *attributeRepo) listAttributes(ctx context.Context, op, query string, categoryID int) ([]models.AttributeDefinition, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		query,
+		categoryID,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", categoryID).
+			Msg(op + ": не удалось выполнить запрос " + op)
+
+		return nil, fmt.Errorf("не удалось выполнить запрос %s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var defs []models.AttributeDefinition
+	for rows.Next() {
+		var def models.AttributeDefinition
+		var allowed []byte
+		if err := rows.Scan(
+			&def.ID,
+			&def.CategoryID,
+			&def.Code,
+			&def.Name,
+			&def.Type,
+			&def.Required,
+			&allowed,
+			&def.Unit,
+			&def.CreatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("category_id", categoryID).
+				Msg(op + ": не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		if err := json.Unmarshal(allowed, &def.AllowedValues); err != nil {
+			return nil, fmt.Errorf("не удалось разобрать допустимые значения атрибута %s: %w", def.Code, err)
+		}
+
+		defs = append(defs, def)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", categoryID).
+			Msg(op + ": не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return defs, nil
+}
@@ -0,0 +1,594 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qCreateCategory = `
+	INSERT INTO categories (parent_id, category_name)
+	VALUES ($1, $2)
+	RETURNING id`
+
+	qListCategories = `
+	SELECT c.id, c.parent_id, c.category_name, COUNT(i.id), c.created_at, c.updated_at
+	FROM categories c
+	LEFT JOIN items i ON i.category_id = c.id
+	GROUP BY c.id
+	ORDER BY c.category_name`
+
+	// Перемещения и объединения категорий выполняются последовательно,
+	// иначе два встречных перемещения могут образовать цикл.
+	qLockCategories = `
+	LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`
+
+	qCategoryExists = `
+	SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1)`
+
+	qIsCategoryInSubtree = `
+	WITH RECURSIVE subtree AS (
+		SELECT id FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+	)
+	SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)`
+
+	qMoveCategory = `
+	UPDATE categories SET parent_id = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qMoveCategoryItems = `
+	UPDATE items SET category_id = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE category_id = $1`
+
+	qMoveCategoryChildren = `
+	UPDATE categories SET parent_id = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE parent_id = $1`
+
+	qDeleteCategory = `
+	DELETE FROM categories
+	WHERE id = $1`
+
+	// Атрибут из схемы источника (с предками), который в схеме цели (с предками) определен иначе:
+	// другим типом, списком значений или как обязательный. Items источника ему могут не соответствовать.
+	qFindMergeAttributeConflict = `
+	WITH RECURSIVE src AS (
+		SELECT id, parent_id FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN src s ON c.id = s.parent_id
+	), dst AS (
+		SELECT id, parent_id FROM categories WHERE id = $2
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN dst d ON c.id = d.parent_id
+	)
+	SELECT s.attr_code
+	FROM attribute_definitions s
+	JOIN src ON src.id = s.category_id
+	JOIN attribute_definitions d ON d.attr_code = s.attr_code
+	JOIN dst ON dst.id = d.category_id
+	WHERE d.id <> s.id
+		AND (d.attr_type <> s.attr_type OR d.allowed_values <> s.allowed_values OR (d.required AND NOT s.required))
+	ORDER BY s.attr_code
+	LIMIT 1`
+
+	// Атрибут, который после объединения будет определен дважды: код схемы источника, переносимый в цель,
+	// уже есть в подкатегории цели, или код схемы цели есть в подкатегории источника.
+	qFindMergeAttributeDuplicate = `
+	WITH RECURSIVE src AS (
+		SELECT id, parent_id FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN src s ON c.id = s.parent_id
+	), dst AS (
+		SELECT id, parent_id FROM categories WHERE id = $2
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN dst d ON c.id = d.parent_id
+	), src_sub AS (
+		SELECT id FROM categories WHERE parent_id = $1
+		UNION ALL
+		SELECT c.id FROM categories c JOIN src_sub s ON c.parent_id = s.id
+	), dst_sub AS (
+		SELECT id FROM categories WHERE parent_id = $2 AND id <> $1
+		UNION ALL
+		SELECT c.id FROM categories c JOIN dst_sub d ON c.parent_id = d.id WHERE c.id <> $1
+	), src_codes AS (
+		SELECT a.attr_code FROM attribute_definitions a JOIN src ON src.id = a.category_id
+	), dst_codes AS (
+		SELECT a.attr_code FROM attribute_definitions a JOIN dst ON dst.id = a.category_id
+	)
+	SELECT a.attr_code
+	FROM attribute_definitions a
+	WHERE (a.category_id IN (SELECT id FROM dst_sub)
+			AND a.attr_code IN (SELECT attr_code FROM src_codes)
+			AND a.attr_code NOT IN (SELECT attr_code FROM dst_codes))
+		OR (a.category_id IN (SELECT id FROM src_sub)
+			AND a.attr_code IN (SELECT attr_code FROM dst_codes))
+	ORDER BY a.attr_code
+	LIMIT 1`
+
+	// Атрибуты схемы источника, которых нет в схеме цели, копируются в цель до удаления источника
+	// необязательными: в цели и ее подкатегориях могут быть items без этих значений.
+	qCopyMergeAttributes = `
+	WITH RECURSIVE src AS (
+		SELECT id, parent_id, 0 AS depth FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id, c.parent_id, s.depth + 1 FROM categories c JOIN src s ON c.id = s.parent_id
+	), dst AS (
+		SELECT id, parent_id FROM categories WHERE id = $2
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN dst d ON c.id = d.parent_id
+	)
+	INSERT INTO attribute_definitions (category_id, attr_code, attr_name, attr_type, required, allowed_values, unit)
+	SELECT DISTINCT ON (a.attr_code) $2::INT, a.attr_code, a.attr_name, a.attr_type, FALSE, a.allowed_values, a.unit
+	FROM attribute_definitions a
+	JOIN src ON src.id = a.category_id
+	WHERE a.attr_code NOT IN (
+		SELECT d.attr_code FROM attribute_definitions d JOIN dst ON dst.id = d.category_id
+	)
+	ORDER BY a.attr_code, src.depth`
+
+	// Атрибут, унаследованный перемещаемой категорией $1 от прежних предков, который в цепочке нового родителя $2
+	// определен другим типом или списком значений.
+	qFindMoveAttributeConflict = `
+	WITH RECURSIVE src AS (
+		SELECT id, parent_id FROM categories WHERE id = (SELECT parent_id FROM categories WHERE id = $1)
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN src s ON c.id = s.parent_id
+	), dst AS (
+		SELECT id, parent_id FROM categories WHERE id = $2
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN dst d ON c.id = d.parent_id
+	)
+	SELECT s.attr_code
+	FROM attribute_definitions s
+	JOIN src ON src.id = s.category_id
+	JOIN attribute_definitions d ON d.attr_code = s.attr_code
+	JOIN dst ON dst.id = d.category_id
+	WHERE d.id <> s.id AND (d.attr_type <> s.attr_type OR d.allowed_values <> s.allowed_values)
+	ORDER BY s.attr_code
+	LIMIT 1`
+
+	// Атрибут цепочки нового родителя $2, который уже определен в поддереве перемещаемой категории $1.
+	qFindMoveAttributeDuplicate = `
+	WITH RECURSIVE dst AS (
+		SELECT id, parent_id FROM categories WHERE id = $2
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN dst d ON c.id = d.parent_id
+	), subtree AS (
+		SELECT id FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+	)
+	SELECT a.attr_code
+	FROM attribute_definitions a
+	JOIN subtree ON subtree.id = a.category_id
+	WHERE a.attr_code IN (SELECT d.attr_code FROM attribute_definitions d JOIN dst ON dst.id = d.category_id)
+	ORDER BY a.attr_code
+	LIMIT 1`
+
+	// Обязательный атрибут схемы категории $2 (с предками), значения которого нет у item
+	// из поддерева категории $1, попадающего в эту схему.
+	qFindMissingRequiredAttribute = `
+	WITH RECURSIVE dst AS (
+		SELECT id, parent_id FROM categories WHERE id = $2
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN dst d ON c.id = d.parent_id
+	), subtree AS (
+		SELECT id FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+	)
+	SELECT d.attr_code
+	FROM attribute_definitions d
+	JOIN dst ON dst.id = d.category_id
+	JOIN items i ON i.category_id IN (SELECT id FROM subtree)
+	WHERE d.required AND NOT (i.attributes ? d.attr_code)
+	ORDER BY d.attr_code
+	LIMIT 1`
+
+	// Атрибуты прежних предков перемещаемой категории $1, которых нет в цепочке нового родителя $2,
+	// копируются в саму категорию, чтобы значения атрибутов items поддерева оставались в схеме.
+	qCopyMoveAttributes = `
+	WITH RECURSIVE src AS (
+		SELECT id, parent_id, 0 AS depth FROM categories WHERE id = (SELECT parent_id FROM categories WHERE id = $1)
+		UNION ALL
+		SELECT c.id, c.parent_id, s.depth + 1 FROM categories c JOIN src s ON c.id = s.parent_id
+	), dst AS (
+		SELECT id, parent_id FROM categories WHERE id = $2
+		UNION ALL
+		SELECT c.id, c.parent_id FROM categories c JOIN dst d ON c.id = d.parent_id
+	)
+	INSERT INTO attribute_definitions (category_id, attr_code, attr_name, attr_type, required, allowed_values, unit)
+	SELECT DISTINCT ON (a.attr_code) $1::INT, a.attr_code, a.attr_name, a.attr_type, a.required, a.allowed_values, a.unit
+	FROM attribute_definitions a
+	JOIN src ON src.id = a.category_id
+	WHERE a.attr_code NOT IN (
+		SELECT d.attr_code FROM attribute_definitions d JOIN dst ON dst.id = d.category_id
+	)
+	ORDER BY a.attr_code, src.depth`
+
+	categoryParentConstraint = "categories_parent_id_fkey"
+)
+
+var _ infra.CategoryRepo = (*categoryRepo)(nil)
+
+type categoryRepo struct {
+	db *dbpg.DB
+}
+
+// CreateCategory - метод для создания категории, при ParentID == nil - корневой.
+func (r *categoryRepo) CreateCategory(ctx context.Context, cat *models.Category) (int, error) {
+	var id int
+	if err := r.db.Master.QueryRowContext(ctx, qCreateCategory, cat.ParentID, cat.Name).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("категория %s уже существует в родительской категории", cat.Name)
+		}
+		if isForeignKeyViolationOn(err, categoryParentConstraint) {
+			return 0, fmt.Errorf("родительская категория с id %d не найдена", *cat.ParentID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Str("category_name", cat.Name).
+			Msg("CreateCategory: не удалось создать категорию")
+
+		return 0, fmt.Errorf("не удалось создать категорию: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListCategories - метод для получения всех категорий с количеством items, назначенных непосредственно в них.
+func (r *categoryRepo) ListCategories(ctx context.Context) ([]models.Category, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListCategories,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListCategories: не удалось выполнить запрос ListCategories")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListCategories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []models.Category
+	for rows.Next() {
+		var cat models.Category
+		var parentID sql.NullInt64
+		if err := rows.Scan(
+			&cat.ID,
+			&parentID,
+			&cat.Name,
+			&cat.ItemCount,
+			&cat.CreatedAt,
+			&cat.UpdatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListCategories: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		if parentID.Valid {
+			id := int(parentID.Int64)
+			cat.ParentID = &id
+		}
+
+		categories = append(categories, cat)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListCategories: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return categories, nil
+}
+
+// MoveCategory - метод для перемещения категории вместе с поддеревом под parentID, при nil - в корень.
+// Атрибуты прежних предков, которых нет в схеме нового родителя, копируются в перемещаемую категорию.
+// При расхождении схем или отсутствии у items обязательных атрибутов нового родителя перемещение запрещено.
+func (r *categoryRepo) MoveCategory(ctx context.Context, id int, parentID *int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", id).
+			Msg("MoveCategory: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, qLockCategories); err != nil {
+		return fmt.Errorf("не удалось заблокировать категории: %w", err)
+	}
+
+	if err := categoryExists(ctx, tx, id); err != nil {
+		return err
+	}
+	if parentID != nil {
+		if err := categoryExists(ctx, tx, *parentID); err != nil {
+			return err
+		}
+
+		var inSubtree bool
+		if err := tx.QueryRowContext(ctx, qIsCategoryInSubtree, id, *parentID).Scan(&inSubtree); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("category_id", id).
+				Msg("MoveCategory: не удалось проверить поддерево категории")
+
+			return fmt.Errorf("не удалось проверить поддерево категории: %w", err)
+		}
+		if inSubtree {
+			return fmt.Errorf("нельзя переместить категорию %d в ее собственную подкатегорию %d", id, *parentID)
+		}
+	}
+
+	if err := moveAttributes(ctx, tx, id, parentID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, qMoveCategory, id, parentID); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("категория с таким названием уже существует в родительской категории")
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", id).
+			Msg("MoveCategory: не удалось переместить категорию")
+
+		return fmt.Errorf("не удалось переместить категорию: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", id).
+			Msg("MoveCategory: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// MergeCategory - метод для объединения категории sourceID с targetID.
+// Items и подкатегории sourceID переносятся в targetID, sourceID удаляется.
+// Атрибуты схемы sourceID, которых нет в схеме targetID, копируются в targetID необязательными,
+// чтобы значения атрибутов перенесенных items оставались в схеме. При расхождении схем объединение запрещено.
+// Возвращает количество перенесенных items.
+func (r *categoryRepo) MergeCategory(ctx context.Context, userID, sourceID, targetID int) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось начать транзакцию")
+
+		return 0, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("MergeCategory: не удалось установить userID")
+
+		return 0, fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qLockCategories); err != nil {
+		return 0, fmt.Errorf("не удалось заблокировать категории: %w", err)
+	}
+
+	if err := categoryExists(ctx, tx, sourceID); err != nil {
+		return 0, err
+	}
+	if err := categoryExists(ctx, tx, targetID); err != nil {
+		return 0, err
+	}
+
+	var inSubtree bool
+	if err := tx.QueryRowContext(ctx, qIsCategoryInSubtree, sourceID, targetID).Scan(&inSubtree); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось проверить поддерево категории")
+
+		return 0, fmt.Errorf("не удалось проверить поддерево категории: %w", err)
+	}
+	if inSubtree {
+		return 0, fmt.Errorf("нельзя объединить категорию %d с ее подкатегорией %d", sourceID, targetID)
+	}
+
+	if err := mergeAttributes(ctx, tx, sourceID, targetID); err != nil {
+		return 0, err
+	}
+
+	details := fmt.Sprintf("объединение категорий: %d -> %d", sourceID, targetID)
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		return 0, fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, qMoveCategoryItems, sourceID, targetID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось перенести items")
+
+		return 0, fmt.Errorf("не удалось перенести items: %w", err)
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("не удалось получить количество перенесенных items: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qMoveCategoryChildren, sourceID, targetID); err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("подкатегория с таким названием уже существует в категории %d", targetID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось перенести подкатегории")
+
+		return 0, fmt.Errorf("не удалось перенести подкатегории: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qDeleteCategory, sourceID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось удалить категорию")
+
+		return 0, fmt.Errorf("не удалось удалить категорию: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось завершить транзакцию")
+
+		return 0, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return int(moved), nil
+}
+
+// mergeAttributes - проверка совместимости схем атрибутов и копирование атрибутов sourceID в targetID
+// в рамках транзакции объединения.
+func mergeAttributes(ctx context.Context, tx *sql.Tx, sourceID, targetID int) error {
+	var code string
+	err := tx.QueryRowContext(ctx, qFindMergeAttributeConflict, sourceID, targetID).Scan(&code)
+	switch {
+	case err == nil:
+		return fmt.Errorf("нельзя объединить категории %d и %d: атрибут %s определен в их схемах по-разному", sourceID, targetID, code)
+	case !errors.Is(err, sql.ErrNoRows):
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось сравнить схемы атрибутов")
+
+		return fmt.Errorf("не удалось сравнить схемы атрибутов: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, qFindMergeAttributeDuplicate, sourceID, targetID).Scan(&code)
+	switch {
+	case err == nil:
+		return fmt.Errorf("нельзя объединить категории %d и %d: атрибут %s окажется определен дважды в подкатегории", sourceID, targetID, code)
+	case !errors.Is(err, sql.ErrNoRows):
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось проверить атрибуты подкатегорий")
+
+		return fmt.Errorf("не удалось проверить атрибуты подкатегорий: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, qFindMissingRequiredAttribute, sourceID, targetID).Scan(&code)
+	switch {
+	case err == nil:
+		return fmt.Errorf("нельзя объединить категории %d и %d: у items нет значения обязательного атрибута %s", sourceID, targetID, code)
+	case !errors.Is(err, sql.ErrNoRows):
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось проверить обязательные атрибуты")
+
+		return fmt.Errorf("не удалось проверить обязательные атрибуты: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qCopyMergeAttributes, sourceID, targetID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", sourceID).
+			Msg("MergeCategory: не удалось перенести атрибуты")
+
+		return fmt.Errorf("не удалось перенести атрибуты: %w", err)
+	}
+
+	return nil
+}
+
+// moveAttributes - проверка совместимости схемы нового родителя parentID с поддеревом категории id
+// и копирование в id атрибутов прежних предков в рамках транзакции перемещения.
+func moveAttributes(ctx context.Context, tx *sql.Tx, id int, parentID *int) error {
+	checks := []struct {
+		query, msg, errMsg string
+	}{
+		{qFindMoveAttributeConflict, "атрибут %s определен в схеме нового родителя по-другому", "не удалось сравнить схемы атрибутов"},
+		{qFindMoveAttributeDuplicate, "атрибут %s окажется определен дважды в подкатегории", "не удалось проверить атрибуты подкатегорий"},
+		{qFindMissingRequiredAttribute, "у items нет значения обязательного атрибута %s", "не удалось проверить обязательные атрибуты"},
+	}
+
+	if parentID != nil {
+		for _, c := range checks {
+			var code string
+			err := tx.QueryRowContext(ctx, c.query, id, *parentID).Scan(&code)
+			switch {
+			case err == nil:
+				return fmt.Errorf("нельзя переместить категорию %d: "+c.msg, id, code)
+			case !errors.Is(err, sql.ErrNoRows):
+				zlog.Logger.Error().
+					Err(err).
+					Int("category_id", id).
+					Msg("MoveCategory: " + c.errMsg)
+
+				return fmt.Errorf("%s: %w", c.errMsg, err)
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, qCopyMoveAttributes, id, parentID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("category_id", id).
+			Msg("MoveCategory: не удалось перенести атрибуты")
+
+		return fmt.Errorf("не удалось перенести атрибуты: %w", err)
+	}
+
+	return nil
+}
+
+// categoryExists - проверка существования категории в рамках транзакции.
+func categoryExists(ctx context.Context, tx *sql.Tx, id int) error {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, qCategoryExists, id).Scan(&exists); err != nil {
+		return fmt.Errorf("не удалось проверить категорию: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("категория с id %d не найдена", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func TestCategoryRepo_MergeCategory_KeepsItemAttributes(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	categories := &categoryRepo{db: db}
+	attributes := &attributeRepo{db: db}
+	items := &itemRepo{db: db}
+
+	admin, err := (&userRepo{db: db}).GetByUsername(ctx, "admin123")
+	require.NoError(t, err)
+
+	suffix := time.Now().Format("150405.000000000")
+	targetID, err := categories.CreateCategory(ctx, &models.Category{Name: "merge-target-" + suffix})
+	require.NoError(t, err)
+	sourceID, err := categories.CreateCategory(ctx, &models.Category{Name: "merge-source-" + suffix})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Master.ExecContext(ctx, `DELETE FROM items WHERE category_id = $1`, targetID)
+		db.Master.ExecContext(ctx, `DELETE FROM categories WHERE id IN ($1, $2)`, sourceID, targetID)
+	})
+
+	_, err = attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: targetID, Code: "weight", Name: "Вес", Type: models.AttrTypeNumber, Unit: "kg",
+	})
+	require.NoError(t, err)
+	_, err = attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: sourceID, Code: "weight", Name: "Вес", Type: models.AttrTypeNumber, Unit: "kg",
+	})
+	require.NoError(t, err)
+	_, err = attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: sourceID, Code: "color", Name: "Цвет", Type: models.AttrTypeEnum, Required: true,
+		AllowedValues: []string{"black", "silver"},
+	})
+	require.NoError(t, err)
+
+	var itemID int
+	require.NoError(t, db.Master.QueryRowContext(ctx,
+		`INSERT INTO items (item_name, category_id, attributes) VALUES ($1, $2, '{"weight": 1.5, "color": "black"}') RETURNING id`,
+		"merge-item-"+suffix, sourceID,
+	).Scan(&itemID))
+
+	moved, err := categories.MergeCategory(ctx, admin.ID, sourceID, targetID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved)
+
+	item, err := items.GetByID(ctx, itemID)
+	require.NoError(t, err)
+	require.NotNil(t, item.CategoryID)
+	assert.Equal(t, targetID, *item.CategoryID)
+
+	schema, err := attributes.GetCategoryAttributes(ctx, targetID)
+	require.NoError(t, err)
+	byCode := make(map[string]models.AttributeDefinition, len(schema))
+	for _, def := range schema {
+		byCode[def.Code] = def
+	}
+	for code := range item.Attributes {
+		assert.Contains(t, byCode, code, fmt.Sprintf("атрибут %s item отсутствует в схеме цели", code))
+	}
+	assert.Len(t, schema, 2)
+	assert.Equal(t, targetID, byCode["color"].CategoryID)
+	assert.Equal(t, []string{"black", "silver"}, byCode["color"].AllowedValues)
+	assert.False(t, byCode["color"].Required)
+}
+
+func TestCategoryRepo_MergeCategory_ErrAttributeConflict(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	categories := &categoryRepo{db: db}
+	attributes := &attributeRepo{db: db}
+
+	admin, err := (&userRepo{db: db}).GetByUsername(ctx, "admin123")
+	require.NoError(t, err)
+
+	suffix := time.Now().Format("150405.000000000")
+	targetID, err := categories.CreateCategory(ctx, &models.Category{Name: "merge-target-" + suffix})
+	require.NoError(t, err)
+	sourceID, err := categories.CreateCategory(ctx, &models.Category{Name: "merge-source-" + suffix})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Master.ExecContext(ctx, `DELETE FROM categories WHERE id IN ($1, $2)`, sourceID, targetID)
+	})
+
+	_, err = attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: targetID, Code: "weight", Name: "Вес", Type: models.AttrTypeNumber,
+	})
+	require.NoError(t, err)
+	_, err = attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: sourceID, Code: "weight", Name: "Вес", Type: models.AttrTypeString,
+	})
+	require.NoError(t, err)
+
+	_, err = categories.MergeCategory(ctx, admin.ID, sourceID, targetID)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя объединить")
+	assert.Contains(t, err.Error(), "weight")
+
+	schema, err := attributes.GetCategoryAttributes(ctx, sourceID)
+	require.NoError(t, err)
+	assert.Len(t, schema, 1)
+}
+
+func TestCategoryRepo_MoveCategory_KeepsInheritedAttributes(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	categories := &categoryRepo{db: db}
+	attributes := &attributeRepo{db: db}
+
+	suffix := time.Now().Format("150405.000000000")
+	parentID, err := categories.CreateCategory(ctx, &models.Category{Name: "move-parent-" + suffix})
+	require.NoError(t, err)
+	childID, err := categories.CreateCategory(ctx, &models.Category{ParentID: &parentID, Name: "move-child-" + suffix})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Master.ExecContext(ctx, `DELETE FROM items WHERE category_id = $1`, childID)
+		db.Master.ExecContext(ctx, `DELETE FROM categories WHERE id IN ($1, $2)`, childID, parentID)
+	})
+
+	_, err = attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: parentID, Code: "color", Name: "Цвет", Type: models.AttrTypeEnum, Required: true,
+		AllowedValues: []string{"black", "silver"},
+	})
+	require.NoError(t, err)
+	_, err = db.Master.ExecContext(ctx,
+		`INSERT INTO items (item_name, category_id, attributes) VALUES ($1, $2, '{"color": "black"}')`,
+		"move-item-"+suffix, childID,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, categories.MoveCategory(ctx, childID, nil))
+
+	schema, err := attributes.GetCategoryAttributes(ctx, childID)
+	require.NoError(t, err)
+	require.Len(t, schema, 1)
+	assert.Equal(t, "color", schema[0].Code)
+	assert.Equal(t, childID, schema[0].CategoryID)
+	assert.True(t, schema[0].Required)
+}
+
+func TestCategoryRepo_MoveCategory_ErrAttributeSchema(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	categories := &categoryRepo{db: db}
+	attributes := &attributeRepo{db: db}
+
+	suffix := time.Now().Format("150405.000000000")
+	parentID, err := categories.CreateCategory(ctx, &models.Category{Name: "move-parent-" + suffix})
+	require.NoError(t, err)
+	childID, err := categories.CreateCategory(ctx, &models.Category{ParentID: &parentID, Name: "move-child-" + suffix})
+	require.NoError(t, err)
+	targetID, err := categories.CreateCategory(ctx, &models.Category{Name: "move-target-" + suffix})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Master.ExecContext(ctx, `DELETE FROM items WHERE category_id = $1`, childID)
+		db.Master.ExecContext(ctx, `DELETE FROM categories WHERE id IN ($1, $2, $3)`, childID, parentID, targetID)
+	})
+
+	_, err = attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: parentID, Code: "weight", Name: "Вес", Type: models.AttrTypeNumber,
+	})
+	require.NoError(t, err)
+	_, err = db.Master.ExecContext(ctx,
+		`INSERT INTO items (item_name, category_id, attributes) VALUES ($1, $2, '{"weight": 1.5}')`,
+		"move-item-"+suffix, childID,
+	)
+	require.NoError(t, err)
+
+	sizeID, err := attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: targetID, Code: "size", Name: "Размер", Type: models.AttrTypeString, Required: true,
+	})
+	require.NoError(t, err)
+
+	err = categories.MoveCategory(ctx, childID, &targetID)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "обязательного атрибута size")
+
+	_, err = db.Master.ExecContext(ctx, `DELETE FROM attribute_definitions WHERE id = $1`, sizeID)
+	require.NoError(t, err)
+	_, err = attributes.CreateAttributeDefinition(ctx, &models.AttributeDefinition{
+		CategoryID: targetID, Code: "weight", Name: "Вес", Type: models.AttrTypeString,
+	})
+	require.NoError(t, err)
+
+	err = categories.MoveCategory(ctx, childID, &targetID)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "атрибут weight определен")
+
+	var parent *int
+	require.NoError(t, db.Master.QueryRowContext(ctx, `SELECT parent_id FROM categories WHERE id = $1`, childID).Scan(&parent))
+	require.NotNil(t, parent)
+	assert.Equal(t, parentID, *parent)
+}
@@ -0,0 +1,414 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qCreateChangeRequest = `
+	INSERT INTO change_requests (operation, target_id, payload, summary, requested_by)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`
+
+	qChangeRequestColumns = `
+	SELECT id, operation, target_id, payload, summary, cr_status, COALESCE(requested_by, 0), decided_by,
+		decision_note, created_at, decided_at
+	FROM change_requests`
+
+	qListChangeRequests = qChangeRequestColumns + `
+	WHERE ($1::TEXT = '' OR cr_status = $1)
+	ORDER BY id DESC`
+
+	qGetChangeRequestByID = qChangeRequestColumns + `
+	WHERE id = $1`
+
+	qLockChangeRequest = qChangeRequestColumns + `
+	WHERE id = $1
+	FOR UPDATE`
+
+	qSetChangeRequestDecision = `
+	UPDATE change_requests SET cr_status = $2, decided_by = $3, decision_note = $4, decided_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qLockItemQuantity = `
+	SELECT quantity
+	FROM items
+	WHERE id = $1
+	FOR UPDATE`
+
+	qSetApprovedQuantity = `
+	UPDATE items SET quantity = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qLockUserRole = `
+	SELECT user_role
+	FROM users
+	WHERE id = $1
+	FOR UPDATE`
+
+	changeRequestPendingConstraint = "uq_change_requests_pending"
+)
+
+var _ infra.ChangeRequestRepo = (*changeRequestRepo)(nil)
+
+type changeRequestRepo struct {
+	db *dbpg.DB
+}
+
+// CreateChangeRequest - метод для создания запроса на согласование операции.
+// На операцию с объектом может быть только один ожидающий запрос. Возвращает id запроса.
+func (r *changeRequestRepo) CreateChangeRequest(ctx context.Context, cr *models.ChangeRequest) (int, error) {
+	payload := "{}"
+	if len(cr.Payload) > 0 {
+		payload = string(cr.Payload)
+	}
+
+	var id int
+	if err := r.db.Master.QueryRowContext(
+		ctx,
+		qCreateChangeRequest,
+		cr.Operation,
+		cr.TargetID,
+		payload,
+		cr.Summary,
+		cr.RequestedBy,
+	).Scan(&id); err != nil {
+		if isUniqueViolationOn(err, changeRequestPendingConstraint) {
+			return 0, fmt.Errorf("нельзя создать запрос на согласование: для %s %d уже есть ожидающий запрос", cr.Operation, cr.TargetID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", cr.RequestedBy).
+			Str("operation", cr.Operation).
+			Int("target_id", cr.TargetID).
+			Msg("CreateChangeRequest: не удалось создать запрос на согласование")
+
+		return 0, fmt.Errorf("не удалось создать запрос на согласование: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListChangeRequests - метод для получения запросов на согласование по статусу, пустой статус - все.
+func (r *changeRequestRepo) ListChangeRequests(ctx context.Context, status string) ([]models.ChangeRequest, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListChangeRequests,
+		status,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("status", status).
+			Msg("ListChangeRequests: не удалось выполнить запрос ListChangeRequests")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListChangeRequests: %w", err)
+	}
+	defer rows.Close()
+
+	var crs []models.ChangeRequest
+	for rows.Next() {
+		var cr models.ChangeRequest
+		if err := scanChangeRequest(rows, &cr); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListChangeRequests: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		crs = append(crs, cr)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListChangeRequests: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return crs, nil
+}
+
+// GetChangeRequest - метод для получения запроса на согласование.
+func (r *changeRequestRepo) GetChangeRequest(ctx context.Context, id int) (*models.ChangeRequest, error) {
+	var cr models.ChangeRequest
+	if err := scanChangeRequest(r.db.QueryRowContext(ctx, qGetChangeRequestByID, id), &cr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("запрос на согласование с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("change_request_id", id).
+			Msg("GetChangeRequest: не удалось выполнить запрос GetChangeRequest")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetChangeRequest: %w", err)
+	}
+
+	return &cr, nil
+}
+
+// ApproveChangeRequest - метод для согласования запроса и выполнения операции в одной транзакции.
+// Согласовать может только другой пользователь, не автор запроса. Возвращает согласованный запрос.
+func (r *changeRequestRepo) ApproveChangeRequest(ctx context.Context, userID, id int, note string) (*models.ChangeRequest, error) {
+	tx, cr, err := r.beginChangeRequestDecision(ctx, "ApproveChangeRequest", userID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if cr.RequestedBy == userID {
+		return nil, fmt.Errorf("нельзя согласовать запрос #%d: автор не может согласовать собственный запрос", id)
+	}
+
+	if err := executeChangeRequest(ctx, tx, userID, cr); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetChangeRequestDecision, id, models.ChangeRequestApproved, userID, note); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("change_request_id", id).
+			Msg("ApproveChangeRequest: не удалось обновить статус запроса")
+
+		return nil, fmt.Errorf("не удалось обновить статус запроса: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("change_request_id", id).
+			Msg("ApproveChangeRequest: не удалось завершить транзакцию")
+
+		return nil, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+	cr.Status = models.ChangeRequestApproved
+	cr.DecidedBy = &userID
+	cr.DecisionNote = note
+
+	return cr, nil
+}
+
+// RejectChangeRequest - метод для отклонения запроса на согласование, операция не выполняется.
+func (r *changeRequestRepo) RejectChangeRequest(ctx context.Context, userID, id int, note string) error {
+	tx, cr, err := r.beginChangeRequestDecision(ctx, "RejectChangeRequest", userID, id)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if cr.RequestedBy == userID {
+		return fmt.Errorf("нельзя отклонить запрос #%d: автор может только отменить собственный запрос", id)
+	}
+
+	return r.finishChangeRequestDecision(ctx, tx, "RejectChangeRequest", userID, id, models.ChangeRequestRejected, note)
+}
+
+// CancelChangeRequest - метод для отмены ожидающего запроса его автором.
+func (r *changeRequestRepo) CancelChangeRequest(ctx context.Context, userID, id int) error {
+	tx, cr, err := r.beginChangeRequestDecision(ctx, "CancelChangeRequest", userID, id)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if cr.RequestedBy != userID {
+		return fmt.Errorf("нельзя отменить запрос #%d: отменить запрос может только его автор", id)
+	}
+
+	return r.finishChangeRequestDecision(ctx, tx, "CancelChangeRequest", userID, id, models.ChangeRequestCancelled, "")
+}
+
+// beginChangeRequestDecision - начало транзакции решения по запросу: userID для триггеров истории,
+// блокировка запроса и проверка, что он ожидает согласования.
+func (r *changeRequestRepo) beginChangeRequestDecision(ctx context.Context, op string, userID, id int) (*sql.Tx, *models.ChangeRequest, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("change_request_id", id).
+			Msg(op + ": не удалось начать транзакцию")
+
+		return nil, nil, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(qSetUserID, userID)); err != nil {
+		tx.Rollback()
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg(op + ": не удалось установить userID")
+
+		return nil, nil, fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var cr models.ChangeRequest
+	if err := scanChangeRequest(tx.QueryRowContext(ctx, qLockChangeRequest, id), &cr); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("запрос на согласование с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("change_request_id", id).
+			Msg(op + ": не удалось заблокировать запрос")
+
+		return nil, nil, fmt.Errorf("не удалось заблокировать запрос: %w", err)
+	}
+
+	if cr.Status != models.ChangeRequestPending {
+		tx.Rollback()
+		return nil, nil, fmt.Errorf("нельзя принять решение по запросу #%d в статусе %s", id, cr.Status)
+	}
+
+	return tx, &cr, nil
+}
+
+// finishChangeRequestDecision - запись решения по запросу без выполнения операции и завершение транзакции.
+func (r *changeRequestRepo) finishChangeRequestDecision(ctx context.Context, tx *sql.Tx, op string, userID, id int, status, note string) error {
+	if _, err := tx.ExecContext(ctx, qSetChangeRequestDecision, id, status, userID, note); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("change_request_id", id).
+			Msg(op + ": не удалось обновить статус запроса")
+
+		return fmt.Errorf("не удалось обновить статус запроса: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("change_request_id", id).
+			Msg(op + ": не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// executeChangeRequest - выполнение согласованной операции в транзакции tx от имени согласующего.
+// Изменение остатка и смена роли выполняются, только если остаток или роль не менялись с момента запроса.
+func executeChangeRequest(ctx context.Context, tx *sql.Tx, userID int, cr *models.ChangeRequest) error {
+	details := fmt.Sprintf("согласование запроса #%d, инициатор %d, согласующий %d", cr.ID, cr.RequestedBy, userID)
+
+	switch cr.Operation {
+	case models.OpItemDelete:
+		return deleteItem(ctx, tx, userID, cr.TargetID)
+	case models.OpItemAdjustment:
+		var change models.QuantityChange
+		if err := json.Unmarshal(cr.Payload, &change); err != nil {
+			return fmt.Errorf("не удалось разобрать параметры запроса: %w", err)
+		}
+
+		var quantity int
+		if err := tx.QueryRowContext(ctx, qLockItemQuantity, cr.TargetID).Scan(&quantity); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("item с id %d не найден", cr.TargetID)
+			}
+
+			return fmt.Errorf("не удалось заблокировать item: %w", err)
+		}
+		if quantity != change.FromQuantity {
+			return fmt.Errorf("нельзя согласовать запрос #%d: остаток item %d изменился с %d до %d с момента запроса",
+				cr.ID, cr.TargetID, change.FromQuantity, quantity)
+		}
+
+		return setApprovedQuantity(ctx, tx, cr.TargetID, change, details)
+	case models.OpUserRole:
+		var change models.RoleChange
+		if err := json.Unmarshal(cr.Payload, &change); err != nil {
+			return fmt.Errorf("не удалось разобрать параметры запроса: %w", err)
+		}
+
+		var role string
+		if err := tx.QueryRowContext(ctx, qLockUserRole, cr.TargetID).Scan(&role); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("пользователь с id %d не найден", cr.TargetID)
+			}
+
+			return fmt.Errorf("не удалось заблокировать пользователя: %w", err)
+		}
+		if role != change.FromRole {
+			return fmt.Errorf("нельзя согласовать запрос #%d: роль пользователя %d изменилась с %s на %s с момента запроса",
+				cr.ID, cr.TargetID, change.FromRole, role)
+		}
+
+		return setUserRole(ctx, tx, cr.TargetID, change.Role)
+	}
+
+	return fmt.Errorf("неизвестная операция %s в запросе #%d", cr.Operation, cr.ID)
+}
+
+// setApprovedQuantity - установка согласованного остатка item со стоимостной корректировкой.
+// Остальные поля item не меняются, остаток не может стать меньше активных резервов.
+func setApprovedQuantity(ctx context.Context, tx *sql.Tx, id int, change models.QuantityChange, details string) error {
+	if change.ToQuantity < change.FromQuantity {
+		var reserved int
+		if err := tx.QueryRowContext(ctx, qGetActiveReserved, id).Scan(&reserved); err != nil {
+			return fmt.Errorf("не удалось получить зарезервированное количество: %w", err)
+		}
+		if change.ToQuantity < reserved {
+			return fmt.Errorf("нельзя уменьшить остаток item %d до %d: в активных резервах %d", id, change.ToQuantity, reserved)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		return fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, qSetApprovedQuantity, id, change.ToQuantity); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", id).
+			Msg("setApprovedQuantity: не удалось изменить остаток")
+
+		return fmt.Errorf("не удалось изменить остаток: %w", err)
+	}
+
+	return adjustCost(ctx, tx, id, change.ToQuantity-change.FromQuantity, "корректировка остатка: "+details)
+}
+
+// scanChangeRequest - перевод строки change_requests в структуру.
+func scanChangeRequest(row interface{ Scan(dest ...any) error }, cr *models.ChangeRequest) error {
+	var decidedBy sql.NullInt64
+	var decidedAt sql.NullTime
+	if err := row.Scan(
+		&cr.ID,
+		&cr.Operation,
+		&cr.TargetID,
+		&cr.Payload,
+		&cr.Summary,
+		&cr.Status,
+		&cr.RequestedBy,
+		&decidedBy,
+		&cr.DecisionNote,
+		&cr.CreatedAt,
+		&decidedAt,
+	); err != nil {
+		return err
+	}
+	cr.DecidedBy = nullIntPtr(decidedBy)
+	if decidedAt.Valid {
+		cr.DecidedAt = &decidedAt.Time
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func TestChangeRequestRepo_Approve_OKQuantityOnly(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	items := &itemRepo{db: db}
+	requests := &changeRequestRepo{db: db}
+	users := &userRepo{db: db}
+
+	admin, err := users.GetByUsername(ctx, "admin123")
+	require.NoError(t, err)
+	manager, err := users.GetByUsername(ctx, "manager123")
+	require.NoError(t, err)
+
+	var itemID int
+	require.NoError(t, db.Master.QueryRowContext(ctx,
+		`INSERT INTO items (item_name, quantity) VALUES ($1, 40) RETURNING id`,
+		"approval-item-"+time.Now().Format("150405.000000000"),
+	).Scan(&itemID))
+	t.Cleanup(func() { db.Master.ExecContext(ctx, `DELETE FROM items WHERE id = $1`, itemID) })
+
+	payload, err := json.Marshal(models.QuantityChange{FromQuantity: 40, ToQuantity: 0})
+	require.NoError(t, err)
+	id, err := requests.CreateChangeRequest(ctx, &models.ChangeRequest{
+		Operation:   models.OpItemAdjustment,
+		TargetID:    itemID,
+		Payload:     payload,
+		RequestedBy: manager.ID,
+	})
+	require.NoError(t, err)
+
+	item, err := items.GetByID(ctx, itemID)
+	require.NoError(t, err)
+	item.Description = "изменено после запроса"
+	require.NoError(t, items.Update(ctx, manager.ID, itemID, item))
+
+	_, err = requests.ApproveChangeRequest(ctx, admin.ID, id, "")
+	require.NoError(t, err)
+
+	item, err = items.GetByID(ctx, itemID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, item.Quantity)
+	assert.Equal(t, "изменено после запроса", item.Description)
+}
+
+func TestChangeRequestRepo_Approve_ErrRoleChanged(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	requests := &changeRequestRepo{db: db}
+	users := &userRepo{db: db}
+
+	admin, err := users.GetByUsername(ctx, "admin123")
+	require.NoError(t, err)
+	viewer, err := users.GetByUsername(ctx, "viewer123")
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(models.RoleChange{FromRole: "manager", Role: "admin"})
+	require.NoError(t, err)
+	id, err := requests.CreateChangeRequest(ctx, &models.ChangeRequest{
+		Operation:   models.OpUserRole,
+		TargetID:    viewer.ID,
+		Payload:     payload,
+		RequestedBy: admin.ID,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Master.ExecContext(ctx, `DELETE FROM change_requests WHERE id = $1`, id) })
+
+	manager, err := users.GetByUsername(ctx, "manager123")
+	require.NoError(t, err)
+	_, err = requests.ApproveChangeRequest(ctx, manager.ID, id, "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "роль пользователя")
+
+	user, err := users.GetByUsername(ctx, "viewer123")
+	require.NoError(t, err)
+	assert.Equal(t, viewer.Role, user.Role)
+}
@@ -0,0 +1,673 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qCreateCountSession = `
+	INSERT INTO count_sessions (location_id, category_id, blind, recount_threshold, created_by)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, session_number`
+
+	// Серийные items не пересчитываются: их остаток ведется по серийным номерам.
+	// Ожидаемый остаток включает запас в статусах, который лежит на полке вместе с доступным:
+	// для сессии по ячейке - в этой ячейке и без ячейки.
+	qCreateCountLines = `
+	WITH RECURSIVE category_tree AS (
+		SELECT id FROM categories WHERE id = $3
+		UNION ALL
+		SELECT c.id FROM categories c JOIN category_tree t ON c.parent_id = t.id
+	)
+	INSERT INTO count_lines (session_id, item_id, expected_qty, held_qty)
+	SELECT $1, i.id, i.quantity + COALESCE(h.held, 0), COALESCE(h.held, 0)
+	FROM items i
+	LEFT JOIN LATERAL (
+		SELECT SUM(s.quantity) AS held
+		FROM item_stock_statuses s
+		WHERE s.item_id = i.id AND ($2::INT IS NULL OR s.location_id IS NULL OR s.location_id = $2)
+	) h ON TRUE
+	WHERE NOT i.serialized
+		AND ($2::INT IS NULL OR i.pick_location_id = $2)
+		AND ($3::INT IS NULL OR i.category_id IN (SELECT id FROM category_tree))
+	ORDER BY i.id`
+
+	// Одновременно item может пересчитываться только в одной активной сессии.
+	qFindCountConflict = `
+	SELECT l.item_id, s.session_number
+	FROM count_lines l
+	JOIN count_lines other ON other.item_id = l.item_id AND other.session_id <> l.session_id
+	JOIN count_sessions s ON s.id = other.session_id AND s.session_status IN ('open', 'review')
+	WHERE l.session_id = $1
+	LIMIT 1`
+
+	qCountSessionColumns = `
+	SELECT s.id, s.session_number, s.location_id, s.category_id, s.blind, s.recount_threshold, s.session_status,
+		COALESCE(s.created_by, 0), s.approved_by, s.approved_at, s.created_at, s.updated_at,
+		(SELECT COUNT(*) FROM count_lines l WHERE l.session_id = s.id),
+		(SELECT COUNT(*) FROM count_lines l WHERE l.session_id = s.id AND l.counted_qty IS NOT NULL AND NOT l.recount_required)
+	FROM count_sessions s`
+
+	qListCountSessions = qCountSessionColumns + `
+	WHERE ($1::TEXT = '' OR s.session_status = $1)
+	ORDER BY s.id DESC`
+
+	qGetCountSessionByID = qCountSessionColumns + `
+	WHERE s.id = $1`
+
+	qLockCountSession = `
+	SELECT session_number, session_status, recount_threshold
+	FROM count_sessions
+	WHERE id = $1
+	FOR UPDATE`
+
+	qListCountLines = `
+	SELECT l.id, l.session_id, l.item_id, i.item_name, l.expected_qty, l.held_qty, l.first_counted_qty, l.counted_qty,
+		l.count_round, l.recount_required, l.counted_by, l.counted_at
+	FROM count_lines l
+	JOIN items i ON i.id = l.item_id
+	WHERE l.session_id = $1
+	ORDER BY i.item_name, l.item_id`
+
+	// Первый подсчет сохраняется отдельно, пересчет запрошенной строки увеличивает раунд и снимает запрос.
+	qRecordCount = `
+	UPDATE count_lines SET
+		counted_qty = $3,
+		first_counted_qty = COALESCE(first_counted_qty, $3),
+		count_round = CASE WHEN recount_required THEN count_round + 1 ELSE GREATEST(count_round, 1) END,
+		recount_required = FALSE,
+		counted_by = $4,
+		counted_at = CURRENT_TIMESTAMP
+	WHERE session_id = $1 AND item_id = $2`
+
+	qAddCountSessionCounter = `
+	INSERT INTO count_session_counters (session_id, user_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING`
+
+	qIsCountSessionCounter = `
+	SELECT EXISTS (SELECT 1 FROM count_session_counters WHERE session_id = $1 AND user_id = $2)`
+
+	qRequestRecount = `
+	UPDATE count_lines SET recount_required = TRUE
+	WHERE id = $1`
+
+	qSetCountSessionStatus = `
+	UPDATE count_sessions SET session_status = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qApproveCountSession = `
+	UPDATE count_sessions SET session_status = 'approved', approved_by = $2, approved_at = CURRENT_TIMESTAMP,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	// Расхождение применяется к текущему доступному остатку, чтобы не потерять движения во время подсчета,
+	// в том числе смену статусов запаса. Запас в статусах не корректируется.
+	// Недостача не может опустить остаток ниже активных резервов.
+	qAdjustCountedItem = `
+	UPDATE items SET quantity = quantity + $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND quantity + $2 >= CASE WHEN $2 < 0 THEN COALESCE((
+		SELECT SUM(r.quantity)
+		FROM reservations r
+		WHERE r.item_id = $1 AND r.reservation_status = 'active'
+	), 0) ELSE 0 END`
+
+	countDetails = "cycle count"
+
+	countSessionLocationConstraint = "count_sessions_location_id_fkey"
+	countSessionCategoryConstraint = "count_sessions_category_id_fkey"
+)
+
+var _ infra.CountRepo = (*countRepo)(nil)
+
+type countRepo struct {
+	db *dbpg.DB
+}
+
+// CreateCountSession - метод для открытия сессии инвентаризации.
+// Фиксирует ожидаемые остатки всех items в области сессии. Возвращает id и номер сессии.
+func (r *countRepo) CreateCountSession(ctx context.Context, session *models.CountSession) (int, string, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("CreateCountSession: не удалось начать транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+	var number string
+	if err := tx.QueryRowContext(
+		ctx,
+		qCreateCountSession,
+		session.LocationID,
+		session.CategoryID,
+		session.Blind,
+		session.RecountThreshold,
+		session.CreatedBy,
+	).Scan(&id, &number); err != nil {
+		if isForeignKeyViolationOn(err, countSessionLocationConstraint) {
+			return 0, "", fmt.Errorf("ячейка с id %d не найдена", *session.LocationID)
+		}
+		if isForeignKeyViolationOn(err, countSessionCategoryConstraint) {
+			return 0, "", fmt.Errorf("категория с id %d не найдена", *session.CategoryID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Msg("CreateCountSession: не удалось создать сессию")
+
+		return 0, "", fmt.Errorf("не удалось создать сессию: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, qCreateCountLines, id, session.LocationID, session.CategoryID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("CreateCountSession: не удалось сформировать строки подсчета")
+
+		return 0, "", fmt.Errorf("не удалось сформировать строки подсчета: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return 0, "", fmt.Errorf("не удалось получить количество добавленных строк: %w", err)
+	} else if affected == 0 {
+		return 0, "", fmt.Errorf("нельзя открыть сессию: в области нет несерийных items")
+	}
+
+	var itemID int
+	var other string
+	err = tx.QueryRowContext(ctx, qFindCountConflict, id).Scan(&itemID, &other)
+	if err == nil {
+		return 0, "", fmt.Errorf("нельзя открыть сессию: item %d уже пересчитывается в сессии %s", itemID, other)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, "", fmt.Errorf("не удалось проверить пересечение сессий: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("CreateCountSession: не удалось завершить транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return id, number, nil
+}
+
+// ListCountSessions - метод для получения сессий инвентаризации по статусу, при пустом статусе - всех.
+// Строки не загружаются, только количество items и посчитанных items.
+func (r *countRepo) ListCountSessions(ctx context.Context, status string) ([]models.CountSession, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListCountSessions,
+		status,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("status", status).
+			Msg("ListCountSessions: не удалось выполнить запрос ListCountSessions")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListCountSessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []models.CountSession
+	for rows.Next() {
+		var session models.CountSession
+		if err := scanCountSession(rows, &session); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListCountSessions: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListCountSessions: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// GetCountSession - метод для получения сессии инвентаризации со строками подсчета.
+func (r *countRepo) GetCountSession(ctx context.Context, id int) (*models.CountSession, error) {
+	var session models.CountSession
+	if err := scanCountSession(r.db.QueryRowContext(ctx, qGetCountSessionByID, id), &session); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("сессия инвентаризации с id %d не найдена", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("GetCountSession: не удалось выполнить запрос GetCountSession")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetCountSession: %w", err)
+	}
+
+	lines, err := listCountLines(ctx, r.db.Master, id)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("GetCountSession: не удалось получить строки подсчета")
+
+		return nil, err
+	}
+	session.Lines = lines
+
+	return &session, nil
+}
+
+// RecordCounts - метод для записи результатов подсчета в открытую сессию.
+// Повторный подсчет item заменяет предыдущий, первый подсчет сохраняется, userID запоминается как счетчик сессии.
+func (r *countRepo) RecordCounts(ctx context.Context, userID, id int, counts []models.CountEntry) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("RecordCounts: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	number, status, _, err := lockCountSession(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if status != models.CountOpen {
+		return fmt.Errorf("нельзя записать подсчет в сессию %s в статусе %s", number, status)
+	}
+
+	for _, count := range counts {
+		result, err := tx.ExecContext(ctx, qRecordCount, id, count.ItemID, count.Quantity, userID)
+		if err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("session_id", id).
+				Int("item_id", count.ItemID).
+				Msg("RecordCounts: не удалось записать подсчет")
+
+			return fmt.Errorf("не удалось записать подсчет: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			return fmt.Errorf("item с id %d не найден в сессии %s", count.ItemID, number)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, qAddCountSessionCounter, id, userID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Int("user_id", userID).
+			Msg("RecordCounts: не удалось запомнить счетчика")
+
+		return fmt.Errorf("не удалось запомнить счетчика: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("RecordCounts: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// SubmitCountSession - метод для завершения подсчета.
+// Строки первого раунда с расхождением выше порога отправляются на пересчет, сессия остается открытой.
+// Без пересчетов сессия переходит на согласование. Возвращает новый статус и строки на пересчет.
+func (r *countRepo) SubmitCountSession(ctx context.Context, id int) (string, []models.CountLine, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("SubmitCountSession: не удалось начать транзакцию")
+
+		return "", nil, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	number, status, threshold, err := lockCountSession(ctx, tx, id)
+	if err != nil {
+		return "", nil, err
+	}
+	if status != models.CountOpen {
+		return "", nil, fmt.Errorf("нельзя завершить подсчет сессии %s в статусе %s", number, status)
+	}
+
+	lines, err := listCountLines(ctx, tx, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	uncounted := 0
+	for _, line := range lines {
+		if !line.Counted() {
+			uncounted++
+		}
+	}
+	if uncounted > 0 {
+		return "", nil, fmt.Errorf("нельзя завершить подсчет сессии %s: не посчитано items: %d", number, uncounted)
+	}
+
+	var recount []models.CountLine
+	for _, line := range lines {
+		if line.Round > 1 || !line.ExceedsThreshold(threshold) {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, qRequestRecount, line.ID); err != nil {
+			return "", nil, fmt.Errorf("не удалось запросить пересчет: %w", err)
+		}
+		line.RecountRequired = true
+		recount = append(recount, line)
+	}
+
+	status = models.CountOpen
+	if len(recount) == 0 {
+		status = models.CountReview
+		if _, err := tx.ExecContext(ctx, qSetCountSessionStatus, id, status); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("session_id", id).
+				Msg("SubmitCountSession: не удалось обновить статус сессии")
+
+			return "", nil, fmt.Errorf("не удалось обновить статус сессии: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("SubmitCountSession: не удалось завершить транзакцию")
+
+		return "", nil, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return status, recount, nil
+}
+
+// ApproveCountSession - метод для согласования сессии и проведения корректировок остатков по расхождениям.
+// Корректировки попадают в историю с причиной "cycle count". Согласовать сессию может только пользователь,
+// не записывавший в нее подсчеты. Возвращает id скорректированных items.
+func (r *countRepo) ApproveCountSession(ctx context.Context, userID, id int) ([]int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("session_id", id).
+			Msg("ApproveCountSession: не удалось начать транзакцию")
+
+		return nil, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(qSetUserID, userID)); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("ApproveCountSession: не удалось установить userID")
+
+		return nil, fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	number, status, _, err := lockCountSession(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if status != models.CountReview {
+		return nil, fmt.Errorf("нельзя согласовать сессию %s в статусе %s", number, status)
+	}
+
+	var counter bool
+	if err := tx.QueryRowContext(ctx, qIsCountSessionCounter, id, userID).Scan(&counter); err != nil {
+		return nil, fmt.Errorf("не удалось проверить счетчиков сессии: %w", err)
+	}
+	if counter {
+		return nil, fmt.Errorf("нельзя согласовать сессию %s: пользователь %d записывал в нее подсчеты", number, userID)
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, fmt.Sprintf("%s %s", countDetails, number)); err != nil {
+		return nil, fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	lines, err := listCountLines(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var adjusted []int
+	for _, line := range lines {
+		variance := line.Variance()
+		if variance == 0 {
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx, qAdjustCountedItem, line.ItemID, variance)
+		if err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("session_id", id).
+				Int("item_id", line.ItemID).
+				Msg("ApproveCountSession: не удалось скорректировать остаток")
+
+			return nil, fmt.Errorf("не удалось скорректировать остаток: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			return nil, fmt.Errorf("нельзя скорректировать остаток item %d на %d: доступный остаток станет меньше "+
+				"активных резервов, резервы нужно снять, а недостачу запаса в статусах перевести в available", line.ItemID, variance)
+		}
+		if err := adjustCost(ctx, tx, line.ItemID, variance, fmt.Sprintf("%s %s", countDetails, number)); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("session_id", id).
+				Int("item_id", line.ItemID).
+				Msg("ApproveCountSession: не удалось учесть себестоимость корректировки")
+
+			return nil, err
+		}
+
+		adjusted = append(adjusted, line.ItemID)
+	}
+
+	if _, err := tx.ExecContext(ctx, qApproveCountSession, id, userID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("ApproveCountSession: не удалось согласовать сессию")
+
+		return nil, fmt.Errorf("не удалось согласовать сессию: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("session_id", id).
+			Msg("ApproveCountSession: не удалось завершить транзакцию")
+
+		return nil, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return adjusted, nil
+}
+
+// CancelCountSession - метод для отмены несогласованной сессии без корректировок.
+func (r *countRepo) CancelCountSession(ctx context.Context, id int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("CancelCountSession: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	number, status, _, err := lockCountSession(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if status != models.CountOpen && status != models.CountReview {
+		return fmt.Errorf("нельзя отменить сессию %s в статусе %s", number, status)
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetCountSessionStatus, id, models.CountCancelled); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("CancelCountSession: не удалось отменить сессию")
+
+		return fmt.Errorf("не удалось отменить сессию: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("CancelCountSession: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// lockCountSession - блокировка сессии, возвращает ее номер, статус и порог пересчета.
+func lockCountSession(ctx context.Context, tx *sql.Tx, id int) (string, string, int, error) {
+	var number, status string
+	var threshold int
+	if err := tx.QueryRowContext(ctx, qLockCountSession, id).Scan(&number, &status, &threshold); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", 0, fmt.Errorf("сессия инвентаризации с id %d не найдена", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("session_id", id).
+			Msg("lockCountSession: не удалось заблокировать сессию")
+
+		return "", "", 0, fmt.Errorf("не удалось заблокировать сессию: %w", err)
+	}
+
+	return number, status, threshold, nil
+}
+
+// listCountLines - получение строк подсчета сессии.
+func listCountLines(ctx context.Context, q interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}, id int) ([]models.CountLine, error) {
+	rows, err := q.QueryContext(ctx, qListCountLines, id)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listCountLines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []models.CountLine
+	for rows.Next() {
+		var line models.CountLine
+		var firstCounted, counted, countedBy sql.NullInt64
+		var countedAt sql.NullTime
+		if err := rows.Scan(
+			&line.ID,
+			&line.SessionID,
+			&line.ItemID,
+			&line.ItemName,
+			&line.ExpectedQty,
+			&line.HeldQty,
+			&firstCounted,
+			&counted,
+			&line.Round,
+			&line.RecountRequired,
+			&countedBy,
+			&countedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		line.FirstCountedQty = nullIntPtr(firstCounted)
+		line.CountedQty = nullIntPtr(counted)
+		line.CountedBy = nullIntPtr(countedBy)
+		if countedAt.Valid {
+			line.CountedAt = &countedAt.Time
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanCountSession - перевод строки count_sessions в структуру.
+func scanCountSession(row interface{ Scan(dest ...any) error }, session *models.CountSession) error {
+	var locationID, categoryID, approvedBy sql.NullInt64
+	var approvedAt sql.NullTime
+	if err := row.Scan(
+		&session.ID,
+		&session.Number,
+		&locationID,
+		&categoryID,
+		&session.Blind,
+		&session.RecountThreshold,
+		&session.Status,
+		&session.CreatedBy,
+		&approvedBy,
+		&approvedAt,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+		&session.ItemsTotal,
+		&session.ItemsCounted,
+	); err != nil {
+		return err
+	}
+
+	session.LocationID = nullIntPtr(locationID)
+	session.CategoryID = nullIntPtr(categoryID)
+	session.ApprovedBy = nullIntPtr(approvedBy)
+	if approvedAt.Valid {
+		session.ApprovedAt = &approvedAt.Time
+	}
+
+	return nil
+}
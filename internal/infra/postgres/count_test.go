@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wb-go/wbf/dbpg"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// openHeldCountSession - сессия по новой категории с одним item: 7 доступно и 3 в карантине.
+// Возвращает id счетчика, item и сессии.
+func openHeldCountSession(t *testing.T, db *dbpg.DB) (int, int, int) {
+	t.Helper()
+	ctx := context.Background()
+
+	admin, err := (&userRepo{db: db}).GetByUsername(ctx, "admin123")
+	require.NoError(t, err)
+
+	suffix := time.Now().Format("150405.000000000")
+	categoryID, err := (&categoryRepo{db: db}).CreateCategory(ctx, &models.Category{Name: "count-held-" + suffix})
+	require.NoError(t, err)
+	itemID, err := (&itemRepo{db: db}).Create(ctx, admin.ID, &models.Item{
+		Name:       "count-held-item-" + suffix,
+		Quantity:   10,
+		BaseUnit:   models.DefaultBaseUnit,
+		CategoryID: &categoryID,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Master.ExecContext(ctx, `DELETE FROM count_sessions WHERE category_id = $1`, categoryID)
+		db.Master.ExecContext(ctx, `DELETE FROM items WHERE id = $1`, itemID)
+		db.Master.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, categoryID)
+	})
+
+	require.NoError(t, (&stockStatusRepo{db: db}).ChangeStockStatus(ctx, admin.ID, &models.StockStatusChange{
+		ItemID:     itemID,
+		FromStatus: models.StockAvailable,
+		ToStatus:   models.StockQuarantine,
+		Quantity:   3,
+		Reason:     "проверка партии",
+	}))
+
+	sessionID, _, err := (&countRepo{db: db}).CreateCountSession(ctx, &models.CountSession{
+		CategoryID: &categoryID,
+		CreatedBy:  admin.ID,
+	})
+	require.NoError(t, err)
+
+	return admin.ID, itemID, sessionID
+}
+
+func TestCountRepo_ApproveCountSession_IncludesHeldStock(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	counts := &countRepo{db: db}
+	userID, itemID, sessionID := openHeldCountSession(t, db)
+	manager, err := (&userRepo{db: db}).GetByUsername(ctx, "manager123")
+	require.NoError(t, err)
+
+	session, err := counts.GetCountSession(ctx, sessionID)
+	require.NoError(t, err)
+	require.Len(t, session.Lines, 1)
+	assert.Equal(t, 10, session.Lines[0].ExpectedQty)
+	assert.Equal(t, 3, session.Lines[0].HeldQty)
+
+	require.NoError(t, counts.RecordCounts(ctx, userID, sessionID, []models.CountEntry{{ItemID: itemID, Quantity: 9}}))
+	status, _, err := counts.SubmitCountSession(ctx, sessionID)
+	require.NoError(t, err)
+	require.Equal(t, models.CountReview, status)
+
+	adjusted, err := counts.ApproveCountSession(ctx, manager.ID, sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{itemID}, adjusted)
+
+	item, err := (&itemRepo{db: db}).GetByID(ctx, itemID)
+	require.NoError(t, err)
+	assert.Equal(t, 6, item.Quantity)
+
+	stock, err := (&stockStatusRepo{db: db}).GetItemStock(ctx, itemID)
+	require.NoError(t, err)
+	require.Len(t, stock.Held, 1)
+	assert.Equal(t, 3, stock.Held[0].Quantity)
+}
+
+func TestCountRepo_ApproveCountSession_ErrShortageOfHeldStock(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	counts := &countRepo{db: db}
+	userID, itemID, sessionID := openHeldCountSession(t, db)
+
+	require.NoError(t, counts.RecordCounts(ctx, userID, sessionID, []models.CountEntry{{ItemID: itemID, Quantity: 2}}))
+	_, _, err := counts.SubmitCountSession(ctx, sessionID)
+	require.NoError(t, err)
+
+	manager, err := (&userRepo{db: db}).GetByUsername(ctx, "manager123")
+	require.NoError(t, err)
+
+	_, err = counts.ApproveCountSession(ctx, manager.ID, sessionID)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя скорректировать")
+
+	item, err := (&itemRepo{db: db}).GetByID(ctx, itemID)
+	require.NoError(t, err)
+	assert.Equal(t, 7, item.Quantity)
+}
+
+func TestCountRepo_ApproveCountSession_ErrShortageOfReserved(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	counts := &countRepo{db: db}
+	userID, itemID, sessionID := openHeldCountSession(t, db)
+
+	_, err := db.Master.ExecContext(ctx,
+		`INSERT INTO reservations (item_id, user_id, quantity, expires_at) VALUES ($1, $2, 5, $3)`,
+		itemID, userID, time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, counts.RecordCounts(ctx, userID, sessionID, []models.CountEntry{{ItemID: itemID, Quantity: 6}}))
+	_, _, err = counts.SubmitCountSession(ctx, sessionID)
+	require.NoError(t, err)
+
+	manager, err := (&userRepo{db: db}).GetByUsername(ctx, "manager123")
+	require.NoError(t, err)
+
+	_, err = counts.ApproveCountSession(ctx, manager.ID, sessionID)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "активных резервов")
+
+	item, err := (&itemRepo{db: db}).GetByID(ctx, itemID)
+	require.NoError(t, err)
+	assert.Equal(t, 7, item.Quantity)
+}
+
+func TestCountRepo_ApproveCountSession_ErrApprovedByCounter(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	counts := &countRepo{db: db}
+	userID, itemID, sessionID := openHeldCountSession(t, db)
+
+	require.NoError(t, counts.RecordCounts(ctx, userID, sessionID, []models.CountEntry{{ItemID: itemID, Quantity: 10}}))
+	_, _, err := counts.SubmitCountSession(ctx, sessionID)
+	require.NoError(t, err)
+
+	_, err = counts.ApproveCountSession(ctx, userID, sessionID)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "записывал в нее подсчеты")
+
+	session, err := counts.GetCountSession(ctx, sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CountReview, session.Status)
+}
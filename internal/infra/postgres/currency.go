@@ -0,0 +1,339 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qListCurrencies = `
+	SELECT code, currency_name, is_base, created_at
+	FROM currencies
+	ORDER BY is_base DESC, code`
+
+	qCreateCurrency = `
+	INSERT INTO currencies (code, currency_name)
+	VALUES ($1, $2)`
+
+	qGetBaseCurrency = `
+	SELECT code
+	FROM currencies
+	WHERE is_base`
+
+	qGetCurrencyRate = `
+	SELECT EXISTS (SELECT 1 FROM currencies WHERE code = $1), currency_rate($1, $2::DATE)`
+
+	qListExchangeRates = `
+	SELECT id, currency_code, rate_date, rate, created_by, updated_at
+	FROM exchange_rates
+	WHERE ($1::TEXT = '' OR currency_code = $1)
+		AND ($2::DATE IS NULL OR rate_date >= $2)
+		AND ($3::DATE IS NULL OR rate_date <= $3)
+	ORDER BY rate_date DESC, currency_code`
+
+	// Курс на ту же дату заменяется: импорт можно повторять без дубликатов.
+	qUpsertExchangeRate = `
+	INSERT INTO exchange_rates (currency_code, rate_date, rate, created_by)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (currency_code, rate_date) DO UPDATE SET
+		rate = EXCLUDED.rate,
+		created_by = EXCLUDED.created_by,
+		updated_at = CURRENT_TIMESTAMP
+	RETURNING id`
+
+	qDeleteExchangeRate = `
+	DELETE FROM exchange_rates
+	WHERE id = $1`
+
+	currencyCodeConstraint         = "currencies_pkey"
+	exchangeRateCurrencyConstraint = "exchange_rates_currency_code_fkey"
+)
+
+var _ infra.CurrencyRepo = (*currencyRepo)(nil)
+
+type currencyRepo struct {
+	db *dbpg.DB
+}
+
+// ListCurrencies - метод для получения валют, базовая - первой.
+func (r *currencyRepo) ListCurrencies(ctx context.Context) ([]models.Currency, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListCurrencies,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListCurrencies: не удалось выполнить запрос ListCurrencies")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListCurrencies: %w", err)
+	}
+	defer rows.Close()
+
+	var currencies []models.Currency
+	for rows.Next() {
+		var cur models.Currency
+		if err := rows.Scan(&cur.Code, &cur.Name, &cur.Base, &cur.CreatedAt); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListCurrencies: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		currencies = append(currencies, cur)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListCurrencies: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return currencies, nil
+}
+
+// CreateCurrency - метод для добавления валюты.
+func (r *currencyRepo) CreateCurrency(ctx context.Context, cur *models.Currency) error {
+	if _, err := r.db.Master.ExecContext(ctx, qCreateCurrency, cur.Code, cur.Name); err != nil {
+		if isUniqueViolationOn(err, currencyCodeConstraint) {
+			return fmt.Errorf("валюта %s уже существует", cur.Code)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Str("currency", cur.Code).
+			Msg("CreateCurrency: не удалось добавить валюту")
+
+		return fmt.Errorf("не удалось добавить валюту: %w", err)
+	}
+
+	return nil
+}
+
+// GetBaseCurrency - метод для получения кода базовой валюты.
+func (r *currencyRepo) GetBaseCurrency(ctx context.Context) (string, error) {
+	var code string
+	if err := r.db.QueryRowContext(ctx, qGetBaseCurrency).Scan(&code); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetBaseCurrency: не удалось получить базовую валюту")
+
+		return "", fmt.Errorf("не удалось получить базовую валюту: %w", err)
+	}
+
+	return code, nil
+}
+
+// GetCurrencyRate - метод для получения курса валюты на дату, для базовой валюты курс равен 1.
+func (r *currencyRepo) GetCurrencyRate(ctx context.Context, code string, date time.Time) (models.Rate, error) {
+	return currencyRate(ctx, r.db, code, date)
+}
+
+// ListExchangeRates - метод для получения курсов валют по фильтру, новые первыми.
+func (r *currencyRepo) ListExchangeRates(ctx context.Context, filter models.ExchangeRateFilter) ([]models.ExchangeRate, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListExchangeRates,
+		filter.Currency,
+		filter.From,
+		filter.To,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListExchangeRates: не удалось выполнить запрос ListExchangeRates")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListExchangeRates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []models.ExchangeRate
+	for rows.Next() {
+		var rate models.ExchangeRate
+		var createdBy sql.NullInt64
+		if err := rows.Scan(
+			&rate.ID,
+			&rate.Currency,
+			&rate.Date,
+			&rate.Rate,
+			&createdBy,
+			&rate.UpdatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListExchangeRates: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		rate.CreatedBy = nullIntPtr(createdBy)
+
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListExchangeRates: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return rates, nil
+}
+
+// SetExchangeRate - метод для задания курса валюты на дату, существующий курс на эту дату заменяется.
+func (r *currencyRepo) SetExchangeRate(ctx context.Context, rate *models.ExchangeRate) (int, error) {
+	return upsertExchangeRate(ctx, r.db.Master, rate)
+}
+
+// ImportExchangeRates - метод для загрузки курсов валют в одной транзакции.
+// При ошибке не сохраняется ни один курс.
+func (r *currencyRepo) ImportExchangeRates(ctx context.Context, rates []models.ExchangeRate) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ImportExchangeRates: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range rates {
+		if _, err := upsertExchangeRate(ctx, tx, &rates[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rates", len(rates)).
+			Msg("ImportExchangeRates: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExchangeRate - метод для удаления курса валюты.
+func (r *currencyRepo) DeleteExchangeRate(ctx context.Context, id int) error {
+	result, err := r.db.Master.ExecContext(ctx, qDeleteExchangeRate, id)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rate_id", id).
+			Msg("DeleteExchangeRate: не удалось удалить курс")
+
+		return fmt.Errorf("не удалось удалить курс: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество удаленных строк: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("курс с id %d не найден", id)
+	}
+
+	return nil
+}
+
+// upsertExchangeRate - сохранение курса валюты в транзакции или вне ее.
+func upsertExchangeRate(ctx context.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}, rate *models.ExchangeRate) (int, error) {
+	var id int
+	if err := q.QueryRowContext(
+		ctx,
+		qUpsertExchangeRate,
+		rate.Currency,
+		rate.Date,
+		rate.Rate,
+		rate.CreatedBy,
+	).Scan(&id); err != nil {
+		if isForeignKeyViolationOn(err, exchangeRateCurrencyConstraint) {
+			return 0, fmt.Errorf("валюта %s не найдена", rate.Currency)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Str("currency", rate.Currency).
+			Msg("upsertExchangeRate: не удалось сохранить курс")
+
+		return 0, fmt.Errorf("не удалось сохранить курс: %w", err)
+	}
+
+	return id, nil
+}
+
+// currencyRate - курс валюты code на дату date в транзакции или вне ее.
+func currencyRate(ctx context.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}, code string, date time.Time) (models.Rate, error) {
+	var exists bool
+	var rate sql.NullString
+	if err := q.QueryRowContext(ctx, qGetCurrencyRate, code, date).Scan(&exists, &rate); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("currency", code).
+			Msg("currencyRate: не удалось получить курс валюты")
+
+		return 0, fmt.Errorf("не удалось получить курс валюты: %w", err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("валюта %s не найдена", code)
+	}
+	if !rate.Valid {
+		return 0, fmt.Errorf("курс валюты %s на %s не найден", code, date.Format(time.DateOnly))
+	}
+
+	var r models.Rate
+	if err := r.Scan(rate.String); err != nil {
+		return 0, fmt.Errorf("не удалось прочитать курс валюты: %w", err)
+	}
+
+	return r, nil
+}
+
+// baseUnitCost - себестоимость единицы unitCost в валюте currency, пересчитанная в базовую валюту
+// по текущему курсу. Пустая валюта - базовая.
+func baseUnitCost(ctx context.Context, tx *sql.Tx, unitCost *models.Money, currency string) (*models.Money, error) {
+	if unitCost == nil || currency == "" {
+		return unitCost, nil
+	}
+
+	rate, err := currencyRate(ctx, tx, currency, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, fmt.Errorf("некорректный unit_cost: %w", err)
+		}
+
+		return nil, err
+	}
+	cost := unitCost.Convert(rate)
+
+	return &cost, nil
+}
@@ -0,0 +1,270 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	// Остаток включает запас в статусах, доступный остаток - за вычетом активных резервов.
+	// Нет в наличии - доступный остаток 0, низкий остаток - доступный остаток не выше точки заказа на весь склад.
+	qDashboardStock = `
+	WITH stock AS (
+		SELECT i.quantity + COALESCE(h.held, 0) AS on_hand, i.quantity - COALESCE(r.reserved, 0) AS available,
+			l.reorder_point
+		FROM items i
+		LEFT JOIN (
+			SELECT item_id, SUM(quantity) AS reserved
+			FROM reservations
+			WHERE reservation_status = 'active'
+			GROUP BY item_id
+		) r ON r.item_id = i.id
+		LEFT JOIN (
+			SELECT item_id, SUM(quantity) AS held
+			FROM item_stock_statuses
+			GROUP BY item_id
+		) h ON h.item_id = i.id
+		LEFT JOIN stock_levels l ON l.item_id = i.id AND l.location_id IS NULL
+	)
+	SELECT COUNT(*), COALESCE(SUM(on_hand), 0),
+		COUNT(*) FILTER (WHERE available <= 0),
+		COUNT(*) FILTER (WHERE available > 0 AND available <= reorder_point)
+	FROM stock`
+
+	qDashboardValue = `
+	SELECT COALESCE(SUM(balance_value), 0)
+	FROM (
+		SELECT DISTINCT ON (item_id) balance_value
+		FROM cost_movements
+		ORDER BY item_id, id DESC
+	) b`
+
+	qDashboardFlow = `
+	SELECT COALESCE(SUM(quantity) FILTER (WHERE movement_type = 'receipt'), 0),
+		COUNT(*) FILTER (WHERE movement_type = 'receipt'),
+		COALESCE(SUM(quantity) FILTER (WHERE movement_type = 'issue'), 0),
+		COUNT(*) FILTER (WHERE movement_type = 'issue')
+	FROM cost_movements
+	WHERE created_at >= $1`
+
+	qDashboardTopMovers = `
+	SELECT m.item_id, i.item_name, SUM(m.quantity) AS issued
+	FROM cost_movements m
+	JOIN items i ON i.id = m.item_id
+	WHERE m.movement_type = 'issue' AND m.created_at >= $1
+	GROUP BY m.item_id, i.item_name
+	ORDER BY issued DESC, m.item_id
+	LIMIT $2`
+
+	qDashboardActiveUsers = `
+	SELECT h.user_id, COALESCE(u.username, ''), COUNT(*) AS changes
+	FROM items_history h
+	LEFT JOIN users u ON u.id = h.user_id
+	WHERE h.changed_at >= $1
+	GROUP BY h.user_id, u.username
+	ORDER BY changes DESC, h.user_id
+	LIMIT $2`
+
+	qDashboardRecentChanges = `
+	SELECT h.id, h.item_id, i.item_name, h.user_id, COALESCE(u.username, ''), h.operation, h.details, h.changed_at
+	FROM items_history h
+	JOIN items i ON i.id = h.item_id
+	LEFT JOIN users u ON u.id = h.user_id
+	ORDER BY h.changed_at DESC, h.id DESC
+	LIMIT $1`
+)
+
+var _ infra.DashboardRepo = (*dashboardRepo)(nil)
+
+type dashboardRepo struct {
+	db *dbpg.DB
+}
+
+// GetDashboard - метод для расчета сводных показателей склада.
+// today - начало текущего дня для поступления и расхода, since - начало периода для лидеров расхода
+// и активности пользователей, limit - длина списков.
+func (r *dashboardRepo) GetDashboard(ctx context.Context, today, since time.Time, limit int) (*models.Dashboard, error) {
+	d := models.Dashboard{Full: true}
+
+	if err := r.db.QueryRowContext(ctx, qDashboardStock).Scan(&d.SKUs, &d.Units, &d.StockOuts, &d.LowStock); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось посчитать остатки")
+
+		return nil, fmt.Errorf("не удалось посчитать остатки: %w", err)
+	}
+
+	if err := r.db.QueryRowContext(ctx, qDashboardValue).Scan(&d.Value); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось посчитать стоимость запаса")
+
+		return nil, fmt.Errorf("не удалось посчитать стоимость запаса: %w", err)
+	}
+
+	if err := r.db.QueryRowContext(ctx, qDashboardFlow, today).Scan(
+		&d.Today.InboundQty,
+		&d.Today.InboundMovements,
+		&d.Today.OutboundQty,
+		&d.Today.OutboundMovements,
+	); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось посчитать движения за день")
+
+		return nil, fmt.Errorf("не удалось посчитать движения за день: %w", err)
+	}
+
+	var err error
+	if d.TopMovers, err = r.topMovers(ctx, since, limit); err != nil {
+		return nil, err
+	}
+	if d.ActiveUsers, err = r.activeUsers(ctx, since, limit); err != nil {
+		return nil, err
+	}
+	if d.RecentChanges, err = r.recentChanges(ctx, limit); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// topMovers - items с наибольшим расходом с момента since.
+func (r *dashboardRepo) topMovers(ctx context.Context, since time.Time, limit int) ([]models.TopMover, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, qDashboardTopMovers, since, limit)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось выполнить запрос DashboardTopMovers")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос DashboardTopMovers: %w", err)
+	}
+	defer rows.Close()
+
+	var movers []models.TopMover
+	for rows.Next() {
+		var m models.TopMover
+		if err := rows.Scan(&m.ItemID, &m.ItemName, &m.Quantity); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("GetDashboard: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		movers = append(movers, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return movers, nil
+}
+
+// activeUsers - пользователи с наибольшим числом изменений items с момента since.
+func (r *dashboardRepo) activeUsers(ctx context.Context, since time.Time, limit int) ([]models.ActiveUser, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, qDashboardActiveUsers, since, limit)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось выполнить запрос DashboardActiveUsers")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос DashboardActiveUsers: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.ActiveUser
+	for rows.Next() {
+		var u models.ActiveUser
+		if err := rows.Scan(&u.UserID, &u.Username, &u.Changes); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("GetDashboard: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return users, nil
+}
+
+// recentChanges - последние записи истории изменений items.
+func (r *dashboardRepo) recentChanges(ctx context.Context, limit int) ([]models.RecentChange, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, qDashboardRecentChanges, limit)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось выполнить запрос DashboardRecentChanges")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос DashboardRecentChanges: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []models.RecentChange
+	for rows.Next() {
+		var c models.RecentChange
+		if err := rows.Scan(
+			&c.ID,
+			&c.ItemID,
+			&c.ItemName,
+			&c.UserID,
+			&c.Username,
+			&c.Operation,
+			&c.Details,
+			&c.ChangedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("GetDashboard: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDashboard: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return changes, nil
+}
@@ -22,6 +22,30 @@ type postgresRepo struct {
 	*userRepo
 	*itemRepo
 	*itemHistoryRepo
+	*reservationRepo
+	*lotRepo
+	*serialRepo
+	*locationRepo
+	*categoryRepo
+	*attributeRepo
+	*unitRepo
+	*stockRepo
+	*supplierRepo
+	*purchaseRepo
+	*outboundRepo
+	*replenishmentRepo
+	*countRepo
+	*returnRepo
+	*stockStatusRepo
+	*writeOffRepo
+	*changeRequestRepo
+	*valuationRepo
+	*currencyRepo
+	*kitRepo
+	*analysisRepo
+	*forecastRepo
+	*dashboardRepo
+	*reportRepo
 }
 
 // New - конструктор нового postgresRepo.
@@ -61,11 +85,59 @@ func New(ctx context.Context, cfg config.DBConfig) (infra.Database, error) {
 	userRepo := &userRepo{db: db}
 	itemRepo := &itemRepo{db: db}
 	itemHistoryRepo := &itemHistoryRepo{db: db}
+	reservationRepo := &reservationRepo{db: db}
+	lotRepo := &lotRepo{db: db}
+	serialRepo := &serialRepo{db: db}
+	locationRepo := &locationRepo{db: db}
+	categoryRepo := &categoryRepo{db: db}
+	attributeRepo := &attributeRepo{db: db}
+	unitRepo := &unitRepo{db: db}
+	stockRepo := &stockRepo{db: db}
+	supplierRepo := &supplierRepo{db: db}
+	purchaseRepo := &purchaseRepo{db: db}
+	outboundRepo := &outboundRepo{db: db}
+	replenishmentRepo := &replenishmentRepo{db: db}
+	countRepo := &countRepo{db: db}
+	returnRepo := &returnRepo{db: db}
+	stockStatusRepo := &stockStatusRepo{db: db}
+	writeOffRepo := &writeOffRepo{db: db}
+	changeRequestRepo := &changeRequestRepo{db: db}
+	valuationRepo := &valuationRepo{db: db}
+	currencyRepo := &currencyRepo{db: db}
+	kitRepo := &kitRepo{db: db}
+	analysisRepo := &analysisRepo{db: db}
+	forecastRepo := &forecastRepo{db: db}
+	dashboardRepo := &dashboardRepo{db: db}
+	reportRepo := &reportRepo{db: db}
 
 	return &postgresRepo{
-		userRepo:        userRepo,
-		itemRepo:        itemRepo,
-		itemHistoryRepo: itemHistoryRepo,
+		userRepo:          userRepo,
+		itemRepo:          itemRepo,
+		itemHistoryRepo:   itemHistoryRepo,
+		reservationRepo:   reservationRepo,
+		lotRepo:           lotRepo,
+		serialRepo:        serialRepo,
+		locationRepo:      locationRepo,
+		categoryRepo:      categoryRepo,
+		attributeRepo:     attributeRepo,
+		unitRepo:          unitRepo,
+		stockRepo:         stockRepo,
+		supplierRepo:      supplierRepo,
+		purchaseRepo:      purchaseRepo,
+		outboundRepo:      outboundRepo,
+		replenishmentRepo: replenishmentRepo,
+		countRepo:         countRepo,
+		returnRepo:        returnRepo,
+		stockStatusRepo:   stockStatusRepo,
+		writeOffRepo:      writeOffRepo,
+		changeRequestRepo: changeRequestRepo,
+		valuationRepo:     valuationRepo,
+		currencyRepo:      currencyRepo,
+		kitRepo:           kitRepo,
+		analysisRepo:      analysisRepo,
+		forecastRepo:      forecastRepo,
+		dashboardRepo:     dashboardRepo,
+		reportRepo:        reportRepo,
 	}, nil
 }
 
@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+// testDB - подключение к БД с примененными migrations/init для тестов репозиториев.
+// Тест пропускается, если TEST_DB_DSN не задан.
+func testDB(t *testing.T) *dbpg.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN не задан, тест репозитория пропущен")
+	}
+
+	db, err := dbpg.New(dsn, nil, &dbpg.Options{MaxOpenConns: 2, MaxIdleConns: 2})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Master.Close() })
+
+	return db
+}
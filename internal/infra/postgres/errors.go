@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+// isUniqueViolation - проверка, что ошибка вызвана нарушением ограничения уникальности.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
+
+// isUniqueViolationOn - проверка, что ошибка вызвана нарушением конкретного ограничения уникальности.
+func isUniqueViolationOn(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == constraint
+}
+
+// isForeignKeyViolationOn - проверка, что ошибка вызвана нарушением конкретного внешнего ключа.
+func isForeignKeyViolationOn(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode && pqErr.Constraint == constraint
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qListItemIssues = `
+	SELECT date_trunc('month', created_at), SUM(quantity), SUM(total_cost)
+	FROM cost_movements
+	WHERE item_id = $1 AND movement_type = 'issue' AND created_at >= $2 AND created_at < $3
+	GROUP BY date_trunc('month', created_at)
+	ORDER BY date_trunc('month', created_at)`
+)
+
+var _ infra.ForecastRepo = (*forecastRepo)(nil)
+
+type forecastRepo struct {
+	db *dbpg.DB
+}
+
+// ListItemIssues - метод для получения расхода item по месяцам в периоде [from, to).
+// Месяцы без расхода не возвращаются.
+func (r *forecastRepo) ListItemIssues(ctx context.Context, itemID int, from, to time.Time) ([]models.DemandPeriod, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, qListItemIssues, itemID, from, to)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("ListItemIssues: не удалось выполнить запрос ListItemIssues")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListItemIssues: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []models.DemandPeriod
+	for rows.Next() {
+		var p models.DemandPeriod
+		if err := rows.Scan(&p.Month, &p.Quantity, &p.Value); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListItemIssues: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		periods = append(periods, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListItemIssues: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return periods, nil
+}
@@ -2,8 +2,17 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/lib/pq"
 	"github.com/wb-go/wbf/dbpg"
 	"github.com/wb-go/wbf/retry"
 	"github.com/wb-go/wbf/zlog"
@@ -14,25 +23,137 @@ import (
 
 const (
 	qCreateItem = `
-	INSERT INTO items (item_name, item_description, quantity) 
-	VALUES ($1, $2, $3) 
+	INSERT INTO items (item_name, item_description, quantity, serialized, sku, category_id, attributes, base_unit) 
+	VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8) 
 	RETURNING id`
 
+	qItemColumns = `
+	SELECT i.id, i.item_name, i.item_description, i.quantity, COALESCE(r.reserved, 0), COALESCE(h.held, '{}'),
+		i.serialized, i.base_unit, COALESCE(i.sku, ''), i.category_id, i.attributes, i.created_at, i.updated_at,
+		COALESCE(a.abc_class, ''), COALESCE(a.xyz_class, ''), COALESCE(a.movement_status, ''), a.last_movement_at
+	FROM items i
+	LEFT JOIN item_analysis a ON a.item_id = i.id
+	LEFT JOIN (
+		SELECT item_id, SUM(quantity) AS reserved
+		FROM reservations
+		WHERE reservation_status = 'active'
+		GROUP BY item_id
+	) r ON r.item_id = i.id
+	LEFT JOIN (
+		SELECT item_id, jsonb_object_agg(stock_status, quantity) AS held
+		FROM (
+			SELECT item_id, stock_status, SUM(quantity) AS quantity
+			FROM item_stock_statuses
+			WHERE quantity > 0
+			GROUP BY item_id, stock_status
+		) s
+		GROUP BY item_id
+	) h ON h.item_id = i.id`
+
 	qListItems = `
-	SELECT id, item_name, item_description, quantity, created_at, updated_at
-	FROM items
-	ORDER BY id`
+	WITH RECURSIVE category_tree AS (
+		SELECT id FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id FROM categories c JOIN category_tree t ON c.parent_id = t.id
+	)` + qItemColumns + `
+	WHERE ($1::INT IS NULL OR i.category_id IN (SELECT id FROM category_tree))
+		AND ($2::JSONB[] IS NULL OR i.attributes @> ANY($2::JSONB[]))
+		AND ($3::TEXT = ''
+			OR ($3 = 'available' AND i.quantity > 0)
+			OR COALESCE((h.held ->> $3)::INT, 0) > 0)
+		AND ($4::TEXT = '' OR a.abc_class = $4)
+		AND ($5::TEXT = '' OR a.xyz_class = $5)
+		AND ($6::TEXT = '' OR a.movement_status = $6)
+	ORDER BY i.id`
+
+	qListAttributeTypes = `
+	SELECT DISTINCT attr_code, attr_type
+	FROM attribute_definitions
+	WHERE attr_code = ANY($1)`
+
+	qGetItemByID = qItemColumns + `
+	WHERE i.id = $1`
+
+	qListItemsByIDs = qItemColumns + `
+	WHERE i.id = ANY($1)
+	ORDER BY i.id`
+
+	qGetItemByBarcode = qItemColumns + `
+	WHERE i.sku = $1 OR i.id = (
+		SELECT item_id
+		FROM item_barcodes
+		WHERE code = $1
+	)
+	LIMIT 1`
 
 	qUpdateItem = `
-	UPDATE items SET item_name = $2, item_description = $3, quantity = $4, updated_at = $5
+	UPDATE items SET item_name = $2, item_description = $3, quantity = $4, updated_at = $5, serialized = $6, sku = NULLIF($7, ''),
+		category_id = $8, attributes = $9
 	WHERE id = $1`
 
+	qLockItemForUpdate = `
+	SELECT attributes, quantity
+	FROM items
+	WHERE id = $1
+	FOR UPDATE`
+
+	qGetActiveReserved = `
+	SELECT COALESCE(SUM(quantity), 0)
+	FROM reservations
+	WHERE item_id = $1 AND reservation_status = 'active'`
+
 	qDeleteItem = `
 	DELETE FROM items 
 	WHERE id = $1`
 
+	qListBarcodes = `
+	SELECT item_id, code, symbology
+	FROM item_barcodes
+	ORDER BY id`
+
+	qListBarcodesByItemIDs = `
+	SELECT item_id, code, symbology
+	FROM item_barcodes
+	WHERE item_id = ANY($1)
+	ORDER BY id`
+
+	qListBarcodesByItemID = `
+	SELECT item_id, code, symbology
+	FROM item_barcodes
+	WHERE item_id = $1
+	ORDER BY id`
+
+	qListUnits = `
+	SELECT item_id, unit_code, factor
+	FROM item_units
+	ORDER BY item_id, factor`
+
+	qListUnitsByItemIDs = `
+	SELECT item_id, unit_code, factor
+	FROM item_units
+	WHERE item_id = ANY($1)
+	ORDER BY item_id, factor`
+
+	qListUnitsByItemID = `
+	SELECT item_id, unit_code, factor
+	FROM item_units
+	WHERE item_id = $1
+	ORDER BY factor`
+
+	qCreateBarcode = `
+	INSERT INTO item_barcodes (item_id, code, symbology)
+	VALUES ($1, $2, $3)`
+
+	qDeleteBarcodesByItemID = `
+	DELETE FROM item_barcodes
+	WHERE item_id = $1`
+
 	qSetUserID = `
 	SET warehouse.user_id = %d`
+
+	skuConstraint      = "uq_items_sku"
+	barcodeConstraint  = "uq_item_barcodes_code"
+	categoryConstraint = "fk_items_category_id"
 )
 
 var _ infra.ItemRepo = (*itemRepo)(nil)
@@ -67,15 +188,31 @@ func (r *itemRepo) Create(ctx context.Context, userID int, item *models.Item) (i
 		return 0, fmt.Errorf("не удалось установить userID: %w", err)
 	}
 
+	attributes, err := marshalAttributes(item.Attributes)
+	if err != nil {
+		return 0, err
+	}
+
 	row := tx.QueryRowContext(
 		ctx,
 		qCreateItem,
 		item.Name,
 		item.Description,
 		item.Quantity,
+		item.Serialized,
+		item.SKU,
+		item.CategoryID,
+		attributes,
+		item.BaseUnit,
 	)
 	var id int
 	if err := row.Scan(&id); err != nil {
+		if isUniqueViolationOn(err, skuConstraint) {
+			return 0, fmt.Errorf("item с sku %s уже существует", item.SKU)
+		}
+		if isForeignKeyViolationOn(err, categoryConstraint) {
+			return 0, fmt.Errorf("категория с id %d не найдена", *item.CategoryID)
+		}
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
@@ -86,6 +223,30 @@ func (r *itemRepo) Create(ctx context.Context, userID int, item *models.Item) (i
 		return 0, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
 	}
 
+	if err := insertBarcodes(ctx, tx, id, item.Barcodes); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", id).
+			Msg("Create: не удалось сохранить штрихкоды")
+
+		return 0, err
+	}
+
+	unitCost, err := baseUnitCost(ctx, tx, item.UnitCost, item.CostCurrency)
+	if err != nil {
+		return 0, err
+	}
+	if err := receiveCost(ctx, tx, id, item.Quantity, unitCost, "начальный остаток"); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", id).
+			Msg("Create: не удалось учесть себестоимость остатка")
+
+		return 0, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		zlog.Logger.Error().
 			Err(err).
@@ -100,16 +261,27 @@ func (r *itemRepo) Create(ctx context.Context, userID int, item *models.Item) (i
 	return id, nil
 }
 
-// List - метод для получения всех items из БД.
-func (r *itemRepo) List(ctx context.Context) ([]models.Item, error) {
+// List - метод для получения items из БД по фильтру.
+func (r *itemRepo) List(ctx context.Context, filter models.ItemFilter) ([]models.Item, error) {
 	strategy := retry.Strategy{
 		Attempts: 3,
 	}
 
+	attributesFilter, err := r.attributeFilterDocs(ctx, strategy, filter.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := r.db.QueryWithRetry(
 		ctx,
 		strategy,
 		qListItems,
+		filter.CategoryID,
+		pq.Array(attributesFilter),
+		filter.StockStatus,
+		filter.ABCClass,
+		filter.XYZClass,
+		filter.MovementStatus,
 	)
 	if err != nil {
 		zlog.Logger.Error().
@@ -123,14 +295,7 @@ func (r *itemRepo) List(ctx context.Context) ([]models.Item, error) {
 	var items []models.Item
 	for rows.Next() {
 		var item models.Item
-		if err := rows.Scan(
-			&item.ID,
-			&item.Name,
-			&item.Description,
-			&item.Quantity,
-			&item.CreatedAt,
-			&item.UpdatedAt,
-		); err != nil {
+		if err := scanItem(rows, &item); err != nil {
 			zlog.Logger.Error().
 				Err(err).
 				Msg("List: не удалось перевести данные из строки в структуру")
@@ -149,9 +314,177 @@ func (r *itemRepo) List(ctx context.Context) ([]models.Item, error) {
 		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
 	}
 
+	barcodes, err := r.listBarcodes(ctx, qListBarcodes)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("List: не удалось получить штрихкоды")
+
+		return nil, err
+	}
+	units, err := r.listUnits(ctx, qListUnits)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("List: не удалось получить единицы измерения")
+
+		return nil, err
+	}
+	for i := range items {
+		items[i].Barcodes = barcodes[items[i].ID]
+		items[i].Units = units[items[i].ID]
+	}
+
 	return items, nil
 }
 
+// GetByID - метод для получения item по id.
+func (r *itemRepo) GetByID(ctx context.Context, id int) (*models.Item, error) {
+	var item models.Item
+	if err := scanItem(r.db.QueryRowContext(ctx, qGetItemByID, id), &item); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("item с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", id).
+			Msg("GetByID: не удалось выполнить запрос GetByID")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetByID: %w", err)
+	}
+
+	barcodes, err := r.listBarcodes(ctx, qListBarcodesByItemID, item.ID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", item.ID).
+			Msg("GetByID: не удалось получить штрихкоды")
+
+		return nil, err
+	}
+	item.Barcodes = barcodes[item.ID]
+
+	units, err := r.listUnits(ctx, qListUnitsByItemID, item.ID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", item.ID).
+			Msg("GetByID: не удалось получить единицы измерения")
+
+		return nil, err
+	}
+	item.Units = units[item.ID]
+
+	return &item, nil
+}
+
+// ListByIDs - метод для получения items с указанными id, отсутствующие id пропускаются.
+func (r *itemRepo) ListByIDs(ctx context.Context, ids []int) ([]models.Item, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListItemsByIDs,
+		pq.Array(ids),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListByIDs: не удалось выполнить запрос ListByIDs")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListByIDs: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.Item
+	for rows.Next() {
+		var item models.Item
+		if err := scanItem(rows, &item); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListByIDs: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListByIDs: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	barcodes, err := r.listBarcodes(ctx, qListBarcodesByItemIDs, pq.Array(ids))
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListByIDs: не удалось получить штрихкоды")
+
+		return nil, err
+	}
+	units, err := r.listUnits(ctx, qListUnitsByItemIDs, pq.Array(ids))
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListByIDs: не удалось получить единицы измерения")
+
+		return nil, err
+	}
+	for i := range items {
+		items[i].Barcodes = barcodes[items[i].ID]
+		items[i].Units = units[items[i].ID]
+	}
+
+	return items, nil
+}
+
+// GetByBarcode - метод для поиска item по штрихкоду или sku.
+func (r *itemRepo) GetByBarcode(ctx context.Context, code string) (*models.Item, error) {
+	var item models.Item
+	if err := scanItem(r.db.QueryRowContext(ctx, qGetItemByBarcode, code), &item); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("item со штрихкодом %s не найден", code)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Str("code", code).
+			Msg("GetByBarcode: не удалось выполнить запрос GetByBarcode")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetByBarcode: %w", err)
+	}
+
+	barcodes, err := r.listBarcodes(ctx, qListBarcodesByItemID, item.ID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", item.ID).
+			Msg("GetByBarcode: не удалось получить штрихкоды")
+
+		return nil, err
+	}
+	item.Barcodes = barcodes[item.ID]
+
+	units, err := r.listUnits(ctx, qListUnitsByItemID, item.ID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", item.ID).
+			Msg("GetByBarcode: не удалось получить единицы измерения")
+
+		return nil, err
+	}
+	item.Units = units[item.ID]
+
+	return &item, nil
+}
+
 // Update - метод для обновления item в БД.
 func (r *itemRepo) Update(ctx context.Context, userID, id int, item *models.Item) error {
 	tx, err := r.db.Master.BeginTx(ctx, nil)
@@ -181,6 +514,121 @@ func (r *itemRepo) Update(ctx context.Context, userID, id int, item *models.Item
 		return fmt.Errorf("не удалось установить userID: %w", err)
 	}
 
+	if err := updateItem(ctx, tx, userID, id, item, ""); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", id).
+			Str("item_name", item.Name).
+			Int("quantity", item.Quantity).
+			Msg("Update: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// Delete - метод для удаления item из БД.
+func (r *itemRepo) Delete(ctx context.Context, userID, id int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", id).
+			Msg("Delete: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("Delete: не удалось установить userID")
+
+		return fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	if err := deleteItem(ctx, tx, userID, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", id).
+			Msg("Delete: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// updateItem - обновление item со штрихкодами в транзакции tx.
+// details дополняет детали операции в истории изменений, например номером запроса на согласование.
+func updateItem(ctx context.Context, tx *sql.Tx, userID, id int, item *models.Item, details string) error {
+	var oldAttributes []byte
+	var oldQuantity int
+	if err := tx.QueryRowContext(ctx, qLockItemForUpdate, id).Scan(&oldAttributes, &oldQuantity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("item с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", id).
+			Msg("updateItem: не удалось получить атрибуты item")
+
+		return fmt.Errorf("не удалось получить атрибуты item: %w", err)
+	}
+
+	if item.Quantity < oldQuantity {
+		var reserved int
+		if err := tx.QueryRowContext(ctx, qGetActiveReserved, id).Scan(&reserved); err != nil {
+			return fmt.Errorf("не удалось получить зарезервированное количество: %w", err)
+		}
+		if item.Quantity < reserved {
+			return fmt.Errorf("нельзя уменьшить остаток item %d до %d: в активных резервах %d", id, item.Quantity, reserved)
+		}
+	}
+
+	attributes, err := marshalAttributes(item.Attributes)
+	if err != nil {
+		return err
+	}
+
+	diff, err := attributesDiff(oldAttributes, item.Attributes)
+	if err != nil {
+		return err
+	}
+	costSource := "корректировка остатка"
+	if details != "" {
+		costSource += ": " + details
+	}
+	if diff != "" {
+		if details != "" {
+			details += "; "
+		}
+		details += diff
+	}
+	if details != "" {
+		if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+			return fmt.Errorf("не удалось установить детали операции: %w", err)
+		}
+	}
+
 	result, err := tx.ExecContext(
 		ctx,
 		qUpdateItem,
@@ -189,15 +637,25 @@ func (r *itemRepo) Update(ctx context.Context, userID, id int, item *models.Item
 		item.Description,
 		item.Quantity,
 		item.UpdatedAt,
+		item.Serialized,
+		item.SKU,
+		item.CategoryID,
+		attributes,
 	)
 	if err != nil {
+		if isUniqueViolationOn(err, skuConstraint) {
+			return fmt.Errorf("item с sku %s уже существует", item.SKU)
+		}
+		if isForeignKeyViolationOn(err, categoryConstraint) {
+			return fmt.Errorf("категория с id %d не найдена", *item.CategoryID)
+		}
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
 			Int("item_id", id).
 			Str("item_name", item.Name).
 			Int("quantity", item.Quantity).
-			Msg("Update: не удалось выполнить запрос Update")
+			Msg("updateItem: не удалось выполнить запрос Update")
 
 		return fmt.Errorf("не удалось выполнить запрос Update: %w", err)
 	}
@@ -210,7 +668,7 @@ func (r *itemRepo) Update(ctx context.Context, userID, id int, item *models.Item
 			Int("item_id", id).
 			Str("item_name", item.Name).
 			Int("quantity", item.Quantity).
-			Msg("Update: не удалось получить количество строк, обновленных запросом Update")
+			Msg("updateItem: не удалось получить количество строк, обновленных запросом Update")
 
 		return fmt.Errorf("не удалось получить количество строк, обновленных запросом Update: %w", err)
 	}
@@ -218,59 +676,67 @@ func (r *itemRepo) Update(ctx context.Context, userID, id int, item *models.Item
 		return fmt.Errorf("item с id %d не найден", id)
 	}
 
-	if err := tx.Commit(); err != nil {
+	if err := adjustCost(ctx, tx, id, item.Quantity-oldQuantity, costSource); err != nil {
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
 			Int("item_id", id).
-			Str("item_name", item.Name).
-			Int("quantity", item.Quantity).
-			Msg("Update: не удалось завершить транзакцию")
+			Msg("updateItem: не удалось учесть себестоимость корректировки")
 
-		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+		return err
 	}
 
-	return nil
-}
-
-// Delete - метод для удаления item из БД.
-func (r *itemRepo) Delete(ctx context.Context, userID, id int) error {
-	tx, err := r.db.Master.BeginTx(ctx, nil)
-	if err != nil {
+	if _, err := tx.ExecContext(ctx, qDeleteBarcodesByItemID, id); err != nil {
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
 			Int("item_id", id).
-			Msg("Delete: не удалось начать транзакцию")
+			Msg("updateItem: не удалось удалить штрихкоды")
 
-		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+		return fmt.Errorf("не удалось удалить штрихкоды: %w", err)
 	}
-	defer tx.Rollback()
 
-	_, err = tx.ExecContext(
-		ctx,
-		fmt.Sprintf(qSetUserID, userID),
-	)
-	if err != nil {
+	if err := insertBarcodes(ctx, tx, id, item.Barcodes); err != nil {
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
-			Msg("Delete: не удалось установить userID")
+			Int("item_id", id).
+			Msg("updateItem: не удалось сохранить штрихкоды")
 
-		return fmt.Errorf("не удалось установить userID: %w", err)
+		return err
 	}
 
+	return nil
+}
+
+// deleteItem - удаление item в транзакции tx.
+func deleteItem(ctx context.Context, tx *sql.Tx, userID, id int) error {
 	result, err := tx.ExecContext(
 		ctx,
 		qDeleteItem,
 		id,
 	)
 	if err != nil {
+		if isForeignKeyViolationOn(err, purchaseOrderLineItemConstraint) {
+			return fmt.Errorf("нельзя удалить item с id %d: он используется в заказах на закупку", id)
+		}
+		if isForeignKeyViolationOn(err, outboundLineItemConstraint) {
+			return fmt.Errorf("нельзя удалить item с id %d: он используется в заказах на отгрузку", id)
+		}
+		if isForeignKeyViolationOn(err, writeOffLineItemConstraint) {
+			return fmt.Errorf("нельзя удалить item с id %d: он используется в списаниях", id)
+		}
+		if isForeignKeyViolationOn(err, kitComponentItemConstraint) {
+			return fmt.Errorf("нельзя удалить item с id %d: он входит в состав комплекта", id)
+		}
+		if isForeignKeyViolationOn(err, kitAssemblyItemConstraint) || isForeignKeyViolationOn(err, kitAssemblyLineConstraint) {
+			return fmt.Errorf("нельзя удалить item с id %d: он используется в документах сборки комплектов", id)
+		}
 		zlog.Logger.Error().
 			Err(err).
 			Int("user_id", userID).
 			Int("item_id", id).
-			Msg("Delete: не удалось выполнить запрос Delete")
+			Msg("deleteItem: не удалось выполнить запрос Delete")
 
 		return fmt.Errorf("не удалось выполнить запрос Delete: %w", err)
 	}
@@ -281,7 +747,7 @@ func (r *itemRepo) Delete(ctx context.Context, userID, id int) error {
 			Err(err).
 			Int("user_id", userID).
 			Int("item_id", id).
-			Msg("Delete: не удалось получить количество строк, удаленных запросом Delete")
+			Msg("deleteItem: не удалось получить количество строк, удаленных запросом Delete")
 
 		return fmt.Errorf("не удалось получить количество строк, удаленных запросом Delete: %w", err)
 	}
@@ -289,15 +755,263 @@ func (r *itemRepo) Delete(ctx context.Context, userID, id int) error {
 		return fmt.Errorf("item с id %d не найден", id)
 	}
 
-	if err := tx.Commit(); err != nil {
+	return nil
+}
+
+// listBarcodes - получение штрихкодов, сгруппированных по item_id.
+func (r *itemRepo) listBarcodes(ctx context.Context, query string, args ...any) (map[int][]models.Barcode, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос штрихкодов: %w", err)
+	}
+	defer rows.Close()
+
+	barcodes := make(map[int][]models.Barcode)
+	for rows.Next() {
+		var itemID int
+		var barcode models.Barcode
+		if err := rows.Scan(&itemID, &barcode.Code, &barcode.Symbology); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		barcodes[itemID] = append(barcodes[itemID], barcode)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return barcodes, nil
+}
+
+// listUnits - получение уровней упаковки, сгруппированных по item_id.
+func (r *itemRepo) listUnits(ctx context.Context, query string, args ...any) (map[int][]models.ItemUnit, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос единиц измерения: %w", err)
+	}
+	defer rows.Close()
+
+	units := make(map[int][]models.ItemUnit)
+	for rows.Next() {
+		var itemID int
+		var unit models.ItemUnit
+		if err := rows.Scan(&itemID, &unit.Code, &unit.Factor); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		units[itemID] = append(units[itemID], unit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return units, nil
+}
+
+// insertBarcodes - сохранение штрихкодов item в рамках транзакции.
+func insertBarcodes(ctx context.Context, tx *sql.Tx, itemID int, barcodes []models.Barcode) error {
+	for _, barcode := range barcodes {
+		if _, err := tx.ExecContext(ctx, qCreateBarcode, itemID, barcode.Code, barcode.Symbology); err != nil {
+			if isUniqueViolationOn(err, barcodeConstraint) {
+				return fmt.Errorf("штрихкод %s уже существует", barcode.Code)
+			}
+
+			return fmt.Errorf("не удалось сохранить штрихкод %s: %w", barcode.Code, err)
+		}
+	}
+
+	return nil
+}
+
+// scanItem - перевод строки, выбранной по qItemColumns, в структуру item.
+func scanItem(row interface{ Scan(dest ...any) error }, item *models.Item) error {
+	var categoryID sql.NullInt64
+	var held, attributes []byte
+	var lastMovementAt sql.NullTime
+	if err := row.Scan(
+		&item.ID,
+		&item.Name,
+		&item.Description,
+		&item.Quantity,
+		&item.Reserved,
+		&held,
+		&item.Serialized,
+		&item.BaseUnit,
+		&item.SKU,
+		&categoryID,
+		&attributes,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+		&item.ABCClass,
+		&item.XYZClass,
+		&item.MovementStatus,
+		&lastMovementAt,
+	); err != nil {
+		return err
+	}
+
+	if categoryID.Valid {
+		id := int(categoryID.Int64)
+		item.CategoryID = &id
+	}
+	if lastMovementAt.Valid {
+		item.LastMovementAt = &lastMovementAt.Time
+	}
+	if err := json.Unmarshal(attributes, &item.Attributes); err != nil {
+		return fmt.Errorf("не удалось разобрать атрибуты item: %w", err)
+	}
+	if err := json.Unmarshal(held, &item.Held); err != nil {
+		return fmt.Errorf("не удалось разобрать запас item по статусам: %w", err)
+	}
+
+	return nil
+}
+
+// attributeFilterDocs - перевод фильтра атрибутов в JSONB-документы для проверки вхождения по индексу idx_items_attributes.
+// Текстовое значение приводится к типу атрибута по схемам категорий, код с разными типами в разных категориях
+// дает несколько документов, item подходит, если содержит любой из них. При пустом фильтре возвращает nil.
+func (r *itemRepo) attributeFilterDocs(ctx context.Context, strategy retry.Strategy, filter map[string]string) ([]string, error) {
+	if len(filter) == 0 {
+		return nil, nil
+	}
+
+	codes := make([]string, 0, len(filter))
+	for code := range filter {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, qListAttributeTypes, pq.Array(codes))
+	if err != nil {
 		zlog.Logger.Error().
 			Err(err).
-			Int("user_id", userID).
-			Int("item_id", id).
-			Msg("Delete: не удалось завершить транзакцию")
+			Msg("List: не удалось получить типы атрибутов")
 
-		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+		return nil, fmt.Errorf("не удалось получить типы атрибутов: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	types := make(map[string][]string, len(codes))
+	for rows.Next() {
+		var code, attrType string
+		if err := rows.Scan(&code, &attrType); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		types[code] = append(types[code], attrType)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	docs := []map[string]any{{}}
+	for _, code := range codes {
+		var values []any
+		for _, attrType := range types[code] {
+			if value, ok := typedAttributeValue(attrType, filter[code]); ok {
+				values = append(values, value)
+			}
+		}
+
+		next := make([]map[string]any, 0, len(docs)*len(values))
+		for _, doc := range docs {
+			for _, value := range values {
+				extended := maps.Clone(doc)
+				extended[code] = value
+				next = append(next, extended)
+			}
+		}
+		docs = next
+	}
+
+	result := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось сериализовать фильтр атрибутов: %w", err)
+		}
+		result = append(result, string(data))
+	}
+
+	return result, nil
+}
+
+// typedAttributeValue - значение фильтра в типе атрибута, false если текст не приводится к типу.
+func typedAttributeValue(attrType, text string) (any, bool) {
+	switch attrType {
+	case models.AttrTypeNumber:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, false
+		}
+
+		return value, true
+	case models.AttrTypeBoolean:
+		if text != "true" && text != "false" {
+			return nil, false
+		}
+
+		return text == "true", true
+	}
+
+	return text, true
+}
+
+// marshalAttributes - сериализация атрибутов item в JSONB, пустые атрибуты сохраняются как {}.
+func marshalAttributes(attributes map[string]any) (string, error) {
+	if len(attributes) == 0 {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return "", fmt.Errorf("не удалось сериализовать атрибуты item: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// attributesDiff - описание изменений атрибутов для истории, пустая строка если изменений нет.
+func attributesDiff(oldData []byte, newAttributes map[string]any) (string, error) {
+	var oldAttributes map[string]any
+	if err := json.Unmarshal(oldData, &oldAttributes); err != nil {
+		return "", fmt.Errorf("не удалось разобрать атрибуты item: %w", err)
+	}
+
+	keys := make(map[string]struct{}, len(oldAttributes)+len(newAttributes))
+	for key := range oldAttributes {
+		keys[key] = struct{}{}
+	}
+	for key := range newAttributes {
+		keys[key] = struct{}{}
+	}
+
+	var changes []string
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		oldValue, hadOld := oldAttributes[key]
+		newValue, hasNew := newAttributes[key]
+		switch {
+		case !hadOld:
+			changes = append(changes, fmt.Sprintf("%s: + %v", key, newValue))
+		case !hasNew:
+			changes = append(changes, fmt.Sprintf("%s: - %v", key, oldValue))
+		case fmt.Sprint(oldValue) != fmt.Sprint(newValue):
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", key, oldValue, newValue))
+		}
+	}
+
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	return "атрибуты: " + strings.Join(changes, ", "), nil
 }
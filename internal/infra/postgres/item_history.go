@@ -14,7 +14,7 @@ import (
 
 const (
 	qGetByItemID = `
-	SELECT id, item_id, user_id, operation, old_value, new_value, changed_at
+	SELECT id, item_id, user_id, operation, old_value, new_value, details, changed_at
 	FROM items_history
 	WHERE item_id = $1`
 )
@@ -57,6 +57,7 @@ func (r *itemHistoryRepo) GetByItemID(ctx context.Context, itemID int) ([]models
 			&itemHistory.Operation,
 			&itemHistory.OldValue,
 			&itemHistory.NewValue,
+			&itemHistory.Details,
 			&itemHistory.ChangedAt,
 		); err != nil {
 			zlog.Logger.Error().
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func TestItemRepo_List_AttributeFilter(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	categories := &categoryRepo{db: db}
+	attributes := &attributeRepo{db: db}
+	items := &itemRepo{db: db}
+
+	suffix := time.Now().Format("150405.000000000")
+	categoryID, err := categories.CreateCategory(ctx, &models.Category{Name: "filter-" + suffix})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Master.ExecContext(ctx, `DELETE FROM items WHERE category_id = $1`, categoryID)
+		db.Master.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, categoryID)
+	})
+
+	for _, def := range []models.AttributeDefinition{
+		{CategoryID: categoryID, Code: "filter_weight", Name: "Вес", Type: models.AttrTypeNumber},
+		{CategoryID: categoryID, Code: "filter_fragile", Name: "Хрупкий", Type: models.AttrTypeBoolean},
+		{CategoryID: categoryID, Code: "filter_size", Name: "Размер", Type: models.AttrTypeString},
+	} {
+		_, err := attributes.CreateAttributeDefinition(ctx, &def)
+		require.NoError(t, err)
+	}
+
+	var itemID int
+	require.NoError(t, db.Master.QueryRowContext(ctx,
+		`INSERT INTO items (item_name, category_id, attributes) VALUES ($1, $2, $3) RETURNING id`,
+		"filter-item-"+suffix, categoryID, `{"filter_weight": 1.5, "filter_fragile": true, "filter_size": "42"}`,
+	).Scan(&itemID))
+
+	for _, tc := range []struct {
+		filter map[string]string
+		found  bool
+	}{
+		{map[string]string{"filter_weight": "1.5", "filter_fragile": "true", "filter_size": "42"}, true},
+		{map[string]string{"filter_weight": "1.50"}, true},
+		{map[string]string{"filter_size": "42.0"}, false},
+		{map[string]string{"filter_fragile": "false"}, false},
+		{map[string]string{"filter_unknown": "1"}, false},
+	} {
+		list, err := items.List(ctx, models.ItemFilter{CategoryID: &categoryID, Attributes: tc.filter})
+		require.NoError(t, err)
+
+		if tc.found {
+			require.Len(t, list, 1, tc.filter)
+			assert.Equal(t, itemID, list[0].ID)
+		} else {
+			assert.Empty(t, list, tc.filter)
+		}
+	}
+}
@@ -0,0 +1,725 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qListKitComponents = `
+	SELECT k.component_id, i.item_name, i.base_unit, k.quantity, i.quantity - COALESCE((
+		SELECT SUM(r.quantity)
+		FROM reservations r
+		WHERE r.item_id = i.id AND r.reservation_status = 'active'
+	), 0), k.updated_at
+	FROM kit_components k
+	JOIN items i ON i.id = k.component_id
+	WHERE k.kit_id = $1
+	ORDER BY k.component_id`
+
+	qLockKitComponents = `
+	SELECT k.component_id, i.item_name, k.quantity
+	FROM kit_components k
+	JOIN items i ON i.id = k.component_id
+	WHERE k.kit_id = $1
+	ORDER BY k.component_id
+	FOR UPDATE OF k`
+
+	qLockItemsSerialized = `
+	SELECT id, serialized
+	FROM items
+	WHERE id = ANY($1)
+	ORDER BY id
+	FOR UPDATE`
+
+	qIsKitComponent = `
+	SELECT EXISTS (
+		SELECT 1
+		FROM kit_components
+		WHERE component_id = $1
+	)`
+
+	qFindKitAmong = `
+	SELECT kit_id
+	FROM kit_components
+	WHERE kit_id = ANY($1)
+	ORDER BY kit_id
+	LIMIT 1`
+
+	qDeleteKitComponents = `
+	DELETE FROM kit_components
+	WHERE kit_id = $1`
+
+	qCreateKitComponent = `
+	INSERT INTO kit_components (kit_id, component_id, quantity)
+	VALUES ($1, $2, $3)`
+
+	qCreateKitAssembly = `
+	INSERT INTO kit_assemblies (kit_id, operation, quantity, note, created_by)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, assembly_number, created_at`
+
+	qSetKitAssemblyCost = `
+	UPDATE kit_assemblies SET unit_cost = $2
+	WHERE id = $1`
+
+	qCreateKitAssemblyLine = `
+	INSERT INTO kit_assembly_lines (assembly_id, component_id, quantity, unit_cost)
+	VALUES ($1, $2, $3, $4)`
+
+	qListKitAssemblies = `
+	SELECT a.id, a.assembly_number, a.kit_id, i.item_name, a.operation, a.quantity, a.unit_cost, a.note,
+		COALESCE(a.created_by, 0), a.created_at
+	FROM kit_assemblies a
+	JOIN items i ON i.id = a.kit_id
+	WHERE a.kit_id = $1
+	ORDER BY a.id DESC`
+
+	qListKitAssemblyLines = `
+	SELECT l.assembly_id, l.component_id, i.item_name, l.quantity, l.unit_cost
+	FROM kit_assembly_lines l
+	JOIN items i ON i.id = l.component_id
+	WHERE l.assembly_id = ANY($1)
+	ORDER BY l.id`
+
+	kitComponentItemConstraint = "kit_components_component_id_fkey"
+	kitAssemblyItemConstraint  = "kit_assemblies_kit_id_fkey"
+	kitAssemblyLineConstraint  = "kit_assembly_lines_component_id_fkey"
+)
+
+var _ infra.KitRepo = (*kitRepo)(nil)
+
+type kitRepo struct {
+	db *dbpg.DB
+}
+
+// kitPart - компонент комплекта при сборке и разборке.
+type kitPart struct {
+	itemID   int
+	name     string
+	quantity int
+}
+
+// GetKit - метод для получения состава комплекта item с доступным остатком компонентов.
+// Для item, который не является комплектом, состав пустой.
+func (r *kitRepo) GetKit(ctx context.Context, itemID int) (*models.Kit, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListKitComponents,
+		itemID,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetKit: не удалось выполнить запрос GetKit")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetKit: %w", err)
+	}
+	defer rows.Close()
+
+	kit := models.Kit{ItemID: itemID}
+	for rows.Next() {
+		var c models.KitComponent
+		if err := rows.Scan(
+			&c.ItemID,
+			&c.ItemName,
+			&c.BaseUnit,
+			&c.Quantity,
+			&c.Available,
+			&c.UpdatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("GetKit: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		kit.Components = append(kit.Components, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetKit: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return &kit, nil
+}
+
+// SetKitComponents - метод для замены состава комплекта целиком.
+// Серийные items и вложенные комплекты в составе не допускаются.
+func (r *kitRepo) SetKitComponents(ctx context.Context, kit *models.Kit) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", kit.ItemID).
+			Msg("SetKitComponents: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int, 0, len(kit.Components)+1)
+	ids = append(ids, kit.ItemID)
+	for _, c := range kit.Components {
+		ids = append(ids, c.ItemID)
+	}
+
+	serialized, err := lockItemsSerialized(ctx, tx, ids)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", kit.ItemID).
+			Msg("SetKitComponents: не удалось заблокировать items")
+
+		return err
+	}
+	if _, ok := serialized[kit.ItemID]; !ok {
+		return fmt.Errorf("item с id %d не найден", kit.ItemID)
+	}
+	if serialized[kit.ItemID] {
+		return fmt.Errorf("нельзя сделать комплектом серийный item %d", kit.ItemID)
+	}
+	for _, c := range kit.Components {
+		isSerialized, ok := serialized[c.ItemID]
+		if !ok {
+			return fmt.Errorf("компонент с id %d не найден", c.ItemID)
+		}
+		if isSerialized {
+			return fmt.Errorf("нельзя включить серийный item %d в комплект", c.ItemID)
+		}
+	}
+
+	var isComponent bool
+	if err := tx.QueryRowContext(ctx, qIsKitComponent, kit.ItemID).Scan(&isComponent); err != nil {
+		return fmt.Errorf("не удалось проверить составы комплектов: %w", err)
+	}
+	if isComponent {
+		return fmt.Errorf("нельзя сделать комплектом item %d: он входит в состав другого комплекта", kit.ItemID)
+	}
+
+	var nestedID int
+	err = tx.QueryRowContext(ctx, qFindKitAmong, pq.Array(ids[1:])).Scan(&nestedID)
+	if err == nil {
+		return fmt.Errorf("нельзя включить комплект %d в состав другого комплекта", nestedID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("не удалось проверить составы комплектов: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qDeleteKitComponents, kit.ItemID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", kit.ItemID).
+			Msg("SetKitComponents: не удалось удалить прежний состав")
+
+		return fmt.Errorf("не удалось удалить прежний состав комплекта: %w", err)
+	}
+	for _, c := range kit.Components {
+		if _, err := tx.ExecContext(ctx, qCreateKitComponent, kit.ItemID, c.ItemID, c.Quantity); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", kit.ItemID).
+				Int("component_id", c.ItemID).
+				Msg("SetKitComponents: не удалось сохранить компонент")
+
+			return fmt.Errorf("не удалось сохранить компонент комплекта: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", kit.ItemID).
+			Msg("SetKitComponents: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteKit - метод для удаления состава комплекта, сам item и его остаток сохраняются.
+func (r *kitRepo) DeleteKit(ctx context.Context, itemID int) error {
+	result, err := r.db.ExecContext(ctx, qDeleteKitComponents, itemID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("DeleteKit: не удалось выполнить запрос DeleteKit")
+
+		return fmt.Errorf("не удалось выполнить запрос DeleteKit: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество строк, удаленных запросом DeleteKit: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("состав комплекта item %d не найден", itemID)
+	}
+
+	return nil
+}
+
+// AssembleKit - метод для сборки или разборки комплектов в одной транзакции.
+// Сборка расходует компоненты из доступного незарезервированного остатка по методу оценки каждого компонента
+// и приходует комплекты по их суммарной себестоимости. Разборка расходует комплекты и распределяет
+// их себестоимость между компонентами пропорционально средней себестоимости компонентов.
+// Заполняет номер, себестоимость и строки документа.
+func (r *kitRepo) AssembleKit(ctx context.Context, a *models.KitAssembly) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", a.CreatedBy).
+			Int("item_id", a.KitID).
+			Msg("AssembleKit: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(qSetUserID, a.CreatedBy)); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", a.CreatedBy).
+			Msg("AssembleKit: не удалось установить userID")
+
+		return fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var kitSerialized bool
+	if err := tx.QueryRowContext(ctx, qLockSerializedItem, a.KitID).Scan(&kitSerialized); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("item с id %d не найден", a.KitID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", a.KitID).
+			Msg("AssembleKit: не удалось заблокировать комплект")
+
+		return fmt.Errorf("не удалось заблокировать item: %w", err)
+	}
+	if kitSerialized {
+		return fmt.Errorf("нельзя собирать серийный item %d как комплект", a.KitID)
+	}
+
+	parts, err := lockKitParts(ctx, tx, a.KitID, a.Quantity)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", a.KitID).
+			Msg("AssembleKit: не удалось получить состав комплекта")
+
+		return err
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("нельзя собрать или разобрать item %d: не задан состав комплекта", a.KitID)
+	}
+
+	if err := tx.QueryRowContext(
+		ctx,
+		qCreateKitAssembly,
+		a.KitID,
+		a.Operation,
+		a.Quantity,
+		a.Note,
+		a.CreatedBy,
+	).Scan(&a.ID, &a.Number, &a.CreatedAt); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", a.KitID).
+			Msg("AssembleKit: не удалось создать документ")
+
+		return fmt.Errorf("не удалось создать документ сборки: %w", err)
+	}
+
+	if a.Operation == models.KitDisassemble {
+		err = disassembleKit(ctx, tx, a, parts)
+	} else {
+		err = assembleKit(ctx, tx, a, parts)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetKitAssemblyCost, a.ID, a.UnitCost); err != nil {
+		return fmt.Errorf("не удалось сохранить себестоимость комплекта: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", a.KitID).
+			Msg("AssembleKit: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// ListKitAssemblies - метод для получения документов сборки и разборки комплекта, новые первыми.
+func (r *kitRepo) ListKitAssemblies(ctx context.Context, kitID int) ([]models.KitAssembly, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListKitAssemblies,
+		kitID,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", kitID).
+			Msg("ListKitAssemblies: не удалось выполнить запрос ListKitAssemblies")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListKitAssemblies: %w", err)
+	}
+	defer rows.Close()
+
+	var assemblies []models.KitAssembly
+	for rows.Next() {
+		var a models.KitAssembly
+		if err := rows.Scan(
+			&a.ID,
+			&a.Number,
+			&a.KitID,
+			&a.KitName,
+			&a.Operation,
+			&a.Quantity,
+			&a.UnitCost,
+			&a.Note,
+			&a.CreatedBy,
+			&a.CreatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListKitAssemblies: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		assemblies = append(assemblies, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListKitAssemblies: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	if len(assemblies) == 0 {
+		return assemblies, nil
+	}
+
+	ids := make([]int, 0, len(assemblies))
+	for _, a := range assemblies {
+		ids = append(ids, a.ID)
+	}
+
+	lines, err := r.listKitAssemblyLines(ctx, ids)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListKitAssemblies: не удалось получить строки документов")
+
+		return nil, err
+	}
+	for i := range assemblies {
+		assemblies[i].Lines = lines[assemblies[i].ID]
+	}
+
+	return assemblies, nil
+}
+
+// listKitAssemblyLines - получение строк документов сборки, сгруппированных по id документа.
+func (r *kitRepo) listKitAssemblyLines(ctx context.Context, ids []int) (map[int][]models.KitAssemblyLine, error) {
+	rows, err := r.db.Master.QueryContext(ctx, qListKitAssemblyLines, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listKitAssemblyLines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[int][]models.KitAssemblyLine, len(ids))
+	for rows.Next() {
+		var assemblyID int
+		var line models.KitAssemblyLine
+		if err := rows.Scan(&assemblyID, &line.ItemID, &line.ItemName, &line.Quantity, &line.UnitCost); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		lines[assemblyID] = append(lines[assemblyID], line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return lines, nil
+}
+
+// assembleKit - расход компонентов на quantity комплектов и приход комплектов по их себестоимости.
+func assembleKit(ctx context.Context, tx *sql.Tx, a *models.KitAssembly, parts []kitPart) error {
+	details := fmt.Sprintf("сборка комплекта %s", a.Number)
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		return fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	var total models.Money
+	for _, part := range parts {
+		result, err := tx.ExecContext(ctx, qDecreaseItemAvailable, part.itemID, part.quantity)
+		if err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", part.itemID).
+				Msg("assembleKit: не удалось уменьшить остаток компонента")
+
+			return fmt.Errorf("не удалось уменьшить остаток компонента: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			return fmt.Errorf("недостаточно доступного незарезервированного остатка компонента %d: нужно %d", part.itemID, part.quantity)
+		}
+
+		cost, err := issueCost(ctx, tx, part.itemID, part.quantity, details)
+		if err != nil {
+			return err
+		}
+		total += cost
+
+		line := models.KitAssemblyLine{
+			ItemID:   part.itemID,
+			ItemName: part.name,
+			Quantity: part.quantity,
+			UnitCost: cost.Div(part.quantity),
+		}
+		if err := createKitAssemblyLine(ctx, tx, a, line); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, qIncreaseItemQuantity, a.KitID, a.Quantity); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", a.KitID).
+			Msg("assembleKit: не удалось увеличить остаток комплекта")
+
+		return fmt.Errorf("не удалось увеличить остаток комплекта: %w", err)
+	}
+
+	a.UnitCost = total.Div(a.Quantity)
+
+	return receiveCost(ctx, tx, a.KitID, a.Quantity, &a.UnitCost, details)
+}
+
+// disassembleKit - расход quantity комплектов и приход компонентов.
+// Себестоимость комплектов делится между компонентами по их средней себестоимости,
+// без себестоимости компонентов - пропорционально количеству.
+func disassembleKit(ctx context.Context, tx *sql.Tx, a *models.KitAssembly, parts []kitPart) error {
+	details := fmt.Sprintf("разборка комплекта %s", a.Number)
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		return fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, qDecreaseItemAvailable, a.KitID, a.Quantity)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", a.KitID).
+			Msg("disassembleKit: не удалось уменьшить остаток комплекта")
+
+		return fmt.Errorf("не удалось уменьшить остаток комплекта: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	} else if affected == 0 {
+		return fmt.Errorf("недостаточно доступного незарезервированного остатка комплекта %d для разборки %d", a.KitID, a.Quantity)
+	}
+
+	total, err := issueCost(ctx, tx, a.KitID, a.Quantity, details)
+	if err != nil {
+		return err
+	}
+	a.UnitCost = total.Div(a.Quantity)
+
+	weights := make([]models.Money, len(parts))
+	var sum models.Money
+	for i, part := range parts {
+		unitCost, err := avgUnitCost(ctx, tx, part.itemID)
+		if err != nil {
+			return err
+		}
+		weights[i] = unitCost.Mul(part.quantity)
+		sum += weights[i]
+	}
+	if sum <= 0 {
+		sum = 0
+		for i, part := range parts {
+			weights[i] = models.Money(part.quantity)
+			sum += weights[i]
+		}
+	}
+
+	rest := total
+	for i, part := range parts {
+		cost := rest
+		if i < len(parts)-1 {
+			cost = total.Share(int(weights[i]), int(sum))
+			rest -= cost
+		}
+
+		if _, err := tx.ExecContext(ctx, qIncreaseItemQuantity, part.itemID, part.quantity); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", part.itemID).
+				Msg("disassembleKit: не удалось увеличить остаток компонента")
+
+			return fmt.Errorf("не удалось увеличить остаток компонента: %w", err)
+		}
+
+		line := models.KitAssemblyLine{
+			ItemID:   part.itemID,
+			ItemName: part.name,
+			Quantity: part.quantity,
+			UnitCost: cost.Div(part.quantity),
+		}
+		if err := receiveCost(ctx, tx, part.itemID, part.quantity, &line.UnitCost, details); err != nil {
+			return err
+		}
+		if err := createKitAssemblyLine(ctx, tx, a, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockKitParts - блокировка состава комплекта и компонентов, количество компонентов на quantity комплектов.
+func lockKitParts(ctx context.Context, tx *sql.Tx, kitID, quantity int) ([]kitPart, error) {
+	rows, err := tx.QueryContext(ctx, qLockKitComponents, kitID)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось заблокировать состав комплекта: %w", err)
+	}
+	defer rows.Close()
+
+	var parts []kitPart
+	for rows.Next() {
+		var part kitPart
+		if err := rows.Scan(&part.itemID, &part.name, &part.quantity); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		part.quantity *= quantity
+
+		parts = append(parts, part)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+	rows.Close()
+
+	ids := make([]int, 0, len(parts))
+	for _, part := range parts {
+		ids = append(ids, part.itemID)
+	}
+	serialized, err := lockItemsSerialized(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		if serialized[part.itemID] {
+			return nil, fmt.Errorf("нельзя собирать комплект из серийного item %d", part.itemID)
+		}
+	}
+
+	return parts, nil
+}
+
+// lockItemsSerialized - блокировка items в порядке id, возвращает признак serialized по id найденных items.
+func lockItemsSerialized(ctx context.Context, tx *sql.Tx, ids []int) (map[int]bool, error) {
+	serialized := make(map[int]bool, len(ids))
+	if len(ids) == 0 {
+		return serialized, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, qLockItemsSerialized, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось заблокировать items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var isSerialized bool
+		if err := rows.Scan(&id, &isSerialized); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		serialized[id] = isSerialized
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return serialized, nil
+}
+
+// avgUnitCost - средняя себестоимость единицы запаса item, при пустом запасе - цена последнего слоя.
+func avgUnitCost(ctx context.Context, tx *sql.Tx, itemID int) (models.Money, error) {
+	_, balanceQty, balanceValue, err := costBalance(ctx, tx, itemID)
+	if err != nil {
+		return 0, err
+	}
+	if balanceQty > 0 {
+		return balanceValue.Div(balanceQty), nil
+	}
+
+	var cost models.Money
+	if err := tx.QueryRowContext(ctx, qGetLastLayerCost, itemID).Scan(&cost); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("не удалось получить себестоимость item: %w", err)
+	}
+
+	return cost, nil
+}
+
+// createKitAssemblyLine - запись строки документа сборки.
+func createKitAssemblyLine(ctx context.Context, tx *sql.Tx, a *models.KitAssembly, line models.KitAssemblyLine) error {
+	if _, err := tx.ExecContext(ctx, qCreateKitAssemblyLine, a.ID, line.ItemID, line.Quantity, line.UnitCost); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("assembly_id", a.ID).
+			Int("item_id", line.ItemID).
+			Msg("createKitAssemblyLine: не удалось сохранить строку документа")
+
+		return fmt.Errorf("не удалось сохранить строку документа сборки: %w", err)
+	}
+	a.Lines = append(a.Lines, line)
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qCreateLocation = `
+	INSERT INTO locations (code, location_name)
+	VALUES ($1, $2)
+	RETURNING id`
+
+	qListLocations = `
+	SELECT id, code, location_name, created_at
+	FROM locations
+	ORDER BY code`
+
+	qGetLocationByID = `
+	SELECT id, code, location_name, created_at
+	FROM locations
+	WHERE id = $1`
+
+	qSetItemPickLocation = `
+	UPDATE items SET pick_location_id = $2
+	WHERE id = $1`
+
+	qGetItemPickLocation = `
+	SELECT pick_location_id
+	FROM items
+	WHERE id = $1`
+
+	// Уровни запаса по ячейке задаются только для ячейки подбора, при ее смене они снимаются вместе с оповещениями.
+	qDeleteStaleLocationLevels = `
+	DELETE FROM stock_levels
+	WHERE item_id = $1 AND location_id IS NOT NULL AND location_id IS DISTINCT FROM $2`
+
+	qResolveStaleLocationAlerts = `
+	UPDATE stock_alerts SET alert_status = 'resolved', resolved_at = CURRENT_TIMESTAMP
+	WHERE item_id = $1 AND location_id IS NOT NULL AND location_id IS DISTINCT FROM $2
+		AND alert_status IN ('open', 'acknowledged')`
+
+	pickLocationConstraint = "fk_items_pick_location_id"
+)
+
+var _ infra.LocationRepo = (*locationRepo)(nil)
+
+type locationRepo struct {
+	db *dbpg.DB
+}
+
+// CreateLocation - метод для создания складской ячейки.
+func (r *locationRepo) CreateLocation(ctx context.Context, loc *models.Location) (int, error) {
+	var id int
+	if err := r.db.Master.QueryRowContext(ctx, qCreateLocation, loc.Code, loc.Name).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("ячейка с кодом %s уже существует", loc.Code)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Str("code", loc.Code).
+			Msg("CreateLocation: не удалось создать ячейку")
+
+		return 0, fmt.Errorf("не удалось создать ячейку: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListLocations - метод для получения всех складских ячеек.
+func (r *locationRepo) ListLocations(ctx context.Context) ([]models.Location, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListLocations,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListLocations: не удалось выполнить запрос ListLocations")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListLocations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []models.Location
+	for rows.Next() {
+		var loc models.Location
+		if err := rows.Scan(
+			&loc.ID,
+			&loc.Code,
+			&loc.Name,
+			&loc.CreatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListLocations: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		locations = append(locations, loc)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListLocations: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return locations, nil
+}
+
+// GetLocationByID - метод для получения складской ячейки по id.
+func (r *locationRepo) GetLocationByID(ctx context.Context, id int) (*models.Location, error) {
+	var loc models.Location
+	if err := r.db.QueryRowContext(ctx, qGetLocationByID, id).Scan(
+		&loc.ID,
+		&loc.Code,
+		&loc.Name,
+		&loc.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("ячейка с id %d не найдена", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("location_id", id).
+			Msg("GetLocationByID: не удалось выполнить запрос GetLocationByID")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetLocationByID: %w", err)
+	}
+
+	return &loc, nil
+}
+
+// SetItemPickLocation - метод для назначения item ячейки подбора, nil снимает назначение.
+// Уровни запаса и оповещения по прежней ячейке подбора снимаются.
+func (r *locationRepo) SetItemPickLocation(ctx context.Context, itemID int, locationID *int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemPickLocation: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, qSetItemPickLocation, itemID, locationID)
+	if err != nil {
+		if isForeignKeyViolationOn(err, pickLocationConstraint) {
+			return fmt.Errorf("ячейка с id %d не найдена", *locationID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemPickLocation: не удалось назначить ячейку подбора")
+
+		return fmt.Errorf("не удалось назначить ячейку подбора: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("item с id %d не найден", itemID)
+	}
+
+	if _, err := tx.ExecContext(ctx, qDeleteStaleLocationLevels, itemID, locationID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemPickLocation: не удалось снять уровни запаса прежней ячейки")
+
+		return fmt.Errorf("не удалось снять уровни запаса прежней ячейки: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, qResolveStaleLocationAlerts, itemID, locationID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemPickLocation: не удалось закрыть оповещения прежней ячейки")
+
+		return fmt.Errorf("не удалось закрыть оповещения прежней ячейки: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemPickLocation: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// GetItemPickLocation - метод для получения ячейки подбора item, nil если она не назначена.
+func (r *locationRepo) GetItemPickLocation(ctx context.Context, itemID int) (*int, error) {
+	var locationID sql.NullInt64
+	if err := r.db.QueryRowContext(ctx, qGetItemPickLocation, itemID).Scan(&locationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("item с id %d не найден", itemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetItemPickLocation: не удалось выполнить запрос GetItemPickLocation")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetItemPickLocation: %w", err)
+	}
+
+	return nullIntPtr(locationID), nil
+}
@@ -0,0 +1,394 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qSetHistoryDetails = `
+	SELECT set_config('warehouse.details', $1, true)`
+
+	qCreateLot = `
+	INSERT INTO item_lots (item_id, lot_number, manufactured_at, expires_at, quantity)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`
+
+	qIncreaseItemQuantity = `
+	UPDATE items SET quantity = quantity + $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qListLotsByItemID = `
+	SELECT id, item_id, lot_number, manufactured_at, expires_at, quantity, created_at
+	FROM item_lots
+	WHERE item_id = $1
+	ORDER BY expires_at NULLS LAST, id`
+
+	qConsumeLot = `
+	UPDATE item_lots SET quantity = quantity - $3
+	WHERE id = $1 AND item_id = $2 AND quantity >= $3`
+
+	qDecreaseItemAvailable = `
+	UPDATE items SET quantity = quantity - $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND quantity - COALESCE((
+		SELECT SUM(r.quantity)
+		FROM reservations r
+		WHERE r.item_id = $1 AND r.reservation_status = 'active'
+	), 0) >= $2`
+
+	qListExpiringLots = `
+	SELECT l.id, l.item_id, l.lot_number, l.manufactured_at, l.expires_at, l.quantity, l.created_at,
+		i.item_name, l.expires_at - CURRENT_DATE
+	FROM item_lots l
+	JOIN items i ON i.id = l.item_id
+	WHERE l.quantity > 0 AND l.expires_at <= $1
+	ORDER BY l.expires_at, l.id`
+)
+
+var _ infra.LotRepo = (*lotRepo)(nil)
+
+type lotRepo struct {
+	db *dbpg.DB
+}
+
+type lotPickDetails struct {
+	LotID     int    `json:"lot_id"`
+	LotNumber string `json:"lot_number"`
+	Quantity  int    `json:"quantity"`
+}
+
+// CreateLot - метод для приемки новой партии item.
+// Создает партию и увеличивает остаток item на ее количество.
+func (r *lotRepo) CreateLot(ctx context.Context, userID int, lot *models.Lot) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", lot.ItemID).
+			Msg("CreateLot: не удалось начать транзакцию")
+
+		return 0, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("CreateLot: не удалось установить userID")
+
+		return 0, fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	details := fmt.Sprintf("приемка партии %s", lot.LotNumber)
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("CreateLot: не удалось установить детали операции")
+
+		return 0, fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, qIncreaseItemQuantity, lot.ItemID, lot.Quantity)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", lot.ItemID).
+			Msg("CreateLot: не удалось увеличить остаток item")
+
+		return 0, fmt.Errorf("не удалось увеличить остаток item: %w", err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("не удалось получить количество строк, обновленных запросом CreateLot: %w", err)
+	} else if rowsAffected == 0 {
+		return 0, fmt.Errorf("item с id %d не найден", lot.ItemID)
+	}
+
+	var id int
+	if err := tx.QueryRowContext(
+		ctx,
+		qCreateLot,
+		lot.ItemID,
+		lot.LotNumber,
+		lot.ManufacturedAt,
+		lot.ExpiresAt,
+		lot.Quantity,
+	).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("партия %s для item с id %d уже существует", lot.LotNumber, lot.ItemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", lot.ItemID).
+			Str("lot_number", lot.LotNumber).
+			Msg("CreateLot: не удалось создать партию")
+
+		return 0, fmt.Errorf("не удалось создать партию: %w", err)
+	}
+
+	unitCost, err := baseUnitCost(ctx, tx, lot.UnitCost, lot.CostCurrency)
+	if err != nil {
+		return 0, err
+	}
+	if err := receiveCost(ctx, tx, lot.ItemID, lot.Quantity, unitCost, details); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", lot.ItemID).
+			Msg("CreateLot: не удалось учесть себестоимость партии")
+
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", lot.ItemID).
+			Msg("CreateLot: не удалось завершить транзакцию")
+
+		return 0, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetLotsByItemID - метод для получения партий item в порядке FEFO.
+func (r *lotRepo) GetLotsByItemID(ctx context.Context, itemID int) ([]models.Lot, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListLotsByItemID,
+		itemID,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetLotsByItemID: не удалось выполнить запрос GetLotsByItemID")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetLotsByItemID: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []models.Lot
+	for rows.Next() {
+		var lot models.Lot
+		if err := rows.Scan(
+			&lot.ID,
+			&lot.ItemID,
+			&lot.LotNumber,
+			&lot.ManufacturedAt,
+			&lot.ExpiresAt,
+			&lot.Quantity,
+			&lot.CreatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", itemID).
+				Msg("GetLotsByItemID: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		lots = append(lots, lot)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetLotsByItemID: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return lots, nil
+}
+
+// IssueLots - метод для списания item из выбранных партий.
+// Уменьшает остатки партий и item в одной транзакции, выбранные партии записываются в историю.
+// Возвращает себестоимость списания по методу оценки item.
+func (r *lotRepo) IssueLots(ctx context.Context, userID, itemID int, picks []models.LotPick) (models.Money, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("IssueLots: не удалось начать транзакцию")
+
+		return 0, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("IssueLots: не удалось установить userID")
+
+		return 0, fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	total := 0
+	details := make([]lotPickDetails, 0, len(picks))
+	for _, pick := range picks {
+		result, err := tx.ExecContext(ctx, qConsumeLot, pick.LotID, itemID, pick.Quantity)
+		if err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", itemID).
+				Int("lot_id", pick.LotID).
+				Msg("IssueLots: не удалось списать партию")
+
+			return 0, fmt.Errorf("не удалось списать партию: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("не удалось получить количество строк, обновленных запросом IssueLots: %w", err)
+		}
+		if rowsAffected == 0 {
+			return 0, fmt.Errorf("недостаточно остатка в партии %s", pick.LotNumber)
+		}
+
+		total += pick.Quantity
+		details = append(details, lotPickDetails(pick))
+	}
+
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, string(detailsJSON)); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("IssueLots: не удалось установить детали операции")
+
+		return 0, fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, qDecreaseItemAvailable, itemID, total)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Int("quantity", total).
+			Msg("IssueLots: не удалось уменьшить остаток item")
+
+		return 0, fmt.Errorf("не удалось уменьшить остаток item: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("не удалось получить количество строк, обновленных запросом IssueLots: %w", err)
+	}
+	if rowsAffected == 0 {
+		return 0, fmt.Errorf("недостаточно доступного остатка item с id %d", itemID)
+	}
+
+	cost, err := issueCost(ctx, tx, itemID, total, "списание из партий")
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("IssueLots: не удалось рассчитать себестоимость списания")
+
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("IssueLots: не удалось завершить транзакцию")
+
+		return 0, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return cost, nil
+}
+
+// GetExpiringLots - метод для получения непустых партий со сроком годности до даты before включительно.
+func (r *lotRepo) GetExpiringLots(ctx context.Context, before time.Time) ([]models.ExpiringLot, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListExpiringLots,
+		before,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetExpiringLots: не удалось выполнить запрос GetExpiringLots")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetExpiringLots: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []models.ExpiringLot
+	for rows.Next() {
+		var lot models.ExpiringLot
+		if err := rows.Scan(
+			&lot.ID,
+			&lot.ItemID,
+			&lot.LotNumber,
+			&lot.ManufacturedAt,
+			&lot.ExpiresAt,
+			&lot.Quantity,
+			&lot.CreatedAt,
+			&lot.ItemName,
+			&lot.DaysLeft,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("GetExpiringLots: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		lots = append(lots, lot)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetExpiringLots: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return lots, nil
+}
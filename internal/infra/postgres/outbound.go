@@ -0,0 +1,907 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qCreateOutboundOrder = `
+	INSERT INTO outbound_orders (customer_ref, ship_to, created_by)
+	VALUES ($1, $2, $3)
+	RETURNING id, order_number`
+
+	qCreateOutboundOrderLine = `
+	INSERT INTO outbound_order_lines (order_id, item_id, ordered_qty)
+	VALUES ($1, $2, $3)`
+
+	qOutboundOrderColumns = `
+	SELECT id, order_number, customer_ref, ship_to, order_status, COALESCE(created_by, 0), created_at, updated_at
+	FROM outbound_orders`
+
+	qListOutboundOrders = qOutboundOrderColumns + `
+	WHERE ($1::TEXT = '' OR order_status = $1)
+	ORDER BY id DESC`
+
+	qGetOutboundOrderByID = qOutboundOrderColumns + `
+	WHERE id = $1`
+
+	qLockOutboundOrder = qOutboundOrderColumns + `
+	WHERE id = $1
+	FOR UPDATE`
+
+	qListOutboundOrderLines = `
+	SELECT l.id, l.order_id, l.item_id, i.item_name, l.ordered_qty, l.allocated_qty, l.packed_qty, l.shipped_qty
+	FROM outbound_order_lines l
+	JOIN items i ON i.id = l.item_id
+	WHERE l.order_id = ANY($1)
+	ORDER BY l.id`
+
+	qListShipments = `
+	SELECT s.id, s.order_id, s.tracking_ref, s.shipped_by, s.shipped_at, sl.line_id, l.item_id, sl.quantity
+	FROM shipments s
+	JOIN shipment_lines sl ON sl.shipment_id = s.id
+	JOIN outbound_order_lines l ON l.id = sl.line_id
+	WHERE s.order_id = $1
+	ORDER BY s.id, sl.line_id`
+
+	qListOutboundOrderEvents = `
+	SELECT id, order_id, from_status, to_status, user_id, note, created_at
+	FROM outbound_order_events
+	WHERE order_id = $1
+	ORDER BY id`
+
+	qCreateOutboundOrderEvent = `
+	INSERT INTO outbound_order_events (order_id, from_status, to_status, user_id, note)
+	VALUES ($1, $2, $3, $4, $5)`
+
+	qSetOutboundOrderStatus = `
+	UPDATE outbound_orders SET order_status = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qSetOutboundLineQuantities = `
+	UPDATE outbound_order_lines SET allocated_qty = $2, packed_qty = $3, shipped_qty = $4
+	WHERE id = $1`
+
+	qIncreaseOutboundReservation = `
+	UPDATE reservations SET quantity = quantity + $2, updated_at = CURRENT_TIMESTAMP
+	WHERE outbound_line_id = $1 AND reservation_status = 'active'`
+
+	qCreateOutboundReservation = `
+	INSERT INTO reservations (item_id, user_id, quantity, order_ref, expires_at, outbound_line_id)
+	VALUES ($1, $2, $3, $4, NULL, $5)`
+
+	// Частичная отгрузка уменьшает резерв строки, полная - подтверждает его.
+	qDecreaseOutboundReservation = `
+	UPDATE reservations SET quantity = quantity - $2, updated_at = CURRENT_TIMESTAMP
+	WHERE outbound_line_id = $1 AND reservation_status = 'active' AND quantity > $2`
+
+	qConfirmOutboundReservation = `
+	UPDATE reservations SET reservation_status = 'confirmed', updated_at = CURRENT_TIMESTAMP
+	WHERE outbound_line_id = $1 AND reservation_status = 'active'`
+
+	qReleaseOutboundReservations = `
+	UPDATE reservations SET reservation_status = 'released', updated_at = CURRENT_TIMESTAMP
+	WHERE reservation_status = 'active' AND outbound_line_id IN (
+		SELECT id FROM outbound_order_lines WHERE order_id = $1
+	)`
+
+	qCreateShipment = `
+	INSERT INTO shipments (order_id, tracking_ref, shipped_by)
+	VALUES ($1, $2, $3)
+	RETURNING id`
+
+	qCreateShipmentLine = `
+	INSERT INTO shipment_lines (shipment_id, line_id, quantity)
+	VALUES ($1, $2, $3)`
+
+	qShipSerial = `
+	UPDATE item_serials SET serial_status = 'shipped', updated_at = CURRENT_TIMESTAMP
+	WHERE item_id = $1 AND serial_number = $2 AND serial_status IN ('in_stock', 'reserved')`
+
+	qListPickList = `
+	SELECT i.pick_location_id, COALESCE(loc.code, ''), l.item_id, i.item_name, COALESCE(i.sku, ''),
+		l.allocated_qty - l.packed_qty
+	FROM outbound_order_lines l
+	JOIN items i ON i.id = l.item_id
+	LEFT JOIN locations loc ON loc.id = i.pick_location_id
+	WHERE l.order_id = $1 AND l.allocated_qty > l.packed_qty
+	ORDER BY loc.code NULLS LAST, i.item_name`
+
+	outboundLineItemConstraint = "outbound_order_lines_item_id_fkey"
+)
+
+var _ infra.OutboundRepo = (*outboundRepo)(nil)
+
+type outboundRepo struct {
+	db *dbpg.DB
+}
+
+// CreateOutboundOrder - метод для создания заказа на отгрузку вместе со строками.
+// Возвращает id и номер заказа.
+func (r *outboundRepo) CreateOutboundOrder(ctx context.Context, order *models.OutboundOrder) (int, string, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("CreateOutboundOrder: не удалось начать транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+	var number string
+	if err := tx.QueryRowContext(
+		ctx,
+		qCreateOutboundOrder,
+		order.CustomerRef,
+		order.ShipTo,
+		order.CreatedBy,
+	).Scan(&id, &number); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("CreateOutboundOrder: не удалось создать заказ")
+
+		return 0, "", fmt.Errorf("не удалось создать заказ: %w", err)
+	}
+
+	for _, line := range order.Lines {
+		if _, err := tx.ExecContext(ctx, qCreateOutboundOrderLine, id, line.ItemID, line.OrderedQty); err != nil {
+			if isForeignKeyViolationOn(err, outboundLineItemConstraint) {
+				return 0, "", fmt.Errorf("item с id %d не найден", line.ItemID)
+			}
+			zlog.Logger.Error().
+				Err(err).
+				Int("order_id", id).
+				Int("item_id", line.ItemID).
+				Msg("CreateOutboundOrder: не удалось добавить строку заказа")
+
+			return 0, "", fmt.Errorf("не удалось добавить строку заказа: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, qCreateOutboundOrderEvent, id, "", models.OutboundNew, order.CreatedBy, ""); err != nil {
+		return 0, "", fmt.Errorf("не удалось записать историю заказа: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("CreateOutboundOrder: не удалось завершить транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return id, number, nil
+}
+
+// ListOutboundOrders - метод для получения заказов на отгрузку со строками по статусу, при пустом статусе - всех.
+func (r *outboundRepo) ListOutboundOrders(ctx context.Context, status string) ([]models.OutboundOrder, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListOutboundOrders,
+		status,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("status", status).
+			Msg("ListOutboundOrders: не удалось выполнить запрос ListOutboundOrders")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListOutboundOrders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []models.OutboundOrder
+	for rows.Next() {
+		var order models.OutboundOrder
+		if err := scanOutboundOrder(rows, &order); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListOutboundOrders: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListOutboundOrders: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	ids := make([]int, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+
+	lines, err := listOutboundOrderLines(ctx, r.db.Master, ids)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListOutboundOrders: не удалось получить строки заказов")
+
+		return nil, err
+	}
+	for i := range orders {
+		orders[i].Lines = lines[orders[i].ID]
+	}
+
+	return orders, nil
+}
+
+// GetOutboundOrder - метод для получения заказа на отгрузку со строками, отгрузками и историей статусов.
+func (r *outboundRepo) GetOutboundOrder(ctx context.Context, id int) (*models.OutboundOrder, error) {
+	var order models.OutboundOrder
+	if err := scanOutboundOrder(r.db.QueryRowContext(ctx, qGetOutboundOrderByID, id), &order); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("заказ на отгрузку с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("GetOutboundOrder: не удалось выполнить запрос GetOutboundOrder")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetOutboundOrder: %w", err)
+	}
+
+	lines, err := listOutboundOrderLines(ctx, r.db.Master, []int{id})
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("GetOutboundOrder: не удалось получить строки заказа")
+
+		return nil, err
+	}
+	order.Lines = lines[id]
+
+	if order.Shipments, err = r.listShipments(ctx, id); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("GetOutboundOrder: не удалось получить отгрузки заказа")
+
+		return nil, err
+	}
+
+	if order.History, err = r.listOutboundOrderEvents(ctx, id); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("GetOutboundOrder: не удалось получить историю заказа")
+
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// AllocateOutboundOrder - метод для резервирования остатка под неразмещенные строки заказа.
+// Размещается столько, сколько доступно, остальное остается к размещению.
+// Возвращает новый статус заказа и количество зарезервированных единиц.
+func (r *outboundRepo) AllocateOutboundOrder(ctx context.Context, userID, id int) (string, int, error) {
+	tx, order, err := r.beginOutboundChange(ctx, "AllocateOutboundOrder", userID, id)
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+
+	if order.Status == models.OutboundShipped || order.Status == models.OutboundCancelled {
+		return "", 0, fmt.Errorf("нельзя разместить заказ %s в статусе %s", order.Number, order.Status)
+	}
+
+	allocated := 0
+	for i, line := range order.Lines {
+		if line.Unallocated() == 0 {
+			continue
+		}
+
+		var onHand, reserved int
+		if err := tx.QueryRowContext(ctx, qLockItemStock, line.ItemID).Scan(&onHand, &reserved); err != nil {
+			return "", 0, fmt.Errorf("не удалось получить остаток item: %w", err)
+		}
+		quantity := min(line.Unallocated(), max(onHand-reserved, 0))
+		if quantity == 0 {
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx, qIncreaseOutboundReservation, line.ID, quantity)
+		if err != nil {
+			return "", 0, fmt.Errorf("не удалось увеличить резерв строки заказа: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return "", 0, fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			if _, err := tx.ExecContext(ctx, qCreateOutboundReservation, line.ItemID, userID, quantity, order.Number, line.ID); err != nil {
+				zlog.Logger.Error().
+					Err(err).
+					Int("order_id", id).
+					Int("item_id", line.ItemID).
+					Msg("AllocateOutboundOrder: не удалось создать резерв")
+
+				return "", 0, fmt.Errorf("не удалось создать резерв: %w", err)
+			}
+		}
+
+		order.Lines[i].AllocatedQty += quantity
+		allocated += quantity
+	}
+	if allocated == 0 {
+		return "", 0, fmt.Errorf("недостаточно доступного остатка для размещения заказа %s", order.Number)
+	}
+
+	status, err := r.commitOutboundChange(ctx, tx, order, userID, "")
+	if err != nil {
+		return "", 0, err
+	}
+
+	return status, allocated, nil
+}
+
+// StartPicking - метод для формирования листа подбора по зарезервированным, но не упакованным строкам.
+// Переводит заказ в статус picking.
+func (r *outboundRepo) StartPicking(ctx context.Context, userID, id int) ([]models.PickListEntry, error) {
+	tx, order, err := r.beginOutboundChange(ctx, "StartPicking", userID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if order.Status == models.OutboundShipped || order.Status == models.OutboundCancelled {
+		return nil, fmt.Errorf("нельзя собирать заказ %s в статусе %s", order.Number, order.Status)
+	}
+
+	rows, err := tx.QueryContext(ctx, qListPickList, id)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("StartPicking: не удалось сформировать лист подбора")
+
+		return nil, fmt.Errorf("не удалось сформировать лист подбора: %w", err)
+	}
+
+	var entries []models.PickListEntry
+	for rows.Next() {
+		var entry models.PickListEntry
+		var locationID sql.NullInt64
+		if err := rows.Scan(
+			&locationID,
+			&entry.LocationCode,
+			&entry.ItemID,
+			&entry.ItemName,
+			&entry.SKU,
+			&entry.Quantity,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		if locationID.Valid {
+			locID := int(locationID.Int64)
+			entry.LocationID = &locID
+		}
+
+		entries = append(entries, entry)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("нельзя сформировать лист подбора заказа %s: нет размещенных неупакованных строк", order.Number)
+	}
+
+	if order.Status != models.OutboundPicking {
+		if err := r.writeOutboundStatus(ctx, tx, id, order.Status, models.OutboundPicking, userID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("StartPicking: не удалось завершить транзакцию")
+
+		return nil, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return entries, nil
+}
+
+// PackOutboundOrder - метод для подтверждения упаковки подобранных items.
+// Возвращает новый статус заказа.
+func (r *outboundRepo) PackOutboundOrder(ctx context.Context, userID, id int, lines []models.OutboundQuantity) (string, error) {
+	tx, order, err := r.beginOutboundChange(ctx, "PackOutboundOrder", userID, id)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if order.Status == models.OutboundShipped || order.Status == models.OutboundCancelled {
+		return "", fmt.Errorf("нельзя упаковать заказ %s в статусе %s", order.Number, order.Status)
+	}
+
+	for _, pack := range lines {
+		i, err := outboundLineIndex(order, pack.ItemID)
+		if err != nil {
+			return "", err
+		}
+		if pack.Quantity > order.Lines[i].ToPick() {
+			return "", fmt.Errorf("нельзя упаковать %d по item %d заказа %s: к упаковке %d",
+				pack.Quantity, pack.ItemID, order.Number, order.Lines[i].ToPick())
+		}
+		order.Lines[i].PackedQty += pack.Quantity
+	}
+
+	return r.commitOutboundChange(ctx, tx, order, userID, "")
+}
+
+// ShipOutboundOrder - метод для отгрузки упакованных items.
+// При пустом lines отгружается все упакованное. Списывает остатки items и резервы строк.
+// Возвращает id отгрузки и новый статус заказа.
+func (r *outboundRepo) ShipOutboundOrder(
+	ctx context.Context,
+	userID, id int,
+	trackingRef string,
+	lines []models.OutboundQuantity,
+) (int, string, error) {
+	tx, order, err := r.beginOutboundChange(ctx, "ShipOutboundOrder", userID, id)
+	if err != nil {
+		return 0, "", err
+	}
+	defer tx.Rollback()
+
+	if order.Status == models.OutboundShipped || order.Status == models.OutboundCancelled {
+		return 0, "", fmt.Errorf("нельзя отгрузить заказ %s в статусе %s", order.Number, order.Status)
+	}
+
+	if len(lines) == 0 {
+		for _, line := range order.Lines {
+			if line.ToShip() > 0 {
+				lines = append(lines, models.OutboundQuantity{ItemID: line.ItemID, Quantity: line.ToShip()})
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return 0, "", fmt.Errorf("нельзя отгрузить заказ %s: нет упакованных строк", order.Number)
+	}
+
+	details := fmt.Sprintf("отгрузка по заказу %s", order.Number)
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		return 0, "", fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	var shipmentID int
+	if err := tx.QueryRowContext(ctx, qCreateShipment, id, trackingRef, userID).Scan(&shipmentID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("ShipOutboundOrder: не удалось создать отгрузку")
+
+		return 0, "", fmt.Errorf("не удалось создать отгрузку: %w", err)
+	}
+
+	for _, ship := range lines {
+		i, err := outboundLineIndex(order, ship.ItemID)
+		if err != nil {
+			return 0, "", err
+		}
+		line := &order.Lines[i]
+		if ship.Quantity > line.ToShip() {
+			return 0, "", fmt.Errorf("нельзя отгрузить %d по item %d заказа %s: упаковано к отгрузке %d",
+				ship.Quantity, ship.ItemID, order.Number, line.ToShip())
+		}
+
+		result, err := tx.ExecContext(ctx, qDecreaseOutboundReservation, line.ID, ship.Quantity)
+		if err != nil {
+			return 0, "", fmt.Errorf("не удалось уменьшить резерв строки заказа: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return 0, "", fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			if _, err := tx.ExecContext(ctx, qConfirmOutboundReservation, line.ID); err != nil {
+				return 0, "", fmt.Errorf("не удалось подтвердить резерв строки заказа: %w", err)
+			}
+		}
+
+		if err := shipItemStock(ctx, tx, ship, details); err != nil {
+			return 0, "", err
+		}
+
+		if _, err := tx.ExecContext(ctx, qCreateShipmentLine, shipmentID, line.ID, ship.Quantity); err != nil {
+			if isUniqueViolation(err) {
+				return 0, "", fmt.Errorf("некорректная отгрузка: item %d указан дважды", ship.ItemID)
+			}
+
+			return 0, "", fmt.Errorf("не удалось сохранить строку отгрузки: %w", err)
+		}
+		line.ShippedQty += ship.Quantity
+	}
+
+	status, err := r.commitOutboundChange(ctx, tx, order, userID, trackingRef)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return shipmentID, status, nil
+}
+
+// CancelOutboundOrder - метод для отмены заказа на отгрузку со снятием резервов неотгруженных строк.
+func (r *outboundRepo) CancelOutboundOrder(ctx context.Context, userID, id int, note string) error {
+	tx, order, err := r.beginOutboundChange(ctx, "CancelOutboundOrder", userID, id)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if order.Status == models.OutboundShipped || order.Status == models.OutboundCancelled {
+		return fmt.Errorf("нельзя отменить заказ %s в статусе %s", order.Number, order.Status)
+	}
+
+	if _, err := tx.ExecContext(ctx, qReleaseOutboundReservations, id); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("CancelOutboundOrder: не удалось снять резервы заказа")
+
+		return fmt.Errorf("не удалось снять резервы заказа: %w", err)
+	}
+
+	if err := r.writeOutboundStatus(ctx, tx, id, order.Status, models.OutboundCancelled, userID, note); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("CancelOutboundOrder: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// beginOutboundChange - начало транзакции изменения заказа: userID для триггеров истории и блокировка заказа со строками.
+func (r *outboundRepo) beginOutboundChange(ctx context.Context, op string, userID, id int) (*sql.Tx, *models.OutboundOrder, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("order_id", id).
+			Msg(op + ": не удалось начать транзакцию")
+
+		return nil, nil, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(qSetUserID, userID)); err != nil {
+		tx.Rollback()
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg(op + ": не удалось установить userID")
+
+		return nil, nil, fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var order models.OutboundOrder
+	if err := scanOutboundOrder(tx.QueryRowContext(ctx, qLockOutboundOrder, id), &order); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("заказ на отгрузку с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg(op + ": не удалось заблокировать заказ")
+
+		return nil, nil, fmt.Errorf("не удалось заблокировать заказ: %w", err)
+	}
+
+	lines, err := listOutboundOrderLines(ctx, tx, []int{id})
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	order.Lines = lines[id]
+
+	return tx, &order, nil
+}
+
+// commitOutboundChange - сохранение количеств по строкам, пересчет статуса заказа и завершение транзакции.
+func (r *outboundRepo) commitOutboundChange(ctx context.Context, tx *sql.Tx, order *models.OutboundOrder, userID int, note string) (string, error) {
+	for _, line := range order.Lines {
+		if _, err := tx.ExecContext(
+			ctx,
+			qSetOutboundLineQuantities,
+			line.ID,
+			line.AllocatedQty,
+			line.PackedQty,
+			line.ShippedQty,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("order_id", order.ID).
+				Int("line_id", line.ID).
+				Msg("commitOutboundChange: не удалось обновить строку заказа")
+
+			return "", fmt.Errorf("не удалось обновить строку заказа: %w", err)
+		}
+	}
+
+	status := order.NextStatus()
+	if status != order.Status {
+		if err := r.writeOutboundStatus(ctx, tx, order.ID, order.Status, status, userID, note); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", order.ID).
+			Msg("commitOutboundChange: не удалось завершить транзакцию")
+
+		return "", fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return status, nil
+}
+
+// writeOutboundStatus - смена статуса заказа с записью в историю статусов.
+func (r *outboundRepo) writeOutboundStatus(ctx context.Context, tx *sql.Tx, id int, from, to string, userID int, note string) error {
+	if _, err := tx.ExecContext(ctx, qSetOutboundOrderStatus, id, to); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Str("status", to).
+			Msg("writeOutboundStatus: не удалось обновить статус заказа")
+
+		return fmt.Errorf("не удалось обновить статус заказа: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qCreateOutboundOrderEvent, id, from, to, userID, note); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", id).
+			Msg("writeOutboundStatus: не удалось записать историю заказа")
+
+		return fmt.Errorf("не удалось записать историю заказа: %w", err)
+	}
+
+	return nil
+}
+
+// shipItemStock - списание отгруженного количества с остатка item.
+// Серийные номера переводятся в статус shipped, остаток серийного item пересчитывается по ним.
+func shipItemStock(ctx context.Context, tx *sql.Tx, ship models.OutboundQuantity, details string) error {
+	var serialized bool
+	if err := tx.QueryRowContext(ctx, qLockSerializedItem, ship.ItemID).Scan(&serialized); err != nil {
+		return fmt.Errorf("не удалось получить item: %w", err)
+	}
+
+	if !serialized {
+		if len(ship.Serials) > 0 {
+			return fmt.Errorf("некорректная отгрузка: item с id %d не является серийным", ship.ItemID)
+		}
+		if _, err := tx.ExecContext(ctx, qDecreaseItemQuantity, ship.ItemID, ship.Quantity); err != nil {
+			return fmt.Errorf("не удалось списать остаток item: %w", err)
+		}
+		_, err := issueCost(ctx, tx, ship.ItemID, ship.Quantity, details)
+
+		return err
+	}
+
+	if len(ship.Serials) != ship.Quantity {
+		return fmt.Errorf("некорректная отгрузка: для серийного item с id %d нужно %d серийных номеров, передано %d",
+			ship.ItemID, ship.Quantity, len(ship.Serials))
+	}
+	for _, serialNumber := range ship.Serials {
+		result, err := tx.ExecContext(ctx, qShipSerial, ship.ItemID, serialNumber)
+		if err != nil {
+			return fmt.Errorf("не удалось отгрузить серийный номер: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			return fmt.Errorf("серийный номер %s item %d на складе не найден", serialNumber, ship.ItemID)
+		}
+	}
+	if _, err := issueCost(ctx, tx, ship.ItemID, ship.Quantity, details); err != nil {
+		return err
+	}
+
+	return syncSerializedQuantity(ctx, tx, ship.ItemID, details)
+}
+
+// outboundLineIndex - индекс строки заказа с item.
+func outboundLineIndex(order *models.OutboundOrder, itemID int) (int, error) {
+	for i, line := range order.Lines {
+		if line.ItemID == itemID {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("item с id %d не найден в заказе %s", itemID, order.Number)
+}
+
+// listShipments - получение отгрузок заказа со строками.
+func (r *outboundRepo) listShipments(ctx context.Context, id int) ([]models.Shipment, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListShipments,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listShipments: %w", err)
+	}
+	defer rows.Close()
+
+	var shipments []models.Shipment
+	for rows.Next() {
+		var shipment models.Shipment
+		var shippedBy sql.NullInt64
+		var line models.ShipmentLine
+		if err := rows.Scan(
+			&shipment.ID,
+			&shipment.OrderID,
+			&shipment.TrackingRef,
+			&shippedBy,
+			&shipment.ShippedAt,
+			&line.LineID,
+			&line.ItemID,
+			&line.Quantity,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		if n := len(shipments); n > 0 && shipments[n-1].ID == shipment.ID {
+			shipments[n-1].Lines = append(shipments[n-1].Lines, line)
+			continue
+		}
+		if shippedBy.Valid {
+			userID := int(shippedBy.Int64)
+			shipment.ShippedBy = &userID
+		}
+		shipment.Lines = []models.ShipmentLine{line}
+
+		shipments = append(shipments, shipment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return shipments, nil
+}
+
+// listOutboundOrderEvents - получение истории статусов заказа.
+func (r *outboundRepo) listOutboundOrderEvents(ctx context.Context, id int) ([]models.OutboundOrderEvent, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListOutboundOrderEvents,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listOutboundOrderEvents: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.OutboundOrderEvent
+	for rows.Next() {
+		var event models.OutboundOrderEvent
+		var userID sql.NullInt64
+		if err := rows.Scan(
+			&event.ID,
+			&event.OrderID,
+			&event.FromStatus,
+			&event.ToStatus,
+			&userID,
+			&event.Note,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		if userID.Valid {
+			id := int(userID.Int64)
+			event.UserID = &id
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return events, nil
+}
+
+// listOutboundOrderLines - получение строк заказов, сгруппированных по id заказа.
+func listOutboundOrderLines(ctx context.Context, q interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}, ids []int) (map[int][]models.OutboundOrderLine, error) {
+	rows, err := q.QueryContext(ctx, qListOutboundOrderLines, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listOutboundOrderLines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[int][]models.OutboundOrderLine, len(ids))
+	for rows.Next() {
+		var line models.OutboundOrderLine
+		if err := rows.Scan(
+			&line.ID,
+			&line.OrderID,
+			&line.ItemID,
+			&line.ItemName,
+			&line.OrderedQty,
+			&line.AllocatedQty,
+			&line.PackedQty,
+			&line.ShippedQty,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		lines[line.OrderID] = append(lines[line.OrderID], line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanOutboundOrder - перевод строки outbound_orders в структуру.
+func scanOutboundOrder(row interface{ Scan(dest ...any) error }, order *models.OutboundOrder) error {
+	return row.Scan(
+		&order.ID,
+		&order.Number,
+		&order.CustomerRef,
+		&order.ShipTo,
+		&order.Status,
+		&order.CreatedBy,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+}
@@ -0,0 +1,728 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	// Пустая валюта - валюта поставщика.
+	qCreatePurchaseOrder = `
+	INSERT INTO purchase_orders (supplier_id, expected_at, notes, created_by, currency)
+	VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''),
+		(SELECT currency FROM suppliers WHERE id = $1),
+		(SELECT code FROM currencies WHERE is_base)))
+	RETURNING id, po_number`
+
+	qCreatePurchaseOrderLine = `
+	INSERT INTO purchase_order_lines (po_id, item_id, ordered_qty, unit_cost)
+	VALUES ($1, $2, $3, $4)`
+
+	qPurchaseOrderColumns = `
+	SELECT o.id, o.po_number, o.supplier_id, s.supplier_name, o.po_status, o.currency, o.expected_at, o.notes,
+		COALESCE(o.created_by, 0), o.sent_at, o.closed_at, o.created_at, o.updated_at
+	FROM purchase_orders o
+	JOIN suppliers s ON s.id = o.supplier_id`
+
+	qListPurchaseOrders = qPurchaseOrderColumns + `
+	WHERE ($1::TEXT = '' OR o.po_status = $1)
+		AND ($2::INT IS NULL OR o.supplier_id = $2)
+	ORDER BY o.id DESC`
+
+	qGetPurchaseOrderByID = qPurchaseOrderColumns + `
+	WHERE o.id = $1`
+
+	qListPurchaseOrderLines = `
+	SELECT l.id, l.po_id, l.item_id, i.item_name, l.ordered_qty, l.received_qty, l.unit_cost
+	FROM purchase_order_lines l
+	JOIN items i ON i.id = l.item_id
+	WHERE l.po_id = ANY($1)
+	ORDER BY l.id`
+
+	qListPurchaseReceipts = `
+	SELECT r.id, r.po_id, r.line_id, l.item_id, r.quantity, r.exchange_rate, r.received_by, r.note, r.received_at
+	FROM purchase_receipts r
+	JOIN purchase_order_lines l ON l.id = r.line_id
+	WHERE r.po_id = $1
+	ORDER BY r.id`
+
+	qUpdatePurchaseOrderStatus = `
+	UPDATE purchase_orders SET po_status = $2::TEXT,
+		sent_at = CASE WHEN $2::TEXT = 'sent' THEN CURRENT_TIMESTAMP ELSE sent_at END,
+		closed_at = CASE WHEN $2::TEXT = 'closed' THEN CURRENT_TIMESTAMP ELSE closed_at END,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND po_status = ANY($3)`
+
+	qGetPurchaseOrderStatus = `
+	SELECT po_status
+	FROM purchase_orders
+	WHERE id = $1`
+
+	qDeleteDraftPurchaseOrder = `
+	DELETE FROM purchase_orders
+	WHERE id = $1 AND po_status = 'draft'`
+
+	qLockPurchaseOrder = `
+	SELECT po_number, po_status, currency
+	FROM purchase_orders
+	WHERE id = $1
+	FOR UPDATE`
+
+	// Сверх заказанного принимается не более tolerance процентов от количества строки.
+	qReceivePurchaseOrderLine = `
+	UPDATE purchase_order_lines SET received_qty = received_qty + $3
+	WHERE po_id = $1 AND item_id = $2
+		AND received_qty + $3 <= ordered_qty + FLOOR(ordered_qty * $4::NUMERIC / 100)
+	RETURNING id, unit_cost`
+
+	qGetPurchaseOrderLine = `
+	SELECT ordered_qty, received_qty
+	FROM purchase_order_lines
+	WHERE po_id = $1 AND item_id = $2`
+
+	qCreatePurchaseReceipt = `
+	INSERT INTO purchase_receipts (po_id, line_id, quantity, received_by, note, exchange_rate)
+	VALUES ($1, $2, $3, $4, $5, $6)`
+
+	qCompletePurchaseReceipt = `
+	UPDATE purchase_orders SET po_status = CASE
+			WHEN (SELECT BOOL_AND(received_qty >= ordered_qty) FROM purchase_order_lines WHERE po_id = $1)
+			THEN 'received' ELSE 'partially_received' END,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1
+	RETURNING po_status`
+
+	purchaseOrderSupplierConstraint = "purchase_orders_supplier_id_fkey"
+	purchaseOrderCurrencyConstraint = "purchase_orders_currency_fkey"
+	purchaseOrderLineItemConstraint = "purchase_order_lines_item_id_fkey"
+)
+
+var _ infra.PurchaseRepo = (*purchaseRepo)(nil)
+
+type purchaseRepo struct {
+	db *dbpg.DB
+}
+
+// CreatePurchaseOrder - метод для создания черновика заказа на закупку вместе со строками.
+// Возвращает id и номер заказа.
+func (r *purchaseRepo) CreatePurchaseOrder(ctx context.Context, po *models.PurchaseOrder) (int, string, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("supplier_id", po.SupplierID).
+			Msg("CreatePurchaseOrder: не удалось начать транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, number, err := insertPurchaseOrder(ctx, tx, po)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("po_id", id).
+			Msg("CreatePurchaseOrder: не удалось завершить транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return id, number, nil
+}
+
+// CreatePurchaseOrders - метод для создания нескольких черновиков заказов в одной транзакции.
+// Заполняет ID и Number у каждого заказа, при ошибке не создается ни один.
+func (r *purchaseRepo) CreatePurchaseOrders(ctx context.Context, pos []*models.PurchaseOrder) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("CreatePurchaseOrders: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, po := range pos {
+		if po.ID, po.Number, err = insertPurchaseOrder(ctx, tx, po); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("orders", len(pos)).
+			Msg("CreatePurchaseOrders: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// insertPurchaseOrder - вставка заказа со строками в рамках транзакции.
+func insertPurchaseOrder(ctx context.Context, tx *sql.Tx, po *models.PurchaseOrder) (int, string, error) {
+	var id int
+	var number string
+	if err := tx.QueryRowContext(
+		ctx,
+		qCreatePurchaseOrder,
+		po.SupplierID,
+		po.ExpectedAt,
+		po.Notes,
+		po.CreatedBy,
+		po.Currency,
+	).Scan(&id, &number); err != nil {
+		switch {
+		case isForeignKeyViolationOn(err, purchaseOrderSupplierConstraint):
+			return 0, "", fmt.Errorf("поставщик с id %d не найден", po.SupplierID)
+		case isForeignKeyViolationOn(err, purchaseOrderCurrencyConstraint):
+			return 0, "", fmt.Errorf("валюта %s не найдена", po.Currency)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("supplier_id", po.SupplierID).
+			Msg("insertPurchaseOrder: не удалось создать заказ")
+
+		return 0, "", fmt.Errorf("не удалось создать заказ: %w", err)
+	}
+
+	for _, line := range po.Lines {
+		if _, err := tx.ExecContext(
+			ctx,
+			qCreatePurchaseOrderLine,
+			id,
+			line.ItemID,
+			line.OrderedQty,
+			line.UnitCost,
+		); err != nil {
+			if isForeignKeyViolationOn(err, purchaseOrderLineItemConstraint) {
+				return 0, "", fmt.Errorf("item с id %d не найден", line.ItemID)
+			}
+			zlog.Logger.Error().
+				Err(err).
+				Int("po_id", id).
+				Int("item_id", line.ItemID).
+				Msg("insertPurchaseOrder: не удалось добавить строку заказа")
+
+			return 0, "", fmt.Errorf("не удалось добавить строку заказа: %w", err)
+		}
+	}
+
+	return id, number, nil
+}
+
+// ListPurchaseOrders - метод для получения заказов на закупку со строками по фильтру.
+func (r *purchaseRepo) ListPurchaseOrders(ctx context.Context, filter models.PurchaseOrderFilter) ([]models.PurchaseOrder, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListPurchaseOrders,
+		filter.Status,
+		filter.SupplierID,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListPurchaseOrders: не удалось выполнить запрос ListPurchaseOrders")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListPurchaseOrders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []models.PurchaseOrder
+	for rows.Next() {
+		var po models.PurchaseOrder
+		if err := scanPurchaseOrder(rows, &po); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListPurchaseOrders: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		orders = append(orders, po)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListPurchaseOrders: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	ids := make([]int, 0, len(orders))
+	for _, po := range orders {
+		ids = append(ids, po.ID)
+	}
+
+	lines, err := r.listPurchaseOrderLines(ctx, ids)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListPurchaseOrders: не удалось получить строки заказов")
+
+		return nil, err
+	}
+	for i := range orders {
+		orders[i].Lines = lines[orders[i].ID]
+	}
+
+	return orders, nil
+}
+
+// GetPurchaseOrder - метод для получения заказа на закупку со строками и приемками.
+func (r *purchaseRepo) GetPurchaseOrder(ctx context.Context, id int) (*models.PurchaseOrder, error) {
+	var po models.PurchaseOrder
+	if err := scanPurchaseOrder(r.db.QueryRowContext(ctx, qGetPurchaseOrderByID, id), &po); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("заказ с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("po_id", id).
+			Msg("GetPurchaseOrder: не удалось выполнить запрос GetPurchaseOrder")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetPurchaseOrder: %w", err)
+	}
+
+	lines, err := r.listPurchaseOrderLines(ctx, []int{id})
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("po_id", id).
+			Msg("GetPurchaseOrder: не удалось получить строки заказа")
+
+		return nil, err
+	}
+	po.Lines = lines[id]
+
+	receipts, err := r.listPurchaseReceipts(ctx, id)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("po_id", id).
+			Msg("GetPurchaseOrder: не удалось получить приемки заказа")
+
+		return nil, err
+	}
+	po.Receipts = receipts
+
+	return &po, nil
+}
+
+// UpdatePurchaseOrderStatus - метод для перевода заказа в статус to из одного из статусов from.
+func (r *purchaseRepo) UpdatePurchaseOrderStatus(ctx context.Context, id int, from []string, to string) error {
+	result, err := r.db.Master.ExecContext(ctx, qUpdatePurchaseOrderStatus, id, to, pq.Array(from))
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("po_id", id).
+			Str("status", to).
+			Msg("UpdatePurchaseOrderStatus: не удалось обновить статус заказа")
+
+		return fmt.Errorf("не удалось обновить статус заказа: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	status, err := r.purchaseOrderStatus(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("нельзя перевести заказ %d из статуса %s в %s", id, status, to)
+}
+
+// DeletePurchaseOrder - метод для удаления черновика заказа на закупку.
+func (r *purchaseRepo) DeletePurchaseOrder(ctx context.Context, id int) error {
+	result, err := r.db.Master.ExecContext(ctx, qDeleteDraftPurchaseOrder, id)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("po_id", id).
+			Msg("DeletePurchaseOrder: не удалось удалить заказ")
+
+		return fmt.Errorf("не удалось удалить заказ: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество удаленных строк: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	status, err := r.purchaseOrderStatus(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("нельзя удалить заказ %d в статусе %s", id, status)
+}
+
+// ReceivePurchaseOrder - метод для приемки товара по заказу на закупку.
+// Увеличивает остатки items, фиксирует приемку по строкам и пересчитывает статус заказа.
+// Для серийных items регистрирует переданные серийные номера.
+// Цены строк пересчитываются в базовую валюту по курсу валюты заказа на дату приемки.
+// Возвращает новый статус заказа.
+func (r *purchaseRepo) ReceivePurchaseOrder(
+	ctx context.Context,
+	userID, id int,
+	lines []models.ReceiptLine,
+	note string,
+	tolerance int,
+) (string, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("po_id", id).
+			Msg("ReceivePurchaseOrder: не удалось начать транзакцию")
+
+		return "", fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("ReceivePurchaseOrder: не удалось установить userID")
+
+		return "", fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var number, status, currency string
+	if err := tx.QueryRowContext(ctx, qLockPurchaseOrder, id).Scan(&number, &status, &currency); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("заказ с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("po_id", id).
+			Msg("ReceivePurchaseOrder: не удалось заблокировать заказ")
+
+		return "", fmt.Errorf("не удалось заблокировать заказ: %w", err)
+	}
+	if status != models.POSent && status != models.POPartiallyReceived {
+		return "", fmt.Errorf("нельзя принять товар по заказу %s в статусе %s", number, status)
+	}
+
+	receivedAt := time.Now()
+	rate, err := currencyRate(ctx, tx, currency, receivedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return "", fmt.Errorf("нельзя принять товар по заказу %s: нет курса валюты %s на %s",
+				number, currency, receivedAt.Format(time.DateOnly))
+		}
+
+		return "", err
+	}
+
+	details := fmt.Sprintf("приемка по заказу %s", number)
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("ReceivePurchaseOrder: не удалось установить детали операции")
+
+		return "", fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	for _, line := range lines {
+		var lineID int
+		var unitCost models.Money
+		if err := tx.QueryRowContext(
+			ctx,
+			qReceivePurchaseOrderLine,
+			id,
+			line.ItemID,
+			line.Quantity,
+			tolerance,
+		).Scan(&lineID, &unitCost); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", overReceiptError(ctx, tx, id, number, line, tolerance)
+			}
+			zlog.Logger.Error().
+				Err(err).
+				Int("po_id", id).
+				Int("item_id", line.ItemID).
+				Msg("ReceivePurchaseOrder: не удалось обновить строку заказа")
+
+			return "", fmt.Errorf("не удалось обновить строку заказа: %w", err)
+		}
+
+		if err := receiveItemStock(ctx, tx, line, details); err != nil {
+			return "", err
+		}
+		unitCost = unitCost.Convert(rate)
+		if err := receiveCost(ctx, tx, line.ItemID, line.Quantity, &unitCost, details); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("po_id", id).
+				Int("item_id", line.ItemID).
+				Msg("ReceivePurchaseOrder: не удалось учесть себестоимость приемки")
+
+			return "", err
+		}
+
+		if _, err := tx.ExecContext(ctx, qCreatePurchaseReceipt, id, lineID, line.Quantity, userID, note, rate); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("po_id", id).
+				Int("line_id", lineID).
+				Msg("ReceivePurchaseOrder: не удалось сохранить приемку")
+
+			return "", fmt.Errorf("не удалось сохранить приемку: %w", err)
+		}
+	}
+
+	if err := tx.QueryRowContext(ctx, qCompletePurchaseReceipt, id).Scan(&status); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("po_id", id).
+			Msg("ReceivePurchaseOrder: не удалось обновить статус заказа")
+
+		return "", fmt.Errorf("не удалось обновить статус заказа: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("po_id", id).
+			Msg("ReceivePurchaseOrder: не удалось завершить транзакцию")
+
+		return "", fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return status, nil
+}
+
+// receiveItemStock - увеличение остатка item при приемке.
+// Остаток серийного item пересчитывается по зарегистрированным серийным номерам.
+func receiveItemStock(ctx context.Context, tx *sql.Tx, line models.ReceiptLine, details string) error {
+	var serialized bool
+	if err := tx.QueryRowContext(ctx, qLockSerializedItem, line.ItemID).Scan(&serialized); err != nil {
+		return fmt.Errorf("не удалось получить item: %w", err)
+	}
+
+	if !serialized {
+		if len(line.Serials) > 0 {
+			return fmt.Errorf("некорректная приемка: item с id %d не является серийным", line.ItemID)
+		}
+		if _, err := tx.ExecContext(ctx, qIncreaseItemQuantity, line.ItemID, line.Quantity); err != nil {
+			return fmt.Errorf("не удалось увеличить остаток item: %w", err)
+		}
+
+		return nil
+	}
+
+	if len(line.Serials) != line.Quantity {
+		return fmt.Errorf("некорректная приемка: для серийного item с id %d нужно %d серийных номеров, передано %d",
+			line.ItemID, line.Quantity, len(line.Serials))
+	}
+	for _, serialNumber := range line.Serials {
+		if _, err := tx.ExecContext(ctx, qCreateSerial, line.ItemID, serialNumber); err != nil {
+			if isUniqueViolation(err) {
+				return fmt.Errorf("серийный номер %s уже существует", serialNumber)
+			}
+
+			return fmt.Errorf("не удалось зарегистрировать серийный номер: %w", err)
+		}
+	}
+
+	return syncSerializedQuantity(ctx, tx, line.ItemID, details)
+}
+
+// overReceiptError - причина, по которой строка заказа не принята: item нет в заказе или превышен допуск.
+func overReceiptError(ctx context.Context, tx *sql.Tx, id int, number string, line models.ReceiptLine, tolerance int) error {
+	var ordered, received int
+	if err := tx.QueryRowContext(ctx, qGetPurchaseOrderLine, id, line.ItemID).Scan(&ordered, &received); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("item с id %d не найден в заказе %s", line.ItemID, number)
+		}
+
+		return fmt.Errorf("не удалось получить строку заказа: %w", err)
+	}
+
+	return fmt.Errorf("нельзя принять %d по item %d заказа %s: заказано %d, принято %d, допуск сверх заказа %d%%",
+		line.Quantity, line.ItemID, number, ordered, received, tolerance)
+}
+
+// purchaseOrderStatus - текущий статус заказа.
+func (r *purchaseRepo) purchaseOrderStatus(ctx context.Context, id int) (string, error) {
+	var status string
+	if err := r.db.Master.QueryRowContext(ctx, qGetPurchaseOrderStatus, id).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("заказ с id %d не найден", id)
+		}
+
+		return "", fmt.Errorf("не удалось получить статус заказа: %w", err)
+	}
+
+	return status, nil
+}
+
+// listPurchaseOrderLines - получение строк заказов, сгруппированных по id заказа.
+func (r *purchaseRepo) listPurchaseOrderLines(ctx context.Context, ids []int) (map[int][]models.PurchaseOrderLine, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListPurchaseOrderLines,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listPurchaseOrderLines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[int][]models.PurchaseOrderLine, len(ids))
+	for rows.Next() {
+		var line models.PurchaseOrderLine
+		if err := rows.Scan(
+			&line.ID,
+			&line.OrderID,
+			&line.ItemID,
+			&line.ItemName,
+			&line.OrderedQty,
+			&line.ReceivedQty,
+			&line.UnitCost,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		lines[line.OrderID] = append(lines[line.OrderID], line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return lines, nil
+}
+
+// listPurchaseReceipts - получение приемок по заказу.
+func (r *purchaseRepo) listPurchaseReceipts(ctx context.Context, id int) ([]models.PurchaseReceipt, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListPurchaseReceipts,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listPurchaseReceipts: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []models.PurchaseReceipt
+	for rows.Next() {
+		var receipt models.PurchaseReceipt
+		var receivedBy sql.NullInt64
+		if err := rows.Scan(
+			&receipt.ID,
+			&receipt.OrderID,
+			&receipt.LineID,
+			&receipt.ItemID,
+			&receipt.Quantity,
+			&receipt.ExchangeRate,
+			&receivedBy,
+			&receipt.Note,
+			&receipt.ReceivedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		if receivedBy.Valid {
+			userID := int(receivedBy.Int64)
+			receipt.ReceivedBy = &userID
+		}
+
+		receipts = append(receipts, receipt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return receipts, nil
+}
+
+// scanPurchaseOrder - перевод строки purchase_orders в структуру.
+func scanPurchaseOrder(row interface{ Scan(dest ...any) error }, po *models.PurchaseOrder) error {
+	var expectedAt, sentAt, closedAt sql.NullTime
+	if err := row.Scan(
+		&po.ID,
+		&po.Number,
+		&po.SupplierID,
+		&po.SupplierName,
+		&po.Status,
+		&po.Currency,
+		&expectedAt,
+		&po.Notes,
+		&po.CreatedBy,
+		&sentAt,
+		&closedAt,
+		&po.CreatedAt,
+		&po.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	if expectedAt.Valid {
+		po.ExpectedAt = &expectedAt.Time
+	}
+	if sentAt.Valid {
+		po.SentAt = &sentAt.Time
+	}
+	if closedAt.Valid {
+		po.ClosedAt = &closedAt.Time
+	}
+
+	return nil
+}
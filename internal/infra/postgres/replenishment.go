@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	// Черновики тоже считаются заказанным количеством, чтобы созданные по предложениям заказы не предлагались повторно.
+	// Закупка ведется на весь склад, поэтому учитываются только уровни без ячейки.
+	// Поставщик - основной, иначе активный с наименьшей ценой в базовой валюте по текущему курсу и сроком поставки.
+	qListReplenishmentCandidates = `
+	SELECT s.item_id, s.min_qty, s.reorder_point, s.max_qty, s.updated_at, i.item_name, i.quantity,
+		COALESCE(r.reserved, 0), COALESCE(o.on_order, 0),
+		sup.supplier_id, COALESCE(sup.supplier_name, ''), COALESCE(sup.unit_cost, 0), COALESCE(sup.currency, ''),
+		COALESCE(sup.min_order_qty, 0), COALESCE(sup.lead_time_days, 0)
+	FROM stock_levels s
+	JOIN items i ON i.id = s.item_id
+	LEFT JOIN (
+		SELECT item_id, SUM(quantity) AS reserved
+		FROM reservations
+		WHERE reservation_status = 'active'
+		GROUP BY item_id
+	) r ON r.item_id = i.id
+	LEFT JOIN (
+		SELECT l.item_id, SUM(GREATEST(l.ordered_qty - l.received_qty, 0)) AS on_order
+		FROM purchase_order_lines l
+		JOIN purchase_orders po ON po.id = l.po_id
+		WHERE po.po_status IN ('draft', 'sent', 'partially_received')
+		GROUP BY l.item_id
+	) o ON o.item_id = i.id
+	LEFT JOIN LATERAL (
+		SELECT isup.supplier_id, sp.supplier_name, isup.unit_cost, sp.currency, isup.min_order_qty, sp.lead_time_days
+		FROM item_suppliers isup
+		JOIN suppliers sp ON sp.id = isup.supplier_id
+		WHERE isup.item_id = i.id AND sp.supplier_status = 'active'
+		ORDER BY isup.preferred DESC, isup.unit_cost * currency_rate(sp.currency, CURRENT_DATE) NULLS LAST,
+			sp.lead_time_days, isup.supplier_id
+		LIMIT 1
+	) sup ON TRUE
+	WHERE s.location_id IS NULL
+	ORDER BY s.item_id`
+)
+
+var _ infra.ReplenishmentRepo = (*replenishmentRepo)(nil)
+
+type replenishmentRepo struct {
+	db *dbpg.DB
+}
+
+// ListReplenishmentCandidates - метод для получения items с уровнями запаса вместе с количеством
+// в открытых заказах на закупку и условиями поставщика.
+func (r *replenishmentRepo) ListReplenishmentCandidates(ctx context.Context) ([]models.ReplenishmentCandidate, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListReplenishmentCandidates,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListReplenishmentCandidates: не удалось выполнить запрос ListReplenishmentCandidates")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListReplenishmentCandidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.ReplenishmentCandidate
+	for rows.Next() {
+		var c models.ReplenishmentCandidate
+		var supplierID sql.NullInt64
+		if err := rows.Scan(
+			&c.ItemID,
+			&c.MinQty,
+			&c.ReorderPoint,
+			&c.MaxQty,
+			&c.UpdatedAt,
+			&c.ItemName,
+			&c.Quantity,
+			&c.Reserved,
+			&c.OnOrder,
+			&supplierID,
+			&c.SupplierName,
+			&c.UnitCost,
+			&c.Currency,
+			&c.MinOrderQty,
+			&c.LeadTimeDays,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListReplenishmentCandidates: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		if supplierID.Valid {
+			id := int(supplierID.Int64)
+			c.SupplierID = &id
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListReplenishmentCandidates: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return candidates, nil
+}
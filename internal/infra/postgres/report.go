@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qStreamMovements = `
+	SELECT m.id, m.item_id, i.item_name, m.movement_type, m.quantity, m.unit_cost, m.total_cost, m.method, m.source,
+		m.balance_qty, m.balance_value, m.created_by, u.username, m.created_at
+	FROM cost_movements m
+	JOIN items i ON i.id = m.item_id
+	LEFT JOIN users u ON u.id = m.created_by
+	WHERE m.created_at >= $1 AND m.created_at < $2
+		AND ($3::INT IS NULL OR m.item_id = $3)
+		AND ($4::INT IS NULL OR m.created_by = $4)
+	ORDER BY m.created_at, m.id`
+
+	qStreamUserActivity = `
+	SELECT h.id, h.user_id, COALESCE(u.username, ''), h.item_id, i.item_name, h.operation, h.details,
+		h.old_value, h.new_value, h.changed_at
+	FROM items_history h
+	JOIN items i ON i.id = h.item_id
+	LEFT JOIN users u ON u.id = h.user_id
+	WHERE h.changed_at >= $1 AND h.changed_at < $2
+		AND ($3::INT IS NULL OR h.item_id = $3)
+		AND ($4::INT IS NULL OR h.user_id = $4)
+	ORDER BY h.user_id, h.changed_at, h.id`
+
+	// Остатки на начало и конец - по последнему движению до границы периода,
+	// items без движений до конца периода в ведомость не попадают.
+	qStreamBalances = `
+	SELECT i.id, i.item_name,
+		COALESCE(o.balance_qty, 0), COALESCE(o.balance_value, 0),
+		COALESCE(p.in_qty, 0), COALESCE(p.in_value, 0),
+		COALESCE(p.out_qty, 0), COALESCE(p.out_value, 0),
+		c.balance_qty, c.balance_value
+	FROM items i
+	JOIN LATERAL (
+		SELECT m.balance_qty, m.balance_value
+		FROM cost_movements m
+		WHERE m.item_id = i.id AND m.created_at < $2
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT 1
+	) c ON TRUE
+	LEFT JOIN LATERAL (
+		SELECT m.balance_qty, m.balance_value
+		FROM cost_movements m
+		WHERE m.item_id = i.id AND m.created_at < $1
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT 1
+	) o ON TRUE
+	LEFT JOIN LATERAL (
+		SELECT SUM(m.quantity) FILTER (WHERE m.movement_type = 'receipt') AS in_qty,
+			SUM(m.total_cost) FILTER (WHERE m.movement_type = 'receipt') AS in_value,
+			SUM(m.quantity) FILTER (WHERE m.movement_type = 'issue') AS out_qty,
+			SUM(m.total_cost) FILTER (WHERE m.movement_type = 'issue') AS out_value
+		FROM cost_movements m
+		WHERE m.item_id = i.id AND m.created_at >= $1 AND m.created_at < $2
+	) p ON TRUE
+	WHERE $3::INT IS NULL OR i.id = $3
+	ORDER BY i.id`
+)
+
+var _ infra.ReportRepo = (*reportRepo)(nil)
+
+type reportRepo struct {
+	db *dbpg.DB
+}
+
+// StreamMovements - метод для построчной выборки стоимостных движений за период по фильтру.
+// Каждая строка передается в fn сразу после чтения, ошибка fn прерывает выборку.
+func (r *reportRepo) StreamMovements(
+	ctx context.Context,
+	filter models.ReportFilter,
+	fn func(models.MovementRecord) error,
+) error {
+	return r.stream(ctx, "StreamMovements", qStreamMovements, func(rows *sql.Rows) error {
+		var m models.MovementRecord
+		var createdBy sql.NullInt64
+		if err := rows.Scan(
+			&m.ID,
+			&m.ItemID,
+			&m.ItemName,
+			&m.Type,
+			&m.Quantity,
+			&m.UnitCost,
+			&m.TotalCost,
+			&m.Method,
+			&m.Source,
+			&m.BalanceQty,
+			&m.BalanceValue,
+			&createdBy,
+			&m.Username,
+			&m.CreatedAt,
+		); err != nil {
+			return scanError("StreamMovements", err)
+		}
+		m.UserID = nullIntPtr(createdBy)
+
+		return fn(m)
+	}, filter.From, filter.Before(), filter.ItemID, filter.UserID)
+}
+
+// StreamUserActivity - метод для построчной выборки истории изменений items за период
+// по пользователям в хронологическом порядке.
+func (r *reportRepo) StreamUserActivity(
+	ctx context.Context,
+	filter models.ReportFilter,
+	fn func(models.ActivityRecord) error,
+) error {
+	return r.stream(ctx, "StreamUserActivity", qStreamUserActivity, func(rows *sql.Rows) error {
+		var a models.ActivityRecord
+		if err := rows.Scan(
+			&a.ID,
+			&a.UserID,
+			&a.Username,
+			&a.ItemID,
+			&a.ItemName,
+			&a.Operation,
+			&a.Details,
+			&a.OldValue,
+			&a.NewValue,
+			&a.ChangedAt,
+		); err != nil {
+			return scanError("StreamUserActivity", err)
+		}
+
+		return fn(a)
+	}, filter.From, filter.Before(), filter.ItemID, filter.UserID)
+}
+
+// StreamBalances - метод для построчной выборки оборотной ведомости за период.
+func (r *reportRepo) StreamBalances(
+	ctx context.Context,
+	filter models.ReportFilter,
+	fn func(models.BalanceRecord) error,
+) error {
+	return r.stream(ctx, "StreamBalances", qStreamBalances, func(rows *sql.Rows) error {
+		var b models.BalanceRecord
+		if err := rows.Scan(
+			&b.ItemID,
+			&b.ItemName,
+			&b.OpeningQty,
+			&b.OpeningValue,
+			&b.InQty,
+			&b.InValue,
+			&b.OutQty,
+			&b.OutValue,
+			&b.ClosingQty,
+			&b.ClosingValue,
+		); err != nil {
+			return scanError("StreamBalances", err)
+		}
+
+		return fn(b)
+	}, filter.From, filter.Before(), filter.ItemID)
+}
+
+// stream - выполнение запроса и передача строк в row по одной без накопления результата.
+func (r *reportRepo) stream(ctx context.Context, op, query string, row func(*sql.Rows) error, args ...any) error {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(ctx, strategy, query, args...)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": не удалось выполнить запрос " + op)
+
+		return fmt.Errorf("не удалось выполнить запрос %s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := row(rows); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": не удалось получить все строки")
+
+		return fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return nil
+}
+
+// scanError - логирование и обертка ошибки чтения строки отчета.
+func scanError(op string, err error) error {
+	zlog.Logger.Error().
+		Err(err).
+		Msg(op + ": не удалось перевести данные из строки в структуру")
+
+	return fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+}
@@ -0,0 +1,363 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qLockItemStock = `
+	SELECT i.quantity, COALESCE((
+		SELECT SUM(r.quantity)
+		FROM reservations r
+		WHERE r.item_id = i.id AND r.reservation_status = 'active'
+	), 0)
+	FROM items i
+	WHERE i.id = $1
+	FOR UPDATE OF i`
+
+	qCreateReservation = `
+	INSERT INTO reservations (item_id, user_id, quantity, order_ref, expires_at)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`
+
+	qListReservationsByItemID = `
+	SELECT id, item_id, user_id, quantity, COALESCE(order_ref, ''), reservation_status, expires_at, created_at, updated_at
+	FROM reservations
+	WHERE item_id = $1
+	ORDER BY id`
+
+	qLockActiveReservation = `
+	SELECT item_id, quantity, outbound_line_id IS NOT NULL, expires_at IS NOT NULL AND expires_at <= CURRENT_TIMESTAMP
+	FROM reservations
+	WHERE id = $1 AND reservation_status = 'active'
+	FOR UPDATE`
+
+	qSetReservationStatus = `
+	UPDATE reservations SET reservation_status = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qDecreaseItemQuantity = `
+	UPDATE items SET quantity = quantity - $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qExpireReservations = `
+	UPDATE reservations SET reservation_status = 'expired', updated_at = CURRENT_TIMESTAMP
+	WHERE reservation_status = 'active' AND expires_at <= $1`
+)
+
+var _ infra.ReservationRepo = (*reservationRepo)(nil)
+
+type reservationRepo struct {
+	db *dbpg.DB
+}
+
+// CreateReservation - метод для создания резерва.
+// Блокирует строку item и проверяет, что доступного остатка хватает на резерв.
+func (r *reservationRepo) CreateReservation(ctx context.Context, res *models.Reservation) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", res.ItemID).
+			Msg("CreateReservation: не удалось начать транзакцию")
+
+		return 0, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	var onHand, reserved int
+	if err := tx.QueryRowContext(ctx, qLockItemStock, res.ItemID).Scan(&onHand, &reserved); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("item с id %d не найден", res.ItemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", res.ItemID).
+			Msg("CreateReservation: не удалось получить остаток item")
+
+		return 0, fmt.Errorf("не удалось получить остаток item: %w", err)
+	}
+
+	if available := onHand - reserved; res.Quantity > available {
+		return 0, fmt.Errorf("недостаточно доступного остатка: запрошено %d, доступно %d", res.Quantity, available)
+	}
+
+	var id int
+	if err := tx.QueryRowContext(
+		ctx,
+		qCreateReservation,
+		res.ItemID,
+		res.UserID,
+		res.Quantity,
+		sql.NullString{String: res.OrderRef, Valid: res.OrderRef != ""},
+		res.ExpiresAt,
+	).Scan(&id); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", res.ItemID).
+			Int("quantity", res.Quantity).
+			Msg("CreateReservation: не удалось создать резерв")
+
+		return 0, fmt.Errorf("не удалось создать резерв: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", res.ItemID).
+			Msg("CreateReservation: не удалось завершить транзакцию")
+
+		return 0, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetReservationsByItemID - метод для получения всех резервов конкретного item.
+func (r *reservationRepo) GetReservationsByItemID(ctx context.Context, itemID int) ([]models.Reservation, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListReservationsByItemID,
+		itemID,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetReservationsByItemID: не удалось выполнить запрос GetReservationsByItemID")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetReservationsByItemID: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []models.Reservation
+	for rows.Next() {
+		var res models.Reservation
+		var expiresAt sql.NullTime
+		if err := rows.Scan(
+			&res.ID,
+			&res.ItemID,
+			&res.UserID,
+			&res.Quantity,
+			&res.OrderRef,
+			&res.Status,
+			&expiresAt,
+			&res.CreatedAt,
+			&res.UpdatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", itemID).
+				Msg("GetReservationsByItemID: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		// У резервов заказов на отгрузку срока нет.
+		res.ExpiresAt = expiresAt.Time
+
+		reservations = append(reservations, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetReservationsByItemID: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// ReleaseReservation - метод для снятия активного резерва без списания остатка.
+func (r *reservationRepo) ReleaseReservation(ctx context.Context, id int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("reservation_id", id).
+			Msg("ReleaseReservation: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	var itemID, quantity int
+	var outbound, expired bool
+	if err := tx.QueryRowContext(ctx, qLockActiveReservation, id).Scan(&itemID, &quantity, &outbound, &expired); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("активный резерв с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("reservation_id", id).
+			Msg("ReleaseReservation: не удалось получить резерв")
+
+		return fmt.Errorf("не удалось получить резерв: %w", err)
+	}
+	if outbound {
+		return fmt.Errorf("нельзя снять резерв %d: он принадлежит заказу на отгрузку", id)
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetReservationStatus, id, models.ReservationReleased); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("reservation_id", id).
+			Msg("ReleaseReservation: не удалось снять резерв")
+
+		return fmt.Errorf("не удалось снять резерв: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("reservation_id", id).
+			Msg("ReleaseReservation: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmReservation - метод для подтверждения резерва.
+// Списывает зарезервированное количество с остатка item, изменение попадает в историю.
+// Истекший резерв не подтверждается, даже если фоновый процесс еще не успел его снять.
+func (r *reservationRepo) ConfirmReservation(ctx context.Context, userID, id int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("reservation_id", id).
+			Msg("ConfirmReservation: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("ConfirmReservation: не удалось установить userID")
+
+		return fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var itemID, quantity int
+	var outbound, expired bool
+	if err := tx.QueryRowContext(ctx, qLockActiveReservation, id).Scan(&itemID, &quantity, &outbound, &expired); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("активный резерв с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("reservation_id", id).
+			Msg("ConfirmReservation: не удалось получить резерв")
+
+		return fmt.Errorf("не удалось получить резерв: %w", err)
+	}
+	if outbound {
+		return fmt.Errorf("нельзя подтвердить резерв %d: он принадлежит заказу на отгрузку", id)
+	}
+	if expired {
+		return fmt.Errorf("нельзя подтвердить резерв %d: срок резерва истек", id)
+	}
+
+	if _, err := tx.ExecContext(ctx, qDecreaseItemQuantity, itemID, quantity); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("reservation_id", id).
+			Int("item_id", itemID).
+			Int("quantity", quantity).
+			Msg("ConfirmReservation: не удалось списать остаток item")
+
+		return fmt.Errorf("не удалось списать остаток item: %w", err)
+	}
+
+	if _, err := issueCost(ctx, tx, itemID, quantity, fmt.Sprintf("подтверждение резерва %d", id)); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("reservation_id", id).
+			Int("item_id", itemID).
+			Msg("ConfirmReservation: не удалось рассчитать себестоимость списания")
+
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetReservationStatus, id, models.ReservationConfirmed); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("reservation_id", id).
+			Msg("ConfirmReservation: не удалось подтвердить резерв")
+
+		return fmt.Errorf("не удалось подтвердить резерв: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("reservation_id", id).
+			Msg("ConfirmReservation: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireReservations - метод для снятия всех активных резервов, истекших к моменту now.
+// Возвращает количество снятых резервов.
+func (r *reservationRepo) ExpireReservations(ctx context.Context, now time.Time) (int, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	result, err := r.db.ExecWithRetry(
+		ctx,
+		strategy,
+		qExpireReservations,
+		now,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ExpireReservations: не удалось выполнить запрос ExpireReservations")
+
+		return 0, fmt.Errorf("не удалось выполнить запрос ExpireReservations: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ExpireReservations: не удалось получить количество строк, обновленных запросом ExpireReservations")
+
+		return 0, fmt.Errorf("не удалось получить количество строк, обновленных запросом ExpireReservations: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wb-go/wbf/dbpg"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// reservedItem - item с остатком 10 и активным резервом на 4 со сроком expiresAt.
+func reservedItem(t *testing.T, db *dbpg.DB, expiresAt time.Time) (int, int, int) {
+	t.Helper()
+	ctx := context.Background()
+
+	admin, err := (&userRepo{db: db}).GetByUsername(ctx, "admin123")
+	require.NoError(t, err)
+
+	var itemID, reservationID int
+	require.NoError(t, db.Master.QueryRowContext(ctx,
+		`INSERT INTO items (item_name, quantity) VALUES ($1, 10) RETURNING id`,
+		"reserved-item-"+time.Now().Format("150405.000000000"),
+	).Scan(&itemID))
+	t.Cleanup(func() { db.Master.ExecContext(ctx, `DELETE FROM items WHERE id = $1`, itemID) })
+
+	require.NoError(t, db.Master.QueryRowContext(ctx,
+		`INSERT INTO reservations (item_id, user_id, quantity, expires_at) VALUES ($1, $2, 4, $3) RETURNING id`,
+		itemID, admin.ID, expiresAt,
+	).Scan(&reservationID))
+
+	return admin.ID, itemID, reservationID
+}
+
+func TestReservationRepo_ConfirmReservation_ErrExpired(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	userID, itemID, reservationID := reservedItem(t, db, time.Now().Add(-time.Minute))
+
+	err := (&reservationRepo{db: db}).ConfirmReservation(ctx, userID, reservationID)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "срок резерва истек")
+
+	var quantity int
+	require.NoError(t, db.Master.QueryRowContext(ctx, `SELECT quantity FROM items WHERE id = $1`, itemID).Scan(&quantity))
+	assert.Equal(t, 10, quantity)
+}
+
+func TestItemRepo_Update_ErrBelowReserved(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	items := &itemRepo{db: db}
+	userID, itemID, _ := reservedItem(t, db, time.Now().Add(time.Hour))
+
+	item := &models.Item{Name: "reserved-item", Quantity: 3, UpdatedAt: time.Now()}
+
+	err := items.Update(ctx, userID, itemID, item)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя уменьшить остаток")
+
+	item.Quantity = 4
+	assert.NoError(t, items.Update(ctx, userID, itemID, item))
+}
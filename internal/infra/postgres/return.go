@@ -0,0 +1,748 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qCreateReturnAuthorization = `
+	INSERT INTO return_authorizations (order_id, note, created_by)
+	VALUES ($1, $2, $3)
+	RETURNING id, rma_number`
+
+	qCreateReturnLine = `
+	INSERT INTO return_lines (rma_id, order_line_id, reason, authorized_qty)
+	VALUES ($1, $2, $3, $4)`
+
+	// Количество, уже разрешенное к возврату по строкам заказа в неотмененных возвратах.
+	qListAuthorizedReturns = `
+	SELECT rl.order_line_id, SUM(rl.authorized_qty)
+	FROM return_lines rl
+	JOIN return_authorizations ra ON ra.id = rl.rma_id
+	WHERE ra.order_id = $1 AND ra.rma_status <> 'cancelled'
+	GROUP BY rl.order_line_id`
+
+	qReturnAuthorizationColumns = `
+	SELECT ra.id, ra.rma_number, ra.order_id, o.order_number, ra.rma_status, ra.note, COALESCE(ra.created_by, 0),
+		ra.created_at, ra.updated_at
+	FROM return_authorizations ra
+	JOIN outbound_orders o ON o.id = ra.order_id`
+
+	qListReturnAuthorizations = qReturnAuthorizationColumns + `
+	WHERE ($1::TEXT = '' OR ra.rma_status = $1) AND ($2::INTEGER = 0 OR ra.order_id = $2)
+	ORDER BY ra.id DESC`
+
+	qGetReturnAuthorizationByID = qReturnAuthorizationColumns + `
+	WHERE ra.id = $1`
+
+	qLockReturnAuthorization = qReturnAuthorizationColumns + `
+	WHERE ra.id = $1
+	FOR UPDATE OF ra`
+
+	qListReturnLines = `
+	SELECT rl.id, rl.rma_id, rl.order_line_id, l.item_id, i.item_name, rl.reason, rl.authorized_qty, rl.received_qty
+	FROM return_lines rl
+	JOIN outbound_order_lines l ON l.id = rl.order_line_id
+	JOIN items i ON i.id = l.item_id
+	WHERE rl.rma_id = ANY($1)
+	ORDER BY rl.id`
+
+	qListReturnReceipts = `
+	SELECT rc.id, rc.rma_id, rc.line_id, l.item_id, rc.quantity, rc.disposition, rc.serial_numbers, rc.note,
+		rc.received_by, rc.received_at
+	FROM return_receipts rc
+	JOIN return_lines rl ON rl.id = rc.line_id
+	JOIN outbound_order_lines l ON l.id = rl.order_line_id
+	WHERE rc.rma_id = $1
+	ORDER BY rc.id`
+
+	qCreateReturnReceipt = `
+	INSERT INTO return_receipts (rma_id, line_id, quantity, disposition, serial_numbers, note, received_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	qSetReturnLineReceived = `
+	UPDATE return_lines SET received_qty = $2
+	WHERE id = $1`
+
+	qSetReturnStatus = `
+	UPDATE return_authorizations SET rma_status = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	// Вернуть можно только отгруженный серийный номер.
+	qReturnSerial = `
+	UPDATE item_serials SET serial_status = $3, updated_at = CURRENT_TIMESTAMP
+	WHERE item_id = $1 AND serial_number = $2 AND serial_status = 'shipped'`
+
+	qReturnReasonStats = `
+	SELECT rl.reason, COUNT(DISTINCT ra.id), SUM(rl.authorized_qty), SUM(rl.received_qty),
+		COALESCE(SUM(rc.restocked), 0), COALESCE(SUM(rc.refurbished), 0),
+		COALESCE(SUM(rc.quarantined), 0), COALESCE(SUM(rc.scrapped), 0)
+	FROM return_lines rl
+	JOIN return_authorizations ra ON ra.id = rl.rma_id
+	LEFT JOIN (
+		SELECT line_id,
+			SUM(quantity) FILTER (WHERE disposition = 'restock') AS restocked,
+			SUM(quantity) FILTER (WHERE disposition = 'refurbish') AS refurbished,
+			SUM(quantity) FILTER (WHERE disposition = 'quarantine') AS quarantined,
+			SUM(quantity) FILTER (WHERE disposition = 'scrap') AS scrapped
+		FROM return_receipts
+		GROUP BY line_id
+	) rc ON rc.line_id = rl.id
+	WHERE ra.rma_status <> 'cancelled'
+		AND ($1::TIMESTAMP IS NULL OR ra.created_at >= $1)
+		AND ($2::TIMESTAMP IS NULL OR ra.created_at < $2)
+	GROUP BY rl.reason
+	ORDER BY SUM(rl.authorized_qty) DESC, rl.reason`
+)
+
+var _ infra.ReturnRepo = (*returnRepo)(nil)
+
+type returnRepo struct {
+	db *dbpg.DB
+}
+
+// CreateReturnAuthorization - метод для оформления возврата по заказу на отгрузку.
+// Вернуть можно не больше отгруженного по строке с учетом других неотмененных возвратов.
+// Возвращает id и номер возврата.
+func (r *returnRepo) CreateReturnAuthorization(ctx context.Context, rma *models.ReturnAuthorization) (int, string, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("CreateReturnAuthorization: не удалось начать транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка заказа не дает параллельным возвратам превысить отгруженное.
+	var order models.OutboundOrder
+	if err := scanOutboundOrder(tx.QueryRowContext(ctx, qLockOutboundOrder, rma.OrderID), &order); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, "", fmt.Errorf("заказ на отгрузку с id %d не найден", rma.OrderID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", rma.OrderID).
+			Msg("CreateReturnAuthorization: не удалось заблокировать заказ")
+
+		return 0, "", fmt.Errorf("не удалось заблокировать заказ: %w", err)
+	}
+
+	lines, err := listOutboundOrderLines(ctx, tx, []int{order.ID})
+	if err != nil {
+		return 0, "", err
+	}
+	order.Lines = lines[order.ID]
+
+	authorized, err := listAuthorizedReturns(ctx, tx, order.ID)
+	if err != nil {
+		return 0, "", err
+	}
+
+	var id int
+	var number string
+	if err := tx.QueryRowContext(ctx, qCreateReturnAuthorization, order.ID, rma.Note, rma.CreatedBy).Scan(&id, &number); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("order_id", order.ID).
+			Msg("CreateReturnAuthorization: не удалось создать возврат")
+
+		return 0, "", fmt.Errorf("не удалось создать возврат: %w", err)
+	}
+
+	for _, line := range rma.Lines {
+		i, err := outboundLineIndex(&order, line.ItemID)
+		if err != nil {
+			return 0, "", err
+		}
+		orderLine := order.Lines[i]
+		if available := orderLine.ShippedQty - authorized[orderLine.ID]; line.AuthorizedQty > available {
+			return 0, "", fmt.Errorf("нельзя оформить возврат %d по item %d заказа %s: отгружено %d, уже в возвратах %d",
+				line.AuthorizedQty, line.ItemID, order.Number, orderLine.ShippedQty, authorized[orderLine.ID])
+		}
+
+		if _, err := tx.ExecContext(ctx, qCreateReturnLine, id, orderLine.ID, line.Reason, line.AuthorizedQty); err != nil {
+			if isUniqueViolation(err) {
+				return 0, "", fmt.Errorf("некорректный возврат: item %d указан дважды", line.ItemID)
+			}
+			zlog.Logger.Error().
+				Err(err).
+				Int("rma_id", id).
+				Int("item_id", line.ItemID).
+				Msg("CreateReturnAuthorization: не удалось добавить строку возврата")
+
+			return 0, "", fmt.Errorf("не удалось добавить строку возврата: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Msg("CreateReturnAuthorization: не удалось завершить транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return id, number, nil
+}
+
+// ListReturnAuthorizations - метод для получения возвратов со строками по статусу и заказу.
+// Пустой статус и orderID == 0 не ограничивают выборку.
+func (r *returnRepo) ListReturnAuthorizations(ctx context.Context, status string, orderID int) ([]models.ReturnAuthorization, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListReturnAuthorizations,
+		status,
+		orderID,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("status", status).
+			Int("order_id", orderID).
+			Msg("ListReturnAuthorizations: не удалось выполнить запрос ListReturnAuthorizations")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListReturnAuthorizations: %w", err)
+	}
+	defer rows.Close()
+
+	var rmas []models.ReturnAuthorization
+	for rows.Next() {
+		var rma models.ReturnAuthorization
+		if err := scanReturnAuthorization(rows, &rma); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListReturnAuthorizations: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		rmas = append(rmas, rma)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListReturnAuthorizations: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	if len(rmas) == 0 {
+		return rmas, nil
+	}
+
+	ids := make([]int, 0, len(rmas))
+	for _, rma := range rmas {
+		ids = append(ids, rma.ID)
+	}
+
+	lines, err := listReturnLines(ctx, r.db.Master, ids)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListReturnAuthorizations: не удалось получить строки возвратов")
+
+		return nil, err
+	}
+	for i := range rmas {
+		rmas[i].Lines = lines[rmas[i].ID]
+	}
+
+	return rmas, nil
+}
+
+// GetReturnAuthorization - метод для получения возврата со строками и приемками.
+func (r *returnRepo) GetReturnAuthorization(ctx context.Context, id int) (*models.ReturnAuthorization, error) {
+	var rma models.ReturnAuthorization
+	if err := scanReturnAuthorization(r.db.QueryRowContext(ctx, qGetReturnAuthorizationByID, id), &rma); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("возврат с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Msg("GetReturnAuthorization: не удалось выполнить запрос GetReturnAuthorization")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetReturnAuthorization: %w", err)
+	}
+
+	lines, err := listReturnLines(ctx, r.db.Master, []int{id})
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Msg("GetReturnAuthorization: не удалось получить строки возврата")
+
+		return nil, err
+	}
+	rma.Lines = lines[id]
+
+	if rma.Receipts, err = r.listReturnReceipts(ctx, id); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Msg("GetReturnAuthorization: не удалось получить приемки возврата")
+
+		return nil, err
+	}
+
+	return &rma, nil
+}
+
+// ReceiveReturn - метод для приемки возвращенных items с результатом осмотра.
+// Остаток проводится по результату осмотра, возвращает новый статус возврата.
+func (r *returnRepo) ReceiveReturn(ctx context.Context, userID, id int, inspections []models.ReturnInspection) (string, error) {
+	tx, rma, err := r.beginReturnChange(ctx, "ReceiveReturn", userID, id)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if rma.Status != models.ReturnAuthorized && rma.Status != models.ReturnPartiallyReceived {
+		return "", fmt.Errorf("нельзя принять возврат %s в статусе %s", rma.Number, rma.Status)
+	}
+
+	for _, inspection := range inspections {
+		i, err := returnLineIndex(rma, inspection.ItemID)
+		if err != nil {
+			return "", err
+		}
+		line := &rma.Lines[i]
+		if inspection.Quantity > line.Outstanding() {
+			return "", fmt.Errorf("нельзя принять %d по item %d возврата %s: ожидается %d",
+				inspection.Quantity, inspection.ItemID, rma.Number, line.Outstanding())
+		}
+
+		details := fmt.Sprintf("возврат %s: %s", rma.Number, inspection.Disposition)
+		if err := postReturnStock(ctx, tx, userID, inspection, details); err != nil {
+			return "", err
+		}
+
+		if _, err := tx.ExecContext(
+			ctx,
+			qCreateReturnReceipt,
+			id,
+			line.ID,
+			inspection.Quantity,
+			inspection.Disposition,
+			pq.Array(inspection.Serials),
+			inspection.Note,
+			userID,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("rma_id", id).
+				Int("line_id", line.ID).
+				Msg("ReceiveReturn: не удалось сохранить приемку")
+
+			return "", fmt.Errorf("не удалось сохранить приемку: %w", err)
+		}
+		line.ReceivedQty += inspection.Quantity
+	}
+
+	for _, line := range rma.Lines {
+		if _, err := tx.ExecContext(ctx, qSetReturnLineReceived, line.ID, line.ReceivedQty); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("rma_id", id).
+				Int("line_id", line.ID).
+				Msg("ReceiveReturn: не удалось обновить строку возврата")
+
+			return "", fmt.Errorf("не удалось обновить строку возврата: %w", err)
+		}
+	}
+
+	status := rma.NextStatus()
+	if _, err := tx.ExecContext(ctx, qSetReturnStatus, id, status); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Str("status", status).
+			Msg("ReceiveReturn: не удалось обновить статус возврата")
+
+		return "", fmt.Errorf("не удалось обновить статус возврата: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Msg("ReceiveReturn: не удалось завершить транзакцию")
+
+		return "", fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return status, nil
+}
+
+// CancelReturnAuthorization - метод для отмены возврата, по которому еще ничего не принято.
+func (r *returnRepo) CancelReturnAuthorization(ctx context.Context, userID, id int) error {
+	tx, rma, err := r.beginReturnChange(ctx, "CancelReturnAuthorization", userID, id)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if rma.Status != models.ReturnAuthorized {
+		return fmt.Errorf("нельзя отменить возврат %s в статусе %s", rma.Number, rma.Status)
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetReturnStatus, id, models.ReturnCancelled); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Msg("CancelReturnAuthorization: не удалось обновить статус возврата")
+
+		return fmt.Errorf("не удалось обновить статус возврата: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Msg("CancelReturnAuthorization: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// ReturnReasonStats - метод для получения статистики неотмененных возвратов по причинам.
+// Возвраты отбираются по дате оформления в полуинтервале [from, to), nil не ограничивает границу.
+func (r *returnRepo) ReturnReasonStats(ctx context.Context, from, to *time.Time) ([]models.ReturnReasonStat, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qReturnReasonStats,
+		from,
+		to,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ReturnReasonStats: не удалось выполнить запрос ReturnReasonStats")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ReturnReasonStats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.ReturnReasonStat
+	for rows.Next() {
+		var stat models.ReturnReasonStat
+		if err := rows.Scan(
+			&stat.Reason,
+			&stat.Returns,
+			&stat.AuthorizedQty,
+			&stat.ReceivedQty,
+			&stat.Restocked,
+			&stat.Refurbished,
+			&stat.Quarantined,
+			&stat.Scrapped,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ReturnReasonStats: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ReturnReasonStats: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return stats, nil
+}
+
+// beginReturnChange - начало транзакции изменения возврата: userID для триггеров истории и блокировка возврата со строками.
+func (r *returnRepo) beginReturnChange(ctx context.Context, op string, userID, id int) (*sql.Tx, *models.ReturnAuthorization, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("rma_id", id).
+			Msg(op + ": не удалось начать транзакцию")
+
+		return nil, nil, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(qSetUserID, userID)); err != nil {
+		tx.Rollback()
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg(op + ": не удалось установить userID")
+
+		return nil, nil, fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var rma models.ReturnAuthorization
+	if err := scanReturnAuthorization(tx.QueryRowContext(ctx, qLockReturnAuthorization, id), &rma); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("возврат с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("rma_id", id).
+			Msg(op + ": не удалось заблокировать возврат")
+
+		return nil, nil, fmt.Errorf("не удалось заблокировать возврат: %w", err)
+	}
+
+	lines, err := listReturnLines(ctx, tx, []int{id})
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	rma.Lines = lines[id]
+
+	return tx, &rma, nil
+}
+
+// postReturnStock - проводка принятого item по результату осмотра.
+// Несерийный item при restock возвращается в доступный остаток, при refurbish и quarantine - в статусы
+// запаса damaged и quarantine. Серийные номера переходят в in_stock, returned или scrapped,
+// остаток серийного item пересчитывается по ним.
+func postReturnStock(ctx context.Context, tx *sql.Tx, userID int, inspection models.ReturnInspection, details string) error {
+	var serialized bool
+	if err := tx.QueryRowContext(ctx, qLockSerializedItem, inspection.ItemID).Scan(&serialized); err != nil {
+		return fmt.Errorf("не удалось получить item: %w", err)
+	}
+
+	if !serialized {
+		if len(inspection.Serials) > 0 {
+			return fmt.Errorf("некорректная приемка: item с id %d не является серийным", inspection.ItemID)
+		}
+		switch inspection.Disposition {
+		case models.DispositionScrap:
+			return nil
+		case models.DispositionRefurbish, models.DispositionQuarantine:
+			status := models.StockQuarantine
+			if inspection.Disposition == models.DispositionRefurbish {
+				status = models.StockDamaged
+			}
+			if err := holdStock(ctx, tx, inspection.ItemID, nil, status, inspection.Quantity); err != nil {
+				return err
+			}
+			if err := receiveCost(ctx, tx, inspection.ItemID, inspection.Quantity, nil, details); err != nil {
+				return err
+			}
+
+			return logStockStatusChange(ctx, tx, userID, &models.StockStatusChange{
+				ItemID:   inspection.ItemID,
+				ToStatus: status,
+				Quantity: inspection.Quantity,
+				Reason:   details,
+			})
+		}
+
+		if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+			return fmt.Errorf("не удалось установить детали операции: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, qIncreaseItemQuantity, inspection.ItemID, inspection.Quantity); err != nil {
+			return fmt.Errorf("не удалось вернуть остаток item: %w", err)
+		}
+
+		return receiveCost(ctx, tx, inspection.ItemID, inspection.Quantity, nil, details)
+	}
+
+	if len(inspection.Serials) != inspection.Quantity {
+		return fmt.Errorf("некорректная приемка: для серийного item с id %d нужно %d серийных номеров, передано %d",
+			inspection.ItemID, inspection.Quantity, len(inspection.Serials))
+	}
+
+	serialStatus := "returned"
+	switch inspection.Disposition {
+	case models.DispositionRestock:
+		serialStatus = "in_stock"
+	case models.DispositionScrap:
+		serialStatus = "scrapped"
+	}
+	for _, serialNumber := range inspection.Serials {
+		result, err := tx.ExecContext(ctx, qReturnSerial, inspection.ItemID, serialNumber, serialStatus)
+		if err != nil {
+			return fmt.Errorf("не удалось обновить серийный номер: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			return fmt.Errorf("отгруженный серийный номер %s item %d не найден", serialNumber, inspection.ItemID)
+		}
+	}
+
+	if inspection.Disposition != models.DispositionRestock {
+		return nil
+	}
+	if err := receiveCost(ctx, tx, inspection.ItemID, inspection.Quantity, nil, details); err != nil {
+		return err
+	}
+
+	return syncSerializedQuantity(ctx, tx, inspection.ItemID, details)
+}
+
+// returnLineIndex - индекс строки возврата с item.
+func returnLineIndex(rma *models.ReturnAuthorization, itemID int) (int, error) {
+	for i, line := range rma.Lines {
+		if line.ItemID == itemID {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("item с id %d не найден в возврате %s", itemID, rma.Number)
+}
+
+// listAuthorizedReturns - количество, разрешенное к возврату по строкам заказа, по id строки.
+func listAuthorizedReturns(ctx context.Context, tx *sql.Tx, orderID int) (map[int]int, error) {
+	rows, err := tx.QueryContext(ctx, qListAuthorizedReturns, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listAuthorizedReturns: %w", err)
+	}
+	defer rows.Close()
+
+	authorized := make(map[int]int)
+	for rows.Next() {
+		var lineID, quantity int
+		if err := rows.Scan(&lineID, &quantity); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		authorized[lineID] = quantity
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return authorized, nil
+}
+
+// listReturnReceipts - получение приемок возврата.
+func (r *returnRepo) listReturnReceipts(ctx context.Context, id int) ([]models.ReturnReceipt, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListReturnReceipts,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listReturnReceipts: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []models.ReturnReceipt
+	for rows.Next() {
+		var receipt models.ReturnReceipt
+		var receivedBy sql.NullInt64
+		if err := rows.Scan(
+			&receipt.ID,
+			&receipt.RMAID,
+			&receipt.LineID,
+			&receipt.ItemID,
+			&receipt.Quantity,
+			&receipt.Disposition,
+			pq.Array(&receipt.Serials),
+			&receipt.Note,
+			&receivedBy,
+			&receipt.ReceivedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		receipt.ReceivedBy = nullIntPtr(receivedBy)
+
+		receipts = append(receipts, receipt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return receipts, nil
+}
+
+// listReturnLines - получение строк возвратов, сгруппированных по id возврата.
+func listReturnLines(ctx context.Context, q interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}, ids []int) (map[int][]models.ReturnLine, error) {
+	rows, err := q.QueryContext(ctx, qListReturnLines, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listReturnLines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[int][]models.ReturnLine, len(ids))
+	for rows.Next() {
+		var line models.ReturnLine
+		if err := rows.Scan(
+			&line.ID,
+			&line.RMAID,
+			&line.OrderLineID,
+			&line.ItemID,
+			&line.ItemName,
+			&line.Reason,
+			&line.AuthorizedQty,
+			&line.ReceivedQty,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		lines[line.RMAID] = append(lines[line.RMAID], line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanReturnAuthorization - перевод строки return_authorizations в структуру.
+func scanReturnAuthorization(row interface{ Scan(dest ...any) error }, rma *models.ReturnAuthorization) error {
+	return row.Scan(
+		&rma.ID,
+		&rma.Number,
+		&rma.OrderID,
+		&rma.OrderNumber,
+		&rma.Status,
+		&rma.Note,
+		&rma.CreatedBy,
+		&rma.CreatedAt,
+		&rma.UpdatedAt,
+	)
+}
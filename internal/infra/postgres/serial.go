@@ -0,0 +1,328 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qLockSerializedItem = `
+	SELECT serialized
+	FROM items
+	WHERE id = $1
+	FOR UPDATE`
+
+	qCreateSerial = `
+	INSERT INTO item_serials (item_id, serial_number)
+	VALUES ($1, $2)`
+
+	qSyncSerializedQuantity = `
+	UPDATE items SET quantity = (
+		SELECT COUNT(*)
+		FROM item_serials
+		WHERE item_id = $1 AND serial_status = 'in_stock'
+	), updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qListSerialsByItemID = `
+	SELECT id, item_id, serial_number, serial_status, created_at, updated_at
+	FROM item_serials
+	WHERE item_id = $1 AND ($2::TEXT = '' OR serial_status = $2::TEXT)
+	ORDER BY serial_number`
+
+	qGetSerialByNumber = `
+	SELECT id, item_id, serial_number, serial_status, created_at, updated_at
+	FROM item_serials
+	WHERE serial_number = $1`
+
+	qUpdateSerialStatus = `
+	UPDATE item_serials SET serial_status = $3, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND serial_status = $2`
+)
+
+var _ infra.SerialRepo = (*serialRepo)(nil)
+
+type serialRepo struct {
+	db *dbpg.DB
+}
+
+// CreateSerials - метод для регистрации серийных номеров item на складе.
+// Пересчитывает количество item по числу серийных номеров на складе.
+func (r *serialRepo) CreateSerials(ctx context.Context, userID, itemID int, serialNumbers []string) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("CreateSerials: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("CreateSerials: не удалось установить userID")
+
+		return fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var serialized bool
+	if err := tx.QueryRowContext(ctx, qLockSerializedItem, itemID).Scan(&serialized); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("item с id %d не найден", itemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("CreateSerials: не удалось получить item")
+
+		return fmt.Errorf("не удалось получить item: %w", err)
+	}
+	if !serialized {
+		return fmt.Errorf("item с id %d не является серийным", itemID)
+	}
+
+	for _, serialNumber := range serialNumbers {
+		if _, err := tx.ExecContext(ctx, qCreateSerial, itemID, serialNumber); err != nil {
+			if isUniqueViolation(err) {
+				return fmt.Errorf("серийный номер %s уже существует", serialNumber)
+			}
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", itemID).
+				Str("serial_number", serialNumber).
+				Msg("CreateSerials: не удалось зарегистрировать серийный номер")
+
+			return fmt.Errorf("не удалось зарегистрировать серийный номер: %w", err)
+		}
+	}
+
+	details := "регистрация серийных номеров: " + strings.Join(serialNumbers, ", ")
+	if err := syncSerializedQuantity(ctx, tx, itemID, details); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("CreateSerials: не удалось пересчитать количество item")
+
+		return err
+	}
+
+	if err := receiveCost(ctx, tx, itemID, len(serialNumbers), nil, details); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("CreateSerials: не удалось учесть себестоимость")
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("CreateSerials: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// GetSerialsByItemID - метод для получения серийных номеров item, при непустом status - только в этом статусе.
+func (r *serialRepo) GetSerialsByItemID(ctx context.Context, itemID int, status string) ([]models.Serial, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListSerialsByItemID,
+		itemID,
+		status,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetSerialsByItemID: не удалось выполнить запрос GetSerialsByItemID")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetSerialsByItemID: %w", err)
+	}
+	defer rows.Close()
+
+	var serials []models.Serial
+	for rows.Next() {
+		var serial models.Serial
+		if err := rows.Scan(
+			&serial.ID,
+			&serial.ItemID,
+			&serial.SerialNumber,
+			&serial.Status,
+			&serial.CreatedAt,
+			&serial.UpdatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", itemID).
+				Msg("GetSerialsByItemID: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		serials = append(serials, serial)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetSerialsByItemID: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return serials, nil
+}
+
+// GetSerialByNumber - метод для поиска серийного номера.
+func (r *serialRepo) GetSerialByNumber(ctx context.Context, serialNumber string) (*models.Serial, error) {
+	var serial models.Serial
+	if err := r.db.QueryRowContext(ctx, qGetSerialByNumber, serialNumber).Scan(
+		&serial.ID,
+		&serial.ItemID,
+		&serial.SerialNumber,
+		&serial.Status,
+		&serial.CreatedAt,
+		&serial.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("серийный номер %s не найден", serialNumber)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Str("serial_number", serialNumber).
+			Msg("GetSerialByNumber: не удалось выполнить запрос GetSerialByNumber")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetSerialByNumber: %w", err)
+	}
+
+	return &serial, nil
+}
+
+// UpdateSerialStatus - метод для перевода серийного номера из текущего статуса в status.
+// Пересчитывает количество item по числу серийных номеров на складе.
+func (r *serialRepo) UpdateSerialStatus(ctx context.Context, userID int, serial *models.Serial, status string) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Str("serial_number", serial.SerialNumber).
+			Msg("UpdateSerialStatus: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("UpdateSerialStatus: не удалось установить userID")
+
+		return fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, qUpdateSerialStatus, serial.ID, serial.Status, status)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("serial_number", serial.SerialNumber).
+			Msg("UpdateSerialStatus: не удалось изменить статус серийного номера")
+
+		return fmt.Errorf("не удалось изменить статус серийного номера: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество строк, обновленных запросом UpdateSerialStatus: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("статус серийного номера %s уже изменен другим пользователем", serial.SerialNumber)
+	}
+
+	details := fmt.Sprintf("серийный номер %s: %s -> %s", serial.SerialNumber, serial.Status, status)
+	if err := syncSerializedQuantity(ctx, tx, serial.ItemID, details); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", serial.ItemID).
+			Msg("UpdateSerialStatus: не удалось пересчитать количество item")
+
+		return err
+	}
+
+	// Номер, ушедший со склада или вернувшийся на него, меняет запас на единицу.
+	delta := 0
+	if serial.Status == models.SerialInStock {
+		delta--
+	}
+	if status == models.SerialInStock {
+		delta++
+	}
+	if err := adjustCost(ctx, tx, serial.ItemID, delta, details); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", serial.ItemID).
+			Msg("UpdateSerialStatus: не удалось учесть себестоимость")
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Str("serial_number", serial.SerialNumber).
+			Msg("UpdateSerialStatus: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// syncSerializedQuantity - приведение количества серийного item к числу его серийных номеров на складе.
+func syncSerializedQuantity(ctx context.Context, tx *sql.Tx, itemID int, details string) error {
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		return fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qSyncSerializedQuantity, itemID); err != nil {
+		return fmt.Errorf("не удалось пересчитать количество item: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,332 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qUpsertStockLevel = `
+	INSERT INTO stock_levels (item_id, location_id, min_qty, reorder_point, max_qty)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (item_id, (COALESCE(location_id, 0))) DO UPDATE SET
+		min_qty = EXCLUDED.min_qty,
+		reorder_point = EXCLUDED.reorder_point,
+		max_qty = EXCLUDED.max_qty,
+		updated_at = CURRENT_TIMESTAMP`
+
+	// Уровни по ячейке задаются только для ячейки подбора item, где хранится весь доступный запас,
+	// поэтому остаток и резервы у них те же, что и у уровней на весь склад.
+	qListStockPositions = `
+	SELECT s.item_id, s.location_id, COALESCE(loc.code, ''), s.min_qty, s.reorder_point, s.max_qty, s.updated_at,
+		i.item_name, i.quantity, COALESCE(r.reserved, 0)
+	FROM stock_levels s
+	JOIN items i ON i.id = s.item_id
+	LEFT JOIN locations loc ON loc.id = s.location_id
+	LEFT JOIN (
+		SELECT item_id, SUM(quantity) AS reserved
+		FROM reservations
+		WHERE reservation_status = 'active'
+		GROUP BY item_id
+	) r ON r.item_id = i.id
+	WHERE ($1::INT IS NULL OR s.item_id = $1)
+	ORDER BY s.item_id, s.location_id NULLS FIRST`
+
+	// Повторное оповещение не создается, пока активно предыдущее по той же ячейке (uq_stock_alerts_active_item_location).
+	qRaiseStockAlert = `
+	INSERT INTO stock_alerts (item_id, location_id, available, reorder_point)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (item_id, (COALESCE(location_id, 0))) WHERE alert_status IN ('open', 'acknowledged') DO NOTHING
+	RETURNING id`
+
+	qResolveStockAlerts = `
+	UPDATE stock_alerts SET alert_status = 'resolved', resolved_at = CURRENT_TIMESTAMP
+	WHERE item_id = $1 AND location_id IS NOT DISTINCT FROM $2 AND alert_status IN ('open', 'acknowledged')`
+
+	qListStockAlerts = `
+	SELECT a.id, a.item_id, i.item_name, a.location_id, COALESCE(loc.code, ''), a.available, a.reorder_point,
+		a.alert_status, a.acknowledged_by, a.acknowledged_at, a.resolved_at, a.created_at
+	FROM stock_alerts a
+	JOIN items i ON i.id = a.item_id
+	LEFT JOIN locations loc ON loc.id = a.location_id
+	WHERE ($1::TEXT = '' OR a.alert_status = $1)
+	ORDER BY a.id DESC`
+
+	qAcknowledgeStockAlert = `
+	UPDATE stock_alerts SET alert_status = 'acknowledged', acknowledged_by = $2, acknowledged_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND alert_status = 'open'`
+
+	qGetStockAlertStatus = `
+	SELECT alert_status
+	FROM stock_alerts
+	WHERE id = $1`
+
+	stockLevelItemConstraint     = "stock_levels_item_id_fkey"
+	stockLevelLocationConstraint = "stock_levels_location_id_fkey"
+)
+
+var _ infra.StockRepo = (*stockRepo)(nil)
+
+type stockRepo struct {
+	db *dbpg.DB
+}
+
+// SetStockLevel - метод для задания уровней запаса item на весь склад или на ячейку.
+func (r *stockRepo) SetStockLevel(ctx context.Context, level *models.StockLevel) error {
+	if _, err := r.db.Master.ExecContext(
+		ctx,
+		qUpsertStockLevel,
+		level.ItemID,
+		level.LocationID,
+		level.MinQty,
+		level.ReorderPoint,
+		level.MaxQty,
+	); err != nil {
+		if isForeignKeyViolationOn(err, stockLevelItemConstraint) {
+			return fmt.Errorf("item с id %d не найден", level.ItemID)
+		}
+		if isForeignKeyViolationOn(err, stockLevelLocationConstraint) {
+			return fmt.Errorf("ячейка с id %d не найдена", *level.LocationID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", level.ItemID).
+			Msg("SetStockLevel: не удалось сохранить уровни запаса")
+
+		return fmt.Errorf("не удалось сохранить уровни запаса: %w", err)
+	}
+
+	return nil
+}
+
+// ListStockPositions - метод для получения остатков items по всем заданным уровням запаса:
+// сначала уровни на весь склад, затем по ячейкам. При itemID == nil возвращаются все такие items.
+func (r *stockRepo) ListStockPositions(ctx context.Context, itemID *int) ([]models.StockPosition, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListStockPositions,
+		itemID,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListStockPositions: не удалось выполнить запрос ListStockPositions")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListStockPositions: %w", err)
+	}
+	defer rows.Close()
+
+	var positions []models.StockPosition
+	for rows.Next() {
+		var pos models.StockPosition
+		var locationID sql.NullInt64
+		if err := rows.Scan(
+			&pos.ItemID,
+			&locationID,
+			&pos.LocationCode,
+			&pos.MinQty,
+			&pos.ReorderPoint,
+			&pos.MaxQty,
+			&pos.UpdatedAt,
+			&pos.ItemName,
+			&pos.Quantity,
+			&pos.Reserved,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListStockPositions: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		pos.LocationID = nullIntPtr(locationID)
+
+		positions = append(positions, pos)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListStockPositions: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return positions, nil
+}
+
+// RaiseStockAlert - метод для создания оповещения о пересечении точки заказа.
+// Возвращает false, если по item в той же ячейке (или на весь склад) уже есть активное оповещение.
+func (r *stockRepo) RaiseStockAlert(ctx context.Context, pos models.StockPosition) (bool, error) {
+	var id int
+	if err := r.db.Master.QueryRowContext(
+		ctx,
+		qRaiseStockAlert,
+		pos.ItemID,
+		pos.LocationID,
+		pos.Available(),
+		pos.ReorderPoint,
+	).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", pos.ItemID).
+			Msg("RaiseStockAlert: не удалось создать оповещение")
+
+		return false, fmt.Errorf("не удалось создать оповещение: %w", err)
+	}
+
+	return true, nil
+}
+
+// ResolveStockAlerts - метод для закрытия активных оповещений item после пополнения
+// на весь склад при locationID == nil или в ячейке locationID.
+func (r *stockRepo) ResolveStockAlerts(ctx context.Context, itemID int, locationID *int) (int, error) {
+	result, err := r.db.Master.ExecContext(ctx, qResolveStockAlerts, itemID, locationID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("ResolveStockAlerts: не удалось закрыть оповещения")
+
+		return 0, fmt.Errorf("не удалось закрыть оповещения: %w", err)
+	}
+
+	resolved, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("не удалось получить количество закрытых оповещений: %w", err)
+	}
+
+	return int(resolved), nil
+}
+
+// ListStockAlerts - метод для получения оповещений по статусу, при пустом статусе - всех.
+func (r *stockRepo) ListStockAlerts(ctx context.Context, status string) ([]models.StockAlert, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListStockAlerts,
+		status,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("status", status).
+			Msg("ListStockAlerts: не удалось выполнить запрос ListStockAlerts")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListStockAlerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []models.StockAlert
+	for rows.Next() {
+		var alert models.StockAlert
+		var locationID, acknowledgedBy sql.NullInt64
+		var acknowledgedAt, resolvedAt sql.NullTime
+		if err := rows.Scan(
+			&alert.ID,
+			&alert.ItemID,
+			&alert.ItemName,
+			&locationID,
+			&alert.LocationCode,
+			&alert.Available,
+			&alert.ReorderPoint,
+			&alert.Status,
+			&acknowledgedBy,
+			&acknowledgedAt,
+			&resolvedAt,
+			&alert.CreatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListStockAlerts: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		alert.LocationID = nullIntPtr(locationID)
+		if acknowledgedBy.Valid {
+			userID := int(acknowledgedBy.Int64)
+			alert.AcknowledgedBy = &userID
+		}
+		if acknowledgedAt.Valid {
+			alert.AcknowledgedAt = &acknowledgedAt.Time
+		}
+		if resolvedAt.Valid {
+			alert.ResolvedAt = &resolvedAt.Time
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListStockAlerts: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// AcknowledgeStockAlert - метод для подтверждения открытого оповещения пользователем.
+func (r *stockRepo) AcknowledgeStockAlert(ctx context.Context, userID, id int) error {
+	result, err := r.db.Master.ExecContext(ctx, qAcknowledgeStockAlert, id, userID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("alert_id", id).
+			Msg("AcknowledgeStockAlert: не удалось подтвердить оповещение")
+
+		return fmt.Errorf("не удалось подтвердить оповещение: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	var status string
+	if err := r.db.Master.QueryRowContext(ctx, qGetStockAlertStatus, id).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("оповещение с id %d не найдено", id)
+		}
+
+		return fmt.Errorf("не удалось получить статус оповещения: %w", err)
+	}
+
+	return fmt.Errorf("нельзя подтвердить оповещение %d в статусе %s", id, status)
+}
+
+// nullIntPtr - перевод nullable INTEGER в указатель.
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int64)
+
+	return &i
+}
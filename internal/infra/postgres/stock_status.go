@@ -0,0 +1,335 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qHoldStock = `
+	INSERT INTO item_stock_statuses (item_id, location_id, stock_status, quantity)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (item_id, (COALESCE(location_id, 0)), stock_status)
+	DO UPDATE SET quantity = item_stock_statuses.quantity + EXCLUDED.quantity, updated_at = CURRENT_TIMESTAMP`
+
+	qReleaseHeldStock = `
+	UPDATE item_stock_statuses SET quantity = quantity - $4, updated_at = CURRENT_TIMESTAMP
+	WHERE item_id = $1 AND COALESCE(location_id, 0) = COALESCE($2, 0) AND stock_status = $3 AND quantity >= $4`
+
+	qDeleteEmptyHeldStock = `
+	DELETE FROM item_stock_statuses
+	WHERE item_id = $1 AND quantity = 0`
+
+	qCreateStockStatusChange = `
+	INSERT INTO stock_status_changes (item_id, location_id, from_status, to_status, quantity, reason, user_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	qGetItemQuantity = `
+	SELECT quantity
+	FROM items
+	WHERE id = $1`
+
+	qListHeldStock = `
+	SELECT s.item_id, s.location_id, COALESCE(loc.code, ''), s.stock_status, s.quantity, s.updated_at
+	FROM item_stock_statuses s
+	LEFT JOIN locations loc ON loc.id = s.location_id
+	WHERE s.item_id = $1 AND s.quantity > 0
+	ORDER BY s.stock_status, loc.code NULLS LAST`
+
+	qListStockStatusChanges = `
+	SELECT id, item_id, location_id, from_status, to_status, quantity, reason, user_id, created_at
+	FROM stock_status_changes
+	WHERE item_id = $1
+	ORDER BY id DESC`
+
+	stockStatusLocationConstraint = "item_stock_statuses_location_id_fkey"
+)
+
+var _ infra.StockStatusRepo = (*stockStatusRepo)(nil)
+
+type stockStatusRepo struct {
+	db *dbpg.DB
+}
+
+// ChangeStockStatus - метод для перевода запаса item между статусами с записью в журнал.
+// Из доступного остатка переводится только незарезервированное количество.
+func (r *stockStatusRepo) ChangeStockStatus(ctx context.Context, userID int, change *models.StockStatusChange) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", change.ItemID).
+			Msg("ChangeStockStatus: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(qSetUserID, userID)); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("ChangeStockStatus: не удалось установить userID")
+
+		return fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var serialized bool
+	if err := tx.QueryRowContext(ctx, qLockSerializedItem, change.ItemID).Scan(&serialized); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("item с id %d не найден", change.ItemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", change.ItemID).
+			Msg("ChangeStockStatus: не удалось заблокировать item")
+
+		return fmt.Errorf("не удалось заблокировать item: %w", err)
+	}
+	if serialized {
+		return fmt.Errorf("нельзя менять статус запаса серийного item %d: статус ведется по серийным номерам", change.ItemID)
+	}
+
+	details := fmt.Sprintf("статус запаса %s → %s: %s", change.FromStatus, change.ToStatus, change.Reason)
+	if change.FromStatus == models.StockAvailable {
+		if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+			return fmt.Errorf("не удалось установить детали операции: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx, qDecreaseItemAvailable, change.ItemID, change.Quantity)
+		if err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", change.ItemID).
+				Msg("ChangeStockStatus: не удалось уменьшить доступный остаток")
+
+			return fmt.Errorf("не удалось уменьшить доступный остаток: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			return fmt.Errorf("недостаточно доступного незарезервированного остатка item %d для перевода %d", change.ItemID, change.Quantity)
+		}
+	} else if err := releaseHeldStock(ctx, tx, change.ItemID, change.LocationID, change.FromStatus, change.Quantity); err != nil {
+		return err
+	}
+
+	if change.ToStatus == models.StockAvailable {
+		if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+			return fmt.Errorf("не удалось установить детали операции: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, qIncreaseItemQuantity, change.ItemID, change.Quantity); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", change.ItemID).
+				Msg("ChangeStockStatus: не удалось увеличить доступный остаток")
+
+			return fmt.Errorf("не удалось увеличить доступный остаток: %w", err)
+		}
+	} else if err := holdStock(ctx, tx, change.ItemID, change.LocationID, change.ToStatus, change.Quantity); err != nil {
+		return err
+	}
+
+	if err := logStockStatusChange(ctx, tx, userID, change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", change.ItemID).
+			Msg("ChangeStockStatus: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// GetItemStock - метод для получения запаса item по статусам и ячейкам вместе с журналом смены статусов.
+func (r *stockStatusRepo) GetItemStock(ctx context.Context, itemID int) (*models.ItemStock, error) {
+	stock := models.ItemStock{ItemID: itemID}
+	if err := r.db.QueryRowContext(ctx, qGetItemQuantity, itemID).Scan(&stock.Available); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("item с id %d не найден", itemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetItemStock: не удалось получить остаток item")
+
+		return nil, fmt.Errorf("не удалось получить остаток item: %w", err)
+	}
+
+	var err error
+	if stock.Held, err = r.listHeldStock(ctx, itemID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetItemStock: не удалось получить запас по статусам")
+
+		return nil, err
+	}
+
+	if stock.Changes, err = r.listStockStatusChanges(ctx, itemID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetItemStock: не удалось получить журнал смены статусов")
+
+		return nil, err
+	}
+
+	return &stock, nil
+}
+
+// holdStock - добавление запаса item в статус вне доступного остатка.
+func holdStock(ctx context.Context, tx *sql.Tx, itemID int, locationID *int, status string, quantity int) error {
+	if _, err := tx.ExecContext(ctx, qHoldStock, itemID, locationID, status, quantity); err != nil {
+		if isForeignKeyViolationOn(err, stockStatusLocationConstraint) {
+			return fmt.Errorf("ячейка с id %d не найдена", *locationID)
+		}
+
+		return fmt.Errorf("не удалось перевести запас в статус %s: %w", status, err)
+	}
+
+	return nil
+}
+
+// releaseHeldStock - списание запаса item из статуса вне доступного остатка.
+func releaseHeldStock(ctx context.Context, tx *sql.Tx, itemID int, locationID *int, status string, quantity int) error {
+	result, err := tx.ExecContext(ctx, qReleaseHeldStock, itemID, locationID, status, quantity)
+	if err != nil {
+		return fmt.Errorf("не удалось списать запас из статуса %s: %w", status, err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	} else if affected == 0 {
+		return fmt.Errorf("недостаточно запаса item %d в статусе %s для перевода %d", itemID, status, quantity)
+	}
+
+	if _, err := tx.ExecContext(ctx, qDeleteEmptyHeldStock, itemID); err != nil {
+		return fmt.Errorf("не удалось удалить пустые строки запаса: %w", err)
+	}
+
+	return nil
+}
+
+// logStockStatusChange - запись перевода запаса в журнал смены статусов.
+func logStockStatusChange(ctx context.Context, tx *sql.Tx, userID int, change *models.StockStatusChange) error {
+	if _, err := tx.ExecContext(
+		ctx,
+		qCreateStockStatusChange,
+		change.ItemID,
+		change.LocationID,
+		change.FromStatus,
+		change.ToStatus,
+		change.Quantity,
+		change.Reason,
+		userID,
+	); err != nil {
+		return fmt.Errorf("не удалось записать журнал смены статуса: %w", err)
+	}
+
+	return nil
+}
+
+// listHeldStock - получение запаса item в статусах вне доступного остатка.
+func (r *stockStatusRepo) listHeldStock(ctx context.Context, itemID int) ([]models.StockStatusQty, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListHeldStock,
+		itemID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listHeldStock: %w", err)
+	}
+	defer rows.Close()
+
+	var held []models.StockStatusQty
+	for rows.Next() {
+		var qty models.StockStatusQty
+		var locationID sql.NullInt64
+		if err := rows.Scan(
+			&qty.ItemID,
+			&locationID,
+			&qty.LocationCode,
+			&qty.Status,
+			&qty.Quantity,
+			&qty.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		qty.LocationID = nullIntPtr(locationID)
+
+		held = append(held, qty)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return held, nil
+}
+
+// listStockStatusChanges - получение журнала смены статусов запаса item, новые записи первыми.
+func (r *stockStatusRepo) listStockStatusChanges(ctx context.Context, itemID int) ([]models.StockStatusChange, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListStockStatusChanges,
+		itemID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listStockStatusChanges: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []models.StockStatusChange
+	for rows.Next() {
+		var change models.StockStatusChange
+		var locationID, userID sql.NullInt64
+		if err := rows.Scan(
+			&change.ID,
+			&change.ItemID,
+			&locationID,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.Quantity,
+			&change.Reason,
+			&userID,
+			&change.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		change.LocationID = nullIntPtr(locationID)
+		change.UserID = nullIntPtr(userID)
+
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return changes, nil
+}
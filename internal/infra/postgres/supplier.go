@@ -0,0 +1,432 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	// Пустая валюта - базовая.
+	qCreateSupplier = `
+	INSERT INTO suppliers (supplier_name, contact_name, email, phone, address, lead_time_days, supplier_status, currency)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), (SELECT code FROM currencies WHERE is_base)))
+	RETURNING id`
+
+	qSupplierColumns = `
+	SELECT id, supplier_name, contact_name, email, phone, address, lead_time_days, supplier_status, currency,
+		created_at, updated_at
+	FROM suppliers`
+
+	qListSuppliers = qSupplierColumns + `
+	WHERE ($1::TEXT = '' OR supplier_status = $1)
+	ORDER BY supplier_name`
+
+	qGetSupplierByID = qSupplierColumns + `
+	WHERE id = $1`
+
+	// Пустая валюта оставляет прежнюю.
+	qUpdateSupplier = `
+	UPDATE suppliers SET supplier_name = $2, contact_name = $3, email = $4, phone = $5, address = $6,
+		lead_time_days = $7, supplier_status = $8, currency = COALESCE(NULLIF($9, ''), currency),
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qDeleteSupplier = `
+	DELETE FROM suppliers
+	WHERE id = $1`
+
+	// Основной поставщик у item один: перед назначением нового снимается флаг с прежнего.
+	qClearPreferredSupplier = `
+	UPDATE item_suppliers SET preferred = FALSE, updated_at = CURRENT_TIMESTAMP
+	WHERE item_id = $1 AND supplier_id <> $2 AND preferred`
+
+	qUpsertItemSupplier = `
+	INSERT INTO item_suppliers (item_id, supplier_id, supplier_sku, unit_cost, min_order_qty, preferred)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (item_id, supplier_id) DO UPDATE SET
+		supplier_sku = EXCLUDED.supplier_sku,
+		unit_cost = EXCLUDED.unit_cost,
+		min_order_qty = EXCLUDED.min_order_qty,
+		preferred = EXCLUDED.preferred,
+		updated_at = CURRENT_TIMESTAMP`
+
+	qDeleteItemSupplier = `
+	DELETE FROM item_suppliers
+	WHERE item_id = $1 AND supplier_id = $2`
+
+	qItemSupplierColumns = `
+	SELECT l.item_id, i.item_name, l.supplier_id, s.supplier_name, l.supplier_sku, l.unit_cost, s.currency,
+		l.min_order_qty, l.preferred, s.lead_time_days, l.updated_at
+	FROM item_suppliers l
+	JOIN items i ON i.id = l.item_id
+	JOIN suppliers s ON s.id = l.supplier_id`
+
+	qListItemSuppliers = qItemSupplierColumns + `
+	WHERE l.item_id = $1
+	ORDER BY l.preferred DESC, s.supplier_name`
+
+	qListSupplierItems = qItemSupplierColumns + `
+	WHERE l.supplier_id = $1
+	ORDER BY i.item_name`
+
+	supplierNameConstraint          = "uq_suppliers_name"
+	supplierCurrencyConstraint      = "suppliers_currency_fkey"
+	itemSupplierItemConstraint      = "item_suppliers_item_id_fkey"
+	itemSupplierSupplierConstraint  = "item_suppliers_supplier_id_fkey"
+	itemSupplierPreferredConstraint = "uq_item_suppliers_preferred"
+)
+
+var _ infra.SupplierRepo = (*supplierRepo)(nil)
+
+type supplierRepo struct {
+	db *dbpg.DB
+}
+
+// CreateSupplier - метод для создания поставщика.
+func (r *supplierRepo) CreateSupplier(ctx context.Context, sup *models.Supplier) (int, error) {
+	var id int
+	if err := r.db.Master.QueryRowContext(
+		ctx,
+		qCreateSupplier,
+		sup.Name,
+		sup.ContactName,
+		sup.Email,
+		sup.Phone,
+		sup.Address,
+		sup.LeadTimeDays,
+		sup.Status,
+		sup.Currency,
+	).Scan(&id); err != nil {
+		switch {
+		case isUniqueViolationOn(err, supplierNameConstraint):
+			return 0, fmt.Errorf("поставщик %s уже существует", sup.Name)
+		case isForeignKeyViolationOn(err, supplierCurrencyConstraint):
+			return 0, fmt.Errorf("валюта %s не найдена", sup.Currency)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Str("supplier_name", sup.Name).
+			Msg("CreateSupplier: не удалось создать поставщика")
+
+		return 0, fmt.Errorf("не удалось создать поставщика: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListSuppliers - метод для получения поставщиков по статусу, при пустом статусе - всех.
+func (r *supplierRepo) ListSuppliers(ctx context.Context, status string) ([]models.Supplier, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListSuppliers,
+		status,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("status", status).
+			Msg("ListSuppliers: не удалось выполнить запрос ListSuppliers")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListSuppliers: %w", err)
+	}
+	defer rows.Close()
+
+	var suppliers []models.Supplier
+	for rows.Next() {
+		var sup models.Supplier
+		if err := scanSupplier(rows, &sup); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListSuppliers: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		suppliers = append(suppliers, sup)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListSuppliers: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return suppliers, nil
+}
+
+// GetSupplierByID - метод для получения поставщика по id.
+func (r *supplierRepo) GetSupplierByID(ctx context.Context, id int) (*models.Supplier, error) {
+	var sup models.Supplier
+	if err := scanSupplier(r.db.QueryRowContext(ctx, qGetSupplierByID, id), &sup); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("поставщик с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("supplier_id", id).
+			Msg("GetSupplierByID: не удалось выполнить запрос GetSupplierByID")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetSupplierByID: %w", err)
+	}
+
+	return &sup, nil
+}
+
+// UpdateSupplier - метод для обновления карточки поставщика.
+func (r *supplierRepo) UpdateSupplier(ctx context.Context, sup *models.Supplier) error {
+	result, err := r.db.Master.ExecContext(
+		ctx,
+		qUpdateSupplier,
+		sup.ID,
+		sup.Name,
+		sup.ContactName,
+		sup.Email,
+		sup.Phone,
+		sup.Address,
+		sup.LeadTimeDays,
+		sup.Status,
+		sup.Currency,
+	)
+	if err != nil {
+		switch {
+		case isUniqueViolationOn(err, supplierNameConstraint):
+			return fmt.Errorf("поставщик %s уже существует", sup.Name)
+		case isForeignKeyViolationOn(err, supplierCurrencyConstraint):
+			return fmt.Errorf("валюта %s не найдена", sup.Currency)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("supplier_id", sup.ID).
+			Msg("UpdateSupplier: не удалось обновить поставщика")
+
+		return fmt.Errorf("не удалось обновить поставщика: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("поставщик с id %d не найден", sup.ID)
+	}
+
+	return nil
+}
+
+// DeleteSupplier - метод для удаления поставщика вместе с его связями с items.
+func (r *supplierRepo) DeleteSupplier(ctx context.Context, id int) error {
+	result, err := r.db.Master.ExecContext(ctx, qDeleteSupplier, id)
+	if err != nil {
+		if isForeignKeyViolationOn(err, purchaseOrderSupplierConstraint) {
+			return fmt.Errorf("нельзя удалить поставщика %d: по нему есть заказы на закупку", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("supplier_id", id).
+			Msg("DeleteSupplier: не удалось удалить поставщика")
+
+		return fmt.Errorf("не удалось удалить поставщика: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество удаленных строк: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("поставщик с id %d не найден", id)
+	}
+
+	return nil
+}
+
+// SetItemSupplier - метод для создания или обновления связи item с поставщиком.
+// При Preferred == true поставщик становится основным для item вместо прежнего.
+func (r *supplierRepo) SetItemSupplier(ctx context.Context, link *models.ItemSupplier) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", link.ItemID).
+			Int("supplier_id", link.SupplierID).
+			Msg("SetItemSupplier: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	if link.Preferred {
+		if _, err := tx.ExecContext(ctx, qClearPreferredSupplier, link.ItemID, link.SupplierID); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", link.ItemID).
+				Msg("SetItemSupplier: не удалось снять признак основного поставщика")
+
+			return fmt.Errorf("не удалось снять признак основного поставщика: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		qUpsertItemSupplier,
+		link.ItemID,
+		link.SupplierID,
+		link.SupplierSKU,
+		link.UnitCost,
+		link.MinOrderQty,
+		link.Preferred,
+	); err != nil {
+		switch {
+		case isForeignKeyViolationOn(err, itemSupplierItemConstraint):
+			return fmt.Errorf("item с id %d не найден", link.ItemID)
+		case isForeignKeyViolationOn(err, itemSupplierSupplierConstraint):
+			return fmt.Errorf("поставщик с id %d не найден", link.SupplierID)
+		case isUniqueViolationOn(err, itemSupplierPreferredConstraint):
+			return fmt.Errorf("основной поставщик item %d уже существует", link.ItemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", link.ItemID).
+			Int("supplier_id", link.SupplierID).
+			Msg("SetItemSupplier: не удалось сохранить связь с поставщиком")
+
+		return fmt.Errorf("не удалось сохранить связь с поставщиком: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", link.ItemID).
+			Int("supplier_id", link.SupplierID).
+			Msg("SetItemSupplier: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveItemSupplier - метод для удаления связи item с поставщиком.
+func (r *supplierRepo) RemoveItemSupplier(ctx context.Context, itemID, supplierID int) error {
+	result, err := r.db.Master.ExecContext(ctx, qDeleteItemSupplier, itemID, supplierID)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Int("supplier_id", supplierID).
+			Msg("RemoveItemSupplier: не удалось удалить связь с поставщиком")
+
+		return fmt.Errorf("не удалось удалить связь с поставщиком: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("не удалось получить количество удаленных строк: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("связь item %d с поставщиком %d не найдена", itemID, supplierID)
+	}
+
+	return nil
+}
+
+// ListItemSuppliers - метод для получения поставщиков item, основной - первым.
+func (r *supplierRepo) ListItemSuppliers(ctx context.Context, itemID int) ([]models.ItemSupplier, error) {
+	return r.listItemSupplierLinks(ctx, "ListItemSuppliers", qListItemSuppliers, itemID)
+}
+
+// ListSupplierItems - метод для получения items, поставляемых поставщиком.
+func (r *supplierRepo) ListSupplierItems(ctx context.Context, supplierID int) ([]models.ItemSupplier, error) {
+	return r.listItemSupplierLinks(ctx, "ListSupplierItems", qListSupplierItems, supplierID)
+}
+
+// listItemSupplierLinks - общий код выборки связей items с поставщиками.
+func (r *supplierRepo) listItemSupplierLinks(ctx context.Context, op, query string, id int) ([]models.ItemSupplier, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		query,
+		id,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("id", id).
+			Msg(op + ": не удалось выполнить запрос " + op)
+
+		return nil, fmt.Errorf("не удалось выполнить запрос %s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var links []models.ItemSupplier
+	for rows.Next() {
+		var link models.ItemSupplier
+		if err := rows.Scan(
+			&link.ItemID,
+			&link.ItemName,
+			&link.SupplierID,
+			&link.SupplierName,
+			&link.SupplierSKU,
+			&link.UnitCost,
+			&link.Currency,
+			&link.MinOrderQty,
+			&link.Preferred,
+			&link.LeadTimeDays,
+			&link.UpdatedAt,
+		); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg(op + ": не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg(op + ": не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return links, nil
+}
+
+// scanSupplier - перевод строки suppliers в структуру.
+func scanSupplier(row interface{ Scan(dest ...any) error }, sup *models.Supplier) error {
+	return row.Scan(
+		&sup.ID,
+		&sup.Name,
+		&sup.ContactName,
+		&sup.Email,
+		&sup.Phone,
+		&sup.Address,
+		&sup.LeadTimeDays,
+		&sup.Status,
+		&sup.Currency,
+		&sup.CreatedAt,
+		&sup.UpdatedAt,
+	)
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qLockItemBaseUnit = `
+	SELECT base_unit, quantity
+	FROM items
+	WHERE id = $1
+	FOR UPDATE`
+
+	qUpdateItemBaseUnit = `
+	UPDATE items SET base_unit = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	qDeleteItemUnits = `
+	DELETE FROM item_units
+	WHERE item_id = $1`
+
+	qCreateItemUnit = `
+	INSERT INTO item_units (item_id, unit_code, factor)
+	VALUES ($1, $2, $3)`
+)
+
+var _ infra.UnitRepo = (*unitRepo)(nil)
+
+type unitRepo struct {
+	db *dbpg.DB
+}
+
+// SetItemUnits - метод для замены базовой единицы и уровней упаковки item.
+// Базовую единицу можно изменить только при нулевом остатке, иначе изменится смысл quantity.
+func (r *unitRepo) SetItemUnits(ctx context.Context, userID, itemID int, baseUnit string, units []models.ItemUnit) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("SetItemUnits: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("SetItemUnits: не удалось установить userID")
+
+		return fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var currentBaseUnit string
+	var quantity int
+	if err := tx.QueryRowContext(ctx, qLockItemBaseUnit, itemID).Scan(&currentBaseUnit, &quantity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("item с id %d не найден", itemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemUnits: не удалось получить item")
+
+		return fmt.Errorf("не удалось получить item: %w", err)
+	}
+	if currentBaseUnit != baseUnit && quantity != 0 {
+		return fmt.Errorf("нельзя изменить базовую единицу item %d с %s на %s при ненулевом остатке", itemID, currentBaseUnit, baseUnit)
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, unitsDetails(baseUnit, units)); err != nil {
+		return fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qUpdateItemBaseUnit, itemID, baseUnit); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemUnits: не удалось обновить базовую единицу")
+
+		return fmt.Errorf("не удалось обновить базовую единицу: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, qDeleteItemUnits, itemID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemUnits: не удалось удалить единицы измерения")
+
+		return fmt.Errorf("не удалось удалить единицы измерения: %w", err)
+	}
+
+	for _, unit := range units {
+		if _, err := tx.ExecContext(ctx, qCreateItemUnit, itemID, unit.Code, unit.Factor); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("item_id", itemID).
+				Str("unit_code", unit.Code).
+				Msg("SetItemUnits: не удалось сохранить единицу измерения")
+
+			return fmt.Errorf("не удалось сохранить единицу измерения %s: %w", unit.Code, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("SetItemUnits: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// unitsDetails - описание единиц измерения для истории, например "единицы измерения: each; case = 12 each".
+func unitsDetails(baseUnit string, units []models.ItemUnit) string {
+	parts := make([]string, 0, len(units))
+	for _, unit := range units {
+		parts = append(parts, unit.Code+" = "+strconv.FormatFloat(unit.Factor, 'f', -1, 64)+" "+baseUnit)
+	}
+
+	details := "единицы измерения: " + baseUnit
+	if len(parts) > 0 {
+		details += "; " + strings.Join(parts, ", ")
+	}
+
+	return details
+}
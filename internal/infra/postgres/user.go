@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/wb-go/wbf/dbpg"
@@ -18,6 +19,15 @@ const (
 	SELECT id, username, password_hash, user_role
 	FROM users
 	WHERE username = $1`
+
+	qGetUserByID = `
+	SELECT id, username, user_role
+	FROM users
+	WHERE id = $1`
+
+	qSetUserRole = `
+	UPDATE users SET user_role = $2
+	WHERE id = $1`
 )
 
 var _ infra.UserRepo = (*userRepo)(nil)
@@ -65,3 +75,49 @@ func (r *userRepo) GetByUsername(ctx context.Context, username string) (*models.
 
 	return &user, nil
 }
+
+// GetUserByID - метод для получения пользователя по id без хеша пароля.
+func (r *userRepo) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	var user models.User
+	if err := r.db.QueryRowContext(ctx, qGetUserByID, id).Scan(&user.ID, &user.Username, &user.Role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("пользователь с id %d не найден", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("target_user_id", id).
+			Msg("GetUserByID: не удалось выполнить запрос GetUserByID")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetUserByID: %w", err)
+	}
+
+	return &user, nil
+}
+
+// SetUserRole - метод для смены роли пользователя.
+func (r *userRepo) SetUserRole(ctx context.Context, id int, role string) error {
+	return setUserRole(ctx, r.db.Master, id, role)
+}
+
+// setUserRole - смена роли пользователя в транзакции или вне ее.
+func setUserRole(ctx context.Context, q interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}, id int, role string) error {
+	result, err := q.ExecContext(ctx, qSetUserRole, id, role)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("target_user_id", id).
+			Str("role", role).
+			Msg("setUserRole: не удалось сменить роль")
+
+		return fmt.Errorf("не удалось сменить роль: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	} else if affected == 0 {
+		return fmt.Errorf("пользователь с id %d не найден", id)
+	}
+
+	return nil
+}
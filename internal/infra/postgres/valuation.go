@@ -0,0 +1,554 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qGetDefaultValuationMethod = `
+	SELECT default_method
+	FROM valuation_settings`
+
+	qSetDefaultValuationMethod = `
+	UPDATE valuation_settings SET default_method = $1, updated_at = CURRENT_TIMESTAMP`
+
+	qSetItemValuationMethod = `
+	UPDATE items SET valuation_method = NULLIF($2, '')
+	WHERE id = $1`
+
+	qGetItemValuationMethod = `
+	SELECT COALESCE(i.valuation_method, s.default_method), COALESCE(i.valuation_method, '')
+	FROM items i
+	CROSS JOIN valuation_settings s
+	WHERE i.id = $1`
+
+	qGetCostBalance = `
+	SELECT balance_qty, balance_value
+	FROM cost_movements
+	WHERE item_id = $1
+	ORDER BY id DESC
+	LIMIT 1`
+
+	qGetLastLayerCost = `
+	SELECT unit_cost
+	FROM cost_layers
+	WHERE item_id = $1
+	ORDER BY id DESC
+	LIMIT 1`
+
+	qCreateCostLayer = `
+	INSERT INTO cost_layers (item_id, received_qty, remaining_qty, unit_cost, source)
+	VALUES ($1, $2, $2, $3, $4)`
+
+	qLockOpenCostLayersFIFO = `
+	SELECT id, remaining_qty, unit_cost
+	FROM cost_layers
+	WHERE item_id = $1 AND remaining_qty > 0
+	ORDER BY id
+	FOR UPDATE`
+
+	qLockOpenCostLayersLIFO = `
+	SELECT id, remaining_qty, unit_cost
+	FROM cost_layers
+	WHERE item_id = $1 AND remaining_qty > 0
+	ORDER BY id DESC
+	FOR UPDATE`
+
+	qConsumeCostLayer = `
+	UPDATE cost_layers SET remaining_qty = remaining_qty - $2
+	WHERE id = $1`
+
+	qCreateCostMovement = `
+	INSERT INTO cost_movements (item_id, movement_type, quantity, unit_cost, total_cost, method, source,
+		balance_qty, balance_value, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF(current_setting('warehouse.user_id', true), '')::INTEGER)`
+
+	qListOpenCostLayers = `
+	SELECT id, item_id, received_qty, remaining_qty, unit_cost, source, received_at
+	FROM cost_layers
+	WHERE item_id = $1 AND remaining_qty > 0
+	ORDER BY id`
+
+	qListCostMovements = `
+	SELECT id, item_id, movement_type, quantity, unit_cost, total_cost, method, source,
+		balance_qty, balance_value, created_by, created_at
+	FROM cost_movements
+	WHERE item_id = $1
+	ORDER BY id DESC`
+
+	qListItemValuations = `
+	SELECT DISTINCT ON (m.item_id) m.item_id, i.item_name, i.category_id, COALESCE(c.category_name, ''),
+		m.balance_qty, m.balance_value
+	FROM cost_movements m
+	JOIN items i ON i.id = m.item_id
+	LEFT JOIN categories c ON c.id = i.category_id
+	WHERE m.created_at < $1
+	ORDER BY m.item_id, m.id DESC`
+)
+
+var _ infra.ValuationRepo = (*valuationRepo)(nil)
+
+type valuationRepo struct {
+	db *dbpg.DB
+}
+
+// GetDefaultValuationMethod - метод для получения метода оценки запасов по умолчанию.
+func (r *valuationRepo) GetDefaultValuationMethod(ctx context.Context) (string, error) {
+	var method string
+	if err := r.db.QueryRowContext(ctx, qGetDefaultValuationMethod).Scan(&method); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetDefaultValuationMethod: не удалось получить метод оценки")
+
+		return "", fmt.Errorf("не удалось получить метод оценки: %w", err)
+	}
+
+	return method, nil
+}
+
+// SetDefaultValuationMethod - метод для смены метода оценки запасов по умолчанию.
+func (r *valuationRepo) SetDefaultValuationMethod(ctx context.Context, method string) error {
+	if _, err := r.db.ExecContext(ctx, qSetDefaultValuationMethod, method); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("method", method).
+			Msg("SetDefaultValuationMethod: не удалось сменить метод оценки")
+
+		return fmt.Errorf("не удалось сменить метод оценки: %w", err)
+	}
+
+	return nil
+}
+
+// SetItemValuationMethod - метод для назначения метода оценки item, пустой метод - метод по умолчанию.
+func (r *valuationRepo) SetItemValuationMethod(ctx context.Context, userID, itemID int, method string) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("SetItemValuationMethod: не удалось начать транзакцию")
+
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf(qSetUserID, userID),
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg("SetItemValuationMethod: не удалось установить userID")
+
+		return fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	details := "метод оценки: по умолчанию"
+	if method != "" {
+		details = "метод оценки: " + method
+	}
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		return fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, qSetItemValuationMethod, itemID, method)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("SetItemValuationMethod: не удалось назначить метод оценки")
+
+		return fmt.Errorf("не удалось назначить метод оценки: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	} else if affected == 0 {
+		return fmt.Errorf("item с id %d не найден", itemID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("item_id", itemID).
+			Msg("SetItemValuationMethod: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// GetItemCost - метод для получения стоимостного учета item: метода оценки, открытых слоев и журнала.
+func (r *valuationRepo) GetItemCost(ctx context.Context, itemID int) (*models.ItemCost, error) {
+	cost := models.ItemCost{ItemID: itemID}
+	if err := r.db.QueryRowContext(ctx, qGetItemValuationMethod, itemID).Scan(&cost.Method, &cost.ItemMethod); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("item с id %d не найден", itemID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetItemCost: не удалось получить метод оценки item")
+
+		return nil, fmt.Errorf("не удалось получить метод оценки item: %w", err)
+	}
+
+	if err := r.db.QueryRowContext(ctx, qGetBaseCurrency).Scan(&cost.Currency); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("GetItemCost: не удалось получить базовую валюту")
+
+		return nil, fmt.Errorf("не удалось получить базовую валюту: %w", err)
+	}
+
+	var err error
+	if cost.Layers, err = r.listOpenCostLayers(ctx, itemID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetItemCost: не удалось получить слои себестоимости")
+
+		return nil, err
+	}
+
+	if cost.Movements, err = r.listCostMovements(ctx, itemID); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("item_id", itemID).
+			Msg("GetItemCost: не удалось получить стоимостной журнал")
+
+		return nil, err
+	}
+	if len(cost.Movements) > 0 {
+		cost.BalanceQty = cost.Movements[0].BalanceQty
+		cost.BalanceValue = cost.Movements[0].BalanceValue
+	}
+
+	return &cost, nil
+}
+
+// ListItemValuations - метод для получения количества и стоимости запаса items на момент before, не включая его.
+// Items без движений до before в результат не попадают.
+func (r *valuationRepo) ListItemValuations(ctx context.Context, before time.Time) ([]models.ItemValuation, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListItemValuations,
+		before,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Time("before", before).
+			Msg("ListItemValuations: не удалось выполнить запрос")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListItemValuations: %w", err)
+	}
+	defer rows.Close()
+
+	var valuations []models.ItemValuation
+	for rows.Next() {
+		var v models.ItemValuation
+		var categoryID sql.NullInt64
+		if err := rows.Scan(
+			&v.ItemID,
+			&v.ItemName,
+			&categoryID,
+			&v.CategoryName,
+			&v.Quantity,
+			&v.Value,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		v.CategoryID = nullIntPtr(categoryID)
+
+		valuations = append(valuations, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return valuations, nil
+}
+
+// listOpenCostLayers - получение слоев себестоимости item с ненулевым остатком, старые первыми.
+func (r *valuationRepo) listOpenCostLayers(ctx context.Context, itemID int) ([]models.CostLayer, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListOpenCostLayers,
+		itemID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listOpenCostLayers: %w", err)
+	}
+	defer rows.Close()
+
+	var layers []models.CostLayer
+	for rows.Next() {
+		var layer models.CostLayer
+		if err := rows.Scan(
+			&layer.ID,
+			&layer.ItemID,
+			&layer.ReceivedQty,
+			&layer.RemainingQty,
+			&layer.UnitCost,
+			&layer.Source,
+			&layer.ReceivedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		layers = append(layers, layer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return layers, nil
+}
+
+// listCostMovements - получение стоимостного журнала item, новые записи первыми.
+func (r *valuationRepo) listCostMovements(ctx context.Context, itemID int) ([]models.CostMovement, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListCostMovements,
+		itemID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listCostMovements: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []models.CostMovement
+	for rows.Next() {
+		var m models.CostMovement
+		var createdBy sql.NullInt64
+		if err := rows.Scan(
+			&m.ID,
+			&m.ItemID,
+			&m.Type,
+			&m.Quantity,
+			&m.UnitCost,
+			&m.TotalCost,
+			&m.Method,
+			&m.Source,
+			&m.BalanceQty,
+			&m.BalanceValue,
+			&createdBy,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		m.CreatedBy = nullIntPtr(createdBy)
+
+		movements = append(movements, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return movements, nil
+}
+
+// costBalance - метод оценки item, количество и стоимость его запаса по стоимостному журналу.
+// Вызывается после блокировки строки item, чтобы движения по item шли последовательно.
+func costBalance(ctx context.Context, tx *sql.Tx, itemID int) (string, int, models.Money, error) {
+	var method, itemMethod string
+	if err := tx.QueryRowContext(ctx, qGetItemValuationMethod, itemID).Scan(&method, &itemMethod); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", 0, 0, fmt.Errorf("item с id %d не найден", itemID)
+		}
+
+		return "", 0, 0, fmt.Errorf("не удалось получить метод оценки item: %w", err)
+	}
+
+	var qty int
+	var value models.Money
+	if err := tx.QueryRowContext(ctx, qGetCostBalance, itemID).Scan(&qty, &value); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", 0, 0, fmt.Errorf("не удалось получить стоимость запаса item: %w", err)
+	}
+
+	return method, qty, value, nil
+}
+
+// receiveCost - приход quantity единиц item в стоимостной учет: новый слой себестоимости и запись журнала.
+// unitCost == nil - по средней себестоимости запаса, а при пустом запасе по цене последнего слоя.
+func receiveCost(ctx context.Context, tx *sql.Tx, itemID, quantity int, unitCost *models.Money, source string) error {
+	if quantity <= 0 {
+		return nil
+	}
+
+	method, balanceQty, balanceValue, err := costBalance(ctx, tx, itemID)
+	if err != nil {
+		return err
+	}
+
+	var cost models.Money
+	switch {
+	case unitCost != nil:
+		cost = *unitCost
+	case balanceQty > 0:
+		cost = balanceValue.Div(balanceQty)
+	default:
+		if err := tx.QueryRowContext(ctx, qGetLastLayerCost, itemID).Scan(&cost); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("не удалось получить себестоимость item: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, qCreateCostLayer, itemID, quantity, cost, source); err != nil {
+		return fmt.Errorf("не удалось создать слой себестоимости: %w", err)
+	}
+
+	total := cost.Mul(quantity)
+	if _, err := tx.ExecContext(
+		ctx,
+		qCreateCostMovement,
+		itemID,
+		models.CostReceipt,
+		quantity,
+		cost,
+		total,
+		method,
+		source,
+		balanceQty+quantity,
+		balanceValue+total,
+	); err != nil {
+		return fmt.Errorf("не удалось записать стоимостной журнал: %w", err)
+	}
+
+	return nil
+}
+
+// issueCost - расход quantity единиц item из стоимостного учета, возвращает себестоимость расхода (COGS).
+// FIFO и LIFO берут стоимость из слоев, средняя себестоимость (wac) - из стоимости запаса, слои при этом
+// уменьшаются от старых к новым. Расход всего запаса списывает всю его стоимость, чтобы не копить остаток от округления.
+func issueCost(ctx context.Context, tx *sql.Tx, itemID, quantity int, source string) (models.Money, error) {
+	if quantity <= 0 {
+		return 0, nil
+	}
+
+	method, balanceQty, balanceValue, err := costBalance(ctx, tx, itemID)
+	if err != nil {
+		return 0, err
+	}
+
+	query := qLockOpenCostLayersFIFO
+	if method == models.ValuationLIFO {
+		query = qLockOpenCostLayersLIFO
+	}
+	rows, err := tx.QueryContext(ctx, query, itemID)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось получить слои себестоимости: %w", err)
+	}
+
+	type consumption struct {
+		layerID, quantity int
+	}
+	var consumed []consumption
+	var layersCost models.Money
+	left := quantity
+	for left > 0 && rows.Next() {
+		var layerID, remaining int
+		var cost models.Money
+		if err := rows.Scan(&layerID, &remaining, &cost); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		take := min(remaining, left)
+		consumed = append(consumed, consumption{layerID: layerID, quantity: take})
+		layersCost += cost.Mul(take)
+		left -= take
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	for _, c := range consumed {
+		if _, err := tx.ExecContext(ctx, qConsumeCostLayer, c.layerID, c.quantity); err != nil {
+			return 0, fmt.Errorf("не удалось уменьшить слой себестоимости: %w", err)
+		}
+	}
+
+	var total models.Money
+	switch {
+	case quantity >= balanceQty:
+		total = balanceValue
+	case method == models.ValuationWAC:
+		total = balanceValue.Share(quantity, balanceQty)
+	default:
+		// Количество сверх слоев оценивается по средней себестоимости.
+		total = layersCost + balanceValue.Share(left, balanceQty)
+	}
+	if total > balanceValue {
+		total = balanceValue
+	}
+
+	newQty := max(balanceQty-quantity, 0)
+	newValue := balanceValue - total
+	if newQty == 0 {
+		newValue = 0
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		qCreateCostMovement,
+		itemID,
+		models.CostIssue,
+		quantity,
+		total.Div(quantity),
+		total,
+		method,
+		source,
+		newQty,
+		newValue,
+	); err != nil {
+		return 0, fmt.Errorf("не удалось записать стоимостной журнал: %w", err)
+	}
+
+	return total, nil
+}
+
+// adjustCost - приход или расход item в стоимостном учете по знаку delta, приход - по средней себестоимости.
+func adjustCost(ctx context.Context, tx *sql.Tx, itemID, delta int, source string) error {
+	if delta < 0 {
+		_, err := issueCost(ctx, tx, itemID, -delta, source)
+		return err
+	}
+
+	return receiveCost(ctx, tx, itemID, delta, nil, source)
+}
@@ -0,0 +1,727 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	qCreateWriteOff = `
+	INSERT INTO write_offs (reason, source_status, note, wo_status, created_by, posted_at, currency)
+	VALUES ($1, $2, $3, $4, $5, CASE WHEN $4 = 'posted' THEN CURRENT_TIMESTAMP END,
+		(SELECT code FROM currencies WHERE is_base))
+	RETURNING id, wo_number, currency`
+
+	qCreateWriteOffLine = `
+	INSERT INTO write_off_lines (write_off_id, item_id, location_id, quantity, unit_cost, total_cost, serial_numbers)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id`
+
+	qSetWriteOffLineCost = `
+	UPDATE write_off_lines SET unit_cost = $2, total_cost = $3
+	WHERE id = $1`
+
+	qWriteOffColumns = `
+	SELECT id, wo_number, reason, source_status, note, wo_status, currency, COALESCE(created_by, 0), decided_by,
+		decision_note, decided_at, posted_at, created_at
+	FROM write_offs`
+
+	qListWriteOffs = qWriteOffColumns + `
+	WHERE ($1::TEXT = '' OR wo_status = $1)
+	ORDER BY id DESC`
+
+	qGetWriteOffByID = qWriteOffColumns + `
+	WHERE id = $1`
+
+	qLockWriteOff = qWriteOffColumns + `
+	WHERE id = $1
+	FOR UPDATE`
+
+	qListWriteOffLines = `
+	SELECT l.id, l.write_off_id, l.item_id, i.item_name, l.location_id, l.quantity, l.unit_cost, l.total_cost,
+		l.serial_numbers
+	FROM write_off_lines l
+	JOIN items i ON i.id = l.item_id
+	WHERE l.write_off_id = ANY($1)
+	ORDER BY l.id`
+
+	qSetWriteOffDecision = `
+	UPDATE write_offs
+	SET wo_status = $2, decided_by = $3, decision_note = $4, decided_at = CURRENT_TIMESTAMP,
+		posted_at = CASE WHEN $2 = 'posted' THEN CURRENT_TIMESTAMP END
+	WHERE id = $1`
+
+	// Списать можно только серийный номер на складе, зарезервированный сначала нужно освободить.
+	qWriteOffSerial = `
+	UPDATE item_serials SET serial_status = 'scrapped', updated_at = CURRENT_TIMESTAMP
+	WHERE item_id = $1 AND serial_number = $2 AND serial_status = 'in_stock'`
+
+	qCreateWriteOffEvidence = `
+	INSERT INTO write_off_evidence (write_off_id, file_name, content_type, data, uploaded_by)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`
+
+	qListWriteOffEvidence = `
+	SELECT id, write_off_id, file_name, content_type, octet_length(data), uploaded_by, created_at
+	FROM write_off_evidence
+	WHERE write_off_id = $1
+	ORDER BY id`
+
+	qGetWriteOffEvidence = `
+	SELECT id, write_off_id, file_name, content_type, octet_length(data), uploaded_by, created_at, data
+	FROM write_off_evidence
+	WHERE write_off_id = $1 AND id = $2`
+
+	writeOffLineItemConstraint     = "write_off_lines_item_id_fkey"
+	writeOffLineLocationConstraint = "write_off_lines_location_id_fkey"
+	writeOffEvidenceConstraint     = "write_off_evidence_write_off_id_fkey"
+)
+
+var _ infra.WriteOffRepo = (*writeOffRepo)(nil)
+
+type writeOffRepo struct {
+	db *dbpg.DB
+}
+
+// ValueWriteOffLines - метод для оценки строк списания по слоям себестоимости в базовой валюте.
+// Расход строк проводится в стоимостном учете в транзакции, которая затем откатывается, поэтому оценка
+// совпадает с себестоимостью, которую списание получило бы при проведении сейчас. Возвращает стоимость строк.
+func (r *writeOffRepo) ValueWriteOffLines(ctx context.Context, lines []models.WriteOffLine) ([]models.Money, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ValueWriteOffLines: не удалось начать транзакцию")
+
+		return nil, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	costs := make([]models.Money, 0, len(lines))
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, qLockSerializedItem, line.ItemID); err != nil {
+			return nil, fmt.Errorf("не удалось заблокировать item: %w", err)
+		}
+
+		cost, err := issueCost(ctx, tx, line.ItemID, line.Quantity, "оценка списания")
+		if err != nil {
+			return nil, err
+		}
+		costs = append(costs, cost)
+	}
+
+	return costs, nil
+}
+
+// CreateWriteOff - метод для оформления документа списания в статусе wo.Status.
+// Проведенный сразу документ уменьшает остаток в той же транзакции. Возвращает id и номер документа.
+func (r *writeOffRepo) CreateWriteOff(ctx context.Context, wo *models.WriteOff) (int, string, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", wo.CreatedBy).
+			Msg("CreateWriteOff: не удалось начать транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(qSetUserID, wo.CreatedBy)); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", wo.CreatedBy).
+			Msg("CreateWriteOff: не удалось установить userID")
+
+		return 0, "", fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	if err := tx.QueryRowContext(
+		ctx,
+		qCreateWriteOff,
+		wo.Reason,
+		wo.SourceStatus,
+		wo.Note,
+		wo.Status,
+		wo.CreatedBy,
+	).Scan(&wo.ID, &wo.Number, &wo.Currency); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", wo.CreatedBy).
+			Msg("CreateWriteOff: не удалось создать документ списания")
+
+		return 0, "", fmt.Errorf("не удалось создать документ списания: %w", err)
+	}
+
+	for i, line := range wo.Lines {
+		if err := tx.QueryRowContext(
+			ctx,
+			qCreateWriteOffLine,
+			wo.ID,
+			line.ItemID,
+			line.LocationID,
+			line.Quantity,
+			line.UnitCost,
+			line.TotalCost,
+			pq.Array(line.Serials),
+		).Scan(&wo.Lines[i].ID); err != nil {
+			if isForeignKeyViolationOn(err, writeOffLineItemConstraint) {
+				return 0, "", fmt.Errorf("item с id %d не найден", line.ItemID)
+			}
+			if isForeignKeyViolationOn(err, writeOffLineLocationConstraint) {
+				return 0, "", fmt.Errorf("ячейка с id %d не найдена", *line.LocationID)
+			}
+			zlog.Logger.Error().
+				Err(err).
+				Int("write_off_id", wo.ID).
+				Int("item_id", line.ItemID).
+				Msg("CreateWriteOff: не удалось добавить строку списания")
+
+			return 0, "", fmt.Errorf("не удалось добавить строку списания: %w", err)
+		}
+	}
+
+	if wo.Status == models.WriteOffPosted {
+		if err := postWriteOff(ctx, tx, wo.CreatedBy, wo); err != nil {
+			return 0, "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", wo.ID).
+			Msg("CreateWriteOff: не удалось завершить транзакцию")
+
+		return 0, "", fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return wo.ID, wo.Number, nil
+}
+
+// ListWriteOffs - метод для получения документов списания со строками по статусу, пустой статус - все.
+func (r *writeOffRepo) ListWriteOffs(ctx context.Context, status string) ([]models.WriteOff, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListWriteOffs,
+		status,
+	)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Str("status", status).
+			Msg("ListWriteOffs: не удалось выполнить запрос ListWriteOffs")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос ListWriteOffs: %w", err)
+	}
+	defer rows.Close()
+
+	var wos []models.WriteOff
+	for rows.Next() {
+		var wo models.WriteOff
+		if err := scanWriteOff(rows, &wo); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Msg("ListWriteOffs: не удалось перевести данные из строки в структуру")
+
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+
+		wos = append(wos, wo)
+	}
+
+	if err := rows.Err(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListWriteOffs: не удалось получить все строки")
+
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	if len(wos) == 0 {
+		return wos, nil
+	}
+
+	ids := make([]int, 0, len(wos))
+	for _, wo := range wos {
+		ids = append(ids, wo.ID)
+	}
+
+	lines, err := listWriteOffLines(ctx, r.db.Master, ids)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("ListWriteOffs: не удалось получить строки списаний")
+
+		return nil, err
+	}
+	for i := range wos {
+		wos[i].Lines = lines[wos[i].ID]
+	}
+
+	return wos, nil
+}
+
+// GetWriteOff - метод для получения документа списания со строками и списком подтверждающих файлов.
+func (r *writeOffRepo) GetWriteOff(ctx context.Context, id int) (*models.WriteOff, error) {
+	var wo models.WriteOff
+	if err := scanWriteOff(r.db.QueryRowContext(ctx, qGetWriteOffByID, id), &wo); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("списание с id %d не найдено", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", id).
+			Msg("GetWriteOff: не удалось выполнить запрос GetWriteOff")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetWriteOff: %w", err)
+	}
+
+	lines, err := listWriteOffLines(ctx, r.db.Master, []int{id})
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", id).
+			Msg("GetWriteOff: не удалось получить строки списания")
+
+		return nil, err
+	}
+	wo.Lines = lines[id]
+
+	if wo.Evidence, err = r.listWriteOffEvidence(ctx, id); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", id).
+			Msg("GetWriteOff: не удалось получить подтверждающие файлы")
+
+		return nil, err
+	}
+
+	return &wo, nil
+}
+
+// ApproveWriteOff - метод для согласования и проведения списания.
+// Согласовать может только другой пользователь, не автор документа. Возвращает проведенный документ.
+func (r *writeOffRepo) ApproveWriteOff(ctx context.Context, userID, id int, note string) (*models.WriteOff, error) {
+	tx, wo, err := r.beginWriteOffDecision(ctx, "ApproveWriteOff", userID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if wo.CreatedBy == userID {
+		return nil, fmt.Errorf("нельзя согласовать списание %s: автор не может согласовать собственный документ", wo.Number)
+	}
+
+	if err := postWriteOff(ctx, tx, userID, wo); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetWriteOffDecision, id, models.WriteOffPosted, userID, note); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", id).
+			Msg("ApproveWriteOff: не удалось обновить статус списания")
+
+		return nil, fmt.Errorf("не удалось обновить статус списания: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", id).
+			Msg("ApproveWriteOff: не удалось завершить транзакцию")
+
+		return nil, fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+	wo.Status = models.WriteOffPosted
+
+	return wo, nil
+}
+
+// RejectWriteOff - метод для отклонения списания, ожидающего согласования. Остаток не меняется.
+func (r *writeOffRepo) RejectWriteOff(ctx context.Context, userID, id int, note string) error {
+	tx, _, err := r.beginWriteOffDecision(ctx, "RejectWriteOff", userID, id)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, qSetWriteOffDecision, id, models.WriteOffRejected, userID, note); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", id).
+			Msg("RejectWriteOff: не удалось обновить статус списания")
+
+		return fmt.Errorf("не удалось обновить статус списания: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", id).
+			Msg("RejectWriteOff: не удалось завершить транзакцию")
+
+		return fmt.Errorf("не удалось завершить транзакцию: %w", err)
+	}
+
+	return nil
+}
+
+// AddWriteOffEvidence - метод для прикрепления подтверждающего файла к списанию. Возвращает id файла.
+func (r *writeOffRepo) AddWriteOffEvidence(ctx context.Context, evidence *models.WriteOffEvidence) (int, error) {
+	var id int
+	if err := r.db.Master.QueryRowContext(
+		ctx,
+		qCreateWriteOffEvidence,
+		evidence.WriteOffID,
+		evidence.FileName,
+		evidence.ContentType,
+		evidence.Data,
+		evidence.UploadedBy,
+	).Scan(&id); err != nil {
+		if isForeignKeyViolationOn(err, writeOffEvidenceConstraint) {
+			return 0, fmt.Errorf("списание с id %d не найдено", evidence.WriteOffID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", evidence.WriteOffID).
+			Msg("AddWriteOffEvidence: не удалось сохранить файл")
+
+		return 0, fmt.Errorf("не удалось сохранить файл: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetWriteOffEvidence - метод для получения подтверждающего файла списания вместе с содержимым.
+func (r *writeOffRepo) GetWriteOffEvidence(ctx context.Context, writeOffID, id int) (*models.WriteOffEvidence, error) {
+	var evidence models.WriteOffEvidence
+	var uploadedBy sql.NullInt64
+	if err := r.db.QueryRowContext(ctx, qGetWriteOffEvidence, writeOffID, id).Scan(
+		&evidence.ID,
+		&evidence.WriteOffID,
+		&evidence.FileName,
+		&evidence.ContentType,
+		&evidence.Size,
+		&uploadedBy,
+		&evidence.CreatedAt,
+		&evidence.Data,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("файл с id %d списания %d не найден", id, writeOffID)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", writeOffID).
+			Int("evidence_id", id).
+			Msg("GetWriteOffEvidence: не удалось выполнить запрос GetWriteOffEvidence")
+
+		return nil, fmt.Errorf("не удалось выполнить запрос GetWriteOffEvidence: %w", err)
+	}
+	evidence.UploadedBy = nullIntPtr(uploadedBy)
+
+	return &evidence, nil
+}
+
+// beginWriteOffDecision - начало транзакции решения по списанию: userID для триггеров истории,
+// блокировка документа со строками и проверка, что он ждет согласования.
+func (r *writeOffRepo) beginWriteOffDecision(ctx context.Context, op string, userID, id int) (*sql.Tx, *models.WriteOff, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Int("write_off_id", id).
+			Msg(op + ": не удалось начать транзакцию")
+
+		return nil, nil, fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(qSetUserID, userID)); err != nil {
+		tx.Rollback()
+		zlog.Logger.Error().
+			Err(err).
+			Int("user_id", userID).
+			Msg(op + ": не удалось установить userID")
+
+		return nil, nil, fmt.Errorf("не удалось установить userID: %w", err)
+	}
+
+	var wo models.WriteOff
+	if err := scanWriteOff(tx.QueryRowContext(ctx, qLockWriteOff, id), &wo); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("списание с id %d не найдено", id)
+		}
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", id).
+			Msg(op + ": не удалось заблокировать списание")
+
+		return nil, nil, fmt.Errorf("не удалось заблокировать списание: %w", err)
+	}
+
+	if wo.Status != models.WriteOffPending {
+		tx.Rollback()
+		return nil, nil, fmt.Errorf("нельзя принять решение по списанию %s в статусе %s", wo.Number, wo.Status)
+	}
+
+	lines, err := listWriteOffLines(ctx, tx, []int{id})
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	wo.Lines = lines[id]
+
+	return tx, &wo, nil
+}
+
+// postWriteOff - уменьшение запаса по строкам списания.
+// Несерийный item списывается из доступного незарезервированного остатка или из статуса запаса
+// с записью в журнал смены статусов. Серийные номера на складе переходят в scrapped,
+// остаток серийного item пересчитывается по ним. Себестоимость расхода сохраняется в строках списания.
+func postWriteOff(ctx context.Context, tx *sql.Tx, userID int, wo *models.WriteOff) error {
+	details := fmt.Sprintf("списание %s: %s", wo.Number, wo.Reason)
+	for i := range wo.Lines {
+		line := &wo.Lines[i]
+		cost, err := postWriteOffLine(ctx, tx, userID, wo, *line, details)
+		if err != nil {
+			return err
+		}
+
+		line.SetCost(cost)
+		if _, err := tx.ExecContext(ctx, qSetWriteOffLineCost, line.ID, line.UnitCost, line.TotalCost); err != nil {
+			zlog.Logger.Error().
+				Err(err).
+				Int("write_off_id", wo.ID).
+				Int("item_id", line.ItemID).
+				Msg("postWriteOff: не удалось сохранить себестоимость строки")
+
+			return fmt.Errorf("не удалось сохранить себестоимость строки: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// postWriteOffLine - уменьшение запаса по одной строке списания, возвращает себестоимость расхода.
+func postWriteOffLine(ctx context.Context, tx *sql.Tx, userID int, wo *models.WriteOff, line models.WriteOffLine, details string) (models.Money, error) {
+	var serialized bool
+	if err := tx.QueryRowContext(ctx, qLockSerializedItem, line.ItemID).Scan(&serialized); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("item с id %d не найден", line.ItemID)
+		}
+
+		return 0, fmt.Errorf("не удалось заблокировать item: %w", err)
+	}
+
+	if serialized {
+		return writeOffSerials(ctx, tx, wo, line, details)
+	}
+	if len(line.Serials) > 0 {
+		return 0, fmt.Errorf("некорректное списание: item с id %d не является серийным", line.ItemID)
+	}
+
+	if wo.SourceStatus != models.StockAvailable {
+		if err := releaseHeldStock(ctx, tx, line.ItemID, line.LocationID, wo.SourceStatus, line.Quantity); err != nil {
+			return 0, err
+		}
+		cost, err := issueCost(ctx, tx, line.ItemID, line.Quantity, details)
+		if err != nil {
+			return 0, err
+		}
+		if err := logStockStatusChange(ctx, tx, userID, &models.StockStatusChange{
+			ItemID:     line.ItemID,
+			LocationID: line.LocationID,
+			FromStatus: wo.SourceStatus,
+			Quantity:   line.Quantity,
+			Reason:     details,
+		}); err != nil {
+			return 0, err
+		}
+
+		return cost, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, qSetHistoryDetails, details); err != nil {
+		return 0, fmt.Errorf("не удалось установить детали операции: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, qDecreaseItemAvailable, line.ItemID, line.Quantity)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Int("write_off_id", wo.ID).
+			Int("item_id", line.ItemID).
+			Msg("postWriteOff: не удалось уменьшить доступный остаток")
+
+		return 0, fmt.Errorf("не удалось уменьшить доступный остаток: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+	} else if affected == 0 {
+		return 0, fmt.Errorf("недостаточно доступного незарезервированного остатка item %d для списания %d", line.ItemID, line.Quantity)
+	}
+
+	return issueCost(ctx, tx, line.ItemID, line.Quantity, details)
+}
+
+// writeOffSerials - списание серийных номеров строки, серийный item списывается только из доступного остатка.
+// Возвращает себестоимость расхода.
+func writeOffSerials(ctx context.Context, tx *sql.Tx, wo *models.WriteOff, line models.WriteOffLine, details string) (models.Money, error) {
+	if wo.SourceStatus != models.StockAvailable {
+		return 0, fmt.Errorf("нельзя списать серийный item %d из статуса %s: статус ведется по серийным номерам", line.ItemID, wo.SourceStatus)
+	}
+	if len(line.Serials) != line.Quantity {
+		return 0, fmt.Errorf("некорректное списание: для серийного item с id %d нужно %d серийных номеров, передано %d",
+			line.ItemID, line.Quantity, len(line.Serials))
+	}
+
+	for _, serialNumber := range line.Serials {
+		result, err := tx.ExecContext(ctx, qWriteOffSerial, line.ItemID, serialNumber)
+		if err != nil {
+			return 0, fmt.Errorf("не удалось обновить серийный номер: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return 0, fmt.Errorf("не удалось получить количество обновленных строк: %w", err)
+		} else if affected == 0 {
+			return 0, fmt.Errorf("серийный номер %s item %d на складе не найден", serialNumber, line.ItemID)
+		}
+	}
+	cost, err := issueCost(ctx, tx, line.ItemID, line.Quantity, details)
+	if err != nil {
+		return 0, err
+	}
+
+	return cost, syncSerializedQuantity(ctx, tx, line.ItemID, details)
+}
+
+// listWriteOffEvidence - получение списка подтверждающих файлов списания без содержимого.
+func (r *writeOffRepo) listWriteOffEvidence(ctx context.Context, id int) ([]models.WriteOffEvidence, error) {
+	strategy := retry.Strategy{
+		Attempts: 3,
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		strategy,
+		qListWriteOffEvidence,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listWriteOffEvidence: %w", err)
+	}
+	defer rows.Close()
+
+	var files []models.WriteOffEvidence
+	for rows.Next() {
+		var evidence models.WriteOffEvidence
+		var uploadedBy sql.NullInt64
+		if err := rows.Scan(
+			&evidence.ID,
+			&evidence.WriteOffID,
+			&evidence.FileName,
+			&evidence.ContentType,
+			&evidence.Size,
+			&uploadedBy,
+			&evidence.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		evidence.UploadedBy = nullIntPtr(uploadedBy)
+
+		files = append(files, evidence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return files, nil
+}
+
+// listWriteOffLines - получение строк списаний, сгруппированных по id документа.
+func listWriteOffLines(ctx context.Context, q interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}, ids []int) (map[int][]models.WriteOffLine, error) {
+	rows, err := q.QueryContext(ctx, qListWriteOffLines, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить запрос listWriteOffLines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[int][]models.WriteOffLine, len(ids))
+	for rows.Next() {
+		var line models.WriteOffLine
+		var locationID sql.NullInt64
+		if err := rows.Scan(
+			&line.ID,
+			&line.WriteOffID,
+			&line.ItemID,
+			&line.ItemName,
+			&locationID,
+			&line.Quantity,
+			&line.UnitCost,
+			&line.TotalCost,
+			pq.Array(&line.Serials),
+		); err != nil {
+			return nil, fmt.Errorf("не удалось перевести данные из строки в структуру: %w", err)
+		}
+		line.LocationID = nullIntPtr(locationID)
+
+		lines[line.WriteOffID] = append(lines[line.WriteOffID], line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось получить все строки: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanWriteOff - перевод строки write_offs в структуру.
+func scanWriteOff(row interface{ Scan(dest ...any) error }, wo *models.WriteOff) error {
+	var decidedBy sql.NullInt64
+	var decidedAt, postedAt sql.NullTime
+	if err := row.Scan(
+		&wo.ID,
+		&wo.Number,
+		&wo.Reason,
+		&wo.SourceStatus,
+		&wo.Note,
+		&wo.Status,
+		&wo.Currency,
+		&wo.CreatedBy,
+		&decidedBy,
+		&wo.DecisionNote,
+		&decidedAt,
+		&postedAt,
+		&wo.CreatedAt,
+	); err != nil {
+		return err
+	}
+	wo.DecidedBy = nullIntPtr(decidedBy)
+	if decidedAt.Valid {
+		wo.DecidedAt = &decidedAt.Time
+	}
+	if postedAt.Valid {
+		wo.PostedAt = &postedAt.Time
+	}
+
+	return nil
+}
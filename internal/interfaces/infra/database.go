@@ -2,6 +2,7 @@ package infra
 
 import (
 	"context"
+	"time"
 
 	"github.com/sunr3d/warehouse-control/models"
 )
@@ -11,17 +12,46 @@ type Database interface {
 	UserRepo
 	ItemRepo
 	ItemHistoryRepo
+	ReservationRepo
+	LotRepo
+	SerialRepo
+	LocationRepo
+	CategoryRepo
+	AttributeRepo
+	UnitRepo
+	StockRepo
+	SupplierRepo
+	PurchaseRepo
+	OutboundRepo
+	ReplenishmentRepo
+	CountRepo
+	ReturnRepo
+	StockStatusRepo
+	WriteOffRepo
+	ChangeRequestRepo
+	ValuationRepo
+	CurrencyRepo
+	KitRepo
+	AnalysisRepo
+	ForecastRepo
+	DashboardRepo
+	ReportRepo
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=UserRepo --output=../../../mocks --filename=mock_user_repo.go --with-expecter
 type UserRepo interface {
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	GetUserByID(ctx context.Context, id int) (*models.User, error)
+	SetUserRole(ctx context.Context, id int, role string) error
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ItemRepo --output=../../../mocks --filename=mock_item_repo.go --with-expecter
 type ItemRepo interface {
 	Create(ctx context.Context, userID int, item *models.Item) (int, error)
-	List(ctx context.Context) ([]models.Item, error)
+	List(ctx context.Context, filter models.ItemFilter) ([]models.Item, error)
+	GetByID(ctx context.Context, id int) (*models.Item, error)
+	ListByIDs(ctx context.Context, ids []int) ([]models.Item, error)
+	GetByBarcode(ctx context.Context, code string) (*models.Item, error)
 	Update(ctx context.Context, userID, id int, item *models.Item) error
 	Delete(ctx context.Context, userID, id int) error
 }
@@ -30,3 +60,212 @@ type ItemRepo interface {
 type ItemHistoryRepo interface {
 	GetByItemID(ctx context.Context, itemID int) ([]models.ItemHistory, error)
 }
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ReservationRepo --output=../../../mocks --filename=mock_reservation_repo.go --with-expecter
+type ReservationRepo interface {
+	CreateReservation(ctx context.Context, res *models.Reservation) (int, error)
+	GetReservationsByItemID(ctx context.Context, itemID int) ([]models.Reservation, error)
+	ReleaseReservation(ctx context.Context, id int) error
+	ConfirmReservation(ctx context.Context, userID, id int) error
+	ExpireReservations(ctx context.Context, now time.Time) (int, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=LotRepo --output=../../../mocks --filename=mock_lot_repo.go --with-expecter
+type LotRepo interface {
+	CreateLot(ctx context.Context, userID int, lot *models.Lot) (int, error)
+	GetLotsByItemID(ctx context.Context, itemID int) ([]models.Lot, error)
+	IssueLots(ctx context.Context, userID, itemID int, picks []models.LotPick) (models.Money, error)
+	GetExpiringLots(ctx context.Context, before time.Time) ([]models.ExpiringLot, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=SerialRepo --output=../../../mocks --filename=mock_serial_repo.go --with-expecter
+type SerialRepo interface {
+	CreateSerials(ctx context.Context, userID, itemID int, serialNumbers []string) error
+	GetSerialsByItemID(ctx context.Context, itemID int, status string) ([]models.Serial, error)
+	GetSerialByNumber(ctx context.Context, serialNumber string) (*models.Serial, error)
+	UpdateSerialStatus(ctx context.Context, userID int, serial *models.Serial, status string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=LocationRepo --output=../../../mocks --filename=mock_location_repo.go --with-expecter
+type LocationRepo interface {
+	CreateLocation(ctx context.Context, loc *models.Location) (int, error)
+	ListLocations(ctx context.Context) ([]models.Location, error)
+	GetLocationByID(ctx context.Context, id int) (*models.Location, error)
+	SetItemPickLocation(ctx context.Context, itemID int, locationID *int) error
+	GetItemPickLocation(ctx context.Context, itemID int) (*int, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=CategoryRepo --output=../../../mocks --filename=mock_category_repo.go --with-expecter
+type CategoryRepo interface {
+	CreateCategory(ctx context.Context, cat *models.Category) (int, error)
+	ListCategories(ctx context.Context) ([]models.Category, error)
+	MoveCategory(ctx context.Context, id int, parentID *int) error
+	MergeCategory(ctx context.Context, userID, sourceID, targetID int) (int, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=AttributeRepo --output=../../../mocks --filename=mock_attribute_repo.go --with-expecter
+type AttributeRepo interface {
+	CreateAttributeDefinition(ctx context.Context, def *models.AttributeDefinition) (int, error)
+	GetCategoryAttributes(ctx context.Context, categoryID int) ([]models.AttributeDefinition, error)
+	GetSubcategoryAttributes(ctx context.Context, categoryID int) ([]models.AttributeDefinition, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=UnitRepo --output=../../../mocks --filename=mock_unit_repo.go --with-expecter
+type UnitRepo interface {
+	SetItemUnits(ctx context.Context, userID, itemID int, baseUnit string, units []models.ItemUnit) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=StockRepo --output=../../../mocks --filename=mock_stock_repo.go --with-expecter
+type StockRepo interface {
+	SetStockLevel(ctx context.Context, level *models.StockLevel) error
+	ListStockPositions(ctx context.Context, itemID *int) ([]models.StockPosition, error)
+	RaiseStockAlert(ctx context.Context, pos models.StockPosition) (bool, error)
+	ResolveStockAlerts(ctx context.Context, itemID int, locationID *int) (int, error)
+	ListStockAlerts(ctx context.Context, status string) ([]models.StockAlert, error)
+	AcknowledgeStockAlert(ctx context.Context, userID, id int) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=SupplierRepo --output=../../../mocks --filename=mock_supplier_repo.go --with-expecter
+type SupplierRepo interface {
+	CreateSupplier(ctx context.Context, sup *models.Supplier) (int, error)
+	ListSuppliers(ctx context.Context, status string) ([]models.Supplier, error)
+	GetSupplierByID(ctx context.Context, id int) (*models.Supplier, error)
+	UpdateSupplier(ctx context.Context, sup *models.Supplier) error
+	DeleteSupplier(ctx context.Context, id int) error
+
+	SetItemSupplier(ctx context.Context, link *models.ItemSupplier) error
+	RemoveItemSupplier(ctx context.Context, itemID, supplierID int) error
+	ListItemSuppliers(ctx context.Context, itemID int) ([]models.ItemSupplier, error)
+	ListSupplierItems(ctx context.Context, supplierID int) ([]models.ItemSupplier, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=PurchaseRepo --output=../../../mocks --filename=mock_purchase_repo.go --with-expecter
+type PurchaseRepo interface {
+	CreatePurchaseOrder(ctx context.Context, po *models.PurchaseOrder) (int, string, error)
+	CreatePurchaseOrders(ctx context.Context, pos []*models.PurchaseOrder) error
+	ListPurchaseOrders(ctx context.Context, filter models.PurchaseOrderFilter) ([]models.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id int) (*models.PurchaseOrder, error)
+	UpdatePurchaseOrderStatus(ctx context.Context, id int, from []string, to string) error
+	DeletePurchaseOrder(ctx context.Context, id int) error
+	ReceivePurchaseOrder(ctx context.Context, userID, id int, lines []models.ReceiptLine, note string, tolerance int) (string, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=OutboundRepo --output=../../../mocks --filename=mock_outbound_repo.go --with-expecter
+type OutboundRepo interface {
+	CreateOutboundOrder(ctx context.Context, order *models.OutboundOrder) (int, string, error)
+	ListOutboundOrders(ctx context.Context, status string) ([]models.OutboundOrder, error)
+	GetOutboundOrder(ctx context.Context, id int) (*models.OutboundOrder, error)
+	AllocateOutboundOrder(ctx context.Context, userID, id int) (string, int, error)
+	StartPicking(ctx context.Context, userID, id int) ([]models.PickListEntry, error)
+	PackOutboundOrder(ctx context.Context, userID, id int, lines []models.OutboundQuantity) (string, error)
+	ShipOutboundOrder(ctx context.Context, userID, id int, trackingRef string, lines []models.OutboundQuantity) (int, string, error)
+	CancelOutboundOrder(ctx context.Context, userID, id int, note string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ReplenishmentRepo --output=../../../mocks --filename=mock_replenishment_repo.go --with-expecter
+type ReplenishmentRepo interface {
+	ListReplenishmentCandidates(ctx context.Context) ([]models.ReplenishmentCandidate, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=CountRepo --output=../../../mocks --filename=mock_count_repo.go --with-expecter
+type CountRepo interface {
+	CreateCountSession(ctx context.Context, session *models.CountSession) (int, string, error)
+	ListCountSessions(ctx context.Context, status string) ([]models.CountSession, error)
+	GetCountSession(ctx context.Context, id int) (*models.CountSession, error)
+	RecordCounts(ctx context.Context, userID, id int, counts []models.CountEntry) error
+	SubmitCountSession(ctx context.Context, id int) (string, []models.CountLine, error)
+	ApproveCountSession(ctx context.Context, userID, id int) ([]int, error)
+	CancelCountSession(ctx context.Context, id int) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ReturnRepo --output=../../../mocks --filename=mock_return_repo.go --with-expecter
+type ReturnRepo interface {
+	CreateReturnAuthorization(ctx context.Context, rma *models.ReturnAuthorization) (int, string, error)
+	ListReturnAuthorizations(ctx context.Context, status string, orderID int) ([]models.ReturnAuthorization, error)
+	GetReturnAuthorization(ctx context.Context, id int) (*models.ReturnAuthorization, error)
+	ReceiveReturn(ctx context.Context, userID, id int, inspections []models.ReturnInspection) (string, error)
+	CancelReturnAuthorization(ctx context.Context, userID, id int) error
+	ReturnReasonStats(ctx context.Context, from, to *time.Time) ([]models.ReturnReasonStat, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=StockStatusRepo --output=../../../mocks --filename=mock_stock_status_repo.go --with-expecter
+type StockStatusRepo interface {
+	ChangeStockStatus(ctx context.Context, userID int, change *models.StockStatusChange) error
+	GetItemStock(ctx context.Context, itemID int) (*models.ItemStock, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=WriteOffRepo --output=../../../mocks --filename=mock_write_off_repo.go --with-expecter
+type WriteOffRepo interface {
+	ValueWriteOffLines(ctx context.Context, lines []models.WriteOffLine) ([]models.Money, error)
+	CreateWriteOff(ctx context.Context, wo *models.WriteOff) (int, string, error)
+	ListWriteOffs(ctx context.Context, status string) ([]models.WriteOff, error)
+	GetWriteOff(ctx context.Context, id int) (*models.WriteOff, error)
+	ApproveWriteOff(ctx context.Context, userID, id int, note string) (*models.WriteOff, error)
+	RejectWriteOff(ctx context.Context, userID, id int, note string) error
+	AddWriteOffEvidence(ctx context.Context, evidence *models.WriteOffEvidence) (int, error)
+	GetWriteOffEvidence(ctx context.Context, writeOffID, id int) (*models.WriteOffEvidence, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ChangeRequestRepo --output=../../../mocks --filename=mock_change_request_repo.go --with-expecter
+type ChangeRequestRepo interface {
+	CreateChangeRequest(ctx context.Context, cr *models.ChangeRequest) (int, error)
+	ListChangeRequests(ctx context.Context, status string) ([]models.ChangeRequest, error)
+	GetChangeRequest(ctx context.Context, id int) (*models.ChangeRequest, error)
+	ApproveChangeRequest(ctx context.Context, userID, id int, note string) (*models.ChangeRequest, error)
+	RejectChangeRequest(ctx context.Context, userID, id int, note string) error
+	CancelChangeRequest(ctx context.Context, userID, id int) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ValuationRepo --output=../../../mocks --filename=mock_valuation_repo.go --with-expecter
+type ValuationRepo interface {
+	GetDefaultValuationMethod(ctx context.Context) (string, error)
+	SetDefaultValuationMethod(ctx context.Context, method string) error
+	SetItemValuationMethod(ctx context.Context, userID, itemID int, method string) error
+	GetItemCost(ctx context.Context, itemID int) (*models.ItemCost, error)
+	ListItemValuations(ctx context.Context, before time.Time) ([]models.ItemValuation, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=CurrencyRepo --output=../../../mocks --filename=mock_currency_repo.go --with-expecter
+type CurrencyRepo interface {
+	ListCurrencies(ctx context.Context) ([]models.Currency, error)
+	CreateCurrency(ctx context.Context, cur *models.Currency) error
+	GetBaseCurrency(ctx context.Context) (string, error)
+	GetCurrencyRate(ctx context.Context, code string, date time.Time) (models.Rate, error)
+	ListExchangeRates(ctx context.Context, filter models.ExchangeRateFilter) ([]models.ExchangeRate, error)
+	SetExchangeRate(ctx context.Context, rate *models.ExchangeRate) (int, error)
+	ImportExchangeRates(ctx context.Context, rates []models.ExchangeRate) error
+	DeleteExchangeRate(ctx context.Context, id int) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=KitRepo --output=../../../mocks --filename=mock_kit_repo.go --with-expecter
+type KitRepo interface {
+	GetKit(ctx context.Context, itemID int) (*models.Kit, error)
+	SetKitComponents(ctx context.Context, kit *models.Kit) error
+	DeleteKit(ctx context.Context, itemID int) error
+	AssembleKit(ctx context.Context, assembly *models.KitAssembly) error
+	ListKitAssemblies(ctx context.Context, kitID int) ([]models.KitAssembly, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=AnalysisRepo --output=../../../mocks --filename=mock_analysis_repo.go --with-expecter
+type AnalysisRepo interface {
+	ListItemDemand(ctx context.Context, from, to time.Time) ([]models.ItemDemand, error)
+	SaveItemAnalysis(ctx context.Context, results []models.ItemAnalysis) error
+	ListItemAnalysis(ctx context.Context) ([]models.ItemAnalysis, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ForecastRepo --output=../../../mocks --filename=mock_forecast_repo.go --with-expecter
+type ForecastRepo interface {
+	ListItemIssues(ctx context.Context, itemID int, from, to time.Time) ([]models.DemandPeriod, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=DashboardRepo --output=../../../mocks --filename=mock_dashboard_repo.go --with-expecter
+type DashboardRepo interface {
+	GetDashboard(ctx context.Context, today, since time.Time, limit int) (*models.Dashboard, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ReportRepo --output=../../../mocks --filename=mock_report_repo.go --with-expecter
+type ReportRepo interface {
+	StreamMovements(ctx context.Context, filter models.ReportFilter, fn func(models.MovementRecord) error) error
+	StreamUserActivity(ctx context.Context, filter models.ReportFilter, fn func(models.ActivityRecord) error) error
+	StreamBalances(ctx context.Context, filter models.ReportFilter, fn func(models.BalanceRecord) error) error
+}
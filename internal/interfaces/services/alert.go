@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=AlertService --output=../../../mocks --filename=mock_alert_service.go --with-expecter
+type AlertService interface {
+	SetStockLevel(ctx context.Context, level *models.StockLevel) error
+	GetLowStock(ctx context.Context) ([]models.StockPosition, error)
+
+	CheckItem(ctx context.Context, itemID int) error
+	CheckAll(ctx context.Context) (int, error)
+
+	GetAlerts(ctx context.Context, status string) ([]models.StockAlert, error)
+	AcknowledgeAlert(ctx context.Context, userID, id int) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=StockNotifier --output=../../../mocks --filename=mock_stock_notifier.go --with-expecter
+type StockNotifier interface {
+	Notify(itemID int)
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=AnalysisService --output=../../../mocks --filename=mock_analysis_service.go --with-expecter
+type AnalysisService interface {
+	Analyze(ctx context.Context) (int, error)
+	GetABCReport(ctx context.Context, filter models.AnalysisFilter) (*models.ABCReport, error)
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ApprovalService --output=../../../mocks --filename=mock_approval_service.go --with-expecter
+type ApprovalService interface {
+	GetChangeRequests(ctx context.Context, status string) ([]models.ChangeRequest, error)
+	GetChangeRequest(ctx context.Context, id int) (*models.ChangeRequest, error)
+
+	ApproveChangeRequest(ctx context.Context, userID, id int, note string) error
+	RejectChangeRequest(ctx context.Context, userID, id int, note string) error
+	CancelChangeRequest(ctx context.Context, userID, id int) error
+}
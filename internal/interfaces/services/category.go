@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=CategoryService --output=../../../mocks --filename=mock_category_service.go --with-expecter
+type CategoryService interface {
+	CreateCategory(ctx context.Context, cat *models.Category) (int, error)
+	GetCategoryTree(ctx context.Context) ([]models.Category, error)
+	MoveCategory(ctx context.Context, id int, parentID *int) error
+	MergeCategory(ctx context.Context, userID, sourceID, targetID int) (int, error)
+
+	CreateAttribute(ctx context.Context, def *models.AttributeDefinition) (int, error)
+	GetCategoryAttributes(ctx context.Context, categoryID int) ([]models.AttributeDefinition, error)
+}
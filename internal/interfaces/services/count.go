@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=CountService --output=../../../mocks --filename=mock_count_service.go --with-expecter
+type CountService interface {
+	CreateSession(ctx context.Context, userID int, session *models.CountSession) (int, string, error)
+	GetSessions(ctx context.Context, status string) ([]models.CountSession, error)
+	GetSession(ctx context.Context, id int) (*models.CountSession, error)
+
+	RecordCounts(ctx context.Context, userID, id int, counts []models.CountEntry) error
+	SubmitSession(ctx context.Context, id int) (string, []models.CountLine, error)
+	ApproveSession(ctx context.Context, userID, id int) (int, error)
+	CancelSession(ctx context.Context, id int) error
+}
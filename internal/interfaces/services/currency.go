@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=CurrencyService --output=../../../mocks --filename=mock_currency_service.go --with-expecter
+type CurrencyService interface {
+	GetCurrencies(ctx context.Context) ([]models.Currency, error)
+	CreateCurrency(ctx context.Context, cur *models.Currency) error
+
+	GetRates(ctx context.Context, filter models.ExchangeRateFilter) ([]models.ExchangeRate, error)
+	SetRate(ctx context.Context, userID int, rate *models.ExchangeRate) (int, error)
+	ImportRates(ctx context.Context, userID int, data []byte) (int, error)
+	DeleteRate(ctx context.Context, id int) error
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=DashboardService --output=../../../mocks --filename=mock_dashboard_service.go --with-expecter
+type DashboardService interface {
+	GetDashboard(ctx context.Context, role string) (*models.Dashboard, error)
+}
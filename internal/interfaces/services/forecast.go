@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ForecastService --output=../../../mocks --filename=mock_forecast_service.go --with-expecter
+type ForecastService interface {
+	GetForecast(ctx context.Context, itemID, horizon int) (*models.Forecast, error)
+	ApplyRecommendation(ctx context.Context, itemID int) (*models.StockLevel, error)
+}
@@ -9,9 +9,11 @@ import (
 //go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=InventoryService --output=../../../mocks --filename=mock_inventory_service.go --with-expecter
 type InventoryService interface {
 	AddItem(ctx context.Context, userID int, item *models.Item) (int, error)
-	GetInventory(ctx context.Context) ([]models.Item, error)
-	UpdateItem(ctx context.Context, userID, id int, item *models.Item) error
-	DeleteItem(ctx context.Context, userID, id int) error
+	GetInventory(ctx context.Context, filter models.ItemFilter) ([]models.Item, error)
+	GetItem(ctx context.Context, id int) (*models.Item, error)
+	GetItemByBarcode(ctx context.Context, code string) (*models.Item, error)
+	UpdateItem(ctx context.Context, userID, id int, item *models.Item) (*models.ChangeRequest, error)
+	DeleteItem(ctx context.Context, userID, id int) (*models.ChangeRequest, error)
 
 	GetItemHistory(ctx context.Context, id int) ([]models.ItemHistory, error)
 }
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=KitService --output=../../../mocks --filename=mock_kit_service.go --with-expecter
+type KitService interface {
+	SetKit(ctx context.Context, kit *models.Kit) (*models.Kit, error)
+	DeleteKit(ctx context.Context, itemID int) error
+
+	AssembleKit(ctx context.Context, userID int, assembly *models.KitAssembly) error
+	GetAssemblies(ctx context.Context, itemID int) ([]models.KitAssembly, error)
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/internal/label"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=LabelService --output=../../../mocks --filename=mock_label_service.go --with-expecter
+type LabelService interface {
+	ItemLabel(ctx context.Context, itemID int, kind, format string) ([]byte, error)
+	LocationLabel(ctx context.Context, locationID int, kind, format string) ([]byte, error)
+	ItemLabelSheet(ctx context.Context, itemIDs []int, kind string, layout label.Layout) ([]byte, error)
+}
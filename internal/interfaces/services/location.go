@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=LocationService --output=../../../mocks --filename=mock_location_service.go --with-expecter
+type LocationService interface {
+	CreateLocation(ctx context.Context, loc *models.Location) (int, error)
+	GetLocations(ctx context.Context) ([]models.Location, error)
+	SetItemPickLocation(ctx context.Context, itemID int, locationID *int) error
+}
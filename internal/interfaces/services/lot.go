@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=LotService --output=../../../mocks --filename=mock_lot_service.go --with-expecter
+type LotService interface {
+	ReceiveLot(ctx context.Context, userID int, lot *models.Lot) (int, error)
+	GetItemLots(ctx context.Context, itemID int) ([]models.Lot, error)
+	Issue(ctx context.Context, userID, itemID, quantity int) ([]models.LotPick, models.Money, error)
+
+	GetExpiringLots(ctx context.Context, days int) ([]models.ExpiringLot, error)
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=OutboundService --output=../../../mocks --filename=mock_outbound_service.go --with-expecter
+type OutboundService interface {
+	CreateOutboundOrder(ctx context.Context, userID int, order *models.OutboundOrder) (int, string, error)
+	GetOutboundOrders(ctx context.Context, status string) ([]models.OutboundOrder, error)
+	GetOutboundOrder(ctx context.Context, id int) (*models.OutboundOrder, error)
+
+	AllocateOutboundOrder(ctx context.Context, userID, id int) (string, int, error)
+	StartPicking(ctx context.Context, userID, id int) ([]models.PickListEntry, error)
+	PackOutboundOrder(ctx context.Context, userID, id int, lines []models.OutboundQuantity) (string, error)
+	ShipOutboundOrder(ctx context.Context, userID, id int, trackingRef string, lines []models.OutboundQuantity) (int, string, error)
+	CancelOutboundOrder(ctx context.Context, userID, id int, note string) error
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=PurchaseService --output=../../../mocks --filename=mock_purchase_service.go --with-expecter
+type PurchaseService interface {
+	CreatePurchaseOrder(ctx context.Context, userID int, po *models.PurchaseOrder) (int, string, error)
+	GetPurchaseOrders(ctx context.Context, filter models.PurchaseOrderFilter) ([]models.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id int) (*models.PurchaseOrder, error)
+	SendPurchaseOrder(ctx context.Context, id int) error
+	ClosePurchaseOrder(ctx context.Context, id int) error
+	DeletePurchaseOrder(ctx context.Context, id int) error
+
+	ReceivePurchaseOrder(ctx context.Context, userID, id int, lines []models.ReceiptLine, note string) (string, error)
+}
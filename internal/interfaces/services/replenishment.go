@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ReplenishmentService --output=../../../mocks --filename=mock_replenishment_service.go --with-expecter
+type ReplenishmentService interface {
+	GetSuggestions(ctx context.Context) ([]models.ReplenishmentSuggestion, error)
+	CreatePurchaseOrders(ctx context.Context, userID int, accepted []models.AcceptedSuggestion) ([]models.PurchaseOrder, error)
+}
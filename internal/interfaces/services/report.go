@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ReportService --output=../../../mocks --filename=mock_report_service.go --with-expecter
+type ReportService interface {
+	WriteMovements(ctx context.Context, filter models.ReportFilter, format string, w io.Writer) error
+	WriteUserActivity(ctx context.Context, filter models.ReportFilter, format string, w io.Writer) error
+	WriteBalances(ctx context.Context, filter models.ReportFilter, format string, w io.Writer) error
+}
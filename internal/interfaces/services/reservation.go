@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ReservationService --output=../../../mocks --filename=mock_reservation_service.go --with-expecter
+type ReservationService interface {
+	Reserve(ctx context.Context, userID int, res *models.Reservation) (int, error)
+	GetItemReservations(ctx context.Context, itemID int) ([]models.Reservation, error)
+	Release(ctx context.Context, id int) error
+	Confirm(ctx context.Context, userID, id int) error
+
+	ReleaseExpired(ctx context.Context) (int, error)
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ReturnService --output=../../../mocks --filename=mock_return_service.go --with-expecter
+type ReturnService interface {
+	CreateReturn(ctx context.Context, userID int, rma *models.ReturnAuthorization) (int, string, error)
+	GetReturns(ctx context.Context, status string, orderID int) ([]models.ReturnAuthorization, error)
+	GetReturn(ctx context.Context, id int) (*models.ReturnAuthorization, error)
+
+	ReceiveReturn(ctx context.Context, userID, id int, inspections []models.ReturnInspection) (string, error)
+	CancelReturn(ctx context.Context, userID, id int) error
+
+	GetReturnReasonStats(ctx context.Context, from, to *time.Time) ([]models.ReturnReasonStat, error)
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=SerialService --output=../../../mocks --filename=mock_serial_service.go --with-expecter
+type SerialService interface {
+	RegisterSerials(ctx context.Context, userID, itemID int, serialNumbers []string) error
+	GetItemSerials(ctx context.Context, itemID int, status string) ([]models.Serial, error)
+	GetSerial(ctx context.Context, serialNumber string) (*models.Serial, error)
+	MoveSerial(ctx context.Context, userID int, serialNumber, status string) error
+}
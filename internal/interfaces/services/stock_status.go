@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=StockStatusService --output=../../../mocks --filename=mock_stock_status_service.go --with-expecter
+type StockStatusService interface {
+	ChangeStockStatus(ctx context.Context, userID int, change *models.StockStatusChange) error
+	GetItemStock(ctx context.Context, itemID int) (*models.ItemStock, error)
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=SupplierService --output=../../../mocks --filename=mock_supplier_service.go --with-expecter
+type SupplierService interface {
+	CreateSupplier(ctx context.Context, sup *models.Supplier) (int, error)
+	GetSuppliers(ctx context.Context, status string) ([]models.Supplier, error)
+	GetSupplier(ctx context.Context, id int) (*models.Supplier, error)
+	UpdateSupplier(ctx context.Context, sup *models.Supplier) error
+	DeleteSupplier(ctx context.Context, id int) error
+
+	SetItemSupplier(ctx context.Context, link *models.ItemSupplier) error
+	RemoveItemSupplier(ctx context.Context, itemID, supplierID int) error
+	GetItemSuppliers(ctx context.Context, itemID int) ([]models.ItemSupplier, error)
+	GetSupplierItems(ctx context.Context, supplierID int) ([]models.ItemSupplier, error)
+}
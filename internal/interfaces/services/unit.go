@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=UnitService --output=../../../mocks --filename=mock_unit_service.go --with-expecter
+type UnitService interface {
+	SetItemUnits(ctx context.Context, userID, itemID int, baseUnit string, units []models.ItemUnit) ([]models.ItemUnit, error)
+	ToBaseQuantity(ctx context.Context, itemID int, qty float64, unit string) (int, error)
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=UserService --output=../../../mocks --filename=mock_user_service.go --with-expecter
+type UserService interface {
+	ChangeUserRole(ctx context.Context, userID, id int, role string) (*models.ChangeRequest, error)
+}
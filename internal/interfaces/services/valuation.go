@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ValuationService --output=../../../mocks --filename=mock_valuation_service.go --with-expecter
+type ValuationService interface {
+	GetDefaultMethod(ctx context.Context) (string, error)
+	SetDefaultMethod(ctx context.Context, method string) error
+	SetItemMethod(ctx context.Context, userID, itemID int, method string) error
+
+	GetItemCost(ctx context.Context, itemID int) (*models.ItemCost, error)
+	GetValuation(ctx context.Context, asOf *time.Time, currency string) (*models.ValuationReport, error)
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=WriteOffService --output=../../../mocks --filename=mock_write_off_service.go --with-expecter
+type WriteOffService interface {
+	CreateWriteOff(ctx context.Context, userID int, wo *models.WriteOff) (int, string, error)
+	GetWriteOffs(ctx context.Context, status string) ([]models.WriteOff, error)
+	GetWriteOff(ctx context.Context, id int) (*models.WriteOff, error)
+
+	ApproveWriteOff(ctx context.Context, userID, id int, note string) error
+	RejectWriteOff(ctx context.Context, userID, id int, note string) error
+
+	AddEvidence(ctx context.Context, userID int, evidence *models.WriteOffEvidence) (int, error)
+	GetEvidence(ctx context.Context, writeOffID, id int) (*models.WriteOffEvidence, error)
+}
@@ -0,0 +1,30 @@
+package label
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	itemCodePrefix = "ITEM-"
+)
+
+// ItemCode - содержимое этикетки item без sku и штрихкодов.
+func ItemCode(id int) string {
+	return itemCodePrefix + strconv.Itoa(id)
+}
+
+// ParseItemCode - получение id item из содержимого этикетки, созданного ItemCode.
+func ParseItemCode(code string) (int, bool) {
+	rest, ok := strings.CutPrefix(code, itemCodePrefix)
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(rest)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,204 @@
+// Package label - генерация этикеток со штрихкодами Code128 и QR.
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+const (
+	KindCode128 = "code128"
+	KindQR      = "qr"
+
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+const (
+	code128QuietZone = 10
+	code128BarHeight = 40
+	qrQuietZone      = 4
+	pngModuleSize    = 4
+)
+
+// symbol - закодированный штрихкод в модулях с учетом тихой зоны.
+type symbol struct {
+	code   barcode.Barcode
+	width  int
+	height int
+	quiet  int
+	linear bool
+}
+
+// rect - прямоугольник из темных модулей.
+type rect struct {
+	x, y, w, h int
+}
+
+// Render - отрисовка штрихкода kind с содержимым content в формате format.
+func Render(w io.Writer, kind, format, content string) error {
+	s, err := encode(kind, content)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatPNG:
+		return s.writePNG(w)
+	case FormatSVG:
+		return s.writeSVG(w)
+	default:
+		return fmt.Errorf("неизвестный формат этикетки %s", format)
+	}
+}
+
+// RenderBytes - отрисовка штрихкода в память.
+func RenderBytes(kind, format, content string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Render(&buf, kind, format, content); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ContentType - MIME-тип для формата этикетки.
+func ContentType(format string) string {
+	switch format {
+	case FormatPNG:
+		return "image/png"
+	case FormatSVG:
+		return "image/svg+xml"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func encode(kind, content string) (*symbol, error) {
+	if content == "" {
+		return nil, fmt.Errorf("содержимое этикетки не может быть пустым")
+	}
+
+	switch kind {
+	case KindCode128:
+		code, err := code128.Encode(content)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось закодировать %q в Code128: %w", content, err)
+		}
+		return &symbol{
+			code:   code,
+			width:  code.Bounds().Dx() + 2*code128QuietZone,
+			height: code128BarHeight,
+			quiet:  code128QuietZone,
+			linear: true,
+		}, nil
+	case KindQR:
+		code, err := qr.Encode(content, qr.M, qr.Unicode)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось закодировать %q в QR: %w", content, err)
+		}
+		size := code.Bounds().Dx() + 2*qrQuietZone
+		return &symbol{
+			code:   code,
+			width:  size,
+			height: size,
+			quiet:  qrQuietZone,
+		}, nil
+	default:
+		return nil, fmt.Errorf("неизвестный тип штрихкода %s", kind)
+	}
+}
+
+// dark - признак темного модуля в координатах с учетом тихой зоны.
+func (s *symbol) dark(x, y int) bool {
+	x -= s.quiet
+	if s.linear {
+		y = 0
+	} else {
+		y -= s.quiet
+	}
+
+	b := s.code.Bounds()
+	if x < 0 || y < 0 || x >= b.Dx() || y >= b.Dy() {
+		return false
+	}
+
+	r, _, _, _ := s.code.At(b.Min.X+x, b.Min.Y+y).RGBA()
+
+	return r == 0
+}
+
+// rects - темные модули, объединенные в горизонтальные полосы.
+// Для линейного штрихкода полосы растянуты на всю высоту.
+func (s *symbol) rects() []rect {
+	rows, h := s.height, 1
+	if s.linear {
+		rows, h = 1, s.height
+	}
+
+	var rects []rect
+	for y := 0; y < rows; y++ {
+		for x := 0; x < s.width; {
+			if !s.dark(x, y) {
+				x++
+				continue
+			}
+			start := x
+			for x < s.width && s.dark(x, y) {
+				x++
+			}
+			rects = append(rects, rect{x: start, y: y, w: x - start, h: h})
+		}
+	}
+
+	return rects
+}
+
+func (s *symbol) writePNG(w io.Writer) error {
+	img := image.NewGray(image.Rect(0, 0, s.width*pngModuleSize, s.height*pngModuleSize))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	for _, r := range s.rects() {
+		for y := r.y * pngModuleSize; y < (r.y+r.h)*pngModuleSize; y++ {
+			for x := r.x * pngModuleSize; x < (r.x+r.w)*pngModuleSize; x++ {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			}
+		}
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("не удалось записать PNG: %w", err)
+	}
+
+	return nil
+}
+
+func (s *symbol) writeSVG(w io.Writer) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" shape-rendering="crispEdges">`,
+		s.width, s.height, s.width*pngModuleSize, s.height*pngModuleSize,
+	)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, s.width, s.height)
+	buf.WriteString(`<path fill="#000" d="`)
+	for _, r := range s.rects() {
+		fmt.Fprintf(&buf, "M%d %dh%dv%dh-%dz", r.x, r.y, r.w, r.h, r.w)
+	}
+	buf.WriteString(`"/></svg>`)
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("не удалось записать SVG: %w", err)
+	}
+
+	return nil
+}
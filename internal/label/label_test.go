@@ -0,0 +1,130 @@
+package label
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_PNG(t *testing.T) {
+	for _, kind := range []string{KindCode128, KindQR} {
+		data, err := RenderBytes(kind, FormatPNG, "SKU-0001")
+		require.NoError(t, err, kind)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err, kind)
+		assert.Positive(t, img.Bounds().Dx(), kind)
+
+		// Тихая зона слева должна быть белой
+		r, _, _, _ := img.At(0, img.Bounds().Dy()/2).RGBA()
+		assert.Equal(t, uint32(0xffff), r, kind)
+	}
+}
+
+func TestRender_SVG(t *testing.T) {
+	data, err := RenderBytes(KindCode128, FormatSVG, "LOC-A-01")
+
+	require.NoError(t, err)
+	svg := string(data)
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.True(t, strings.HasSuffix(svg, "</svg>"))
+	assert.Contains(t, svg, `<path fill="#000" d="M`)
+}
+
+func TestRender_QRModulesAreSquare(t *testing.T) {
+	s, err := encode(KindQR, "LOC-A-01")
+
+	require.NoError(t, err)
+	assert.Equal(t, s.width, s.height)
+	for _, r := range s.rects() {
+		assert.Equal(t, 1, r.h)
+	}
+}
+
+func TestRender_ErrUnknownKind(t *testing.T) {
+	_, err := RenderBytes("datamatrix", FormatPNG, "SKU-0001")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "неизвестный тип штрихкода")
+}
+
+func TestRender_ErrUnknownFormat(t *testing.T) {
+	_, err := RenderBytes(KindQR, "gif", "SKU-0001")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "неизвестный формат")
+}
+
+func TestRender_ErrEmptyContent(t *testing.T) {
+	_, err := RenderBytes(KindCode128, FormatSVG, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не может быть пустым")
+}
+
+func TestRender_ErrCode128NonASCII(t *testing.T) {
+	_, err := RenderBytes(KindCode128, FormatPNG, "Товар")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Code128")
+}
+
+func TestSheet_OK(t *testing.T) {
+	layout := Layouts["a4-65"]
+	labels := make([]Label, 0, 70)
+	for i := 0; i < 70; i++ {
+		labels = append(labels, Label{Title: "Очень длинное название товара для этикетки", Content: "SKU-0001"})
+	}
+
+	for _, kind := range []string{KindCode128, KindQR} {
+		var buf bytes.Buffer
+		err := Sheet(&buf, layout, kind, labels)
+
+		require.NoError(t, err, kind)
+		assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")), kind)
+		// 70 этикеток на листах по 65 - две страницы
+		assert.Equal(t, 2, bytes.Count(buf.Bytes(), []byte("/Type /Page\n")), kind)
+	}
+}
+
+func TestSheet_ErrEmptyLabels(t *testing.T) {
+	var buf bytes.Buffer
+	err := Sheet(&buf, Layouts["a4-21"], KindQR, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не может быть пустым")
+}
+
+func TestLayout_Validate(t *testing.T) {
+	for _, name := range LayoutNames() {
+		assert.NoError(t, Layouts[name].Validate(), name)
+	}
+
+	tooWide := Layouts["a4-21"]
+	tooWide.Columns = 4
+	assert.ErrorContains(t, tooWide.Validate(), "не помещаются")
+
+	noRows := Layouts["a4-21"]
+	noRows.Rows = 0
+	assert.ErrorContains(t, noRows.Validate(), "больше 0")
+
+	tooLow := Layouts["a4-21"]
+	tooLow.LabelHeight = 8
+	assert.ErrorContains(t, tooLow.Validate(), "высота этикетки")
+}
+
+func TestItemCode_RoundTrip(t *testing.T) {
+	id, ok := ParseItemCode(ItemCode(42))
+
+	assert.True(t, ok)
+	assert.Equal(t, 42, id)
+
+	for _, code := range []string{"42", "ITEM-", "ITEM-abc", "ITEM--1", "SKU-42"} {
+		_, ok := ParseItemCode(code)
+		assert.False(t, ok, code)
+	}
+}
@@ -0,0 +1,198 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	sheetFont       = "goregular"
+	labelPadding    = 2.0
+	titleFontSize   = 8.0
+	captionFontSize = 7.0
+	textLineHeight  = 3.5
+	ellipsis        = "..."
+)
+
+// Layout - раскладка листа этикеток, размеры в миллиметрах.
+type Layout struct {
+	Name        string
+	PageWidth   float64
+	PageHeight  float64
+	Columns     int
+	Rows        int
+	LabelWidth  float64
+	LabelHeight float64
+	MarginLeft  float64
+	MarginTop   float64
+	GapX        float64
+	GapY        float64
+}
+
+// Layouts - стандартные раскладки листов A4 с самоклеящимися этикетками.
+var Layouts = map[string]Layout{
+	"a4-14": {
+		Name: "a4-14", PageWidth: 210, PageHeight: 297, Columns: 2, Rows: 7,
+		LabelWidth: 99.1, LabelHeight: 38.1, MarginLeft: 4.65, MarginTop: 15.15, GapX: 2.5,
+	},
+	"a4-21": {
+		Name: "a4-21", PageWidth: 210, PageHeight: 297, Columns: 3, Rows: 7,
+		LabelWidth: 63.5, LabelHeight: 38.1, MarginLeft: 7.2, MarginTop: 15.15, GapX: 2.5,
+	},
+	"a4-65": {
+		Name: "a4-65", PageWidth: 210, PageHeight: 297, Columns: 5, Rows: 13,
+		LabelWidth: 38.1, LabelHeight: 21.2, MarginLeft: 4.65, MarginTop: 10.7, GapX: 2.5,
+	},
+}
+
+// LayoutNames - отсортированные имена стандартных раскладок.
+func LayoutNames() []string {
+	names := make([]string, 0, len(Layouts))
+	for name := range Layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Validate - проверка, что этикетки раскладки помещаются на лист.
+func (l Layout) Validate() error {
+	if l.PageWidth <= 0 || l.PageHeight <= 0 || l.LabelWidth <= 0 || l.LabelHeight <= 0 {
+		return fmt.Errorf("размеры листа и этикетки должны быть больше 0")
+	}
+	if l.Columns <= 0 || l.Rows <= 0 {
+		return fmt.Errorf("количество столбцов и строк должно быть больше 0")
+	}
+	if l.MarginLeft < 0 || l.MarginTop < 0 || l.GapX < 0 || l.GapY < 0 {
+		return fmt.Errorf("поля и промежутки не могут быть отрицательными")
+	}
+
+	width := l.MarginLeft + float64(l.Columns)*l.LabelWidth + float64(l.Columns-1)*l.GapX
+	height := l.MarginTop + float64(l.Rows)*l.LabelHeight + float64(l.Rows-1)*l.GapY
+	if width > l.PageWidth || height > l.PageHeight {
+		return fmt.Errorf("этикетки не помещаются на лист %.1fx%.1f мм", l.PageWidth, l.PageHeight)
+	}
+	if l.LabelHeight <= 2*labelPadding+2*textLineHeight {
+		return fmt.Errorf("высота этикетки должна быть больше %.1f мм", 2*labelPadding+2*textLineHeight)
+	}
+
+	return nil
+}
+
+// Label - содержимое одной этикетки листа.
+type Label struct {
+	Title   string
+	Content string
+}
+
+// Sheet - формирование PDF листов этикеток kind по раскладке layout.
+func Sheet(w io.Writer, layout Layout, kind string, labels []Label) error {
+	if len(labels) == 0 {
+		return fmt.Errorf("список этикеток не может быть пустым")
+	}
+	if err := layout.Validate(); err != nil {
+		return fmt.Errorf("некорректная раскладка: %w", err)
+	}
+
+	symbols := make([]*symbol, 0, len(labels))
+	for _, l := range labels {
+		s, err := encode(kind, l.Content)
+		if err != nil {
+			return err
+		}
+		symbols = append(symbols, s)
+	}
+
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "mm",
+		Size:           fpdf.SizeType{Wd: layout.PageWidth, Ht: layout.PageHeight},
+	})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddUTF8FontFromBytes(sheetFont, "", goregular.TTF)
+
+	perPage := layout.Columns * layout.Rows
+	for i, l := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+
+		cell := i % perPage
+		x := layout.MarginLeft + float64(cell%layout.Columns)*(layout.LabelWidth+layout.GapX)
+		y := layout.MarginTop + float64(cell/layout.Columns)*(layout.LabelHeight+layout.GapY)
+
+		drawLabel(pdf, layout, x, y, l, symbols[i])
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return fmt.Errorf("не удалось сформировать PDF: %w", err)
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("не удалось записать PDF: %w", err)
+	}
+
+	return nil
+}
+
+// drawLabel - отрисовка этикетки: название сверху, штрихкод по центру, содержимое снизу.
+func drawLabel(pdf *fpdf.Fpdf, layout Layout, x, y float64, l Label, s *symbol) {
+	innerWidth := layout.LabelWidth - 2*labelPadding
+	top := y + labelPadding
+	bottom := y + layout.LabelHeight - labelPadding
+
+	pdf.SetFont(sheetFont, "", titleFontSize)
+	pdf.SetXY(x+labelPadding, top)
+	pdf.CellFormat(innerWidth, textLineHeight, fitText(pdf, l.Title, innerWidth), "", 0, "C", false, 0, "")
+
+	pdf.SetFont(sheetFont, "", captionFontSize)
+	pdf.SetXY(x+labelPadding, bottom-textLineHeight)
+	pdf.CellFormat(innerWidth, textLineHeight, fitText(pdf, l.Content, innerWidth), "", 0, "C", false, 0, "")
+
+	areaTop := top + textLineHeight
+	areaHeight := bottom - textLineHeight - areaTop
+
+	module := innerWidth / float64(s.width)
+	symbolHeight := areaHeight
+	if !s.linear {
+		module = min(module, areaHeight/float64(s.height))
+		symbolHeight = module * float64(s.height)
+	}
+	symbolWidth := module * float64(s.width)
+	moduleHeight := symbolHeight / float64(s.height)
+
+	originX := x + (layout.LabelWidth-symbolWidth)/2
+	originY := areaTop + (areaHeight-symbolHeight)/2
+
+	pdf.SetFillColor(0, 0, 0)
+	for _, r := range s.rects() {
+		pdf.Rect(
+			originX+float64(r.x)*module,
+			originY+float64(r.y)*moduleHeight,
+			float64(r.w)*module,
+			float64(r.h)*moduleHeight,
+			"F",
+		)
+	}
+}
+
+// fitText - обрезка текста до ширины width с многоточием.
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+ellipsis) > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + ellipsis
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// utf8BOM - метка порядка байтов, чтобы Excel открывал CSV с кириллицей в UTF-8.
+const utf8BOM = "\xef\xbb\xbf"
+
+// csvWriter - CSV со строкой заголовка.
+type csvWriter struct {
+	w      *csv.Writer
+	fields []string
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, err
+	}
+
+	cw := &csvWriter{w: csv.NewWriter(w), fields: make([]string, len(columns))}
+	if err := cw.w.Write(columns); err != nil {
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+// WriteRow - запись строки CSV.
+func (cw *csvWriter) WriteRow(values ...any) error {
+	for i := range cw.fields {
+		cw.fields[i] = ""
+		if i < len(values) {
+			cw.fields[i], _ = text(values[i])
+		}
+	}
+
+	return cw.w.Write(cw.fields)
+}
+
+// Close - сброс буфера.
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+
+	return cw.w.Error()
+}
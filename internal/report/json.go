@@ -0,0 +1,75 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonWriter - массив JSON объектов, ключи в порядке колонок.
+type jsonWriter struct {
+	w     *bufio.Writer
+	keys  [][]byte
+	first bool
+}
+
+func newJSONWriter(w io.Writer, columns []string) (*jsonWriter, error) {
+	keys := make([][]byte, 0, len(columns))
+	for _, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	jw := &jsonWriter{w: bufio.NewWriter(w), keys: keys, first: true}
+	if _, err := jw.w.WriteString("["); err != nil {
+		return nil, err
+	}
+
+	return jw, nil
+}
+
+// WriteRow - запись строки как JSON объекта.
+func (jw *jsonWriter) WriteRow(values ...any) error {
+	if !jw.first {
+		jw.w.WriteString(",")
+	}
+	jw.first = false
+
+	jw.w.WriteString("{")
+	for i, key := range jw.keys {
+		if i > 0 {
+			jw.w.WriteString(",")
+		}
+		jw.w.Write(key)
+		jw.w.WriteString(":")
+
+		var v any
+		if i < len(values) {
+			v = values[i]
+		}
+		if t, ok := v.(time.Time); ok {
+			v = t.Format(time.RFC3339)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		jw.w.Write(data)
+	}
+	_, err := jw.w.WriteString("}")
+
+	return err
+}
+
+// Close - закрытие массива и сброс буфера.
+func (jw *jsonWriter) Close() error {
+	if _, err := jw.w.WriteString("]"); err != nil {
+		return err
+	}
+
+	return jw.w.Flush()
+}
@@ -0,0 +1,91 @@
+// Package report - потоковая запись табличных отчетов в JSON, CSV и XLSX.
+// Строки пишутся в выходной поток по мере получения и не накапливаются в памяти.
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Writer - построчная запись отчета. Close дописывает завершающую часть формата и обязателен.
+type Writer interface {
+	WriteRow(values ...any) error
+	Close() error
+}
+
+// ValidateFormat - проверка формата отчета.
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatJSON, FormatCSV, FormatXLSX:
+		return nil
+	}
+
+	return fmt.Errorf("некорректный формат отчета %q: ожидается json, csv или xlsx", format)
+}
+
+// ContentType - MIME тип отчета в формате format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// NewWriter - запись отчета с колонками columns в w в формате format.
+// Для JSON колонки становятся ключами объектов, для CSV и XLSX - строкой заголовка.
+func NewWriter(w io.Writer, format string, columns []string) (Writer, error) {
+	switch format {
+	case FormatJSON:
+		return newJSONWriter(w, columns)
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	}
+
+	return nil, ValidateFormat(format)
+}
+
+// text - значение ячейки в виде текста и признак числа.
+// nil и nil указатели - пустая ячейка, время - RFC3339.
+func text(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case *string:
+		if v == nil {
+			return "", false
+		}
+		return *v, false
+	case int:
+		return strconv.Itoa(v), true
+	case *int:
+		if v == nil {
+			return "", false
+		}
+		return strconv.Itoa(*v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case time.Time:
+		return v.Format(time.RFC3339), false
+	case fmt.Stringer:
+		// Денежные суммы и другие десятичные типы записываются как числа.
+		return v.String(), true
+	default:
+		return fmt.Sprint(v), false
+	}
+}
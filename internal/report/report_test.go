@@ -0,0 +1,111 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var (
+	testColumns = []string{"id", "name", "cost", "note", "created_at"}
+	testTime    = time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+)
+
+func writeTestReport(t *testing.T, format string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format, testColumns)
+	require.NoError(t, err)
+
+	note := "a & <b>"
+	require.NoError(t, w.WriteRow(1, "Болт, М8", models.Money(24000), &note, testTime))
+	require.NoError(t, w.WriteRow(2, "Гайка", models.Money(0), (*string)(nil), testTime))
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestNewWriter_JSON(t *testing.T) {
+	data := writeTestReport(t, FormatJSON)
+
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal(data, &rows))
+	assert.Equal(t, []map[string]any{
+		{"id": 1.0, "name": "Болт, М8", "cost": 2.4, "note": "a & <b>", "created_at": "2026-03-01T10:30:00Z"},
+		{"id": 2.0, "name": "Гайка", "cost": 0.0, "note": nil, "created_at": "2026-03-01T10:30:00Z"},
+	}, rows)
+	assert.True(t, bytes.HasPrefix(data, []byte(`[{"id":1,"name"`)))
+}
+
+func TestNewWriter_JSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatJSON, testColumns)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "[]", buf.String())
+}
+
+func TestNewWriter_CSV(t *testing.T) {
+	data := writeTestReport(t, FormatCSV)
+
+	assert.Equal(t, utf8BOM+
+		"id,name,cost,note,created_at\n"+
+		"1,\"Болт, М8\",2.40,a & <b>,2026-03-01T10:30:00Z\n"+
+		"2,Гайка,0.00,,2026-03-01T10:30:00Z\n", string(data))
+}
+
+func TestNewWriter_XLSX(t *testing.T) {
+	data := writeTestReport(t, FormatXLSX)
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	var names []string
+	var sheet []byte
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			sheet, err = io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+		}
+	}
+
+	assert.Equal(t, []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/worksheets/sheet1.xml",
+	}, names)
+	assert.Contains(t, string(sheet), `<row><c t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, string(sheet), `<row><c><v>1</v></c><c t="inlineStr"><is><t xml:space="preserve">Болт, М8</t></is></c>`+
+		`<c><v>2.40</v></c><c t="inlineStr"><is><t xml:space="preserve">a &amp; &lt;b&gt;</t></is></c>`)
+	assert.Contains(t, string(sheet), `<c><v>0.00</v></c><c/>`)
+	assert.True(t, bytes.HasSuffix(sheet, []byte("</sheetData></worksheet>")))
+}
+
+func TestNewWriter_ErrFormat(t *testing.T) {
+	_, err := NewWriter(io.Discard, "pdf", testColumns)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный формат отчета")
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/json; charset=utf-8", ContentType(FormatJSON))
+	assert.Equal(t, "text/csv; charset=utf-8", ContentType(FormatCSV))
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", ContentType(FormatXLSX))
+}
@@ -0,0 +1,111 @@
+package report
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+)
+
+// sheetName - имя единственного листа книги.
+const sheetName = "report"
+
+// Служебные части книги XLSX: типы содержимого, связи и описание книги с одним листом.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + sheetName + `" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxWriter - книга XLSX с одним листом, строки листа пишутся в zip поток по мере поступления.
+// Строки хранятся как inline строки, поэтому общая таблица строк в памяти не собирается.
+type xlsxWriter struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	columns int
+}
+
+func newXLSXWriter(w io.Writer, columns []string) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	// Лист создается последним: zip допускает запись только в последний открытый файл.
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	xw := &xlsxWriter{zip: zw, sheet: bufio.NewWriter(f), columns: len(columns)}
+	xw.sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	header := make([]any, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column)
+	}
+	if err := xw.WriteRow(header...); err != nil {
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+// WriteRow - запись строки листа: числа - числовыми ячейками, остальное - текстом.
+func (xw *xlsxWriter) WriteRow(values ...any) error {
+	xw.sheet.WriteString("<row>")
+	for i := 0; i < xw.columns; i++ {
+		var v any
+		if i < len(values) {
+			v = values[i]
+		}
+		s, numeric := text(v)
+		switch {
+		case s == "":
+			xw.sheet.WriteString("<c/>")
+		case numeric:
+			xw.sheet.WriteString("<c><v>" + s + "</v></c>")
+		default:
+			xw.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			if err := xml.EscapeText(xw.sheet, []byte(s)); err != nil {
+				return err
+			}
+			xw.sheet.WriteString("</t></is></c>")
+		}
+	}
+	_, err := xw.sheet.WriteString("</row>")
+
+	return err
+}
+
+// Close - закрытие листа и zip архива.
+func (xw *xlsxWriter) Close() error {
+	if _, err := xw.sheet.WriteString("</sheetData></worksheet>"); err != nil {
+		return err
+	}
+	if err := xw.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return xw.zip.Close()
+}
@@ -0,0 +1,76 @@
+package alertsvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+)
+
+// checkerQueueSize - размер очереди сигналов, при переполнении item проверяется периодическим обходом.
+const checkerQueueSize = 256
+
+var _ services.StockNotifier = (*checker)(nil)
+
+type checker struct {
+	svc      services.AlertService
+	interval time.Duration
+	signals  chan int
+}
+
+// NewChecker - конструктор фонового процесса проверки точек заказа.
+// Проверяет items по сигналам Notify и периодически обходит все items с уровнями запаса.
+func NewChecker(svc services.AlertService, interval time.Duration) *checker {
+	return &checker{
+		svc:      svc,
+		interval: interval,
+		signals:  make(chan int, checkerQueueSize),
+	}
+}
+
+// Notify - сигнал об изменении остатка item, не блокирует вызывающий сервис.
+func (c *checker) Notify(itemID int) {
+	select {
+	case c.signals <- itemID:
+	default:
+		zlog.Logger.Warn().
+			Int("item_id", itemID).
+			Msg("Checker: очередь проверок переполнена, item будет проверен периодическим обходом")
+	}
+}
+
+// Run - запуск проверки точек заказа до отмены контекста.
+func (c *checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			zlog.Logger.Info().Msg("Checker: остановлен")
+			return
+		case itemID := <-c.signals:
+			if err := c.svc.CheckItem(ctx, itemID); err != nil {
+				zlog.Logger.Error().
+					Err(err).
+					Int("item_id", itemID).
+					Msg("Checker: не удалось проверить точку заказа item")
+			}
+		case <-ticker.C:
+			count, err := c.svc.CheckAll(ctx)
+			if err != nil {
+				zlog.Logger.Error().
+					Err(err).
+					Msg("Checker: не удалось проверить точки заказа")
+				continue
+			}
+			if count > 0 {
+				zlog.Logger.Info().
+					Int("count", count).
+					Msg("Checker: созданы оповещения о низком остатке")
+			}
+		}
+	}
+}
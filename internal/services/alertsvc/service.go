@@ -0,0 +1,173 @@
+package alertsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.AlertService = (*alertSvc)(nil)
+
+type alertSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового alertSvc.
+func New(db infra.Database) services.AlertService {
+	return &alertSvc{db: db}
+}
+
+// SetStockLevel - метод для задания уровней запаса item на весь склад или на его ячейку подбора
+// с немедленной проверкой точки заказа. Доступный остаток хранится только в ячейке подбора,
+// поэтому уровни для других ячеек не задаются.
+func (s *alertSvc) SetStockLevel(ctx context.Context, level *models.StockLevel) error {
+	if level.MinQty < 0 || level.MinQty > level.ReorderPoint || level.ReorderPoint >= level.MaxQty {
+		return fmt.Errorf("некорректные уровни запаса: требуется 0 <= min_qty <= reorder_point < max_qty")
+	}
+	if level.LocationID != nil {
+		if *level.LocationID <= 0 {
+			return fmt.Errorf("некорректные уровни запаса: location_id должен быть положительным")
+		}
+
+		pick, err := s.db.GetItemPickLocation(ctx, level.ItemID)
+		if err != nil {
+			if strings.Contains(err.Error(), "не найден") {
+				return err
+			}
+
+			return fmt.Errorf("db.GetItemPickLocation: %w", err)
+		}
+		if pick == nil || *pick != *level.LocationID {
+			return fmt.Errorf("нельзя задать уровни запаса item %d для ячейки %d: она не является ячейкой подбора item",
+				level.ItemID, *level.LocationID)
+		}
+	}
+
+	if err := s.db.SetStockLevel(ctx, level); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return err
+		}
+
+		return fmt.Errorf("db.SetStockLevel: %w", err)
+	}
+
+	return s.CheckItem(ctx, level.ItemID)
+}
+
+// GetLowStock - метод для получения items, доступный остаток которых достиг точки заказа на складе или в ячейке подбора.
+func (s *alertSvc) GetLowStock(ctx context.Context) ([]models.StockPosition, error) {
+	positions, err := s.db.ListStockPositions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListStockPositions: %w", err)
+	}
+
+	low := make([]models.StockPosition, 0, len(positions))
+	for _, pos := range positions {
+		if pos.BelowReorderPoint() {
+			low = append(low, pos)
+		}
+	}
+
+	return low, nil
+}
+
+// CheckItem - метод для проверки точки заказа одного item по всем его уровням запаса.
+func (s *alertSvc) CheckItem(ctx context.Context, itemID int) error {
+	positions, err := s.db.ListStockPositions(ctx, &itemID)
+	if err != nil {
+		return fmt.Errorf("db.ListStockPositions: %w", err)
+	}
+
+	for _, pos := range positions {
+		if _, err := s.check(ctx, pos); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckAll - метод для проверки точки заказа всех items с заданными уровнями запаса.
+// Возвращает количество созданных оповещений.
+func (s *alertSvc) CheckAll(ctx context.Context) (int, error) {
+	positions, err := s.db.ListStockPositions(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("db.ListStockPositions: %w", err)
+	}
+
+	raised := 0
+	for _, pos := range positions {
+		created, err := s.check(ctx, pos)
+		if err != nil {
+			return raised, err
+		}
+		if created {
+			raised++
+		}
+	}
+
+	return raised, nil
+}
+
+// GetAlerts - метод для получения оповещений по статусу, при пустом статусе - всех.
+func (s *alertSvc) GetAlerts(ctx context.Context, status string) ([]models.StockAlert, error) {
+	switch status {
+	case "", models.AlertOpen, models.AlertAcknowledged, models.AlertResolved:
+	default:
+		return nil, fmt.Errorf("некорректный статус оповещения %s", status)
+	}
+
+	alerts, err := s.db.ListStockAlerts(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListStockAlerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// AcknowledgeAlert - метод для подтверждения открытого оповещения.
+// Подтвержденное оповещение остается активным и не создается повторно, пока остаток не вернется выше точки заказа.
+func (s *alertSvc) AcknowledgeAlert(ctx context.Context, userID, id int) error {
+	if err := s.db.AcknowledgeStockAlert(ctx, userID, id); err != nil {
+		if strings.Contains(err.Error(), "не найдено") || strings.Contains(err.Error(), "нельзя") {
+			return err
+		}
+
+		return fmt.Errorf("db.AcknowledgeStockAlert: %w", err)
+	}
+
+	return nil
+}
+
+// check - создание оповещения при достижении точки заказа или закрытие активных оповещений после пополнения.
+// Оповещения ведутся отдельно для уровней на весь склад и для ячейки подбора.
+func (s *alertSvc) check(ctx context.Context, pos models.StockPosition) (bool, error) {
+	if !pos.BelowReorderPoint() {
+		if _, err := s.db.ResolveStockAlerts(ctx, pos.ItemID, pos.LocationID); err != nil {
+			return false, fmt.Errorf("db.ResolveStockAlerts: %w", err)
+		}
+
+		return false, nil
+	}
+
+	created, err := s.db.RaiseStockAlert(ctx, pos)
+	if err != nil {
+		return false, fmt.Errorf("db.RaiseStockAlert: %w", err)
+	}
+	if created {
+		zlog.Logger.Warn().
+			Int("item_id", pos.ItemID).
+			Str("location_code", pos.LocationCode).
+			Int("available", pos.Available()).
+			Int("reorder_point", pos.ReorderPoint).
+			Msg("check: остаток item достиг точки заказа")
+	}
+
+	return created, nil
+}
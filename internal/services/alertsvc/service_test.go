@@ -0,0 +1,287 @@
+package alertsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func testPosition(itemID, quantity, reserved int) models.StockPosition {
+	return models.StockPosition{
+		StockLevel: models.StockLevel{ItemID: itemID, MinQty: 5, ReorderPoint: 10, MaxQty: 50},
+		ItemName:   "Товар",
+		Quantity:   quantity,
+		Reserved:   reserved,
+	}
+}
+
+// TestAlertSvc_SetStockLevel - тесты для метода SetStockLevel
+func TestAlertSvc_SetStockLevel_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	level := &models.StockLevel{ItemID: 1, MinQty: 5, ReorderPoint: 10, MaxQty: 50}
+	itemID := 1
+
+	mockDB.EXPECT().
+		SetStockLevel(mock.Anything, level).
+		Return(nil)
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return([]models.StockPosition{testPosition(1, 8, 0)}, nil)
+	mockDB.EXPECT().
+		RaiseStockAlert(mock.Anything, testPosition(1, 8, 0)).
+		Return(true, nil)
+
+	err := svc.SetStockLevel(context.Background(), level)
+
+	assert.NoError(t, err)
+}
+
+func TestAlertSvc_SetStockLevel_ErrInvalidLevels(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.SetStockLevel(context.Background(), &models.StockLevel{ItemID: 1, MinQty: 20, ReorderPoint: 10, MaxQty: 50})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректные уровни запаса")
+}
+
+func TestAlertSvc_SetStockLevel_ErrInvalidLocation(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	locationID := 0
+	err := svc.SetStockLevel(context.Background(), &models.StockLevel{
+		ItemID: 1, LocationID: &locationID, MinQty: 5, ReorderPoint: 10, MaxQty: 50,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "location_id должен быть положительным")
+}
+
+func TestAlertSvc_SetStockLevel_OKLocation(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	itemID, locationID := 1, 4
+	level := &models.StockLevel{ItemID: 1, LocationID: &locationID, MinQty: 5, ReorderPoint: 10, MaxQty: 50}
+	pick := testPosition(1, 20, 0)
+	pick.StockLevel = *level
+
+	mockDB.EXPECT().
+		GetItemPickLocation(mock.Anything, 1).
+		Return(&locationID, nil)
+	mockDB.EXPECT().
+		SetStockLevel(mock.Anything, level).
+		Return(nil)
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return([]models.StockPosition{pick}, nil)
+	mockDB.EXPECT().
+		ResolveStockAlerts(mock.Anything, 1, &locationID).
+		Return(0, nil)
+
+	err := svc.SetStockLevel(context.Background(), level)
+
+	assert.NoError(t, err)
+}
+
+func TestAlertSvc_SetStockLevel_ErrNotPickLocation(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	pickID, locationID := 4, 9
+	mockDB.EXPECT().
+		GetItemPickLocation(mock.Anything, 1).
+		Return(&pickID, nil)
+
+	err := svc.SetStockLevel(context.Background(), &models.StockLevel{
+		ItemID: 1, LocationID: &locationID, MinQty: 5, ReorderPoint: 10, MaxQty: 50,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не является ячейкой подбора")
+}
+
+func TestAlertSvc_SetStockLevel_ErrItemNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	level := &models.StockLevel{ItemID: 404, MinQty: 0, ReorderPoint: 10, MaxQty: 50}
+
+	mockDB.EXPECT().
+		SetStockLevel(mock.Anything, level).
+		Return(fmt.Errorf("item с id 404 не найден"))
+
+	err := svc.SetStockLevel(context.Background(), level)
+
+	assert.Error(t, err)
+	assert.Equal(t, "item с id 404 не найден", err.Error())
+}
+
+// TestAlertSvc_GetLowStock - тесты для метода GetLowStock
+func TestAlertSvc_GetLowStock_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, (*int)(nil)).
+		Return([]models.StockPosition{
+			testPosition(1, 30, 0),
+			testPosition(2, 15, 5),
+			testPosition(3, 3, 0),
+		}, nil)
+
+	low, err := svc.GetLowStock(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, low, 2)
+	assert.Equal(t, 2, low[0].ItemID)
+	assert.Equal(t, 40, low[0].SuggestedOrder())
+	assert.Equal(t, 3, low[1].ItemID)
+}
+
+// TestAlertSvc_CheckAll - тесты для метода CheckAll
+func TestAlertSvc_CheckAll_RaisesAndResolves(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, (*int)(nil)).
+		Return([]models.StockPosition{
+			testPosition(1, 30, 0),
+			testPosition(2, 10, 0),
+			testPosition(3, 4, 0),
+		}, nil)
+	mockDB.EXPECT().
+		ResolveStockAlerts(mock.Anything, 1, (*int)(nil)).
+		Return(1, nil)
+	mockDB.EXPECT().
+		RaiseStockAlert(mock.Anything, testPosition(2, 10, 0)).
+		Return(true, nil)
+	mockDB.EXPECT().
+		RaiseStockAlert(mock.Anything, testPosition(3, 4, 0)).
+		Return(false, nil)
+
+	raised, err := svc.CheckAll(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, raised)
+}
+
+func TestAlertSvc_CheckAll_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, (*int)(nil)).
+		Return(nil, fmt.Errorf("database error"))
+
+	_, err := svc.CheckAll(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.ListStockPositions")
+}
+
+// TestAlertSvc_CheckItem - тесты для метода CheckItem
+func TestAlertSvc_CheckItem_WithoutLevels(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	itemID := 7
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return(nil, nil)
+
+	err := svc.CheckItem(context.Background(), 7)
+
+	assert.NoError(t, err)
+}
+
+func TestAlertSvc_CheckItem_PerLocation(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	itemID, pickID := 1, 4
+	warehouse := testPosition(1, 12, 0)
+	pick := testPosition(1, 12, 0)
+	pick.LocationID = &pickID
+	pick.LocationCode = "A-01"
+	pick.ReorderPoint = 15
+	pick.MaxQty = 30
+
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return([]models.StockPosition{warehouse, pick}, nil)
+	mockDB.EXPECT().
+		ResolveStockAlerts(mock.Anything, 1, (*int)(nil)).
+		Return(0, nil)
+	mockDB.EXPECT().
+		RaiseStockAlert(mock.Anything, pick).
+		Return(true, nil)
+
+	err := svc.CheckItem(context.Background(), 1)
+
+	assert.NoError(t, err)
+}
+
+// TestAlertSvc_GetAlerts - тесты для метода GetAlerts
+func TestAlertSvc_GetAlerts_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListStockAlerts(mock.Anything, models.AlertOpen).
+		Return([]models.StockAlert{{ID: 1, ItemID: 2, Status: models.AlertOpen}}, nil)
+
+	alerts, err := svc.GetAlerts(context.Background(), models.AlertOpen)
+
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 1)
+}
+
+func TestAlertSvc_GetAlerts_ErrInvalidStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	_, err := svc.GetAlerts(context.Background(), "closed")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный статус")
+}
+
+// TestAlertSvc_AcknowledgeAlert - тесты для метода AcknowledgeAlert
+func TestAlertSvc_AcknowledgeAlert_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		AcknowledgeStockAlert(mock.Anything, 1, 3).
+		Return(nil)
+
+	err := svc.AcknowledgeAlert(context.Background(), 1, 3)
+
+	assert.NoError(t, err)
+}
+
+func TestAlertSvc_AcknowledgeAlert_ErrResolved(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		AcknowledgeStockAlert(mock.Anything, 1, 3).
+		Return(fmt.Errorf("нельзя подтвердить оповещение 3 в статусе resolved"))
+
+	err := svc.AcknowledgeAlert(context.Background(), 1, 3)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя")
+}
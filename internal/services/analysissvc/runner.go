@@ -0,0 +1,53 @@
+package analysissvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+)
+
+type runner struct {
+	svc      services.AnalysisService
+	interval time.Duration
+}
+
+// NewRunner - конструктор фонового процесса, периодически пересчитывающего ABC/XYZ анализ.
+func NewRunner(svc services.AnalysisService, interval time.Duration) *runner {
+	return &runner{svc: svc, interval: interval}
+}
+
+// Run - запуск анализа при старте и далее с интервалом до отмены контекста.
+func (r *runner) Run(ctx context.Context) {
+	r.analyze(ctx)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			zlog.Logger.Info().Msg("AnalysisRunner: остановлен")
+			return
+		case <-ticker.C:
+			r.analyze(ctx)
+		}
+	}
+}
+
+// analyze - один пересчет анализа с логированием результата.
+func (r *runner) analyze(ctx context.Context) {
+	count, err := r.svc.Analyze(ctx)
+	if err != nil {
+		zlog.Logger.Error().
+			Err(err).
+			Msg("AnalysisRunner: не удалось выполнить анализ")
+		return
+	}
+
+	zlog.Logger.Info().
+		Int("count", count).
+		Msg("AnalysisRunner: анализ выполнен")
+}
@@ -0,0 +1,240 @@
+package analysissvc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// Границы классов: накопленная доля стоимости расхода в процентах для ABC
+// и коэффициент вариации месячного спроса для XYZ.
+const (
+	abcShareA = 80.0
+	abcShareB = 95.0
+	xyzCVX    = 0.5
+	xyzCVY    = 1.0
+)
+
+var _ services.AnalysisService = (*analysisSvc)(nil)
+
+type analysisSvc struct {
+	db           infra.Database
+	periodMonths int
+	slowDays     int
+	deadDays     int
+}
+
+// New - конструктор нового analysisSvc.
+// periodMonths - число полных месяцев расхода в анализе,
+// slowDays и deadDays - дни без движения, после которых запас считается медленным и неликвидным.
+func New(db infra.Database, periodMonths, slowDays, deadDays int) services.AnalysisService {
+	return &analysisSvc{db: db, periodMonths: periodMonths, slowDays: slowDays, deadDays: deadDays}
+}
+
+// Analyze - метод для пересчета ABC/XYZ классов и статусов движения всех items.
+// Период анализа - последние periodMonths полных месяцев, для item, созданного позже начала периода,
+// ряд спроса начинается с месяца создания. Возвращает число проанализированных items.
+func (s *analysisSvc) Analyze(ctx context.Context) (int, error) {
+	now := time.Now()
+	to := monthStart(now)
+	from := to.AddDate(0, -s.periodMonths, 0)
+
+	demands, err := s.db.ListItemDemand(ctx, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("db.ListItemDemand: %w", err)
+	}
+
+	results := make([]models.ItemAnalysis, 0, len(demands))
+	var total models.Money
+	for _, d := range demands {
+		a := models.ItemAnalysis{
+			ItemID:         d.ItemID,
+			ItemName:       d.ItemName,
+			OnHand:         d.OnHand,
+			LastMovementAt: d.LastMovementAt,
+			PeriodFrom:     from,
+			PeriodTo:       to.AddDate(0, 0, -1),
+			AnalyzedAt:     now,
+		}
+
+		start := from
+		if created := monthStart(d.CreatedAt); created.After(start) {
+			start = created
+		}
+		series := make([]int, max(monthIndex(to)-monthIndex(start), 0))
+		for _, p := range d.Periods {
+			i := monthIndex(p.Month) - monthIndex(start)
+			if i < 0 || i >= len(series) {
+				continue
+			}
+			series[i] += p.Quantity
+			a.ConsumptionQty += p.Quantity
+			a.ConsumptionValue += p.Value
+		}
+		total += a.ConsumptionValue
+
+		a.XYZClass, a.DemandCV = xyzClass(series)
+		a.MovementStatus = s.movementStatus(a.OnHand, d.LastMovementAt, now)
+		results = append(results, a)
+	}
+
+	classifyABC(results, total)
+
+	if err := s.db.SaveItemAnalysis(ctx, results); err != nil {
+		return 0, fmt.Errorf("db.SaveItemAnalysis: %w", err)
+	}
+
+	return len(results), nil
+}
+
+// GetABCReport - метод для получения отчета по последнему анализу.
+// Сводка по классам и матрица ABC/XYZ считаются по всем items, фильтр ограничивает только список items.
+func (s *analysisSvc) GetABCReport(ctx context.Context, filter models.AnalysisFilter) (*models.ABCReport, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	results, err := s.db.ListItemAnalysis(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListItemAnalysis: %w", err)
+	}
+
+	report := models.ABCReport{
+		Classes: []models.ABCClassSummary{
+			{Class: models.ABCClassA},
+			{Class: models.ABCClassB},
+			{Class: models.ABCClassC},
+		},
+		Matrix: make(map[string]int, 9),
+		Items:  make([]models.ItemAnalysis, 0, len(results)),
+	}
+	for _, abc := range []string{models.ABCClassA, models.ABCClassB, models.ABCClassC} {
+		for _, xyz := range []string{models.XYZClassX, models.XYZClassY, models.XYZClassZ} {
+			report.Matrix[abc+xyz] = 0
+		}
+	}
+
+	var total models.Money
+	for _, a := range results {
+		if report.AnalyzedAt == nil || a.AnalyzedAt.After(*report.AnalyzedAt) {
+			report.AnalyzedAt, report.PeriodFrom, report.PeriodTo = &a.AnalyzedAt, &a.PeriodFrom, &a.PeriodTo
+		}
+		for i := range report.Classes {
+			if report.Classes[i].Class == a.ABCClass {
+				report.Classes[i].Items++
+				report.Classes[i].Value += a.ConsumptionValue
+			}
+		}
+		report.Matrix[a.ABCClass+a.XYZClass]++
+		total += a.ConsumptionValue
+
+		if filter.Match(a) {
+			report.Items = append(report.Items, a)
+		}
+	}
+	for i := range report.Classes {
+		report.Classes[i].ValueShare = share(report.Classes[i].Value, total)
+	}
+
+	return &report, nil
+}
+
+// movementStatus - статус движения запаса по дням без движения, пустой для item без запаса.
+func (s *analysisSvc) movementStatus(onHand int, lastMovementAt, now time.Time) string {
+	if onHand <= 0 {
+		return ""
+	}
+
+	days := models.DaysSince(lastMovementAt, now)
+	switch {
+	case days >= s.deadDays:
+		return models.MovementDead
+	case days >= s.slowDays:
+		return models.MovementSlow
+	default:
+		return models.MovementActive
+	}
+}
+
+// classifyABC - назначение ABC классов по убыванию стоимости расхода.
+// Item относится к A, пока накопленная доля предыдущих items меньше abcShareA, к B - меньше abcShareB.
+// Items без расхода всегда C.
+func classifyABC(results []models.ItemAnalysis, total models.Money) {
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return results[order[i]].ConsumptionValue > results[order[j]].ConsumptionValue
+	})
+
+	var cumulative float64
+	for _, i := range order {
+		a := &results[i]
+		a.ValueShare = share(a.ConsumptionValue, total)
+		switch {
+		case a.ConsumptionValue <= 0:
+			a.ABCClass = models.ABCClassC
+		case cumulative < abcShareA:
+			a.ABCClass = models.ABCClassA
+		case cumulative < abcShareB:
+			a.ABCClass = models.ABCClassB
+		default:
+			a.ABCClass = models.ABCClassC
+		}
+		cumulative += a.ValueShare
+	}
+}
+
+// xyzClass - XYZ класс по коэффициенту вариации месячного спроса.
+// Ряд без расхода или пустой ряд - Z без коэффициента.
+func xyzClass(series []int) (string, *float64) {
+	var sum float64
+	for _, q := range series {
+		sum += float64(q)
+	}
+	if sum <= 0 {
+		return models.XYZClassZ, nil
+	}
+
+	mean := sum / float64(len(series))
+	var variance float64
+	for _, q := range series {
+		variance += (float64(q) - mean) * (float64(q) - mean)
+	}
+	cv := math.Sqrt(variance/float64(len(series))) / mean
+
+	switch {
+	case cv <= xyzCVX:
+		return models.XYZClassX, &cv
+	case cv <= xyzCVY:
+		return models.XYZClassY, &cv
+	default:
+		return models.XYZClassZ, &cv
+	}
+}
+
+// share - доля part в whole в процентах, 0 при нулевом whole.
+func share(part, whole models.Money) float64 {
+	if whole <= 0 {
+		return 0
+	}
+
+	return float64(part) / float64(whole) * 100
+}
+
+// monthStart - начало месяца t.
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// monthIndex - порядковый номер месяца t для подсчета разницы в месяцах.
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month())
+}
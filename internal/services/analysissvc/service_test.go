@@ -0,0 +1,172 @@
+package analysissvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestAnalysisSvc_Analyze - тесты для метода Analyze
+func TestAnalysisSvc_Analyze_OKClasses(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, 3, 90, 180)
+
+	now := time.Now()
+	to := monthStart(now)
+	from := to.AddDate(0, -3, 0)
+	longAgo := now.AddDate(-1, 0, 0)
+
+	var saved []models.ItemAnalysis
+	mockDB.EXPECT().
+		ListItemDemand(mock.Anything, from, to).
+		Return([]models.ItemDemand{
+			{ItemID: 1, OnHand: 5, CreatedAt: longAgo, LastMovementAt: now.AddDate(0, 0, -1), Periods: []models.DemandPeriod{
+				{Month: from, Quantity: 10, Value: 8000},
+				{Month: from.AddDate(0, 1, 0), Quantity: 10, Value: 8000},
+				{Month: from.AddDate(0, 2, 0), Quantity: 10, Value: 8000},
+			}},
+			{ItemID: 2, OnHand: 0, CreatedAt: longAgo, LastMovementAt: now.AddDate(0, 0, -300), Periods: []models.DemandPeriod{
+				{Month: from, Quantity: 30, Value: 5000},
+			}},
+			{ItemID: 3, OnHand: 3, CreatedAt: longAgo, LastMovementAt: now.AddDate(0, 0, -100), Periods: []models.DemandPeriod{
+				{Month: from, Quantity: 5, Value: 1000},
+				{Month: from.AddDate(0, 1, 0), Quantity: 5, Value: 1000},
+			}},
+			{ItemID: 4, OnHand: 10, CreatedAt: longAgo, LastMovementAt: now.AddDate(0, 0, -200)},
+		}, nil)
+	mockDB.EXPECT().
+		SaveItemAnalysis(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, results []models.ItemAnalysis) { saved = results }).
+		Return(nil)
+
+	count, err := svc.Analyze(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.Len(t, saved, 4)
+
+	assert.Equal(t, models.ABCClassA, saved[0].ABCClass)
+	assert.Equal(t, models.XYZClassX, saved[0].XYZClass)
+	assert.Equal(t, 30, saved[0].ConsumptionQty)
+	assert.Equal(t, "2.40", saved[0].ConsumptionValue.String())
+	assert.Equal(t, models.MovementActive, saved[0].MovementStatus)
+	assert.Equal(t, from, saved[0].PeriodFrom)
+	assert.Equal(t, to.AddDate(0, 0, -1), saved[0].PeriodTo)
+
+	assert.Equal(t, models.ABCClassA, saved[1].ABCClass)
+	assert.Equal(t, models.XYZClassZ, saved[1].XYZClass)
+	assert.Empty(t, saved[1].MovementStatus)
+
+	assert.Equal(t, models.ABCClassB, saved[2].ABCClass)
+	assert.Equal(t, models.XYZClassY, saved[2].XYZClass)
+	assert.Equal(t, models.MovementSlow, saved[2].MovementStatus)
+
+	assert.Equal(t, models.ABCClassC, saved[3].ABCClass)
+	assert.Equal(t, models.XYZClassZ, saved[3].XYZClass)
+	assert.Nil(t, saved[3].DemandCV)
+	assert.Equal(t, models.MovementDead, saved[3].MovementStatus)
+}
+
+func TestAnalysisSvc_Analyze_OKSeriesFromCreation(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, 12, 90, 180)
+
+	now := time.Now()
+	lastMonth := monthStart(now).AddDate(0, -1, 0)
+
+	var saved []models.ItemAnalysis
+	mockDB.EXPECT().
+		ListItemDemand(mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.ItemDemand{
+			{ItemID: 1, OnHand: 1, CreatedAt: lastMonth.AddDate(0, 0, 3), LastMovementAt: now, Periods: []models.DemandPeriod{
+				{Month: lastMonth, Quantity: 4, Value: 400},
+			}},
+		}, nil)
+	mockDB.EXPECT().
+		SaveItemAnalysis(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, results []models.ItemAnalysis) { saved = results }).
+		Return(nil)
+
+	_, err := svc.Analyze(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.XYZClassX, saved[0].XYZClass)
+	assert.Equal(t, 0.0, *saved[0].DemandCV)
+	assert.Equal(t, 100.0, saved[0].ValueShare)
+}
+
+func TestAnalysisSvc_Analyze_ErrDB(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, 12, 90, 180)
+
+	mockDB.EXPECT().
+		ListItemDemand(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("db error"))
+
+	_, err := svc.Analyze(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.ListItemDemand")
+}
+
+// TestAnalysisSvc_GetABCReport - тесты для метода GetABCReport
+func TestAnalysisSvc_GetABCReport_OKFiltered(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, 12, 90, 180)
+
+	analyzedAt := time.Date(2026, 4, 1, 3, 0, 0, 0, time.UTC)
+	mockDB.EXPECT().
+		ListItemAnalysis(mock.Anything).
+		Return([]models.ItemAnalysis{
+			{ItemID: 1, ABCClass: "A", XYZClass: "X", ConsumptionValue: 8000, AnalyzedAt: analyzedAt},
+			{ItemID: 2, ABCClass: "B", XYZClass: "Z", ConsumptionValue: 1500, MovementStatus: "slow", AnalyzedAt: analyzedAt},
+			{ItemID: 3, ABCClass: "C", XYZClass: "Z", ConsumptionValue: 500, MovementStatus: "dead", AnalyzedAt: analyzedAt},
+		}, nil)
+
+	report, err := svc.GetABCReport(context.Background(), models.AnalysisFilter{XYZClass: "Z"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, analyzedAt, *report.AnalyzedAt)
+	assert.Equal(t, 1, report.Classes[0].Items)
+	assert.Equal(t, 80.0, report.Classes[0].ValueShare)
+	assert.Equal(t, 15.0, report.Classes[1].ValueShare)
+	assert.Equal(t, 1, report.Matrix["AX"])
+	assert.Equal(t, 1, report.Matrix["CZ"])
+	assert.Equal(t, 0, report.Matrix["AY"])
+	assert.Len(t, report.Items, 2)
+	assert.Equal(t, 2, report.Items[0].ItemID)
+}
+
+func TestAnalysisSvc_GetABCReport_OKNotAnalyzed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, 12, 90, 180)
+
+	mockDB.EXPECT().
+		ListItemAnalysis(mock.Anything).
+		Return(nil, nil)
+
+	report, err := svc.GetABCReport(context.Background(), models.AnalysisFilter{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, report.AnalyzedAt)
+	assert.Len(t, report.Classes, 3)
+	assert.Len(t, report.Matrix, 9)
+	assert.Empty(t, report.Items)
+}
+
+func TestAnalysisSvc_GetABCReport_ErrInvalidFilter(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, 12, 90, 180)
+
+	_, err := svc.GetABCReport(context.Background(), models.AnalysisFilter{MovementStatus: "frozen"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный статус движения")
+}
@@ -0,0 +1,111 @@
+package approvalsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.ApprovalService = (*approvalSvc)(nil)
+
+type approvalSvc struct {
+	db       infra.Database
+	notifier services.StockNotifier
+}
+
+// New - конструктор нового approvalSvc.
+// notifier получает сигналы об изменении остатка после согласования, nil - без сигналов.
+func New(db infra.Database, notifier services.StockNotifier) services.ApprovalService {
+	return &approvalSvc{db: db, notifier: notifier}
+}
+
+// GetChangeRequests - метод для получения запросов на согласование по статусу, пустой статус - все.
+func (s *approvalSvc) GetChangeRequests(ctx context.Context, status string) ([]models.ChangeRequest, error) {
+	switch status {
+	case "", models.ChangeRequestPending, models.ChangeRequestApproved, models.ChangeRequestRejected, models.ChangeRequestCancelled:
+	default:
+		return nil, fmt.Errorf("некорректный статус запроса %s", status)
+	}
+
+	crs, err := s.db.ListChangeRequests(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListChangeRequests: %w", err)
+	}
+
+	return crs, nil
+}
+
+// GetChangeRequest - метод для получения запроса на согласование.
+func (s *approvalSvc) GetChangeRequest(ctx context.Context, id int) (*models.ChangeRequest, error) {
+	cr, err := s.db.GetChangeRequest(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetChangeRequest: %w", err)
+	}
+
+	return cr, nil
+}
+
+// ApproveChangeRequest - метод для согласования запроса пользователем, не являющимся автором.
+// Операция выполняется атомарно вместе с записью решения.
+func (s *approvalSvc) ApproveChangeRequest(ctx context.Context, userID, id int, note string) error {
+	cr, err := s.db.ApproveChangeRequest(ctx, userID, id, strings.TrimSpace(note))
+	if err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.ApproveChangeRequest: %w", err)
+	}
+
+	if s.notifier != nil && cr.Operation == models.OpItemAdjustment {
+		s.notifier.Notify(cr.TargetID)
+	}
+
+	return nil
+}
+
+// RejectChangeRequest - метод для отклонения запроса, причина обязательна.
+func (s *approvalSvc) RejectChangeRequest(ctx context.Context, userID, id int, note string) error {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return fmt.Errorf("некорректное отклонение: не указана причина")
+	}
+
+	if err := s.db.RejectChangeRequest(ctx, userID, id, note); err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.RejectChangeRequest: %w", err)
+	}
+
+	return nil
+}
+
+// CancelChangeRequest - метод для отмены ожидающего запроса его автором.
+func (s *approvalSvc) CancelChangeRequest(ctx context.Context, userID, id int) error {
+	if err := s.db.CancelChangeRequest(ctx, userID, id); err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.CancelChangeRequest: %w", err)
+	}
+
+	return nil
+}
+
+// knownError - ошибки, которые отдаются клиенту как есть.
+func knownError(err error) bool {
+	return strings.Contains(err.Error(), "не найден") ||
+		strings.Contains(err.Error(), "нельзя") ||
+		strings.Contains(err.Error(), "уже существует")
+}
@@ -0,0 +1,122 @@
+package approvalsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestApprovalSvc_GetChangeRequests - тесты для метода GetChangeRequests
+func TestApprovalSvc_GetChangeRequests_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		ListChangeRequests(mock.Anything, models.ChangeRequestPending).
+		Return([]models.ChangeRequest{{ID: 1, Operation: models.OpItemDelete, TargetID: 5}}, nil)
+
+	crs, err := svc.GetChangeRequests(context.Background(), models.ChangeRequestPending)
+
+	assert.NoError(t, err)
+	assert.Len(t, crs, 1)
+}
+
+func TestApprovalSvc_GetChangeRequests_ErrStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, err := svc.GetChangeRequests(context.Background(), "done")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный статус запроса")
+}
+
+// TestApprovalSvc_ApproveChangeRequest - тесты для метода ApproveChangeRequest
+func TestApprovalSvc_ApproveChangeRequest_OKNotifiesAdjustment(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		ApproveChangeRequest(mock.Anything, 2, 4, "сверено с актом").
+		Return(&models.ChangeRequest{ID: 4, Operation: models.OpItemAdjustment, TargetID: 7}, nil)
+	notifier.EXPECT().Notify(7).Return()
+
+	err := svc.ApproveChangeRequest(context.Background(), 2, 4, " сверено с актом ")
+
+	assert.NoError(t, err)
+}
+
+func TestApprovalSvc_ApproveChangeRequest_OKRoleChange(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		ApproveChangeRequest(mock.Anything, 2, 4, "").
+		Return(&models.ChangeRequest{ID: 4, Operation: models.OpUserRole, TargetID: 3}, nil)
+
+	err := svc.ApproveChangeRequest(context.Background(), 2, 4, "")
+
+	assert.NoError(t, err)
+}
+
+func TestApprovalSvc_ApproveChangeRequest_ErrOwnRequest(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		ApproveChangeRequest(mock.Anything, 1, 4, "").
+		Return(nil, fmt.Errorf("нельзя согласовать запрос #4: автор не может согласовать собственный запрос"))
+
+	err := svc.ApproveChangeRequest(context.Background(), 1, 4, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя согласовать")
+}
+
+func TestApprovalSvc_ApproveChangeRequest_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		ApproveChangeRequest(mock.Anything, 2, 4, "").
+		Return(nil, fmt.Errorf("database connection error"))
+
+	err := svc.ApproveChangeRequest(context.Background(), 2, 4, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.ApproveChangeRequest")
+}
+
+// TestApprovalSvc_RejectChangeRequest - тесты для метода RejectChangeRequest
+func TestApprovalSvc_RejectChangeRequest_ErrNoReason(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	err := svc.RejectChangeRequest(context.Background(), 2, 4, " ")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не указана причина")
+}
+
+// TestApprovalSvc_CancelChangeRequest - тесты для метода CancelChangeRequest
+func TestApprovalSvc_CancelChangeRequest_ErrNotAuthor(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		CancelChangeRequest(mock.Anything, 2, 4).
+		Return(fmt.Errorf("нельзя отменить запрос #4: отменить запрос может только его автор"))
+
+	err := svc.CancelChangeRequest(context.Background(), 2, 4)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "только его автор")
+}
@@ -0,0 +1,228 @@
+package categorysvc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	maxNameLength = 255
+	maxUnitLength = 32
+)
+
+var attrCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+var _ services.CategoryService = (*categorySvc)(nil)
+
+type categorySvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового categorySvc.
+func New(db infra.Database) services.CategoryService {
+	return &categorySvc{db: db}
+}
+
+// CreateCategory - метод для создания категории.
+func (s *categorySvc) CreateCategory(ctx context.Context, cat *models.Category) (int, error) {
+	cat.Name = strings.TrimSpace(cat.Name)
+	if cat.Name == "" || utf8.RuneCountInString(cat.Name) > maxNameLength {
+		return 0, fmt.Errorf("некорректное название категории: должно содержать от 1 до %d символов", maxNameLength)
+	}
+
+	id, err := s.db.CreateCategory(ctx, cat)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найдена") || strings.Contains(err.Error(), "уже существует") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.CreateCategory: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetCategoryTree - метод для получения дерева категорий.
+// TotalCount категории включает items всех ее подкатегорий.
+func (s *categorySvc) GetCategoryTree(ctx context.Context) ([]models.Category, error) {
+	categories, err := s.db.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListCategories: %w", err)
+	}
+
+	return buildTree(categories), nil
+}
+
+// MoveCategory - метод для перемещения категории под parentID, при nil - в корень.
+func (s *categorySvc) MoveCategory(ctx context.Context, id int, parentID *int) error {
+	if parentID != nil && *parentID == id {
+		return fmt.Errorf("нельзя переместить категорию %d в саму себя", id)
+	}
+
+	if err := s.db.MoveCategory(ctx, id, parentID); err != nil {
+		if strings.Contains(err.Error(), "не найдена") ||
+			strings.Contains(err.Error(), "нельзя") ||
+			strings.Contains(err.Error(), "уже существует") {
+			return err
+		}
+
+		return fmt.Errorf("db.MoveCategory: %w", err)
+	}
+
+	return nil
+}
+
+// MergeCategory - метод для объединения категории sourceID с targetID.
+// Возвращает количество перенесенных items.
+func (s *categorySvc) MergeCategory(ctx context.Context, userID, sourceID, targetID int) (int, error) {
+	if sourceID == targetID {
+		return 0, fmt.Errorf("нельзя объединить категорию %d с самой собой", sourceID)
+	}
+
+	moved, err := s.db.MergeCategory(ctx, userID, sourceID, targetID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найдена") ||
+			strings.Contains(err.Error(), "нельзя") ||
+			strings.Contains(err.Error(), "уже существует") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.MergeCategory: %w", err)
+	}
+
+	return moved, nil
+}
+
+// CreateAttribute - метод для добавления атрибута в схему категории.
+// Код атрибута должен быть уникален с учетом атрибутов родительских категорий и всех подкатегорий.
+func (s *categorySvc) CreateAttribute(ctx context.Context, def *models.AttributeDefinition) (int, error) {
+	if err := validateAttribute(def); err != nil {
+		return 0, err
+	}
+
+	inherited, err := s.db.GetCategoryAttributes(ctx, def.CategoryID)
+	if err != nil {
+		return 0, fmt.Errorf("db.GetCategoryAttributes: %w", err)
+	}
+	for _, existing := range inherited {
+		if existing.Code == def.Code {
+			return 0, fmt.Errorf("атрибут %s уже существует в категории %d", def.Code, existing.CategoryID)
+		}
+	}
+
+	// Атрибут наследуется всеми подкатегориями, код не должен совпадать с их собственными атрибутами.
+	descendant, err := s.db.GetSubcategoryAttributes(ctx, def.CategoryID)
+	if err != nil {
+		return 0, fmt.Errorf("db.GetSubcategoryAttributes: %w", err)
+	}
+	for _, existing := range descendant {
+		if existing.Code == def.Code {
+			return 0, fmt.Errorf("атрибут %s уже существует в подкатегории %d", def.Code, existing.CategoryID)
+		}
+	}
+
+	id, err := s.db.CreateAttributeDefinition(ctx, def)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найдена") || strings.Contains(err.Error(), "уже существует") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.CreateAttributeDefinition: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetCategoryAttributes - метод для получения схемы атрибутов категории вместе с унаследованными.
+func (s *categorySvc) GetCategoryAttributes(ctx context.Context, categoryID int) ([]models.AttributeDefinition, error) {
+	defs, err := s.db.GetCategoryAttributes(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("db.GetCategoryAttributes: %w", err)
+	}
+
+	return defs, nil
+}
+
+// validateAttribute - проверка описания атрибута перед добавлением в схему.
+func validateAttribute(def *models.AttributeDefinition) error {
+	def.Code = strings.TrimSpace(def.Code)
+	def.Name = strings.TrimSpace(def.Name)
+	def.Unit = strings.TrimSpace(def.Unit)
+
+	if !attrCodePattern.MatchString(def.Code) {
+		return fmt.Errorf("некорректный код атрибута %s: допускаются строчные латинские буквы, цифры и _ длиной до 64", def.Code)
+	}
+	if def.Name == "" || utf8.RuneCountInString(def.Name) > maxNameLength {
+		return fmt.Errorf("некорректное название атрибута: должно содержать от 1 до %d символов", maxNameLength)
+	}
+	if utf8.RuneCountInString(def.Unit) > maxUnitLength {
+		return fmt.Errorf("некорректная единица измерения атрибута: не более %d символов", maxUnitLength)
+	}
+
+	switch def.Type {
+	case models.AttrTypeEnum:
+		if len(def.AllowedValues) == 0 {
+			return fmt.Errorf("некорректный атрибут %s: для типа enum нужны допустимые значения", def.Code)
+		}
+		seen := make(map[string]struct{}, len(def.AllowedValues))
+		for _, value := range def.AllowedValues {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("некорректный атрибут %s: допустимое значение не может быть пустым", def.Code)
+			}
+			if _, ok := seen[value]; ok {
+				return fmt.Errorf("некорректный атрибут %s: значение %s указано несколько раз", def.Code, value)
+			}
+			seen[value] = struct{}{}
+		}
+	case models.AttrTypeString, models.AttrTypeNumber, models.AttrTypeBoolean:
+		if len(def.AllowedValues) > 0 {
+			return fmt.Errorf("некорректный атрибут %s: допустимые значения задаются только для типа enum", def.Code)
+		}
+	default:
+		return fmt.Errorf("некорректный тип атрибута %s", def.Type)
+	}
+
+	return nil
+}
+
+// buildTree - сборка дерева из плоского списка категорий с подсчетом items поддеревьев.
+// Порядок соседей сохраняется из списка.
+func buildTree(categories []models.Category) []models.Category {
+	children := make(map[int][]int, len(categories))
+	var roots []int
+	for i, cat := range categories {
+		if cat.ParentID == nil {
+			roots = append(roots, i)
+			continue
+		}
+		children[*cat.ParentID] = append(children[*cat.ParentID], i)
+	}
+
+	var build func(i int) models.Category
+	build = func(i int) models.Category {
+		cat := categories[i]
+		cat.TotalCount = cat.ItemCount
+		cat.Children = make([]models.Category, 0, len(children[cat.ID]))
+		for _, child := range children[cat.ID] {
+			node := build(child)
+			cat.TotalCount += node.TotalCount
+			cat.Children = append(cat.Children, node)
+		}
+
+		return cat
+	}
+
+	tree := make([]models.Category, 0, len(roots))
+	for _, i := range roots {
+		tree = append(tree, build(i))
+	}
+
+	return tree
+}
@@ -0,0 +1,391 @@
+package categorysvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// TestCategorySvc_CreateCategory - тесты для метода CreateCategory
+func TestCategorySvc_CreateCategory_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateCategory(mock.Anything, &models.Category{Name: "Электроника", ParentID: intPtr(1)}).
+		Return(2, nil)
+
+	id, err := svc.CreateCategory(context.Background(), &models.Category{Name: " Электроника ", ParentID: intPtr(1)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, id)
+}
+
+func TestCategorySvc_CreateCategory_ErrEmptyName(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	id, err := svc.CreateCategory(context.Background(), &models.Category{Name: "   "})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "некорректное название")
+}
+
+func TestCategorySvc_CreateCategory_ErrParentNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateCategory(mock.Anything, mock.Anything).
+		Return(0, fmt.Errorf("родительская категория с id 404 не найдена"))
+
+	id, err := svc.CreateCategory(context.Background(), &models.Category{Name: "Электроника", ParentID: intPtr(404)})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "не найдена")
+}
+
+func TestCategorySvc_CreateCategory_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateCategory(mock.Anything, mock.Anything).
+		Return(0, fmt.Errorf("database error"))
+
+	id, err := svc.CreateCategory(context.Background(), &models.Category{Name: "Электроника"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "db.CreateCategory")
+}
+
+// TestCategorySvc_GetCategoryTree - тесты для метода GetCategoryTree
+func TestCategorySvc_GetCategoryTree_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListCategories(mock.Anything).
+		Return([]models.Category{
+			{ID: 1, Name: "Бытовая техника", ItemCount: 1},
+			{ID: 2, Name: "Кухня", ParentID: intPtr(1), ItemCount: 3},
+			{ID: 3, Name: "Чайники", ParentID: intPtr(2), ItemCount: 5},
+			{ID: 4, Name: "Одежда"},
+		}, nil)
+
+	tree, err := svc.GetCategoryTree(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, 9, tree[0].TotalCount)
+	assert.Equal(t, 1, tree[0].ItemCount)
+	assert.Equal(t, 8, tree[0].Children[0].TotalCount)
+	assert.Equal(t, 5, tree[0].Children[0].Children[0].TotalCount)
+	assert.Equal(t, 0, tree[1].TotalCount)
+	assert.Empty(t, tree[1].Children)
+}
+
+func TestCategorySvc_GetCategoryTree_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListCategories(mock.Anything).
+		Return(nil, fmt.Errorf("database error"))
+
+	tree, err := svc.GetCategoryTree(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, tree)
+	assert.Contains(t, err.Error(), "db.ListCategories")
+}
+
+// TestCategorySvc_MoveCategory - тесты для метода MoveCategory
+func TestCategorySvc_MoveCategory_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		MoveCategory(mock.Anything, 2, intPtr(4)).
+		Return(nil)
+
+	err := svc.MoveCategory(context.Background(), 2, intPtr(4))
+
+	assert.NoError(t, err)
+}
+
+func TestCategorySvc_MoveCategory_OKToRoot(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		MoveCategory(mock.Anything, 2, (*int)(nil)).
+		Return(nil)
+
+	err := svc.MoveCategory(context.Background(), 2, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestCategorySvc_MoveCategory_ErrIntoItself(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.MoveCategory(context.Background(), 2, intPtr(2))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя")
+}
+
+func TestCategorySvc_MoveCategory_ErrIntoDescendant(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		MoveCategory(mock.Anything, 1, intPtr(3)).
+		Return(fmt.Errorf("нельзя переместить категорию 1 в ее собственную подкатегорию 3"))
+
+	err := svc.MoveCategory(context.Background(), 1, intPtr(3))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "подкатегорию")
+}
+
+// TestCategorySvc_MergeCategory - тесты для метода MergeCategory
+func TestCategorySvc_MergeCategory_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		MergeCategory(mock.Anything, 1, 2, 4).
+		Return(7, nil)
+
+	moved, err := svc.MergeCategory(context.Background(), 1, 2, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, moved)
+}
+
+func TestCategorySvc_MergeCategory_ErrSameCategory(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	moved, err := svc.MergeCategory(context.Background(), 1, 2, 2)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, moved)
+	assert.Contains(t, err.Error(), "с самой собой")
+}
+
+func TestCategorySvc_MergeCategory_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		MergeCategory(mock.Anything, 1, 2, 404).
+		Return(0, fmt.Errorf("категория с id 404 не найдена"))
+
+	moved, err := svc.MergeCategory(context.Background(), 1, 2, 404)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, moved)
+	assert.Contains(t, err.Error(), "не найдена")
+}
+
+func TestCategorySvc_MergeCategory_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		MergeCategory(mock.Anything, 1, 2, 4).
+		Return(0, fmt.Errorf("database error"))
+
+	moved, err := svc.MergeCategory(context.Background(), 1, 2, 4)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, moved)
+	assert.Contains(t, err.Error(), "db.MergeCategory")
+}
+
+// TestCategorySvc_CreateAttribute - тесты для метода CreateAttribute
+func TestCategorySvc_CreateAttribute_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	def := &models.AttributeDefinition{
+		CategoryID:    3,
+		Code:          "color",
+		Name:          " Цвет ",
+		Type:          models.AttrTypeEnum,
+		AllowedValues: []string{"black", "silver"},
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 3).
+		Return([]models.AttributeDefinition{{ID: 1, CategoryID: 1, Code: "weight", Type: models.AttrTypeNumber}}, nil)
+	mockDB.EXPECT().
+		GetSubcategoryAttributes(mock.Anything, 3).
+		Return([]models.AttributeDefinition{{ID: 2, CategoryID: 7, Code: "size", Type: models.AttrTypeString}}, nil)
+	mockDB.EXPECT().
+		CreateAttributeDefinition(mock.Anything, def).
+		Return(5, nil)
+
+	id, err := svc.CreateAttribute(context.Background(), def)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, id)
+	assert.Equal(t, "Цвет", def.Name)
+}
+
+func TestCategorySvc_CreateAttribute_ErrInvalidCode(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	def := &models.AttributeDefinition{
+		CategoryID: 3,
+		Code:       "Color",
+		Name:       "Цвет",
+		Type:       models.AttrTypeString,
+	}
+
+	_, err := svc.CreateAttribute(context.Background(), def)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный код атрибута")
+}
+
+func TestCategorySvc_CreateAttribute_ErrEnumWithoutValues(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	def := &models.AttributeDefinition{
+		CategoryID: 3,
+		Code:       "color",
+		Name:       "Цвет",
+		Type:       models.AttrTypeEnum,
+	}
+
+	_, err := svc.CreateAttribute(context.Background(), def)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нужны допустимые значения")
+}
+
+func TestCategorySvc_CreateAttribute_ErrValuesForNonEnum(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	def := &models.AttributeDefinition{
+		CategoryID:    3,
+		Code:          "weight",
+		Name:          "Вес",
+		Type:          models.AttrTypeNumber,
+		AllowedValues: []string{"1"},
+	}
+
+	_, err := svc.CreateAttribute(context.Background(), def)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "только для типа enum")
+}
+
+func TestCategorySvc_CreateAttribute_ErrInheritedCode(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	def := &models.AttributeDefinition{
+		CategoryID: 3,
+		Code:       "weight",
+		Name:       "Вес",
+		Type:       models.AttrTypeNumber,
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 3).
+		Return([]models.AttributeDefinition{{ID: 1, CategoryID: 1, Code: "weight", Type: models.AttrTypeNumber}}, nil)
+
+	_, err := svc.CreateAttribute(context.Background(), def)
+
+	assert.Error(t, err)
+	assert.Equal(t, "атрибут weight уже существует в категории 1", err.Error())
+}
+
+func TestCategorySvc_CreateAttribute_ErrSubcategoryCode(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	def := &models.AttributeDefinition{
+		CategoryID: 1,
+		Code:       "color",
+		Name:       "Цвет",
+		Type:       models.AttrTypeString,
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 1).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		GetSubcategoryAttributes(mock.Anything, 1).
+		Return([]models.AttributeDefinition{{ID: 4, CategoryID: 6, Code: "color", Type: models.AttrTypeEnum}}, nil)
+
+	_, err := svc.CreateAttribute(context.Background(), def)
+
+	assert.Error(t, err)
+	assert.Equal(t, "атрибут color уже существует в подкатегории 6", err.Error())
+}
+
+func TestCategorySvc_CreateAttribute_ErrCategoryNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	def := &models.AttributeDefinition{
+		CategoryID: 404,
+		Code:       "weight",
+		Name:       "Вес",
+		Type:       models.AttrTypeNumber,
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 404).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		GetSubcategoryAttributes(mock.Anything, 404).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		CreateAttributeDefinition(mock.Anything, def).
+		Return(0, fmt.Errorf("категория с id 404 не найдена"))
+
+	_, err := svc.CreateAttribute(context.Background(), def)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найдена")
+}
+
+// TestCategorySvc_GetCategoryAttributes - тесты для метода GetCategoryAttributes
+func TestCategorySvc_GetCategoryAttributes_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 3).
+		Return(nil, fmt.Errorf("database error"))
+
+	defs, err := svc.GetCategoryAttributes(context.Background(), 3)
+
+	assert.Error(t, err)
+	assert.Nil(t, defs)
+	assert.Contains(t, err.Error(), "db.GetCategoryAttributes")
+}
@@ -0,0 +1,167 @@
+package countsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const maxRecountThreshold = 100
+
+var _ services.CountService = (*countSvc)(nil)
+
+type countSvc struct {
+	db               infra.Database
+	notifier         services.StockNotifier
+	recountThreshold int
+}
+
+// New - конструктор нового countSvc.
+// notifier получает сигналы об изменении остатка, nil - без сигналов.
+// recountThreshold - порог пересчета в процентах для сессий, где он не задан.
+func New(db infra.Database, notifier services.StockNotifier, recountThreshold int) services.CountService {
+	return &countSvc{db: db, notifier: notifier, recountThreshold: min(max(recountThreshold, 0), maxRecountThreshold)}
+}
+
+// CreateSession - метод для открытия сессии инвентаризации по ячейке подбора, категории или всему складу.
+// Отрицательный порог пересчета заменяется порогом по умолчанию.
+func (s *countSvc) CreateSession(ctx context.Context, userID int, session *models.CountSession) (int, string, error) {
+	if session.LocationID != nil && session.CategoryID != nil {
+		return 0, "", fmt.Errorf("некорректная область сессии: укажите ячейку или категорию, но не обе")
+	}
+	if session.RecountThreshold < 0 {
+		session.RecountThreshold = s.recountThreshold
+	}
+	if session.RecountThreshold > maxRecountThreshold {
+		return 0, "", fmt.Errorf("некорректный порог пересчета %d: допускается от 0 до %d процентов",
+			session.RecountThreshold, maxRecountThreshold)
+	}
+	session.CreatedBy = userID
+
+	id, number, err := s.db.CreateCountSession(ctx, session)
+	if err != nil {
+		if knownError(err) {
+			return 0, "", err
+		}
+
+		return 0, "", fmt.Errorf("db.CreateCountSession: %w", err)
+	}
+
+	return id, number, nil
+}
+
+// GetSessions - метод для получения сессий инвентаризации по статусу.
+func (s *countSvc) GetSessions(ctx context.Context, status string) ([]models.CountSession, error) {
+	switch status {
+	case "", models.CountOpen, models.CountReview, models.CountApproved, models.CountCancelled:
+	default:
+		return nil, fmt.Errorf("некорректный статус сессии %s", status)
+	}
+
+	sessions, err := s.db.ListCountSessions(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListCountSessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// GetSession - метод для получения сессии инвентаризации со строками подсчета.
+func (s *countSvc) GetSession(ctx context.Context, id int) (*models.CountSession, error) {
+	session, err := s.db.GetCountSession(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetCountSession: %w", err)
+	}
+
+	return session, nil
+}
+
+// RecordCounts - метод для записи результатов подсчета.
+func (s *countSvc) RecordCounts(ctx context.Context, userID, id int, counts []models.CountEntry) error {
+	if len(counts) == 0 {
+		return fmt.Errorf("некорректный подсчет: нет строк")
+	}
+	seen := make(map[int]struct{}, len(counts))
+	for _, count := range counts {
+		if count.Quantity < 0 {
+			return fmt.Errorf("некорректное количество %d для item %d: должно быть не меньше 0", count.Quantity, count.ItemID)
+		}
+		if _, ok := seen[count.ItemID]; ok {
+			return fmt.Errorf("некорректный подсчет: item %d указан дважды", count.ItemID)
+		}
+		seen[count.ItemID] = struct{}{}
+	}
+
+	if err := s.db.RecordCounts(ctx, userID, id, counts); err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.RecordCounts: %w", err)
+	}
+
+	return nil
+}
+
+// SubmitSession - метод для завершения подсчета.
+// Возвращает статус сессии: open, если запрошен пересчет строк с расхождением выше порога, иначе review.
+func (s *countSvc) SubmitSession(ctx context.Context, id int) (string, []models.CountLine, error) {
+	status, recount, err := s.db.SubmitCountSession(ctx, id)
+	if err != nil {
+		if knownError(err) {
+			return "", nil, err
+		}
+
+		return "", nil, fmt.Errorf("db.SubmitCountSession: %w", err)
+	}
+
+	return status, recount, nil
+}
+
+// ApproveSession - метод для согласования сессии с корректировкой остатков по расхождениям.
+// Возвращает количество скорректированных items.
+func (s *countSvc) ApproveSession(ctx context.Context, userID, id int) (int, error) {
+	adjusted, err := s.db.ApproveCountSession(ctx, userID, id)
+	if err != nil {
+		if knownError(err) {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.ApproveCountSession: %w", err)
+	}
+
+	if s.notifier != nil {
+		for _, itemID := range adjusted {
+			s.notifier.Notify(itemID)
+		}
+	}
+
+	return len(adjusted), nil
+}
+
+// CancelSession - метод для отмены сессии без корректировок.
+func (s *countSvc) CancelSession(ctx context.Context, id int) error {
+	if err := s.db.CancelCountSession(ctx, id); err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.CancelCountSession: %w", err)
+	}
+
+	return nil
+}
+
+// knownError - ошибки, которые отдаются клиенту как есть.
+func knownError(err error) bool {
+	return strings.Contains(err.Error(), "не найден") ||
+		strings.Contains(err.Error(), "нельзя")
+}
@@ -0,0 +1,149 @@
+package countsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestCountSvc_CreateSession - тесты для метода CreateSession
+func TestCountSvc_CreateSession_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 5)
+
+	locID := 3
+	mockDB.EXPECT().
+		CreateCountSession(mock.Anything, mock.MatchedBy(func(session *models.CountSession) bool {
+			return session.CreatedBy == 2 && session.RecountThreshold == 5 && session.Blind && *session.LocationID == 3
+		})).
+		Return(1, "CC-000001", nil)
+
+	id, number, err := svc.CreateSession(context.Background(), 2, &models.CountSession{
+		LocationID:       &locID,
+		Blind:            true,
+		RecountThreshold: -1,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Equal(t, "CC-000001", number)
+}
+
+func TestCountSvc_CreateSession_ErrBothScopes(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 5)
+
+	locID, catID := 3, 4
+	_, _, err := svc.CreateSession(context.Background(), 2, &models.CountSession{LocationID: &locID, CategoryID: &catID})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная область")
+}
+
+func TestCountSvc_CreateSession_ErrOverlap(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 5)
+
+	mockDB.EXPECT().
+		CreateCountSession(mock.Anything, mock.Anything).
+		Return(0, "", fmt.Errorf("нельзя открыть сессию: item 7 уже пересчитывается в сессии CC-000001"))
+
+	_, _, err := svc.CreateSession(context.Background(), 2, &models.CountSession{})
+
+	assert.EqualError(t, err, "нельзя открыть сессию: item 7 уже пересчитывается в сессии CC-000001")
+}
+
+// TestCountSvc_RecordCounts - тесты для метода RecordCounts
+func TestCountSvc_RecordCounts_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 5)
+
+	counts := []models.CountEntry{{ItemID: 1, Quantity: 10}, {ItemID: 2, Quantity: 0}}
+	mockDB.EXPECT().
+		RecordCounts(mock.Anything, 2, 1, counts).
+		Return(nil)
+
+	err := svc.RecordCounts(context.Background(), 2, 1, counts)
+
+	assert.NoError(t, err)
+}
+
+func TestCountSvc_RecordCounts_ErrNegative(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 5)
+
+	err := svc.RecordCounts(context.Background(), 2, 1, []models.CountEntry{{ItemID: 1, Quantity: -1}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректное количество")
+}
+
+func TestCountSvc_RecordCounts_ErrNotInSession(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 5)
+
+	mockDB.EXPECT().
+		RecordCounts(mock.Anything, 2, 1, mock.Anything).
+		Return(fmt.Errorf("item с id 9 не найден в сессии CC-000001"))
+
+	err := svc.RecordCounts(context.Background(), 2, 1, []models.CountEntry{{ItemID: 9, Quantity: 1}})
+
+	assert.EqualError(t, err, "item с id 9 не найден в сессии CC-000001")
+}
+
+// TestCountSvc_SubmitSession - тесты для метода SubmitSession
+func TestCountSvc_SubmitSession_Recount(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 5)
+
+	counted := 4
+	recount := []models.CountLine{{ItemID: 1, ExpectedQty: 10, CountedQty: &counted, Round: 1, RecountRequired: true}}
+	mockDB.EXPECT().
+		SubmitCountSession(mock.Anything, 1).
+		Return(models.CountOpen, recount, nil)
+
+	status, lines, err := svc.SubmitSession(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.CountOpen, status)
+	assert.Equal(t, recount, lines)
+}
+
+// TestCountSvc_ApproveSession - тесты для метода ApproveSession
+func TestCountSvc_ApproveSession_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier, 5)
+
+	mockDB.EXPECT().
+		ApproveCountSession(mock.Anything, 2, 1).
+		Return([]int{4, 7}, nil)
+	notifier.EXPECT().Notify(4).Return()
+	notifier.EXPECT().Notify(7).Return()
+
+	adjusted, err := svc.ApproveSession(context.Background(), 2, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, adjusted)
+}
+
+func TestCountSvc_ApproveSession_ErrStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier, 5)
+
+	mockDB.EXPECT().
+		ApproveCountSession(mock.Anything, 2, 1).
+		Return(nil, fmt.Errorf("нельзя согласовать сессию CC-000001 в статусе open"))
+
+	_, err := svc.ApproveSession(context.Background(), 2, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя согласовать")
+}
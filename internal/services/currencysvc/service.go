@@ -0,0 +1,248 @@
+package currencysvc
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	maxNameLength = 100
+	dateLayout    = "2006-01-02"
+)
+
+var _ services.CurrencyService = (*currencySvc)(nil)
+
+type currencySvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового currencySvc.
+func New(db infra.Database) services.CurrencyService {
+	return &currencySvc{db: db}
+}
+
+// GetCurrencies - метод для получения валют, базовая - первой.
+func (s *currencySvc) GetCurrencies(ctx context.Context) ([]models.Currency, error) {
+	currencies, err := s.db.ListCurrencies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListCurrencies: %w", err)
+	}
+
+	return currencies, nil
+}
+
+// CreateCurrency - метод для добавления валюты, в которой можно вести цены и строить отчеты.
+func (s *currencySvc) CreateCurrency(ctx context.Context, cur *models.Currency) error {
+	code, err := models.ParseCurrencyCode(cur.Code)
+	if err != nil {
+		return err
+	}
+	cur.Code = code
+	cur.Name = strings.TrimSpace(cur.Name)
+	if cur.Name == "" || utf8.RuneCountInString(cur.Name) > maxNameLength {
+		return fmt.Errorf("некорректное название валюты: от 1 до %d символов", maxNameLength)
+	}
+
+	if err := s.db.CreateCurrency(ctx, cur); err != nil {
+		if strings.Contains(err.Error(), "уже существует") {
+			return err
+		}
+
+		return fmt.Errorf("db.CreateCurrency: %w", err)
+	}
+
+	return nil
+}
+
+// GetRates - метод для получения курсов валют по фильтру.
+func (s *currencySvc) GetRates(ctx context.Context, filter models.ExchangeRateFilter) ([]models.ExchangeRate, error) {
+	if filter.Currency != "" {
+		code, err := models.ParseCurrencyCode(filter.Currency)
+		if err != nil {
+			return nil, err
+		}
+		filter.Currency = code
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, fmt.Errorf("некорректный период: to раньше from")
+	}
+
+	rates, err := s.db.ListExchangeRates(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListExchangeRates: %w", err)
+	}
+
+	return rates, nil
+}
+
+// SetRate - метод для задания курса валюты на дату, курс на ту же дату заменяется.
+func (s *currencySvc) SetRate(ctx context.Context, userID int, rate *models.ExchangeRate) (int, error) {
+	code, err := models.ParseCurrencyCode(rate.Currency)
+	if err != nil {
+		return 0, err
+	}
+	rate.Currency = code
+	if rate.Rate <= 0 {
+		return 0, fmt.Errorf("некорректный курс %s: должен быть больше 0", rate.Rate)
+	}
+	if rate.Date.IsZero() {
+		return 0, fmt.Errorf("некорректная дата курса: не указана")
+	}
+
+	base, err := s.db.GetBaseCurrency(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("db.GetBaseCurrency: %w", err)
+	}
+	if code == base {
+		return 0, fmt.Errorf("нельзя задать курс базовой валюты %s: он всегда равен 1", base)
+	}
+	rate.CreatedBy = &userID
+
+	id, err := s.db.SetExchangeRate(ctx, rate)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.SetExchangeRate: %w", err)
+	}
+
+	return id, nil
+}
+
+// ImportRates - метод для загрузки курсов из CSV со столбцами currency, date, rate.
+// Строка заголовка необязательна, разделитель - запятая или точка с запятой.
+// Курсы загружаются все или ни одного, возвращает число загруженных курсов.
+func (s *currencySvc) ImportRates(ctx context.Context, userID int, data []byte) (int, error) {
+	if len(data) > models.ExchangeRateImportMaxSize {
+		return 0, fmt.Errorf("некорректный файл: размер больше %d байт", models.ExchangeRateImportMaxSize)
+	}
+
+	currencies, err := s.db.ListCurrencies(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("db.ListCurrencies: %w", err)
+	}
+	known := make(map[string]bool, len(currencies))
+	for _, cur := range currencies {
+		known[cur.Code] = cur.Base
+	}
+
+	rates, err := parseRatesCSV(data, known, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(rates) == 0 {
+		return 0, fmt.Errorf("некорректный файл: нет курсов")
+	}
+
+	if err := s.db.ImportExchangeRates(ctx, rates); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.ImportExchangeRates: %w", err)
+	}
+
+	return len(rates), nil
+}
+
+// DeleteRate - метод для удаления курса валюты.
+func (s *currencySvc) DeleteRate(ctx context.Context, id int) error {
+	if err := s.db.DeleteExchangeRate(ctx, id); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return err
+		}
+
+		return fmt.Errorf("db.DeleteExchangeRate: %w", err)
+	}
+
+	return nil
+}
+
+// parseRatesCSV - разбор и проверка строк CSV с курсами.
+// known - известные валюты с признаком базовой.
+func parseRatesCSV(data []byte, known map[string]bool, userID int) ([]models.ExchangeRate, error) {
+	// BOM добавляет Excel при сохранении CSV в UTF-8.
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	semicolon := bytes.Contains(firstLine, []byte(";"))
+	if semicolon {
+		reader.Comma = ';'
+	}
+
+	var rates []models.ExchangeRate
+	seen := make(map[string]int)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("некорректная строка %d: %w", line, err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "currency") {
+			continue
+		}
+
+		code, err := models.ParseCurrencyCode(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("некорректная строка %d: %w", line, err)
+		}
+		base, ok := known[code]
+		if !ok {
+			return nil, fmt.Errorf("некорректная строка %d: неизвестная валюта %s", line, code)
+		}
+		if base {
+			return nil, fmt.Errorf("некорректная строка %d: курс базовой валюты %s всегда равен 1", line, code)
+		}
+
+		date, err := time.Parse(dateLayout, strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("некорректная строка %d: дата %q должна быть в формате %s", line, record[1], dateLayout)
+		}
+
+		// В файлах с точкой с запятой дробная часть часто отделяется запятой.
+		value := strings.TrimSpace(record[2])
+		if semicolon {
+			value = strings.Replace(value, ",", ".", 1)
+		}
+		rate, err := models.ParseRate(value)
+		if err != nil {
+			return nil, fmt.Errorf("некорректная строка %d: %w", line, err)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("некорректная строка %d: курс %s должен быть больше 0", line, rate)
+		}
+
+		key := code + " " + date.Format(dateLayout)
+		if prev, ok := seen[key]; ok {
+			return nil, fmt.Errorf("некорректная строка %d: курс %s на %s уже указан в строке %d",
+				line, code, date.Format(dateLayout), prev)
+		}
+		seen[key] = line
+
+		rates = append(rates, models.ExchangeRate{
+			Currency:  code,
+			Date:      date,
+			Rate:      rate,
+			CreatedBy: &userID,
+		})
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,204 @@
+package currencysvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var testCurrencies = []models.Currency{
+	{Code: "RUB", Name: "Российский рубль", Base: true},
+	{Code: "CNY", Name: "Китайский юань"},
+	{Code: "EUR", Name: "Евро"},
+}
+
+// TestCurrencySvc_CreateCurrency - тесты для метода CreateCurrency
+func TestCurrencySvc_CreateCurrency_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateCurrency(mock.Anything, mock.MatchedBy(func(cur *models.Currency) bool {
+			return cur.Code == "USD" && cur.Name == "Доллар США"
+		})).
+		Return(nil)
+
+	err := svc.CreateCurrency(context.Background(), &models.Currency{Code: " usd ", Name: " Доллар США "})
+
+	assert.NoError(t, err)
+}
+
+func TestCurrencySvc_CreateCurrency_ErrInvalidCode(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.CreateCurrency(context.Background(), &models.Currency{Code: "US1", Name: "Доллар"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный код валюты")
+}
+
+// TestCurrencySvc_SetRate - тесты для метода SetRate
+func TestCurrencySvc_SetRate_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockDB.EXPECT().
+		GetBaseCurrency(mock.Anything).
+		Return("RUB", nil)
+	mockDB.EXPECT().
+		SetExchangeRate(mock.Anything, mock.MatchedBy(func(rate *models.ExchangeRate) bool {
+			return rate.Currency == "CNY" && rate.Date.Equal(date) && rate.Rate.String() == "12.4537" &&
+				rate.CreatedBy != nil && *rate.CreatedBy == 1
+		})).
+		Return(7, nil)
+
+	id, err := svc.SetRate(context.Background(), 1, &models.ExchangeRate{
+		Currency: "cny",
+		Date:     date,
+		Rate:     models.Rate(1245370000),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, id)
+}
+
+func TestCurrencySvc_SetRate_ErrBaseCurrency(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetBaseCurrency(mock.Anything).
+		Return("RUB", nil)
+
+	_, err := svc.SetRate(context.Background(), 1, &models.ExchangeRate{
+		Currency: "RUB",
+		Date:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Rate:     models.RateScale,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя задать курс базовой валюты")
+}
+
+func TestCurrencySvc_SetRate_ErrInvalidRate(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	_, err := svc.SetRate(context.Background(), 1, &models.ExchangeRate{
+		Currency: "EUR",
+		Date:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Rate:     -1,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный курс")
+}
+
+// TestCurrencySvc_ImportRates - тесты для метода ImportRates
+func TestCurrencySvc_ImportRates_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListCurrencies(mock.Anything).
+		Return(testCurrencies, nil)
+	mockDB.EXPECT().
+		ImportExchangeRates(mock.Anything, mock.MatchedBy(func(rates []models.ExchangeRate) bool {
+			return len(rates) == 2 &&
+				rates[0].Currency == "CNY" && rates[0].Rate.String() == "12.4537" &&
+				rates[1].Currency == "EUR" && rates[1].Rate.String() == "98.1" &&
+				rates[1].Date.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+		})).
+		Return(nil)
+
+	data := "\xef\xbb\xbfcurrency,date,rate\nCNY,2026-03-01,12.4537\neur, 2026-03-02, 98.10\n"
+	imported, err := svc.ImportRates(context.Background(), 1, []byte(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, imported)
+}
+
+func TestCurrencySvc_ImportRates_OKSemicolon(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListCurrencies(mock.Anything).
+		Return(testCurrencies, nil)
+	mockDB.EXPECT().
+		ImportExchangeRates(mock.Anything, mock.MatchedBy(func(rates []models.ExchangeRate) bool {
+			return len(rates) == 1 && rates[0].Rate.String() == "12.45"
+		})).
+		Return(nil)
+
+	imported, err := svc.ImportRates(context.Background(), 1, []byte("CNY;2026-03-01;12,45\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, imported)
+}
+
+func TestCurrencySvc_ImportRates_ErrUnknownCurrency(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListCurrencies(mock.Anything).
+		Return(testCurrencies, nil)
+
+	_, err := svc.ImportRates(context.Background(), 1, []byte("CNY,2026-03-01,12.45\nUSD,2026-03-01,90\n"))
+
+	assert.Error(t, err)
+	assert.Equal(t, "некорректная строка 2: неизвестная валюта USD", err.Error())
+}
+
+func TestCurrencySvc_ImportRates_ErrDuplicate(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListCurrencies(mock.Anything).
+		Return(testCurrencies, nil)
+
+	_, err := svc.ImportRates(context.Background(), 1, []byte("EUR,2026-03-01,98\nEUR,2026-03-01,99\n"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная строка 2: курс EUR на 2026-03-01 уже указан в строке 1")
+}
+
+func TestCurrencySvc_ImportRates_ErrBaseCurrency(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListCurrencies(mock.Anything).
+		Return(testCurrencies, nil)
+
+	_, err := svc.ImportRates(context.Background(), 1, []byte("RUB,2026-03-01,1\n"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "курс базовой валюты RUB всегда равен 1")
+}
+
+// TestCurrencySvc_DeleteRate - тесты для метода DeleteRate
+func TestCurrencySvc_DeleteRate_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		DeleteExchangeRate(mock.Anything, 999).
+		Return(errors.New("курс с id 999 не найден"))
+
+	err := svc.DeleteRate(context.Background(), 999)
+
+	assert.Error(t, err)
+	assert.Equal(t, "курс с id 999 не найден", err.Error())
+}
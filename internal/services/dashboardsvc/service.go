@@ -0,0 +1,119 @@
+package dashboardsvc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	// periodDays - период для лидеров расхода и активности пользователей.
+	periodDays = 30
+	// listLimit - длина списков лидеров расхода, активных пользователей и последних изменений.
+	listLimit = 10
+	// queryTimeout - ограничение расчета показателей, не зависящее от запросов, которые его ждут.
+	queryTimeout = 30 * time.Second
+)
+
+var _ services.DashboardService = (*dashboardSvc)(nil)
+
+type dashboardSvc struct {
+	db       infra.Database
+	cacheTTL time.Duration
+
+	// group объединяет конкурентные расчеты в один, mu защищает только кэш.
+	group     singleflight.Group
+	mu        sync.Mutex
+	cached    *models.Dashboard
+	expiresAt time.Time
+}
+
+// New - конструктор нового dashboardSvc.
+// cacheTTL - время, в течение которого показатели отдаются из кэша без обращения к БД.
+func New(db infra.Database, cacheTTL time.Duration) services.DashboardService {
+	return &dashboardSvc{db: db, cacheTTL: cacheTTL}
+}
+
+// GetDashboard - метод для получения сводных показателей склада в объеме, доступном роли.
+// Показатели считаются один раз на cacheTTL для всех ролей, конкурентные запросы ждут одного расчета.
+func (s *dashboardSvc) GetDashboard(ctx context.Context, role string) (*models.Dashboard, error) {
+	switch role {
+	case models.RoleAdmin, models.RoleManager, models.RoleViewer:
+	default:
+		return nil, fmt.Errorf("нельзя получить показатели для роли %q", role)
+	}
+
+	dashboard, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if role == models.RoleViewer {
+		restricted := dashboard.Restricted()
+		return &restricted, nil
+	}
+
+	return &dashboard, nil
+}
+
+// load - показатели из кэша или результат общего расчета, если кэш устарел.
+// Расчет идет в контексте, отвязанном от запроса: отмена запроса прерывает только его ожидание,
+// остальные ожидающие получают показатели, а результат попадает в кэш.
+func (s *dashboardSvc) load(ctx context.Context) (models.Dashboard, error) {
+	if dashboard, ok := s.fromCache(time.Now()); ok {
+		return dashboard, nil
+	}
+
+	result := s.group.DoChan("dashboard", func() (any, error) {
+		qCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+		defer cancel()
+
+		return s.refresh(qCtx)
+	})
+
+	select {
+	case res := <-result:
+		if res.Err != nil {
+			return models.Dashboard{}, res.Err
+		}
+		return res.Val.(models.Dashboard), nil
+	case <-ctx.Done():
+		return models.Dashboard{}, fmt.Errorf("ожидание показателей прервано: %w", ctx.Err())
+	}
+}
+
+// fromCache - показатели из кэша, если он не устарел к now.
+func (s *dashboardSvc) fromCache(now time.Time) (models.Dashboard, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached == nil || !now.Before(s.expiresAt) {
+		return models.Dashboard{}, false
+	}
+
+	return *s.cached, true
+}
+
+// refresh - расчет показателей и замена кэша.
+func (s *dashboardSvc) refresh(ctx context.Context) (models.Dashboard, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dashboard, err := s.db.GetDashboard(ctx, today, now.AddDate(0, 0, -periodDays), listLimit)
+	if err != nil {
+		return models.Dashboard{}, fmt.Errorf("db.GetDashboard: %w", err)
+	}
+	dashboard.GeneratedAt = now
+
+	s.mu.Lock()
+	s.cached = dashboard
+	s.expiresAt = now.Add(s.cacheTTL)
+	s.mu.Unlock()
+
+	return *dashboard, nil
+}
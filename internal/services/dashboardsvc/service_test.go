@@ -0,0 +1,178 @@
+package dashboardsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func testDashboard() *models.Dashboard {
+	return &models.Dashboard{
+		Full:          true,
+		SKUs:          3,
+		Units:         120,
+		Value:         15000000,
+		StockOuts:     1,
+		LowStock:      1,
+		Today:         models.DashboardFlow{InboundQty: 20, InboundMovements: 1, OutboundQty: 5, OutboundMovements: 2},
+		TopMovers:     []models.TopMover{{ItemID: 1, ItemName: "Товар 1", Quantity: 40}},
+		ActiveUsers:   []models.ActiveUser{{UserID: 1, Username: "admin123", Changes: 7}},
+		RecentChanges: []models.RecentChange{{ID: 10, ItemID: 1, Operation: "UPDATE"}},
+	}
+}
+
+// TestDashboardSvc_GetDashboard - тесты для метода GetDashboard
+func TestDashboardSvc_GetDashboard_OKFull(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, time.Minute)
+
+	mockDB.EXPECT().
+		GetDashboard(mock.Anything, mock.MatchedBy(func(today time.Time) bool {
+			return today.Hour() == 0 && today.Minute() == 0 && today.YearDay() == time.Now().YearDay()
+		}), mock.Anything, listLimit).
+		Return(testDashboard(), nil)
+
+	dashboard, err := svc.GetDashboard(context.Background(), models.RoleManager)
+
+	assert.NoError(t, err)
+	assert.True(t, dashboard.Full)
+	assert.Equal(t, "1500.00", dashboard.Value.String())
+	assert.Len(t, dashboard.ActiveUsers, 1)
+	assert.Len(t, dashboard.RecentChanges, 1)
+	assert.False(t, dashboard.GeneratedAt.IsZero())
+}
+
+func TestDashboardSvc_GetDashboard_OKViewerRestricted(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, time.Minute)
+
+	mockDB.EXPECT().
+		GetDashboard(mock.Anything, mock.Anything, mock.Anything, listLimit).
+		Return(testDashboard(), nil)
+
+	dashboard, err := svc.GetDashboard(context.Background(), models.RoleViewer)
+
+	assert.NoError(t, err)
+	assert.False(t, dashboard.Full)
+	assert.Equal(t, 3, dashboard.SKUs)
+	assert.Equal(t, 1, dashboard.StockOuts)
+	assert.Len(t, dashboard.TopMovers, 1)
+	assert.Zero(t, dashboard.Value)
+	assert.Nil(t, dashboard.ActiveUsers)
+	assert.Nil(t, dashboard.RecentChanges)
+}
+
+func TestDashboardSvc_GetDashboard_OKCached(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, time.Minute)
+
+	mockDB.EXPECT().
+		GetDashboard(mock.Anything, mock.Anything, mock.Anything, listLimit).
+		Return(testDashboard(), nil).
+		Once()
+
+	viewer, err := svc.GetDashboard(context.Background(), models.RoleViewer)
+	assert.NoError(t, err)
+	admin, err := svc.GetDashboard(context.Background(), models.RoleAdmin)
+	assert.NoError(t, err)
+
+	assert.Nil(t, viewer.ActiveUsers)
+	assert.Len(t, admin.ActiveUsers, 1)
+	assert.Equal(t, viewer.GeneratedAt, admin.GeneratedAt)
+}
+
+func TestDashboardSvc_GetDashboard_OKExpired(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, 0)
+
+	mockDB.EXPECT().
+		GetDashboard(mock.Anything, mock.Anything, mock.Anything, listLimit).
+		Return(testDashboard(), nil).
+		Twice()
+
+	_, err := svc.GetDashboard(context.Background(), models.RoleAdmin)
+	assert.NoError(t, err)
+	_, err = svc.GetDashboard(context.Background(), models.RoleAdmin)
+	assert.NoError(t, err)
+}
+
+func TestDashboardSvc_GetDashboard_ErrRole(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, time.Minute)
+
+	_, err := svc.GetDashboard(context.Background(), "guest")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя")
+}
+
+func TestDashboardSvc_GetDashboard_ErrDB(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, time.Minute)
+
+	mockDB.EXPECT().
+		GetDashboard(mock.Anything, mock.Anything, mock.Anything, listLimit).
+		Return(nil, errors.New("db error"))
+
+	_, err := svc.GetDashboard(context.Background(), models.RoleAdmin)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.GetDashboard")
+}
+
+func TestDashboardSvc_GetDashboard_OKFirstCallerCanceled(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, time.Minute)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	queryErr := make(chan error, 1)
+	mockDB.EXPECT().
+		GetDashboard(mock.Anything, mock.Anything, mock.Anything, listLimit).
+		Run(func(ctx context.Context, _ time.Time, _ time.Time, _ int) {
+			close(started)
+			<-release
+			queryErr <- ctx.Err()
+		}).
+		Return(testDashboard(), nil).
+		Once()
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := svc.GetDashboard(firstCtx, models.RoleAdmin)
+		firstErr <- err
+	}()
+	<-started
+
+	type result struct {
+		dashboard *models.Dashboard
+		err       error
+	}
+	second := make(chan result, 1)
+	go func() {
+		dashboard, err := svc.GetDashboard(context.Background(), models.RoleViewer)
+		second <- result{dashboard, err}
+	}()
+
+	cancel()
+	err := <-firstErr
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	res := <-second
+	assert.NoError(t, res.err)
+	assert.Equal(t, 3, res.dashboard.SKUs)
+	assert.NoError(t, <-queryErr)
+
+	cached, err := svc.GetDashboard(context.Background(), models.RoleAdmin)
+	assert.NoError(t, err)
+	assert.Equal(t, res.dashboard.GeneratedAt, cached.GeneratedAt)
+}
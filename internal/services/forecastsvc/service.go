@@ -0,0 +1,386 @@
+package forecastsvc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	// maxHorizon - наибольший горизонт прогноза в месяцах.
+	maxHorizon = 24
+	// sesAlpha - коэффициент сглаживания простого экспоненциального сглаживания.
+	sesAlpha = 0.3
+	// maWindow - окно скользящего среднего в месяцах.
+	maWindow = 3
+	// seasonLength - длина сезона в месяцах, сезонность учитывается при истории не меньше двух сезонов.
+	seasonLength = 12
+	// daysPerMonth - дней в месяце для перевода месячного спроса в дневной.
+	daysPerMonth = 30.0
+)
+
+var _ services.ForecastService = (*forecastSvc)(nil)
+
+type forecastSvc struct {
+	db              infra.Database
+	notifier        services.StockNotifier
+	historyMonths   int
+	serviceLevel    float64
+	defaultLeadDays int
+}
+
+// New - конструктор нового forecastSvc.
+// historyMonths - число полных месяцев истории расхода, serviceLevel - вероятность не уйти в дефицит
+// за срок поставки, defaultLeadDays - срок поставки для items без поставщика.
+// notifier получает сигналы о смене уровней запаса, nil - без сигналов.
+func New(
+	db infra.Database,
+	notifier services.StockNotifier,
+	historyMonths int,
+	serviceLevel float64,
+	defaultLeadDays int,
+) services.ForecastService {
+	return &forecastSvc{
+		db:              db,
+		notifier:        notifier,
+		historyMonths:   historyMonths,
+		serviceLevel:    serviceLevel,
+		defaultLeadDays: defaultLeadDays,
+	}
+}
+
+// GetForecast - метод для прогноза месячного спроса item на horizon месяцев вперед.
+// Прогноз строится по расходу за последние полные месяцы простым экспоненциальным сглаживанием
+// и скользящим средним с сезонными индексами, выбирается метод с меньшей ошибкой на истории.
+func (s *forecastSvc) GetForecast(ctx context.Context, itemID, horizon int) (*models.Forecast, error) {
+	if horizon <= 0 || horizon > maxHorizon {
+		return nil, fmt.Errorf("некорректный горизонт прогноза %d: должен быть от 1 до %d месяцев", horizon, maxHorizon)
+	}
+
+	item, err := s.db.GetByID(ctx, itemID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetByID: %w", err)
+	}
+
+	to := monthStart(time.Now())
+	from := to.AddDate(0, -s.historyMonths, 0)
+	if created := monthStart(item.CreatedAt); created.After(from) {
+		from = created
+	}
+
+	issues, err := s.db.ListItemIssues(ctx, itemID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListItemIssues: %w", err)
+	}
+
+	forecast := models.Forecast{
+		ItemID:       item.ID,
+		ItemName:     item.Name,
+		ServiceLevel: s.serviceLevel,
+		History:      fillHistory(issues, from, to),
+	}
+	series := make([]float64, len(forecast.History))
+	for i, p := range forecast.History {
+		series[i] = float64(p.Quantity)
+	}
+
+	var predict func(month time.Time, step int) float64
+	forecast.Method, predict, forecast.MAE, forecast.RMSE = bestMethod(series, from)
+	for step := 1; step <= horizon; step++ {
+		month := to.AddDate(0, step-1, 0)
+		forecast.Periods = append(forecast.Periods, models.ForecastPeriod{
+			Month:    month,
+			Quantity: round2(math.Max(predict(month, step), 0)),
+		})
+	}
+
+	if forecast.LeadTimeDays, err = s.leadTimeDays(ctx, itemID); err != nil {
+		return nil, err
+	}
+	forecast.Recommendation = s.recommend(forecast.Periods[0].Quantity, forecast.RMSE, forecast.LeadTimeDays)
+
+	positions, err := s.db.ListStockPositions(ctx, &itemID)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListStockPositions: %w", err)
+	}
+	for _, pos := range positions {
+		if pos.LocationID == nil {
+			forecast.Current = &pos.StockLevel
+			break
+		}
+	}
+
+	return &forecast, nil
+}
+
+// ApplyRecommendation - метод для замены уровней запаса item на весь склад рекомендованными по прогнозу.
+// Точка заказа и максимальный уровень начинают действовать в пополнении и оповещениях о низком остатке.
+// Без расхода за период истории рекомендация нулевая, поэтому не применяется.
+func (s *forecastSvc) ApplyRecommendation(ctx context.Context, itemID int) (*models.StockLevel, error) {
+	forecast, err := s.GetForecast(ctx, itemID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !forecast.HasDemand() {
+		return nil, fmt.Errorf("нельзя применить рекомендацию для item %d: нет расхода за период истории", itemID)
+	}
+
+	level := forecast.Recommendation.StockLevel(itemID)
+	if err := s.db.SetStockLevel(ctx, &level); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.SetStockLevel: %w", err)
+	}
+
+	if s.notifier != nil {
+		s.notifier.Notify(itemID)
+	}
+
+	return &level, nil
+}
+
+// leadTimeDays - срок поставки основного поставщика item, иначе самый короткий из поставщиков,
+// для item без поставщиков - срок по умолчанию.
+func (s *forecastSvc) leadTimeDays(ctx context.Context, itemID int) (int, error) {
+	links, err := s.db.ListItemSuppliers(ctx, itemID)
+	if err != nil {
+		return 0, fmt.Errorf("db.ListItemSuppliers: %w", err)
+	}
+	if len(links) == 0 {
+		return s.defaultLeadDays, nil
+	}
+
+	days := links[0].LeadTimeDays
+	for _, link := range links {
+		if link.Preferred {
+			return link.LeadTimeDays, nil
+		}
+		days = min(days, link.LeadTimeDays)
+	}
+
+	return days, nil
+}
+
+// recommend - уровни запаса по прогнозу месячного спроса и его среднеквадратичной ошибке.
+// Страховой запас - z * σ * √L, где σ - дневное отклонение спроса, L - срок поставки в днях.
+func (s *forecastSvc) recommend(monthly, rmse float64, leadDays int) models.StockRecommendation {
+	daily := monthly / daysPerMonth
+	sigma := rmse / math.Sqrt(daysPerMonth)
+	z := math.Sqrt2 * math.Erfinv(2*s.serviceLevel-1)
+
+	safety := int(math.Ceil(z * sigma * math.Sqrt(float64(leadDays))))
+	reorder := safety + int(math.Ceil(daily*float64(leadDays)))
+
+	return models.StockRecommendation{
+		DailyDemand:  round2(daily),
+		SafetyStock:  safety,
+		ReorderPoint: reorder,
+		MaxQty:       reorder + max(int(math.Ceil(monthly)), 1),
+	}
+}
+
+// bestMethod - выбор метода прогноза с наименьшей средней абсолютной ошибкой одношагового прогноза на истории.
+// Возвращает метод, функцию прогноза на шаг step вперед для месяца month и ошибки MAE и RMSE метода.
+func bestMethod(series []float64, from time.Time) (string, func(time.Time, int) float64, float64, float64) {
+	if len(series) == 0 {
+		return models.ForecastSES, func(time.Time, int) float64 { return 0 }, 0, 0
+	}
+
+	sesFitted, sesLevel := ses(series)
+	method, predict, fitted := models.ForecastSES, func(time.Time, int) float64 { return sesLevel }, sesFitted
+
+	if len(series) > maWindow {
+		maMethod := models.ForecastMovingAverage
+		indices := seasonalIndices(series, from)
+		if indices != nil {
+			maMethod = models.ForecastSeasonalAverage
+		}
+		maFitted, maLevel := movingAverage(series, from, indices)
+
+		// Методы сравниваются на одних и тех же месяцах, где прогноз есть у обоих.
+		if mae(series, maFitted, maWindow) < mae(series, sesFitted, maWindow) {
+			method, fitted = maMethod, maFitted
+			predict = func(month time.Time, _ int) float64 {
+				if indices == nil {
+					return maLevel
+				}
+
+				return maLevel * indices[month.Month()-1]
+			}
+		}
+	}
+
+	errMAE, errRMSE := mae(series, fitted, 0), rmse(series, fitted)
+	if math.IsNaN(errRMSE) {
+		// История из одного месяца: прогнозов на истории нет, ошибка оценивается по самому ряду.
+		errMAE, errRMSE = stddev(series), stddev(series)
+	}
+
+	return method, predict, round2(errMAE), round2(errRMSE)
+}
+
+// ses - простое экспоненциальное сглаживание.
+// Возвращает одношаговые прогнозы для месяцев ряда (NaN там, где прогноза нет) и последний уровень.
+func ses(series []float64) ([]float64, float64) {
+	fitted := make([]float64, len(series))
+	level := series[0]
+	fitted[0] = math.NaN()
+	for t := 1; t < len(series); t++ {
+		fitted[t] = level
+		level = sesAlpha*series[t] + (1-sesAlpha)*level
+	}
+
+	return fitted, level
+}
+
+// movingAverage - скользящее среднее по окну maWindow, при заданных индексах - по ряду без сезонности.
+// Возвращает одношаговые прогнозы с учетом сезонности и последний уровень без сезонности.
+func movingAverage(series []float64, from time.Time, indices []float64) ([]float64, float64) {
+	adjusted := make([]float64, len(series))
+	for t, q := range series {
+		adjusted[t] = q / seasonalIndex(indices, from, t)
+	}
+
+	fitted := make([]float64, len(series))
+	for t := range series {
+		if t < maWindow {
+			fitted[t] = math.NaN()
+			continue
+		}
+		fitted[t] = mean(adjusted[t-maWindow:t]) * seasonalIndex(indices, from, t)
+	}
+
+	return fitted, mean(adjusted[len(adjusted)-maWindow:])
+}
+
+// seasonalIndices - сезонные индексы по месяцам года: средний расход месяца к среднему расходу ряда.
+// nil, если история короче двух сезонов или расхода не было.
+func seasonalIndices(series []float64, from time.Time) []float64 {
+	if len(series) < 2*seasonLength {
+		return nil
+	}
+	overall := mean(series)
+	if overall == 0 {
+		return nil
+	}
+
+	sums := make([]float64, seasonLength)
+	counts := make([]int, seasonLength)
+	for t, q := range series {
+		m := from.AddDate(0, t, 0).Month() - 1
+		sums[m] += q
+		counts[m]++
+	}
+
+	indices := make([]float64, seasonLength)
+	for m := range indices {
+		indices[m] = sums[m] / float64(counts[m]) / overall
+	}
+
+	return indices
+}
+
+// seasonalIndex - сезонный индекс месяца t ряда, 1 без сезонности или при нулевом индексе.
+func seasonalIndex(indices []float64, from time.Time, t int) float64 {
+	if indices == nil {
+		return 1
+	}
+	if index := indices[from.AddDate(0, t, 0).Month()-1]; index > 0 {
+		return index
+	}
+
+	return 1
+}
+
+// fillHistory - помесячный ряд расхода от from до to, месяцы без расхода заполняются нулями.
+func fillHistory(issues []models.DemandPeriod, from, to time.Time) []models.DemandPeriod {
+	var history []models.DemandPeriod
+	for month := from; month.Before(to); month = month.AddDate(0, 1, 0) {
+		history = append(history, models.DemandPeriod{Month: month})
+	}
+	for _, p := range issues {
+		i := monthIndex(p.Month) - monthIndex(from)
+		if i < 0 || i >= len(history) {
+			continue
+		}
+		history[i].Quantity += p.Quantity
+		history[i].Value += p.Value
+	}
+
+	return history
+}
+
+// mae - средняя абсолютная ошибка прогнозов fitted начиная с месяца start, NaN если прогнозов нет.
+func mae(series, fitted []float64, start int) float64 {
+	var sum float64
+	var n int
+	for t, f := range fitted {
+		if t < start || math.IsNaN(f) {
+			continue
+		}
+		sum += math.Abs(series[t] - f)
+		n++
+	}
+
+	return sum / float64(n)
+}
+
+// rmse - среднеквадратичная ошибка прогнозов fitted, NaN если прогнозов нет.
+func rmse(series, fitted []float64) float64 {
+	var sum float64
+	var n int
+	for t, f := range fitted {
+		if math.IsNaN(f) {
+			continue
+		}
+		sum += (series[t] - f) * (series[t] - f)
+		n++
+	}
+
+	return math.Sqrt(sum / float64(n))
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+func stddev(values []float64) float64 {
+	m := mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// monthStart - начало месяца t.
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// monthIndex - порядковый номер месяца t для подсчета разницы в месяцах.
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month())
+}
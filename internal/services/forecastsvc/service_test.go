@@ -0,0 +1,250 @@
+package forecastsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// issues - помесячный расход начиная с месяца, отстоящего от текущего на len(quantities) месяцев назад.
+func issues(quantities ...int) []models.DemandPeriod {
+	from := monthStart(time.Now()).AddDate(0, -len(quantities), 0)
+	periods := make([]models.DemandPeriod, 0, len(quantities))
+	for i, q := range quantities {
+		if q > 0 {
+			periods = append(periods, models.DemandPeriod{Month: from.AddDate(0, i, 0), Quantity: q})
+		}
+	}
+
+	return periods
+}
+
+func expectItem(mockDB *mocks.Database, itemID int) {
+	mockDB.EXPECT().
+		GetByID(mock.Anything, itemID).
+		Return(&models.Item{ID: itemID, Name: "Товар", CreatedAt: time.Now().AddDate(-5, 0, 0)}, nil)
+}
+
+// TestForecastSvc_GetForecast - тесты для метода GetForecast
+func TestForecastSvc_GetForecast_OKStableDemand(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 6, 0.95, 14)
+
+	itemID := 1
+	expectItem(mockDB, itemID)
+	mockDB.EXPECT().
+		ListItemIssues(mock.Anything, itemID, mock.Anything, mock.Anything).
+		Return(issues(10, 10, 10, 10, 10, 10), nil)
+	mockDB.EXPECT().
+		ListItemSuppliers(mock.Anything, itemID).
+		Return([]models.ItemSupplier{
+			{SupplierID: 1, LeadTimeDays: 5},
+			{SupplierID: 2, LeadTimeDays: 15, Preferred: true},
+		}, nil)
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return([]models.StockPosition{{StockLevel: models.StockLevel{ItemID: itemID, ReorderPoint: 2, MaxQty: 8}}}, nil)
+
+	forecast, err := svc.GetForecast(context.Background(), itemID, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ForecastSES, forecast.Method)
+	assert.Len(t, forecast.History, 6)
+	assert.Len(t, forecast.Periods, 2)
+	assert.Equal(t, 10.0, forecast.Periods[0].Quantity)
+	assert.Equal(t, monthStart(time.Now()), forecast.Periods[0].Month)
+	assert.Equal(t, 0.0, forecast.RMSE)
+	assert.Equal(t, 15, forecast.LeadTimeDays)
+	assert.Equal(t, models.StockRecommendation{DailyDemand: 0.33, SafetyStock: 0, ReorderPoint: 5, MaxQty: 15}, forecast.Recommendation)
+	assert.Equal(t, 8, forecast.Current.MaxQty)
+}
+
+func TestForecastSvc_GetForecast_OKMovingAverageAfterShift(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 8, 0.95, 14)
+
+	itemID := 1
+	expectItem(mockDB, itemID)
+	mockDB.EXPECT().
+		ListItemIssues(mock.Anything, itemID, mock.Anything, mock.Anything).
+		Return(issues(0, 0, 0, 0, 30, 30, 30, 30), nil)
+	mockDB.EXPECT().
+		ListItemSuppliers(mock.Anything, itemID).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return(nil, nil)
+
+	forecast, err := svc.GetForecast(context.Background(), itemID, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ForecastMovingAverage, forecast.Method)
+	assert.Equal(t, 30.0, forecast.Periods[0].Quantity)
+	assert.Equal(t, 14, forecast.LeadTimeDays)
+	assert.Greater(t, forecast.Recommendation.SafetyStock, 0)
+	assert.Nil(t, forecast.Current)
+}
+
+func TestForecastSvc_GetForecast_OKCurrentWarehouseLevel(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 6, 0.95, 14)
+
+	itemID, locationID := 1, 4
+	expectItem(mockDB, itemID)
+	mockDB.EXPECT().
+		ListItemIssues(mock.Anything, itemID, mock.Anything, mock.Anything).
+		Return(issues(10, 10, 10, 10, 10, 10), nil)
+	mockDB.EXPECT().
+		ListItemSuppliers(mock.Anything, itemID).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return([]models.StockPosition{
+			{StockLevel: models.StockLevel{ItemID: itemID, LocationID: &locationID, ReorderPoint: 1, MaxQty: 3}},
+			{StockLevel: models.StockLevel{ItemID: itemID, ReorderPoint: 2, MaxQty: 8}},
+		}, nil)
+
+	forecast, err := svc.GetForecast(context.Background(), itemID, 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, forecast.Current.LocationID)
+	assert.Equal(t, 8, forecast.Current.MaxQty)
+}
+
+func TestForecastSvc_GetForecast_OKSeasonal(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 24, 0.95, 14)
+
+	itemID := 1
+	from := monthStart(time.Now()).AddDate(0, -24, 0)
+	quantities := make([]int, 24)
+	for i := range quantities {
+		quantities[i] = 10
+		if from.AddDate(0, i, 0).Month() == time.December {
+			quantities[i] = 100
+		}
+	}
+
+	expectItem(mockDB, itemID)
+	mockDB.EXPECT().
+		ListItemIssues(mock.Anything, itemID, from, monthStart(time.Now())).
+		Return(issues(quantities...), nil)
+	mockDB.EXPECT().
+		ListItemSuppliers(mock.Anything, itemID).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return(nil, nil)
+
+	forecast, err := svc.GetForecast(context.Background(), itemID, 12)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ForecastSeasonalAverage, forecast.Method)
+	for _, p := range forecast.Periods {
+		if p.Month.Month() == time.December {
+			assert.Equal(t, 100.0, p.Quantity)
+		} else {
+			assert.Equal(t, 10.0, p.Quantity)
+		}
+	}
+}
+
+func TestForecastSvc_GetForecast_ErrHorizon(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 24, 0.95, 14)
+
+	_, err := svc.GetForecast(context.Background(), 1, 25)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный горизонт")
+}
+
+func TestForecastSvc_GetForecast_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 24, 0.95, 14)
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 99).
+		Return(nil, errors.New("item 99 не найден"))
+
+	_, err := svc.GetForecast(context.Background(), 99, 3)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+// TestForecastSvc_ApplyRecommendation - тесты для метода ApplyRecommendation
+func TestForecastSvc_ApplyRecommendation_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier, 6, 0.95, 30)
+
+	itemID := 1
+	expectItem(mockDB, itemID)
+	mockDB.EXPECT().
+		ListItemIssues(mock.Anything, itemID, mock.Anything, mock.Anything).
+		Return(issues(30, 30, 30, 30, 30, 30), nil)
+	mockDB.EXPECT().
+		ListItemSuppliers(mock.Anything, itemID).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		SetStockLevel(mock.Anything, &models.StockLevel{ItemID: itemID, MinQty: 0, ReorderPoint: 30, MaxQty: 60}).
+		Return(nil)
+	notifier.EXPECT().Notify(itemID).Return()
+
+	level, err := svc.ApplyRecommendation(context.Background(), itemID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30, level.ReorderPoint)
+	assert.Equal(t, 60, level.MaxQty)
+}
+
+func TestForecastSvc_ApplyRecommendation_ErrNoHistory(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 6, 0.95, 30)
+
+	itemID := 1
+	mockDB.EXPECT().
+		GetByID(mock.Anything, itemID).
+		Return(&models.Item{ID: itemID, Name: "Товар", CreatedAt: time.Now()}, nil)
+	mockDB.EXPECT().
+		ListItemIssues(mock.Anything, itemID, mock.Anything, mock.Anything).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		ListItemSuppliers(mock.Anything, itemID).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		ListStockPositions(mock.Anything, &itemID).
+		Return(nil, nil)
+
+	_, err := svc.ApplyRecommendation(context.Background(), itemID)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя применить рекомендацию")
+}
+
+func TestForecastSvc_ApplyRecommendation_ErrDB(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 6, 0.95, 30)
+
+	itemID := 1
+	expectItem(mockDB, itemID)
+	mockDB.EXPECT().
+		ListItemIssues(mock.Anything, itemID, mock.Anything, mock.Anything).
+		Return(nil, errors.New("db error"))
+
+	_, err := svc.ApplyRecommendation(context.Background(), itemID)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.ListItemIssues")
+}
@@ -2,42 +2,94 @@ package inventorysvc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/sunr3d/warehouse-control/internal/barcode"
 	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
 	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/internal/label"
+	"github.com/sunr3d/warehouse-control/internal/uom"
 	"github.com/sunr3d/warehouse-control/models"
 )
 
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]{0,63}$`)
+
 var _ services.InventoryService = (*inventorySvc)(nil)
 
 type inventorySvc struct {
-	db infra.Database
+	db       infra.Database
+	notifier services.StockNotifier
+	policy   models.ApprovalPolicy
 }
 
 // New - конструктор нового inventorySvc.
-func New(db infra.Database) services.InventoryService {
-	return &inventorySvc{db: db}
+// notifier получает сигналы об изменении остатка, nil - без сигналов.
+// policy определяет, какие удаления и изменения остатка ждут согласования вторым пользователем.
+func New(db infra.Database, notifier services.StockNotifier, policy models.ApprovalPolicy) services.InventoryService {
+	return &inventorySvc{db: db, notifier: notifier, policy: policy}
 }
 
 // AddItem - метод для добавления нового item в БД.
 func (s *inventorySvc) AddItem(ctx context.Context, userID int, item *models.Item) (int, error) {
-	if item.Quantity <= 0 {
+	if item.Serialized && item.Quantity != 0 {
+		return 0, fmt.Errorf("quantity серийного item задается регистрацией серийных номеров и должно быть равно 0")
+	}
+	if !item.Serialized && item.Quantity <= 0 {
 		return 0, fmt.Errorf("quantity должно быть больше 0")
 	}
+	if item.BaseUnit == "" {
+		item.BaseUnit = models.DefaultBaseUnit
+	}
+	if err := uom.ValidateCode(item.BaseUnit); err != nil {
+		return 0, err
+	}
+	if err := validateIdentifiers(item); err != nil {
+		return 0, err
+	}
+	if err := s.validateAttributes(ctx, item); err != nil {
+		return 0, err
+	}
+	if item.CostCurrency != "" {
+		code, err := models.ParseCurrencyCode(item.CostCurrency)
+		if err != nil {
+			return 0, err
+		}
+		item.CostCurrency = code
+	}
 
 	id, err := s.db.Create(ctx, userID, item)
 	if err != nil {
+		if strings.Contains(err.Error(), "категория") {
+			return 0, err
+		}
+
 		return 0, fmt.Errorf("db.Create: %w", err)
 	}
 
 	return id, nil
 }
 
-// GetInventory - метод для получения всех items из БД.
-func (s *inventorySvc) GetInventory(ctx context.Context) ([]models.Item, error) {
-	items, err := s.db.List(ctx)
+// GetInventory - метод для получения items из БД по фильтру.
+func (s *inventorySvc) GetInventory(ctx context.Context, filter models.ItemFilter) ([]models.Item, error) {
+	switch filter.StockStatus {
+	case "", models.StockAvailable, models.StockQuarantine, models.StockDamaged, models.StockQCHold, models.StockBlocked:
+	default:
+		return nil, fmt.Errorf("некорректный статус запаса %q", filter.StockStatus)
+	}
+	analysis := models.AnalysisFilter{
+		ABCClass:       filter.ABCClass,
+		XYZClass:       filter.XYZClass,
+		MovementStatus: filter.MovementStatus,
+	}
+	if err := analysis.Validate(); err != nil {
+		return nil, err
+	}
+
+	items, err := s.db.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("db.List: %w", err)
 	}
@@ -45,16 +97,119 @@ func (s *inventorySvc) GetInventory(ctx context.Context) ([]models.Item, error)
 	return items, nil
 }
 
+// GetItem - метод для получения item по id, для комплекта - вместе с составом и количеством,
+// которое можно собрать из доступного остатка компонентов.
+func (s *inventorySvc) GetItem(ctx context.Context, id int) (*models.Item, error) {
+	item, err := s.getItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	kit, err := s.db.GetKit(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("db.GetKit: %w", err)
+	}
+	if len(kit.Components) > 0 {
+		item.Kit = kit
+	}
+
+	return item, nil
+}
+
+// GetItemByBarcode - метод для поиска item по штрихкоду, sku или внутреннему коду с этикетки.
+func (s *inventorySvc) GetItemByBarcode(ctx context.Context, code string) (*models.Item, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, fmt.Errorf("штрихкод не может быть пустым")
+	}
+
+	if id, ok := label.ParseItemCode(code); ok {
+		item, err := s.db.GetByID(ctx, id)
+		if err != nil {
+			if strings.Contains(err.Error(), "не найден") {
+				return nil, fmt.Errorf("item со штрихкодом %s не найден", code)
+			}
+
+			return nil, fmt.Errorf("db.GetByID: %w", err)
+		}
+
+		return item, nil
+	}
+
+	item, err := s.db.GetByBarcode(ctx, code)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, fmt.Errorf("item со штрихкодом %s не найден", code)
+		}
+
+		return nil, fmt.Errorf("db.GetByBarcode: %w", err)
+	}
+
+	return item, nil
+}
+
 // UpdateItem - метод для обновления item в БД.
-func (s *inventorySvc) UpdateItem(ctx context.Context, userID, id int, item *models.Item) error {
+// Изменение остатка сверх порога политики согласования не выполняется, а возвращается как запрос на согласование,
+// остальные поля item при этом обновляются сразу.
+func (s *inventorySvc) UpdateItem(ctx context.Context, userID, id int, item *models.Item) (*models.ChangeRequest, error) {
 	if item.Quantity < 0 {
-		return fmt.Errorf("quantity должно быть больше или равно 0")
+		return nil, fmt.Errorf("quantity должно быть больше или равно 0")
+	}
+	if err := validateIdentifiers(item); err != nil {
+		return nil, err
+	}
+	if err := s.validateAttributes(ctx, item); err != nil {
+		return nil, err
 	}
 
+	if s.policy.Requires(models.OpItemAdjustment) {
+		current, err := s.getItem(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if s.policy.RequiresAdjustment(current.Quantity, item.Quantity) {
+			payload, err := json.Marshal(models.QuantityChange{FromQuantity: current.Quantity, ToQuantity: item.Quantity})
+			if err != nil {
+				return nil, fmt.Errorf("json.Marshal: %w", err)
+			}
+
+			rest := *item
+			rest.Quantity = current.Quantity
+			if err := s.updateItem(ctx, userID, id, &rest); err != nil {
+				return nil, err
+			}
+
+			return s.requestChange(ctx, &models.ChangeRequest{
+				Operation:   models.OpItemAdjustment,
+				TargetID:    id,
+				Payload:     payload,
+				Summary:     fmt.Sprintf("изменение item %d %q: quantity %d → %d", id, current.Name, current.Quantity, item.Quantity),
+				RequestedBy: userID,
+			})
+		}
+	}
+
+	if err := s.updateItem(ctx, userID, id, item); err != nil {
+		return nil, err
+	}
+
+	s.notifyStock(id)
+
+	return nil, nil
+}
+
+// updateItem - обновление item с переводом ошибок БД.
+func (s *inventorySvc) updateItem(ctx context.Context, userID, id int, item *models.Item) error {
 	if err := s.db.Update(ctx, userID, id, item); err != nil {
+		if strings.Contains(err.Error(), "категория") || strings.Contains(err.Error(), "нельзя") {
+			return err
+		}
 		if strings.Contains(err.Error(), "не найден") {
 			return fmt.Errorf("item с id %d не найден", id)
 		}
+		if strings.Contains(err.Error(), "серийного item") {
+			return fmt.Errorf("quantity серийного item с id %d должно совпадать с количеством серийных номеров на складе", id)
+		}
 
 		return fmt.Errorf("db.Update: %w", err)
 	}
@@ -63,16 +218,34 @@ func (s *inventorySvc) UpdateItem(ctx context.Context, userID, id int, item *mod
 }
 
 // DeleteItem - метод для удаления item из БД.
-func (s *inventorySvc) DeleteItem(ctx context.Context, userID, id int) error {
+// Если удаление требует согласования, возвращается запрос на согласование, а item остается.
+func (s *inventorySvc) DeleteItem(ctx context.Context, userID, id int) (*models.ChangeRequest, error) {
+	if s.policy.Requires(models.OpItemDelete) {
+		current, err := s.getItem(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.requestChange(ctx, &models.ChangeRequest{
+			Operation:   models.OpItemDelete,
+			TargetID:    id,
+			Summary:     fmt.Sprintf("удаление item %d %q, quantity %d", id, current.Name, current.Quantity),
+			RequestedBy: userID,
+		})
+	}
+
 	if err := s.db.Delete(ctx, userID, id); err != nil {
 		if strings.Contains(err.Error(), "не найден") {
-			return fmt.Errorf("item с id %d не найден", id)
+			return nil, fmt.Errorf("item с id %d не найден", id)
+		}
+		if strings.Contains(err.Error(), "нельзя") {
+			return nil, err
 		}
 
-		return fmt.Errorf("db.Delete: %w", err)
+		return nil, fmt.Errorf("db.Delete: %w", err)
 	}
 
-	return nil
+	return nil, nil
 }
 
 // GetItemHistory - метод для получения истории изменений для конкретного itemID.
@@ -88,3 +261,133 @@ func (s *inventorySvc) GetItemHistory(ctx context.Context, id int) ([]models.Ite
 
 	return history, nil
 }
+
+// validateIdentifiers - проверка формата sku и контрольных сумм штрихкодов item.
+func validateIdentifiers(item *models.Item) error {
+	if item.SKU != "" && !skuPattern.MatchString(item.SKU) {
+		return fmt.Errorf("некорректный sku %s: допускаются латинские буквы, цифры и символы ._/- длиной до 64", item.SKU)
+	}
+
+	seen := make(map[string]struct{}, len(item.Barcodes))
+	for _, b := range item.Barcodes {
+		if err := barcode.Validate(b.Symbology, b.Code); err != nil {
+			return fmt.Errorf("некорректный штрихкод: %w", err)
+		}
+		if _, ok := seen[b.Code]; ok {
+			return fmt.Errorf("некорректный штрихкод: %s указан несколько раз", b.Code)
+		}
+		seen[b.Code] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateAttributes - проверка атрибутов item по схеме его категории, включая унаследованные атрибуты.
+// Item без категории не может иметь атрибутов.
+func (s *inventorySvc) validateAttributes(ctx context.Context, item *models.Item) error {
+	if item.CategoryID == nil {
+		if len(item.Attributes) > 0 {
+			return fmt.Errorf("некорректный атрибут: атрибуты задаются только для item с категорией")
+		}
+
+		return nil
+	}
+
+	defs, err := s.db.GetCategoryAttributes(ctx, *item.CategoryID)
+	if err != nil {
+		return fmt.Errorf("db.GetCategoryAttributes: %w", err)
+	}
+
+	schema := make(map[string]models.AttributeDefinition, len(defs))
+	for _, def := range defs {
+		schema[def.Code] = def
+	}
+
+	for code := range item.Attributes {
+		if _, ok := schema[code]; !ok {
+			return fmt.Errorf("некорректный атрибут %s: отсутствует в схеме категории %d", code, *item.CategoryID)
+		}
+	}
+
+	for _, def := range defs {
+		value, ok := item.Attributes[def.Code]
+		if !ok || value == nil {
+			if def.Required {
+				return fmt.Errorf("некорректный атрибут %s: обязателен для категории %d", def.Code, *item.CategoryID)
+			}
+			delete(item.Attributes, def.Code)
+			continue
+		}
+		if err := checkAttributeValue(def, value); err != nil {
+			return fmt.Errorf("некорректный атрибут %s: %w", def.Code, err)
+		}
+	}
+
+	return nil
+}
+
+// checkAttributeValue - проверка значения атрибута на соответствие типу из схемы.
+func checkAttributeValue(def models.AttributeDefinition, value any) error {
+	switch def.Type {
+	case models.AttrTypeNumber:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("ожидается число")
+		}
+	case models.AttrTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("ожидается true или false")
+		}
+	case models.AttrTypeString:
+		str, ok := value.(string)
+		if !ok || strings.TrimSpace(str) == "" {
+			return fmt.Errorf("ожидается непустая строка")
+		}
+	case models.AttrTypeEnum:
+		str, ok := value.(string)
+		if !ok || !slices.Contains(def.AllowedValues, str) {
+			return fmt.Errorf("допустимые значения: %s", strings.Join(def.AllowedValues, ", "))
+		}
+	default:
+		return fmt.Errorf("неизвестный тип %s", def.Type)
+	}
+
+	return nil
+}
+
+// getItem - получение текущего item.
+func (s *inventorySvc) getItem(ctx context.Context, id int) (*models.Item, error) {
+	item, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, fmt.Errorf("item с id %d не найден", id)
+		}
+
+		return nil, fmt.Errorf("db.GetByID: %w", err)
+	}
+
+	return item, nil
+}
+
+// requestChange - создание запроса на согласование вместо выполнения операции.
+func (s *inventorySvc) requestChange(ctx context.Context, cr *models.ChangeRequest) (*models.ChangeRequest, error) {
+	cr.Status = models.ChangeRequestPending
+
+	id, err := s.db.CreateChangeRequest(ctx, cr)
+	if err != nil {
+		if strings.Contains(err.Error(), "нельзя") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.CreateChangeRequest: %w", err)
+	}
+	cr.ID = id
+
+	return cr, nil
+}
+
+// notifyStock - сигнал об изменении остатка item для проверки точки заказа.
+func (s *inventorySvc) notifyStock(itemID int) {
+	if s.notifier != nil {
+		s.notifier.Notify(itemID)
+	}
+}
@@ -2,6 +2,7 @@ package inventorysvc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -15,7 +16,7 @@ import (
 // TestInventorySvc_AddItem - тесты для метода AddItem
 func TestInventorySvc_AddItem_OK(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Товар 1",
@@ -35,7 +36,7 @@ func TestInventorySvc_AddItem_OK(t *testing.T) {
 
 func TestInventorySvc_AddItem_ErrZeroQuantity(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Товар 1",
@@ -52,7 +53,7 @@ func TestInventorySvc_AddItem_ErrZeroQuantity(t *testing.T) {
 
 func TestInventorySvc_AddItem_ErrNegativeQuantity(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Товар 1",
@@ -69,7 +70,7 @@ func TestInventorySvc_AddItem_ErrNegativeQuantity(t *testing.T) {
 
 func TestInventorySvc_AddItem_ErrDBFailed(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Товар 1",
@@ -88,10 +89,126 @@ func TestInventorySvc_AddItem_ErrDBFailed(t *testing.T) {
 	assert.Contains(t, err.Error(), "db.Create")
 }
 
+func TestInventorySvc_AddItem_OKSerializedZeroQuantity(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:       "Сервер",
+		Quantity:   0,
+		Serialized: true,
+	}
+
+	mockDB.EXPECT().
+		Create(mock.Anything, 1, item).
+		Return(1, nil)
+
+	id, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestInventorySvc_AddItem_ErrSerializedWithQuantity(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:       "Сервер",
+		Quantity:   5,
+		Serialized: true,
+	}
+
+	id, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "quantity серийного item")
+}
+
+func TestInventorySvc_AddItem_OKWithIdentifiers(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Товар 1",
+		Quantity: 10,
+		SKU:      "ABC-001",
+		Barcodes: []models.Barcode{
+			{Code: "4006381333931", Symbology: models.SymbologyEAN13},
+			{Code: "036000291452", Symbology: models.SymbologyUPCA},
+		},
+	}
+
+	mockDB.EXPECT().
+		Create(mock.Anything, 1, item).
+		Return(1, nil)
+
+	id, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestInventorySvc_AddItem_ErrInvalidSKU(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Товар 1",
+		Quantity: 10,
+		SKU:      "ABC 001",
+	}
+
+	id, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "некорректный sku")
+}
+
+func TestInventorySvc_AddItem_ErrInvalidBarcodeChecksum(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Товар 1",
+		Quantity: 10,
+		Barcodes: []models.Barcode{{Code: "4006381333932", Symbology: models.SymbologyEAN13}},
+	}
+
+	id, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "некорректный штрихкод")
+}
+
+func TestInventorySvc_AddItem_ErrBarcodeAlreadyExists(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Товар 1",
+		Quantity: 10,
+		Barcodes: []models.Barcode{{Code: "4006381333931", Symbology: models.SymbologyEAN13}},
+	}
+
+	mockDB.EXPECT().
+		Create(mock.Anything, 1, item).
+		Return(0, fmt.Errorf("штрихкод 4006381333931 уже существует"))
+
+	id, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "уже существует")
+}
+
 // TestInventorySvc_GetInventory - тесты для метода GetInventory
 func TestInventorySvc_GetInventory_OK(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	expectedItems := []models.Item{
 		{ID: 1, Name: "Товар 1", Description: "Описание 1", Quantity: 10},
@@ -99,10 +216,10 @@ func TestInventorySvc_GetInventory_OK(t *testing.T) {
 	}
 
 	mockDB.EXPECT().
-		List(mock.Anything).
+		List(mock.Anything, models.ItemFilter{}).
 		Return(expectedItems, nil)
 
-	items, err := svc.GetInventory(context.Background())
+	items, err := svc.GetInventory(context.Background(), models.ItemFilter{})
 
 	assert.NoError(t, err)
 	assert.Len(t, items, 2)
@@ -111,13 +228,13 @@ func TestInventorySvc_GetInventory_OK(t *testing.T) {
 
 func TestInventorySvc_GetInventory_ErrDBFailed(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	mockDB.EXPECT().
-		List(mock.Anything).
+		List(mock.Anything, models.ItemFilter{}).
 		Return(nil, fmt.Errorf("database error"))
 
-	items, err := svc.GetInventory(context.Background())
+	items, err := svc.GetInventory(context.Background(), models.ItemFilter{})
 
 	assert.Error(t, err)
 	assert.Nil(t, items)
@@ -126,22 +243,227 @@ func TestInventorySvc_GetInventory_ErrDBFailed(t *testing.T) {
 
 func TestInventorySvc_GetInventory_EmptyList(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	mockDB.EXPECT().
-		List(mock.Anything).
+		List(mock.Anything, models.ItemFilter{}).
 		Return([]models.Item{}, nil)
 
-	items, err := svc.GetInventory(context.Background())
+	items, err := svc.GetInventory(context.Background(), models.ItemFilter{})
 
 	assert.NoError(t, err)
 	assert.Len(t, items, 0)
 }
 
+func TestInventorySvc_GetInventory_OKByStockStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	filter := models.ItemFilter{StockStatus: models.StockQuarantine}
+	expectedItems := []models.Item{
+		{ID: 1, Name: "Товар 1", Quantity: 10, Held: map[string]int{models.StockQuarantine: 2}},
+	}
+
+	mockDB.EXPECT().
+		List(mock.Anything, filter).
+		Return(expectedItems, nil)
+
+	items, err := svc.GetInventory(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedItems, items)
+}
+
+func TestInventorySvc_GetInventory_ErrStockStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	items, err := svc.GetInventory(context.Background(), models.ItemFilter{StockStatus: "lost"})
+
+	assert.Error(t, err)
+	assert.Nil(t, items)
+	assert.Contains(t, err.Error(), "некорректный статус запаса")
+}
+
+func TestInventorySvc_GetInventory_OKByAnalysis(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	filter := models.ItemFilter{ABCClass: models.ABCClassC, MovementStatus: models.MovementDead}
+	expectedItems := []models.Item{{ID: 1, Name: "Товар 1", Quantity: 10, ABCClass: "C", MovementStatus: "dead"}}
+
+	mockDB.EXPECT().
+		List(mock.Anything, filter).
+		Return(expectedItems, nil)
+
+	items, err := svc.GetInventory(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedItems, items)
+}
+
+func TestInventorySvc_GetInventory_ErrABCClass(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	items, err := svc.GetInventory(context.Background(), models.ItemFilter{ABCClass: "D"})
+
+	assert.Error(t, err)
+	assert.Nil(t, items)
+	assert.Contains(t, err.Error(), "некорректный класс ABC")
+}
+
+func TestInventorySvc_GetInventory_OKByCategory(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	categoryID := 5
+	filter := models.ItemFilter{CategoryID: &categoryID}
+	expectedItems := []models.Item{{ID: 1, Name: "Товар 1", Quantity: 10, CategoryID: &categoryID}}
+
+	mockDB.EXPECT().
+		List(mock.Anything, filter).
+		Return(expectedItems, nil)
+
+	items, err := svc.GetInventory(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedItems, items)
+}
+
+// TestInventorySvc_GetItem - тесты для метода GetItem
+func TestInventorySvc_GetItem_OKKit(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	kit := &models.Kit{ItemID: 1, Components: []models.KitComponent{
+		{ItemID: 2, Quantity: 2, Available: 9},
+		{ItemID: 3, Quantity: 1, Available: 7},
+	}}
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 1).
+		Return(&models.Item{ID: 1, Name: "Набор"}, nil)
+	mockDB.EXPECT().
+		GetKit(mock.Anything, 1).
+		Return(kit, nil)
+
+	item, err := svc.GetItem(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, kit, item.Kit)
+	assert.Equal(t, 4, item.Kit.AvailableToBuild())
+}
+
+func TestInventorySvc_GetItem_OKNotKit(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 2).
+		Return(&models.Item{ID: 2, Name: "Товар"}, nil)
+	mockDB.EXPECT().
+		GetKit(mock.Anything, 2).
+		Return(&models.Kit{ItemID: 2}, nil)
+
+	item, err := svc.GetItem(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Nil(t, item.Kit)
+}
+
+func TestInventorySvc_GetItem_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 999).
+		Return(nil, fmt.Errorf("item с id 999 не найден"))
+
+	_, err := svc.GetItem(context.Background(), 999)
+
+	assert.Error(t, err)
+	assert.Equal(t, "item с id 999 не найден", err.Error())
+}
+
+// TestInventorySvc_GetItemByBarcode - тесты для метода GetItemByBarcode
+func TestInventorySvc_GetItemByBarcode_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	expected := &models.Item{ID: 1, Name: "Товар 1", Quantity: 10, SKU: "ABC-001"}
+
+	mockDB.EXPECT().
+		GetByBarcode(mock.Anything, "4006381333931").
+		Return(expected, nil)
+
+	item, err := svc.GetItemByBarcode(context.Background(), " 4006381333931 ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, item)
+}
+
+func TestInventorySvc_GetItemByBarcode_OKItemCode(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	expected := &models.Item{ID: 7, Name: "Товар 7", Quantity: 10}
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 7).
+		Return(expected, nil)
+
+	item, err := svc.GetItemByBarcode(context.Background(), "ITEM-7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, item)
+}
+
+func TestInventorySvc_GetItemByBarcode_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	mockDB.EXPECT().
+		GetByBarcode(mock.Anything, "4006381333931").
+		Return(nil, fmt.Errorf("item не найден"))
+
+	item, err := svc.GetItemByBarcode(context.Background(), "4006381333931")
+
+	assert.Error(t, err)
+	assert.Nil(t, item)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestInventorySvc_GetItemByBarcode_ErrEmptyCode(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item, err := svc.GetItemByBarcode(context.Background(), "  ")
+
+	assert.Error(t, err)
+	assert.Nil(t, item)
+	assert.Contains(t, err.Error(), "не может быть пустым")
+}
+
+func TestInventorySvc_GetItemByBarcode_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	mockDB.EXPECT().
+		GetByBarcode(mock.Anything, "4006381333931").
+		Return(nil, fmt.Errorf("database error"))
+
+	item, err := svc.GetItemByBarcode(context.Background(), "4006381333931")
+
+	assert.Error(t, err)
+	assert.Nil(t, item)
+	assert.Contains(t, err.Error(), "db.GetByBarcode")
+}
+
 // TestInventorySvc_UpdateItem - тесты для метода UpdateItem
 func TestInventorySvc_UpdateItem_OK(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Обновленный товар",
@@ -153,14 +475,59 @@ func TestInventorySvc_UpdateItem_OK(t *testing.T) {
 		Update(mock.Anything, 1, 1, item).
 		Return(nil)
 
-	err := svc.UpdateItem(context.Background(), 1, 1, item)
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.NoError(t, err)
+}
+
+func TestInventorySvc_UpdateItem_OKPendingApproval(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{Operations: []string{models.OpItemAdjustment}, AdjustmentThreshold: 100})
+
+	item := &models.Item{Name: "Товар переименованный", Quantity: 0}
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 1).
+		Return(&models.Item{ID: 1, Name: "Товар", Quantity: 40}, nil)
+	mockDB.EXPECT().
+		Update(mock.Anything, 2, 1, &models.Item{Name: "Товар переименованный", Quantity: 40}).
+		Return(nil)
+	mockDB.EXPECT().
+		CreateChangeRequest(mock.Anything, mock.MatchedBy(func(cr *models.ChangeRequest) bool {
+			var change models.QuantityChange
+			return cr.Operation == models.OpItemAdjustment &&
+				json.Unmarshal(cr.Payload, &change) == nil && change == models.QuantityChange{FromQuantity: 40, ToQuantity: 0}
+		})).
+		Return(3, nil)
+
+	cr, err := svc.UpdateItem(context.Background(), 2, 1, item)
 
 	assert.NoError(t, err)
+	assert.Equal(t, 3, cr.ID)
+}
+
+func TestInventorySvc_UpdateItem_OKBelowApprovalThreshold(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{Operations: []string{models.OpItemAdjustment}, AdjustmentThreshold: 100})
+
+	item := &models.Item{Name: "Товар", Quantity: 90}
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 1).
+		Return(&models.Item{ID: 1, Name: "Товар", Quantity: 40}, nil)
+	mockDB.EXPECT().
+		Update(mock.Anything, 2, 1, item).
+		Return(nil)
+
+	cr, err := svc.UpdateItem(context.Background(), 2, 1, item)
+
+	assert.NoError(t, err)
+	assert.Nil(t, cr)
 }
 
 func TestInventorySvc_UpdateItem_OKZeroQuantity(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Обновленный товар",
@@ -172,14 +539,14 @@ func TestInventorySvc_UpdateItem_OKZeroQuantity(t *testing.T) {
 		Update(mock.Anything, 1, 1, item).
 		Return(nil)
 
-	err := svc.UpdateItem(context.Background(), 1, 1, item)
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
 
 	assert.NoError(t, err)
 }
 
 func TestInventorySvc_UpdateItem_ErrNegativeQuantity(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Обновленный товар",
@@ -187,7 +554,7 @@ func TestInventorySvc_UpdateItem_ErrNegativeQuantity(t *testing.T) {
 		Quantity:    -5,
 	}
 
-	err := svc.UpdateItem(context.Background(), 1, 1, item)
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "quantity должно быть больше или равно 0")
@@ -195,7 +562,7 @@ func TestInventorySvc_UpdateItem_ErrNegativeQuantity(t *testing.T) {
 
 func TestInventorySvc_UpdateItem_ErrItemNotFound(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Обновленный товар",
@@ -207,7 +574,7 @@ func TestInventorySvc_UpdateItem_ErrItemNotFound(t *testing.T) {
 		Update(mock.Anything, 1, 999, item).
 		Return(fmt.Errorf("item с id 999 не найден"))
 
-	err := svc.UpdateItem(context.Background(), 1, 999, item)
+	_, err := svc.UpdateItem(context.Background(), 1, 999, item)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "не найден")
@@ -215,7 +582,7 @@ func TestInventorySvc_UpdateItem_ErrItemNotFound(t *testing.T) {
 
 func TestInventorySvc_UpdateItem_ErrDBFailed(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	item := &models.Item{
 		Name:        "Обновленный товар",
@@ -227,35 +594,313 @@ func TestInventorySvc_UpdateItem_ErrDBFailed(t *testing.T) {
 		Update(mock.Anything, 1, 1, item).
 		Return(fmt.Errorf("database connection error"))
 
-	err := svc.UpdateItem(context.Background(), 1, 1, item)
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "db.Update")
 }
 
+func TestInventorySvc_UpdateItem_ErrSerializedQuantityMismatch(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:       "Сервер",
+		Quantity:   5,
+		Serialized: true,
+	}
+
+	mockDB.EXPECT().
+		Update(mock.Anything, 1, 1, item).
+		Return(fmt.Errorf("pq: quantity серийного item 1 должно совпадать с количеством серийных номеров на складе (3)"))
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "серийного item")
+}
+
+func TestInventorySvc_UpdateItem_ErrSKUAlreadyExists(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Товар 1",
+		Quantity: 10,
+		SKU:      "ABC-001",
+	}
+
+	mockDB.EXPECT().
+		Update(mock.Anything, 1, 1, item).
+		Return(fmt.Errorf("item с sku ABC-001 уже существует"))
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "уже существует")
+}
+
+func TestInventorySvc_UpdateItem_ErrDuplicateBarcodeInRequest(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Товар 1",
+		Quantity: 10,
+		Barcodes: []models.Barcode{
+			{Code: "4006381333931", Symbology: models.SymbologyEAN13},
+			{Code: "4006381333931", Symbology: models.SymbologyEAN13},
+		},
+	}
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "указан несколько раз")
+}
+
+func TestInventorySvc_UpdateItem_ErrCategoryNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	categoryID := 404
+	item := &models.Item{
+		Name:       "Товар 1",
+		Quantity:   10,
+		CategoryID: &categoryID,
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 404).
+		Return(nil, nil)
+
+	mockDB.EXPECT().
+		Update(mock.Anything, 1, 1, item).
+		Return(fmt.Errorf("категория с id 404 не найдена"))
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.Error(t, err)
+	assert.Equal(t, "категория с id 404 не найдена", err.Error())
+}
+
+func TestInventorySvc_UpdateItem_AttributesOK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	categoryID := 3
+	item := &models.Item{
+		Name:       "Ноутбук",
+		Quantity:   10,
+		CategoryID: &categoryID,
+		Attributes: map[string]any{"color": "black", "weight": 1.4, "ssd": true},
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 3).
+		Return(testAttributeSchema(), nil)
+	mockDB.EXPECT().
+		Update(mock.Anything, 1, 1, item).
+		Return(nil)
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.NoError(t, err)
+}
+
+func TestInventorySvc_UpdateItem_NotifiesStockChecker(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	mockNotifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, mockNotifier, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Товар 1",
+		Quantity: 3,
+	}
+
+	mockDB.EXPECT().
+		Update(mock.Anything, 1, 7, item).
+		Return(nil)
+	mockNotifier.EXPECT().
+		Notify(7).
+		Return()
+
+	_, err := svc.UpdateItem(context.Background(), 1, 7, item)
+
+	assert.NoError(t, err)
+}
+
+func TestInventorySvc_UpdateItem_ErrAttributeUnknown(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	categoryID := 3
+	item := &models.Item{
+		Name:       "Ноутбук",
+		Quantity:   10,
+		CategoryID: &categoryID,
+		Attributes: map[string]any{"color": "black", "size": "XL"},
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 3).
+		Return(testAttributeSchema(), nil)
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный атрибут size")
+}
+
+func TestInventorySvc_UpdateItem_ErrAttributeRequired(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	categoryID := 3
+	item := &models.Item{
+		Name:       "Ноутбук",
+		Quantity:   10,
+		CategoryID: &categoryID,
+		Attributes: map[string]any{"weight": 1.4},
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 3).
+		Return(testAttributeSchema(), nil)
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "color: обязателен")
+}
+
+func TestInventorySvc_UpdateItem_ErrAttributeType(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	categoryID := 3
+	item := &models.Item{
+		Name:       "Ноутбук",
+		Quantity:   10,
+		CategoryID: &categoryID,
+		Attributes: map[string]any{"color": "black", "weight": "тяжелый"},
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 3).
+		Return(testAttributeSchema(), nil)
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "weight: ожидается число")
+}
+
+func TestInventorySvc_UpdateItem_ErrAttributeEnumValue(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	categoryID := 3
+	item := &models.Item{
+		Name:       "Ноутбук",
+		Quantity:   10,
+		CategoryID: &categoryID,
+		Attributes: map[string]any{"color": "pink"},
+	}
+
+	mockDB.EXPECT().
+		GetCategoryAttributes(mock.Anything, 3).
+		Return(testAttributeSchema(), nil)
+
+	_, err := svc.UpdateItem(context.Background(), 1, 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "допустимые значения: black, silver")
+}
+
+func TestInventorySvc_AddItem_ErrInvalidBaseUnit(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Мука",
+		Quantity: 10,
+		BaseUnit: "Kg",
+	}
+
+	_, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная единица измерения Kg")
+}
+
+func TestInventorySvc_AddItem_DefaultBaseUnit(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:     "Товар 1",
+		Quantity: 10,
+	}
+
+	mockDB.EXPECT().
+		Create(mock.Anything, 1, item).
+		Return(1, nil)
+
+	_, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultBaseUnit, item.BaseUnit)
+}
+
+func TestInventorySvc_AddItem_ErrAttributesWithoutCategory(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
+
+	item := &models.Item{
+		Name:       "Ноутбук",
+		Quantity:   10,
+		Attributes: map[string]any{"color": "black"},
+	}
+
+	_, err := svc.AddItem(context.Background(), 1, item)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "только для item с категорией")
+}
+
+// testAttributeSchema - схема атрибутов категории для тестов: цвет из родительской категории и собственные атрибуты.
+func testAttributeSchema() []models.AttributeDefinition {
+	return []models.AttributeDefinition{
+		{ID: 1, CategoryID: 1, Code: "color", Name: "Цвет", Type: models.AttrTypeEnum, Required: true, AllowedValues: []string{"black", "silver"}},
+		{ID: 2, CategoryID: 3, Code: "ssd", Name: "SSD", Type: models.AttrTypeBoolean},
+		{ID: 3, CategoryID: 3, Code: "weight", Name: "Вес", Type: models.AttrTypeNumber, Unit: "кг"},
+	}
+}
+
 // TestInventorySvc_DeleteItem - тесты для метода DeleteItem
 func TestInventorySvc_DeleteItem_OK(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	mockDB.EXPECT().
 		Delete(mock.Anything, 1, 1).
 		Return(nil)
 
-	err := svc.DeleteItem(context.Background(), 1, 1)
+	_, err := svc.DeleteItem(context.Background(), 1, 1)
 
 	assert.NoError(t, err)
 }
 
 func TestInventorySvc_DeleteItem_ErrItemNotFound(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	mockDB.EXPECT().
 		Delete(mock.Anything, 1, 999).
 		Return(fmt.Errorf("item с id 999 не найден"))
 
-	err := svc.DeleteItem(context.Background(), 1, 999)
+	_, err := svc.DeleteItem(context.Background(), 1, 999)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "не найден")
@@ -263,22 +908,59 @@ func TestInventorySvc_DeleteItem_ErrItemNotFound(t *testing.T) {
 
 func TestInventorySvc_DeleteItem_ErrDBFailed(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	mockDB.EXPECT().
 		Delete(mock.Anything, 1, 1).
 		Return(fmt.Errorf("database connection error"))
 
-	err := svc.DeleteItem(context.Background(), 1, 1)
+	_, err := svc.DeleteItem(context.Background(), 1, 1)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "db.Delete")
 }
 
+func TestInventorySvc_DeleteItem_OKPendingApproval(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{Operations: []string{models.OpItemDelete}})
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 1).
+		Return(&models.Item{ID: 1, Name: "Товар", Quantity: 10}, nil)
+	mockDB.EXPECT().
+		CreateChangeRequest(mock.Anything, mock.MatchedBy(func(cr *models.ChangeRequest) bool {
+			return cr.Operation == models.OpItemDelete && cr.TargetID == 1 && cr.RequestedBy == 2
+		})).
+		Return(5, nil)
+
+	cr, err := svc.DeleteItem(context.Background(), 2, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, cr.ID)
+	assert.Equal(t, models.ChangeRequestPending, cr.Status)
+}
+
+func TestInventorySvc_DeleteItem_ErrPendingExists(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, models.ApprovalPolicy{Operations: []string{models.OpItemDelete}})
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 1).
+		Return(&models.Item{ID: 1, Name: "Товар", Quantity: 10}, nil)
+	mockDB.EXPECT().
+		CreateChangeRequest(mock.Anything, mock.Anything).
+		Return(0, fmt.Errorf("нельзя создать запрос на согласование: для item_delete 1 уже есть ожидающий запрос"))
+
+	_, err := svc.DeleteItem(context.Background(), 2, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя")
+}
+
 // TestInventorySvc_GetItemHistory - тесты для метода GetItemHistory
 func TestInventorySvc_GetItemHistory_OK(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	oldValue := `{"id":1,"name":"Старое название","quantity":10}`
 	newValue := `{"id":1,"name":"Новое название","quantity":15}`
@@ -301,7 +983,7 @@ func TestInventorySvc_GetItemHistory_OK(t *testing.T) {
 
 func TestInventorySvc_GetItemHistory_ErrItemNotFound(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	mockDB.EXPECT().
 		GetByItemID(mock.Anything, 999).
@@ -316,7 +998,7 @@ func TestInventorySvc_GetItemHistory_ErrItemNotFound(t *testing.T) {
 
 func TestInventorySvc_GetItemHistory_ErrDBFailed(t *testing.T) {
 	mockDB := mocks.NewDatabase(t)
-	svc := New(mockDB)
+	svc := New(mockDB, nil, models.ApprovalPolicy{})
 
 	mockDB.EXPECT().
 		GetByItemID(mock.Anything, 1).
@@ -0,0 +1,132 @@
+package kitsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.KitService = (*kitSvc)(nil)
+
+type kitSvc struct {
+	db       infra.Database
+	notifier services.StockNotifier
+}
+
+// New - конструктор нового kitSvc.
+// notifier получает сигналы об изменении доступного остатка, nil - без сигналов.
+func New(db infra.Database, notifier services.StockNotifier) services.KitService {
+	return &kitSvc{db: db, notifier: notifier}
+}
+
+// SetKit - метод для задания состава комплекта, прежний состав заменяется целиком.
+// Возвращает сохраненный состав с доступным остатком компонентов.
+func (s *kitSvc) SetKit(ctx context.Context, kit *models.Kit) (*models.Kit, error) {
+	if len(kit.Components) == 0 {
+		return nil, fmt.Errorf("некорректный состав комплекта: нет компонентов")
+	}
+
+	seen := make(map[int]bool, len(kit.Components))
+	for _, c := range kit.Components {
+		if c.ItemID == kit.ItemID {
+			return nil, fmt.Errorf("некорректный состав комплекта: item %d не может входить в свой комплект", c.ItemID)
+		}
+		if seen[c.ItemID] {
+			return nil, fmt.Errorf("некорректный состав комплекта: компонент %d указан дважды", c.ItemID)
+		}
+		seen[c.ItemID] = true
+		if c.Quantity <= 0 {
+			return nil, fmt.Errorf("некорректное количество компонента %d: должно быть больше 0", c.ItemID)
+		}
+	}
+
+	if err := s.db.SetKitComponents(ctx, kit); err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "нельзя") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.SetKitComponents: %w", err)
+	}
+
+	saved, err := s.db.GetKit(ctx, kit.ItemID)
+	if err != nil {
+		return nil, fmt.Errorf("db.GetKit: %w", err)
+	}
+
+	return saved, nil
+}
+
+// DeleteKit - метод для удаления состава комплекта, item и его остаток сохраняются.
+func (s *kitSvc) DeleteKit(ctx context.Context, itemID int) error {
+	if err := s.db.DeleteKit(ctx, itemID); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return err
+		}
+
+		return fmt.Errorf("db.DeleteKit: %w", err)
+	}
+
+	return nil
+}
+
+// AssembleKit - метод для сборки или разборки комплектов.
+// Компоненты и комплекты меняются в одной транзакции, документ получает номер и себестоимость.
+func (s *kitSvc) AssembleKit(ctx context.Context, userID int, assembly *models.KitAssembly) error {
+	switch assembly.Operation {
+	case models.KitAssemble, models.KitDisassemble:
+	default:
+		return fmt.Errorf("некорректная операция с комплектом %q", assembly.Operation)
+	}
+	if assembly.Quantity <= 0 {
+		return fmt.Errorf("некорректное количество %d: должно быть больше 0", assembly.Quantity)
+	}
+	assembly.Note = strings.TrimSpace(assembly.Note)
+	assembly.CreatedBy = userID
+
+	if err := s.db.AssembleKit(ctx, assembly); err != nil {
+		if strings.Contains(err.Error(), "не найден") ||
+			strings.Contains(err.Error(), "нельзя") ||
+			strings.Contains(err.Error(), "недостаточно") {
+			return err
+		}
+
+		return fmt.Errorf("db.AssembleKit: %w", err)
+	}
+
+	s.notify(assembly)
+
+	return nil
+}
+
+// GetAssemblies - метод для получения документов сборки и разборки комплекта.
+func (s *kitSvc) GetAssemblies(ctx context.Context, itemID int) ([]models.KitAssembly, error) {
+	if _, err := s.db.GetByID(ctx, itemID); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetByID: %w", err)
+	}
+
+	assemblies, err := s.db.ListKitAssemblies(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListKitAssemblies: %w", err)
+	}
+
+	return assemblies, nil
+}
+
+// notify - сигнал об изменении доступного остатка комплекта и его компонентов.
+func (s *kitSvc) notify(assembly *models.KitAssembly) {
+	if s.notifier == nil {
+		return
+	}
+	s.notifier.Notify(assembly.KitID)
+	for _, line := range assembly.Lines {
+		s.notifier.Notify(line.ItemID)
+	}
+}
@@ -0,0 +1,162 @@
+package kitsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestKitSvc_SetKit - тесты для метода SetKit
+func TestKitSvc_SetKit_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	kit := &models.Kit{ItemID: 1, Components: []models.KitComponent{
+		{ItemID: 2, Quantity: 2},
+		{ItemID: 3, Quantity: 1},
+	}}
+	saved := &models.Kit{ItemID: 1, Components: []models.KitComponent{
+		{ItemID: 2, Quantity: 2, Available: 5},
+		{ItemID: 3, Quantity: 1, Available: 1},
+	}}
+
+	mockDB.EXPECT().
+		SetKitComponents(mock.Anything, kit).
+		Return(nil)
+	mockDB.EXPECT().
+		GetKit(mock.Anything, 1).
+		Return(saved, nil)
+
+	result, err := svc.SetKit(context.Background(), kit)
+
+	assert.NoError(t, err)
+	assert.Equal(t, saved, result)
+	assert.Equal(t, 1, result.AvailableToBuild())
+}
+
+func TestKitSvc_SetKit_ErrSelfComponent(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, err := svc.SetKit(context.Background(), &models.Kit{ItemID: 1, Components: []models.KitComponent{
+		{ItemID: 1, Quantity: 1},
+	}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не может входить в свой комплект")
+}
+
+func TestKitSvc_SetKit_ErrDuplicateComponent(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, err := svc.SetKit(context.Background(), &models.Kit{ItemID: 1, Components: []models.KitComponent{
+		{ItemID: 2, Quantity: 1},
+		{ItemID: 2, Quantity: 3},
+	}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "компонент 2 указан дважды")
+}
+
+func TestKitSvc_SetKit_ErrNestedKit(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		SetKitComponents(mock.Anything, mock.Anything).
+		Return(errors.New("нельзя включить комплект 2 в состав другого комплекта"))
+
+	_, err := svc.SetKit(context.Background(), &models.Kit{ItemID: 1, Components: []models.KitComponent{
+		{ItemID: 2, Quantity: 1},
+	}})
+
+	assert.Error(t, err)
+	assert.Equal(t, "нельзя включить комплект 2 в состав другого комплекта", err.Error())
+}
+
+// TestKitSvc_AssembleKit - тесты для метода AssembleKit
+func TestKitSvc_AssembleKit_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		AssembleKit(mock.Anything, mock.MatchedBy(func(a *models.KitAssembly) bool {
+			return a.KitID == 1 && a.Operation == models.KitAssemble && a.Quantity == 3 &&
+				a.CreatedBy == 5 && a.Note == "заказ 17"
+		})).
+		RunAndReturn(func(_ context.Context, a *models.KitAssembly) error {
+			a.ID = 7
+			a.Number = "KA-000007"
+			a.Lines = []models.KitAssemblyLine{{ItemID: 2, Quantity: 6}}
+			return nil
+		})
+	notifier.EXPECT().Notify(1).Return()
+	notifier.EXPECT().Notify(2).Return()
+
+	assembly := &models.KitAssembly{KitID: 1, Operation: models.KitAssemble, Quantity: 3, Note: " заказ 17 "}
+	err := svc.AssembleKit(context.Background(), 5, assembly)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "KA-000007", assembly.Number)
+}
+
+func TestKitSvc_AssembleKit_ErrInsufficient(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, mocks.NewStockNotifier(t))
+
+	mockDB.EXPECT().
+		AssembleKit(mock.Anything, mock.Anything).
+		Return(errors.New("недостаточно доступного незарезервированного остатка компонента 2: нужно 6"))
+
+	err := svc.AssembleKit(context.Background(), 5, &models.KitAssembly{KitID: 1, Operation: models.KitAssemble, Quantity: 3})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "недостаточно")
+}
+
+func TestKitSvc_AssembleKit_ErrInvalidQuantity(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	err := svc.AssembleKit(context.Background(), 5, &models.KitAssembly{KitID: 1, Operation: models.KitDisassemble})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректное количество")
+}
+
+func TestKitSvc_AssembleKit_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		AssembleKit(mock.Anything, mock.Anything).
+		Return(errors.New("connection refused"))
+
+	err := svc.AssembleKit(context.Background(), 5, &models.KitAssembly{KitID: 1, Operation: models.KitDisassemble, Quantity: 1})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.AssembleKit")
+}
+
+// TestKitSvc_DeleteKit - тесты для метода DeleteKit
+func TestKitSvc_DeleteKit_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		DeleteKit(mock.Anything, 4).
+		Return(errors.New("состав комплекта item 4 не найден"))
+
+	err := svc.DeleteKit(context.Background(), 4)
+
+	assert.Error(t, err)
+	assert.Equal(t, "состав комплекта item 4 не найден", err.Error())
+}
@@ -0,0 +1,105 @@
+package labelsvc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/internal/label"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	maxSheetItems = 500
+)
+
+var _ services.LabelService = (*labelSvc)(nil)
+
+type labelSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового labelSvc.
+func New(db infra.Database) services.LabelService {
+	return &labelSvc{db: db}
+}
+
+// ItemLabel - метод для получения этикетки item в виде PNG или SVG.
+func (s *labelSvc) ItemLabel(ctx context.Context, itemID int, kind, format string) ([]byte, error) {
+	item, err := s.db.GetByID(ctx, itemID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetByID: %w", err)
+	}
+
+	return label.RenderBytes(kind, format, itemContent(item))
+}
+
+// LocationLabel - метод для получения этикетки складской ячейки в виде PNG или SVG.
+func (s *labelSvc) LocationLabel(ctx context.Context, locationID int, kind, format string) ([]byte, error) {
+	loc, err := s.db.GetLocationByID(ctx, locationID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetLocationByID: %w", err)
+	}
+
+	return label.RenderBytes(kind, format, loc.Code)
+}
+
+// ItemLabelSheet - метод для формирования PDF листа этикеток выбранных items.
+// Этикетки идут в порядке itemIDs, повторяющийся id печатается несколько раз.
+func (s *labelSvc) ItemLabelSheet(ctx context.Context, itemIDs []int, kind string, layout label.Layout) ([]byte, error) {
+	if len(itemIDs) == 0 {
+		return nil, fmt.Errorf("список items не может быть пустым")
+	}
+	if len(itemIDs) > maxSheetItems {
+		return nil, fmt.Errorf("за один раз можно напечатать не более %d этикеток", maxSheetItems)
+	}
+
+	items, err := s.db.ListByIDs(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListByIDs: %w", err)
+	}
+
+	byID := make(map[int]*models.Item, len(items))
+	for i := range items {
+		byID[items[i].ID] = &items[i]
+	}
+
+	labels := make([]label.Label, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		item, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("item с id %d не найден", id)
+		}
+		labels = append(labels, label.Label{Title: item.Name, Content: itemContent(item)})
+	}
+
+	var buf bytes.Buffer
+	if err := label.Sheet(&buf, layout, kind, labels); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// itemContent - содержимое этикетки item: sku, первый штрихкод или внутренний код по id.
+func itemContent(item *models.Item) string {
+	if item.SKU != "" {
+		return item.SKU
+	}
+	if len(item.Barcodes) > 0 {
+		return item.Barcodes[0].Code
+	}
+
+	return label.ItemCode(item.ID)
+}
@@ -0,0 +1,163 @@
+package labelsvc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/internal/label"
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestLabelSvc_ItemLabel - тесты для метода ItemLabel
+func TestLabelSvc_ItemLabel_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 1).
+		Return(&models.Item{ID: 1, Name: "Товар 1", SKU: "ABC-001"}, nil)
+
+	data, err := svc.ItemLabel(context.Background(), 1, label.KindCode128, label.FormatSVG)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "<svg "))
+}
+
+func TestLabelSvc_ItemLabel_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 404).
+		Return(nil, fmt.Errorf("item с id 404 не найден"))
+
+	data, err := svc.ItemLabel(context.Background(), 404, label.KindQR, label.FormatPNG)
+
+	assert.Error(t, err)
+	assert.Nil(t, data)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestLabelSvc_ItemLabel_ErrUnknownFormat(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 1).
+		Return(&models.Item{ID: 1, Name: "Товар 1"}, nil)
+
+	data, err := svc.ItemLabel(context.Background(), 1, label.KindQR, "gif")
+
+	assert.Error(t, err)
+	assert.Nil(t, data)
+	assert.Contains(t, err.Error(), "неизвестный формат")
+}
+
+// TestLabelSvc_LocationLabel - тесты для метода LocationLabel
+func TestLabelSvc_LocationLabel_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetLocationByID(mock.Anything, 1).
+		Return(&models.Location{ID: 1, Code: "A-01-02"}, nil)
+
+	data, err := svc.LocationLabel(context.Background(), 1, label.KindQR, label.FormatPNG)
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("\x89PNG")))
+}
+
+func TestLabelSvc_LocationLabel_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetLocationByID(mock.Anything, 1).
+		Return(nil, fmt.Errorf("database error"))
+
+	data, err := svc.LocationLabel(context.Background(), 1, label.KindQR, label.FormatPNG)
+
+	assert.Error(t, err)
+	assert.Nil(t, data)
+	assert.Contains(t, err.Error(), "db.GetLocationByID")
+}
+
+// TestLabelSvc_ItemLabelSheet - тесты для метода ItemLabelSheet
+func TestLabelSvc_ItemLabelSheet_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListByIDs(mock.Anything, []int{2, 1, 2}).
+		Return([]models.Item{
+			{ID: 1, Name: "Товар 1", SKU: "ABC-001"},
+			{ID: 2, Name: "Товар 2", Barcodes: []models.Barcode{{Code: "4006381333931", Symbology: models.SymbologyEAN13}}},
+		}, nil)
+
+	data, err := svc.ItemLabelSheet(context.Background(), []int{2, 1, 2}, label.KindCode128, label.Layouts["a4-21"])
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+}
+
+func TestLabelSvc_ItemLabelSheet_ErrItemNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListByIDs(mock.Anything, []int{1, 3}).
+		Return([]models.Item{{ID: 1, Name: "Товар 1"}}, nil)
+
+	data, err := svc.ItemLabelSheet(context.Background(), []int{1, 3}, label.KindQR, label.Layouts["a4-21"])
+
+	assert.Error(t, err)
+	assert.Nil(t, data)
+	assert.Contains(t, err.Error(), "item с id 3 не найден")
+}
+
+func TestLabelSvc_ItemLabelSheet_ErrEmptyList(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	data, err := svc.ItemLabelSheet(context.Background(), nil, label.KindQR, label.Layouts["a4-21"])
+
+	assert.Error(t, err)
+	assert.Nil(t, data)
+	assert.Contains(t, err.Error(), "не может быть пустым")
+}
+
+func TestLabelSvc_ItemLabelSheet_ErrInvalidLayout(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	layout := label.Layouts["a4-21"]
+	layout.Columns = 10
+
+	mockDB.EXPECT().
+		ListByIDs(mock.Anything, []int{1}).
+		Return([]models.Item{{ID: 1, Name: "Товар 1"}}, nil)
+
+	data, err := svc.ItemLabelSheet(context.Background(), []int{1}, label.KindQR, layout)
+
+	assert.Error(t, err)
+	assert.Nil(t, data)
+	assert.Contains(t, err.Error(), "некорректная раскладка")
+}
+
+// TestItemContent - тесты для выбора содержимого этикетки item
+func TestItemContent(t *testing.T) {
+	assert.Equal(t, "ABC-001", itemContent(&models.Item{ID: 1, SKU: "ABC-001"}))
+	assert.Equal(t, "4006381333931", itemContent(&models.Item{
+		ID:       1,
+		Barcodes: []models.Barcode{{Code: "4006381333931", Symbology: models.SymbologyEAN13}},
+	}))
+	assert.Equal(t, "ITEM-7", itemContent(&models.Item{ID: 7}))
+}
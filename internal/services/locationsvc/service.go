@@ -0,0 +1,68 @@
+package locationsvc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var codePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9._/-]{0,31}$`)
+
+var _ services.LocationService = (*locationSvc)(nil)
+
+type locationSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового locationSvc.
+func New(db infra.Database) services.LocationService {
+	return &locationSvc{db: db}
+}
+
+// CreateLocation - метод для создания складской ячейки.
+// Код ячейки приводится к верхнему регистру, чтобы совпадать с напечатанной этикеткой.
+func (s *locationSvc) CreateLocation(ctx context.Context, loc *models.Location) (int, error) {
+	loc.Code = strings.ToUpper(strings.TrimSpace(loc.Code))
+	if !codePattern.MatchString(loc.Code) {
+		return 0, fmt.Errorf("некорректный код ячейки %s: допускаются латинские буквы, цифры и символы ._/- длиной до 32", loc.Code)
+	}
+
+	id, err := s.db.CreateLocation(ctx, loc)
+	if err != nil {
+		if strings.Contains(err.Error(), "уже существует") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.CreateLocation: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetLocations - метод для получения всех складских ячеек.
+func (s *locationSvc) GetLocations(ctx context.Context) ([]models.Location, error) {
+	locations, err := s.db.ListLocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListLocations: %w", err)
+	}
+
+	return locations, nil
+}
+
+// SetItemPickLocation - метод для назначения item ячейки подбора, по ней группируются листы подбора.
+func (s *locationSvc) SetItemPickLocation(ctx context.Context, itemID int, locationID *int) error {
+	if err := s.db.SetItemPickLocation(ctx, itemID, locationID); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return err
+		}
+
+		return fmt.Errorf("db.SetItemPickLocation: %w", err)
+	}
+
+	return nil
+}
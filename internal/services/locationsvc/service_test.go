@@ -0,0 +1,130 @@
+package locationsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestLocationSvc_CreateLocation - тесты для метода CreateLocation
+func TestLocationSvc_CreateLocation_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateLocation(mock.Anything, &models.Location{Code: "A-01-02", Name: "Стеллаж A"}).
+		Return(1, nil)
+
+	id, err := svc.CreateLocation(context.Background(), &models.Location{Code: " a-01-02 ", Name: "Стеллаж A"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestLocationSvc_CreateLocation_ErrInvalidCode(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	id, err := svc.CreateLocation(context.Background(), &models.Location{Code: "A 01"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "некорректный код ячейки")
+}
+
+func TestLocationSvc_CreateLocation_ErrAlreadyExists(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateLocation(mock.Anything, mock.Anything).
+		Return(0, fmt.Errorf("ячейка с кодом A-01 уже существует"))
+
+	id, err := svc.CreateLocation(context.Background(), &models.Location{Code: "A-01"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "уже существует")
+}
+
+func TestLocationSvc_CreateLocation_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateLocation(mock.Anything, mock.Anything).
+		Return(0, fmt.Errorf("database error"))
+
+	id, err := svc.CreateLocation(context.Background(), &models.Location{Code: "A-01"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "db.CreateLocation")
+}
+
+// TestLocationSvc_GetLocations - тесты для метода GetLocations
+func TestLocationSvc_GetLocations_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	expected := []models.Location{{ID: 1, Code: "A-01"}, {ID: 2, Code: "A-02"}}
+
+	mockDB.EXPECT().
+		ListLocations(mock.Anything).
+		Return(expected, nil)
+
+	locations, err := svc.GetLocations(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, locations)
+}
+
+func TestLocationSvc_GetLocations_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListLocations(mock.Anything).
+		Return(nil, fmt.Errorf("database error"))
+
+	locations, err := svc.GetLocations(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, locations)
+	assert.Contains(t, err.Error(), "db.ListLocations")
+}
+
+// TestLocationSvc_SetItemPickLocation - тесты для метода SetItemPickLocation
+func TestLocationSvc_SetItemPickLocation_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	locID := 3
+	mockDB.EXPECT().
+		SetItemPickLocation(mock.Anything, 1, &locID).
+		Return(nil)
+
+	err := svc.SetItemPickLocation(context.Background(), 1, &locID)
+
+	assert.NoError(t, err)
+}
+
+func TestLocationSvc_SetItemPickLocation_ErrLocationNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	locID := 9
+	mockDB.EXPECT().
+		SetItemPickLocation(mock.Anything, 1, &locID).
+		Return(fmt.Errorf("ячейка с id 9 не найдена"))
+
+	err := svc.SetItemPickLocation(context.Background(), 1, &locID)
+
+	assert.EqualError(t, err, "ячейка с id 9 не найдена")
+}
@@ -0,0 +1,157 @@
+package lotsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.LotService = (*lotSvc)(nil)
+
+type lotSvc struct {
+	db       infra.Database
+	notifier services.StockNotifier
+}
+
+// New - конструктор нового lotSvc.
+// notifier получает сигналы об изменении остатка, nil - без сигналов.
+func New(db infra.Database, notifier services.StockNotifier) services.LotService {
+	return &lotSvc{db: db, notifier: notifier}
+}
+
+// ReceiveLot - метод для приемки новой партии item.
+func (s *lotSvc) ReceiveLot(ctx context.Context, userID int, lot *models.Lot) (int, error) {
+	if lot.Quantity <= 0 {
+		return 0, fmt.Errorf("quantity должно быть больше 0")
+	}
+	if strings.TrimSpace(lot.LotNumber) == "" {
+		return 0, fmt.Errorf("lot_number не может быть пустым")
+	}
+	if lot.ManufacturedAt != nil && lot.ExpiresAt != nil && lot.ExpiresAt.Before(*lot.ManufacturedAt) {
+		return 0, fmt.Errorf("expires_at не может быть раньше manufactured_at")
+	}
+	if lot.CostCurrency != "" {
+		code, err := models.ParseCurrencyCode(lot.CostCurrency)
+		if err != nil {
+			return 0, err
+		}
+		lot.CostCurrency = code
+	}
+
+	id, err := s.db.CreateLot(ctx, userID, lot)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") ||
+			strings.Contains(err.Error(), "уже существует") ||
+			strings.Contains(err.Error(), "некорректн") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.CreateLot: %w", err)
+	}
+
+	s.notifyStock(lot.ItemID)
+
+	return id, nil
+}
+
+// GetItemLots - метод для получения партий item в порядке FEFO.
+func (s *lotSvc) GetItemLots(ctx context.Context, itemID int) ([]models.Lot, error) {
+	lots, err := s.db.GetLotsByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("db.GetLotsByItemID: %w", err)
+	}
+
+	return lots, nil
+}
+
+// Issue - метод для списания item из партий по принципу FEFO (first expired, first out).
+// Просроченные партии пропускаются. Возвращает выбранные партии и себестоимость списания.
+func (s *lotSvc) Issue(ctx context.Context, userID, itemID, quantity int) ([]models.LotPick, models.Money, error) {
+	if quantity <= 0 {
+		return nil, 0, fmt.Errorf("quantity должно быть больше 0")
+	}
+
+	lots, err := s.db.GetLotsByItemID(ctx, itemID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("db.GetLotsByItemID: %w", err)
+	}
+
+	picks, err := pickFEFO(lots, quantity, today())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cost, err := s.db.IssueLots(ctx, userID, itemID, picks)
+	if err != nil {
+		if strings.Contains(err.Error(), "недостаточно") {
+			return nil, 0, err
+		}
+
+		return nil, 0, fmt.Errorf("db.IssueLots: %w", err)
+	}
+
+	s.notifyStock(itemID)
+
+	return picks, cost, nil
+}
+
+// GetExpiringLots - метод для получения партий, срок годности которых истекает в ближайшие days дней.
+// Уже просроченные партии также попадают в отчет.
+func (s *lotSvc) GetExpiringLots(ctx context.Context, days int) ([]models.ExpiringLot, error) {
+	if days < 0 {
+		return nil, fmt.Errorf("days должно быть больше или равно 0")
+	}
+
+	lots, err := s.db.GetExpiringLots(ctx, today().AddDate(0, 0, days))
+	if err != nil {
+		return nil, fmt.Errorf("db.GetExpiringLots: %w", err)
+	}
+
+	return lots, nil
+}
+
+// pickFEFO - выбор партий для списания quantity единиц.
+// Партии должны быть отсортированы по сроку годности, партии без срока идут последними.
+func pickFEFO(lots []models.Lot, quantity int, day time.Time) ([]models.LotPick, error) {
+	var picks []models.LotPick
+	remaining := quantity
+	for _, lot := range lots {
+		if remaining == 0 {
+			break
+		}
+		if lot.Quantity <= 0 || lot.Expired(day) {
+			continue
+		}
+
+		take := min(lot.Quantity, remaining)
+		picks = append(picks, models.LotPick{
+			LotID:     lot.ID,
+			LotNumber: lot.LotNumber,
+			Quantity:  take,
+		})
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		return nil, fmt.Errorf("недостаточно остатка в непросроченных партиях: запрошено %d, доступно %d", quantity, quantity-remaining)
+	}
+
+	return picks, nil
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// notifyStock - сигнал об изменении остатка item для проверки точки заказа.
+func (s *lotSvc) notifyStock(itemID int) {
+	if s.notifier != nil {
+		s.notifier.Notify(itemID)
+	}
+}
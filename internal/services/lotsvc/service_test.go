@@ -0,0 +1,186 @@
+package lotsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func date(days int) *time.Time {
+	d := today().AddDate(0, 0, days)
+	return &d
+}
+
+// TestLotSvc_ReceiveLot - тесты для метода ReceiveLot
+func TestLotSvc_ReceiveLot_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	lot := &models.Lot{ItemID: 1, LotNumber: "L-001", ExpiresAt: date(90), Quantity: 10}
+
+	mockDB.EXPECT().
+		CreateLot(mock.Anything, 1, lot).
+		Return(5, nil)
+
+	id, err := svc.ReceiveLot(context.Background(), 1, lot)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, id)
+}
+
+func TestLotSvc_ReceiveLot_ErrZeroQuantity(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	id, err := svc.ReceiveLot(context.Background(), 1, &models.Lot{ItemID: 1, LotNumber: "L-001"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "quantity должно быть больше 0")
+}
+
+func TestLotSvc_ReceiveLot_ErrExpiresBeforeManufactured(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	lot := &models.Lot{ItemID: 1, LotNumber: "L-001", ManufacturedAt: date(0), ExpiresAt: date(-1), Quantity: 1}
+
+	id, err := svc.ReceiveLot(context.Background(), 1, lot)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "expires_at")
+}
+
+func TestLotSvc_ReceiveLot_ErrDuplicate(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	lot := &models.Lot{ItemID: 1, LotNumber: "L-001", Quantity: 1}
+
+	mockDB.EXPECT().
+		CreateLot(mock.Anything, 1, lot).
+		Return(0, fmt.Errorf("партия L-001 для item с id 1 уже существует"))
+
+	_, err := svc.ReceiveLot(context.Background(), 1, lot)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "уже существует")
+}
+
+// TestLotSvc_Issue - тесты для метода Issue
+func TestLotSvc_Issue_OKFEFO(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	lots := []models.Lot{
+		{ID: 1, ItemID: 1, LotNumber: "EXPIRED", ExpiresAt: date(-1), Quantity: 100},
+		{ID: 2, ItemID: 1, LotNumber: "SOON", ExpiresAt: date(5), Quantity: 3},
+		{ID: 3, ItemID: 1, LotNumber: "EMPTY", ExpiresAt: date(10), Quantity: 0},
+		{ID: 4, ItemID: 1, LotNumber: "LATER", ExpiresAt: date(30), Quantity: 10},
+		{ID: 5, ItemID: 1, LotNumber: "NO-EXPIRY", Quantity: 10},
+	}
+	expectedPicks := []models.LotPick{
+		{LotID: 2, LotNumber: "SOON", Quantity: 3},
+		{LotID: 4, LotNumber: "LATER", Quantity: 5},
+	}
+
+	mockDB.EXPECT().
+		GetLotsByItemID(mock.Anything, 1).
+		Return(lots, nil)
+	mockDB.EXPECT().
+		IssueLots(mock.Anything, 7, 1, expectedPicks).
+		Return(models.Money(960000), nil)
+
+	picks, cost, err := svc.Issue(context.Background(), 7, 1, 8)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPicks, picks)
+	assert.Equal(t, "96.00", cost.String())
+}
+
+func TestLotSvc_Issue_ErrNotEnoughInLots(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	lots := []models.Lot{
+		{ID: 1, ItemID: 1, LotNumber: "EXPIRED", ExpiresAt: date(-1), Quantity: 100},
+		{ID: 2, ItemID: 1, LotNumber: "SOON", ExpiresAt: date(5), Quantity: 3},
+	}
+
+	mockDB.EXPECT().
+		GetLotsByItemID(mock.Anything, 1).
+		Return(lots, nil)
+
+	picks, _, err := svc.Issue(context.Background(), 7, 1, 5)
+
+	assert.Error(t, err)
+	assert.Nil(t, picks)
+	assert.Contains(t, err.Error(), "недостаточно остатка")
+}
+
+func TestLotSvc_Issue_ErrNotEnoughAvailable(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	lots := []models.Lot{{ID: 1, ItemID: 1, LotNumber: "L-001", Quantity: 10}}
+
+	mockDB.EXPECT().
+		GetLotsByItemID(mock.Anything, 1).
+		Return(lots, nil)
+	mockDB.EXPECT().
+		IssueLots(mock.Anything, 7, 1, mock.Anything).
+		Return(0, fmt.Errorf("недостаточно доступного остатка item с id 1"))
+
+	_, _, err := svc.Issue(context.Background(), 7, 1, 5)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "недостаточно доступного остатка")
+}
+
+func TestLotSvc_Issue_ErrZeroQuantity(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	picks, _, err := svc.Issue(context.Background(), 7, 1, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, picks)
+	assert.Contains(t, err.Error(), "quantity должно быть больше 0")
+}
+
+// TestLotSvc_GetExpiringLots - тесты для метода GetExpiringLots
+func TestLotSvc_GetExpiringLots_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	expected := []models.ExpiringLot{
+		{Lot: models.Lot{ID: 1, ItemID: 1, LotNumber: "L-001", ExpiresAt: date(3), Quantity: 4}, ItemName: "Товар 1", DaysLeft: 3},
+	}
+
+	mockDB.EXPECT().
+		GetExpiringLots(mock.Anything, *date(7)).
+		Return(expected, nil)
+
+	lots, err := svc.GetExpiringLots(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, lots)
+}
+
+func TestLotSvc_GetExpiringLots_ErrNegativeDays(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	lots, err := svc.GetExpiringLots(context.Background(), -1)
+
+	assert.Error(t, err)
+	assert.Nil(t, lots)
+}
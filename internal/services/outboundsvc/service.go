@@ -0,0 +1,246 @@
+package outboundsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.OutboundService = (*outboundSvc)(nil)
+
+type outboundSvc struct {
+	db       infra.Database
+	notifier services.StockNotifier
+}
+
+// New - конструктор нового outboundSvc.
+// notifier получает сигналы об изменении доступного остатка, nil - без сигналов.
+func New(db infra.Database, notifier services.StockNotifier) services.OutboundService {
+	return &outboundSvc{db: db, notifier: notifier}
+}
+
+// CreateOutboundOrder - метод для создания заказа на отгрузку.
+func (s *outboundSvc) CreateOutboundOrder(ctx context.Context, userID int, order *models.OutboundOrder) (int, string, error) {
+	if len(order.Lines) == 0 {
+		return 0, "", fmt.Errorf("некорректный заказ: нет строк")
+	}
+	seen := make(map[int]struct{}, len(order.Lines))
+	for _, line := range order.Lines {
+		if line.OrderedQty <= 0 {
+			return 0, "", fmt.Errorf("некорректное количество %d для item %d: должно быть больше 0", line.OrderedQty, line.ItemID)
+		}
+		if _, ok := seen[line.ItemID]; ok {
+			return 0, "", fmt.Errorf("некорректный заказ: item %d указан дважды", line.ItemID)
+		}
+		seen[line.ItemID] = struct{}{}
+	}
+
+	order.CustomerRef = strings.TrimSpace(order.CustomerRef)
+	order.ShipTo = strings.TrimSpace(order.ShipTo)
+	order.CreatedBy = userID
+
+	id, number, err := s.db.CreateOutboundOrder(ctx, order)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return 0, "", err
+		}
+
+		return 0, "", fmt.Errorf("db.CreateOutboundOrder: %w", err)
+	}
+
+	return id, number, nil
+}
+
+// GetOutboundOrders - метод для получения заказов на отгрузку по статусу.
+func (s *outboundSvc) GetOutboundOrders(ctx context.Context, status string) ([]models.OutboundOrder, error) {
+	if status != "" && !validStatus(status) {
+		return nil, fmt.Errorf("некорректный статус заказа %s", status)
+	}
+
+	orders, err := s.db.ListOutboundOrders(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListOutboundOrders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// GetOutboundOrder - метод для получения заказа на отгрузку с отгрузками и историей статусов.
+func (s *outboundSvc) GetOutboundOrder(ctx context.Context, id int) (*models.OutboundOrder, error) {
+	order, err := s.db.GetOutboundOrder(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetOutboundOrder: %w", err)
+	}
+
+	return order, nil
+}
+
+// AllocateOutboundOrder - метод для резервирования остатка под заказ.
+// Возвращает новый статус заказа и количество зарезервированных единиц.
+func (s *outboundSvc) AllocateOutboundOrder(ctx context.Context, userID, id int) (string, int, error) {
+	status, allocated, err := s.db.AllocateOutboundOrder(ctx, userID, id)
+	if err != nil {
+		if knownError(err) {
+			return "", 0, err
+		}
+
+		return "", 0, fmt.Errorf("db.AllocateOutboundOrder: %w", err)
+	}
+
+	s.notifyOrder(ctx, id)
+
+	return status, allocated, nil
+}
+
+// StartPicking - метод для получения листа подбора, сгруппированного по ячейкам.
+func (s *outboundSvc) StartPicking(ctx context.Context, userID, id int) ([]models.PickListEntry, error) {
+	entries, err := s.db.StartPicking(ctx, userID, id)
+	if err != nil {
+		if knownError(err) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.StartPicking: %w", err)
+	}
+
+	return entries, nil
+}
+
+// PackOutboundOrder - метод для подтверждения упаковки.
+func (s *outboundSvc) PackOutboundOrder(ctx context.Context, userID, id int, lines []models.OutboundQuantity) (string, error) {
+	if len(lines) == 0 {
+		return "", fmt.Errorf("некорректная упаковка: нет строк")
+	}
+	if err := validateQuantities(lines, "упаковка"); err != nil {
+		return "", err
+	}
+	for _, line := range lines {
+		if len(line.Serials) > 0 {
+			return "", fmt.Errorf("некорректная упаковка: серийные номера указываются при отгрузке")
+		}
+	}
+
+	status, err := s.db.PackOutboundOrder(ctx, userID, id, lines)
+	if err != nil {
+		if knownError(err) {
+			return "", err
+		}
+
+		return "", fmt.Errorf("db.PackOutboundOrder: %w", err)
+	}
+
+	return status, nil
+}
+
+// ShipOutboundOrder - метод для отгрузки упакованного товара со списанием остатков.
+// Пустой lines отгружает все упакованное. Возвращает id отгрузки и новый статус заказа.
+func (s *outboundSvc) ShipOutboundOrder(
+	ctx context.Context,
+	userID, id int,
+	trackingRef string,
+	lines []models.OutboundQuantity,
+) (int, string, error) {
+	if err := validateQuantities(lines, "отгрузка"); err != nil {
+		return 0, "", err
+	}
+
+	shipmentID, status, err := s.db.ShipOutboundOrder(ctx, userID, id, strings.TrimSpace(trackingRef), lines)
+	if err != nil {
+		if knownError(err) {
+			return 0, "", err
+		}
+
+		return 0, "", fmt.Errorf("db.ShipOutboundOrder: %w", err)
+	}
+
+	s.notifyOrder(ctx, id)
+
+	return shipmentID, status, nil
+}
+
+// CancelOutboundOrder - метод для отмены заказа со снятием резервов.
+func (s *outboundSvc) CancelOutboundOrder(ctx context.Context, userID, id int, note string) error {
+	if err := s.db.CancelOutboundOrder(ctx, userID, id, strings.TrimSpace(note)); err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.CancelOutboundOrder: %w", err)
+	}
+
+	s.notifyOrder(ctx, id)
+
+	return nil
+}
+
+// notifyOrder - сигнал об изменении остатка по всем items заказа.
+// Ошибка чтения заказа не влияет на результат операции: проверка точки заказа повторится при следующем изменении.
+func (s *outboundSvc) notifyOrder(ctx context.Context, id int) {
+	if s.notifier == nil {
+		return
+	}
+
+	order, err := s.db.GetOutboundOrder(ctx, id)
+	if err != nil {
+		return
+	}
+	for _, line := range order.Lines {
+		s.notifier.Notify(line.ItemID)
+	}
+}
+
+// validateQuantities - проверка количеств и серийных номеров строк упаковки или отгрузки.
+func validateQuantities(lines []models.OutboundQuantity, op string) error {
+	seen := make(map[int]struct{}, len(lines))
+	serials := make(map[string]struct{})
+	for i, line := range lines {
+		if line.Quantity <= 0 {
+			return fmt.Errorf("некорректное количество %d для item %d: должно быть больше 0", line.Quantity, line.ItemID)
+		}
+		if _, ok := seen[line.ItemID]; ok {
+			return fmt.Errorf("некорректная %s: item %d указан дважды", op, line.ItemID)
+		}
+		seen[line.ItemID] = struct{}{}
+
+		for j, serial := range line.Serials {
+			serial = strings.TrimSpace(serial)
+			if serial == "" {
+				return fmt.Errorf("некорректная %s: пустой серийный номер для item %d", op, line.ItemID)
+			}
+			if _, ok := serials[serial]; ok {
+				return fmt.Errorf("некорректная %s: серийный номер %s указан дважды", op, serial)
+			}
+			serials[serial] = struct{}{}
+			lines[i].Serials[j] = serial
+		}
+	}
+
+	return nil
+}
+
+// knownError - ошибки, которые отдаются клиенту как есть.
+func knownError(err error) bool {
+	return strings.Contains(err.Error(), "не найден") ||
+		strings.Contains(err.Error(), "нельзя") ||
+		strings.Contains(err.Error(), "недостаточно") ||
+		strings.Contains(err.Error(), "некорректн")
+}
+
+// validStatus - проверка статуса заказа.
+func validStatus(status string) bool {
+	switch status {
+	case models.OutboundNew, models.OutboundPartiallyAllocated, models.OutboundAllocated, models.OutboundPicking,
+		models.OutboundPacked, models.OutboundPartiallyShipped, models.OutboundShipped, models.OutboundCancelled:
+		return true
+	}
+
+	return false
+}
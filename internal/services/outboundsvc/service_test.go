@@ -0,0 +1,229 @@
+package outboundsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestOutboundSvc_CreateOutboundOrder - тесты для метода CreateOutboundOrder
+func TestOutboundSvc_CreateOutboundOrder_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		CreateOutboundOrder(mock.Anything, mock.MatchedBy(func(order *models.OutboundOrder) bool {
+			return order.CreatedBy == 3 && order.CustomerRef == "ORD-77" && len(order.Lines) == 2
+		})).
+		Return(4, "SO-000004", nil)
+
+	id, number, err := svc.CreateOutboundOrder(context.Background(), 3, &models.OutboundOrder{
+		CustomerRef: " ORD-77 ",
+		Lines: []models.OutboundOrderLine{
+			{ItemID: 1, OrderedQty: 5},
+			{ItemID: 2, OrderedQty: 1},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id)
+	assert.Equal(t, "SO-000004", number)
+}
+
+func TestOutboundSvc_CreateOutboundOrder_ErrDuplicateItem(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, _, err := svc.CreateOutboundOrder(context.Background(), 3, &models.OutboundOrder{
+		Lines: []models.OutboundOrderLine{
+			{ItemID: 1, OrderedQty: 5},
+			{ItemID: 1, OrderedQty: 1},
+		},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "указан дважды")
+}
+
+func TestOutboundSvc_CreateOutboundOrder_ErrItemNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		CreateOutboundOrder(mock.Anything, mock.Anything).
+		Return(0, "", fmt.Errorf("item с id 9 не найден"))
+
+	_, _, err := svc.CreateOutboundOrder(context.Background(), 3, &models.OutboundOrder{
+		Lines: []models.OutboundOrderLine{{ItemID: 9, OrderedQty: 1}},
+	})
+
+	assert.EqualError(t, err, "item с id 9 не найден")
+}
+
+// TestOutboundSvc_GetOutboundOrders - тесты для метода GetOutboundOrders
+func TestOutboundSvc_GetOutboundOrders_ErrStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, err := svc.GetOutboundOrders(context.Background(), "lost")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный статус")
+}
+
+// TestOutboundSvc_AllocateOutboundOrder - тесты для метода AllocateOutboundOrder
+func TestOutboundSvc_AllocateOutboundOrder_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		AllocateOutboundOrder(mock.Anything, 3, 4).
+		Return(models.OutboundPartiallyAllocated, 5, nil)
+	mockDB.EXPECT().
+		GetOutboundOrder(mock.Anything, 4).
+		Return(&models.OutboundOrder{ID: 4, Lines: []models.OutboundOrderLine{{ItemID: 1}, {ItemID: 2}}}, nil)
+	notifier.EXPECT().Notify(1).Return()
+	notifier.EXPECT().Notify(2).Return()
+
+	status, allocated, err := svc.AllocateOutboundOrder(context.Background(), 3, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.OutboundPartiallyAllocated, status)
+	assert.Equal(t, 5, allocated)
+}
+
+func TestOutboundSvc_AllocateOutboundOrder_ErrNoStock(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		AllocateOutboundOrder(mock.Anything, 3, 4).
+		Return("", 0, fmt.Errorf("недостаточно доступного остатка для размещения заказа SO-000004"))
+
+	_, _, err := svc.AllocateOutboundOrder(context.Background(), 3, 4)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "недостаточно")
+}
+
+// TestOutboundSvc_StartPicking - тесты для метода StartPicking
+func TestOutboundSvc_StartPicking_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	locID := 2
+	entries := []models.PickListEntry{
+		{LocationID: &locID, LocationCode: "A-01", ItemID: 1, Quantity: 3},
+		{ItemID: 2, Quantity: 1},
+	}
+	mockDB.EXPECT().
+		StartPicking(mock.Anything, 3, 4).
+		Return(entries, nil)
+
+	got, err := svc.StartPicking(context.Background(), 3, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entries, got)
+}
+
+// TestOutboundSvc_PackOutboundOrder - тесты для метода PackOutboundOrder
+func TestOutboundSvc_PackOutboundOrder_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	lines := []models.OutboundQuantity{{ItemID: 1, Quantity: 3}}
+	mockDB.EXPECT().
+		PackOutboundOrder(mock.Anything, 3, 4, lines).
+		Return(models.OutboundPicking, nil)
+
+	status, err := svc.PackOutboundOrder(context.Background(), 3, 4, lines)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.OutboundPicking, status)
+}
+
+func TestOutboundSvc_PackOutboundOrder_ErrNoLines(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, err := svc.PackOutboundOrder(context.Background(), 3, 4, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нет строк")
+}
+
+func TestOutboundSvc_PackOutboundOrder_ErrOverPick(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		PackOutboundOrder(mock.Anything, 3, 4, mock.Anything).
+		Return("", fmt.Errorf("нельзя упаковать 5 по item 1 заказа SO-000004: к упаковке 3"))
+
+	_, err := svc.PackOutboundOrder(context.Background(), 3, 4, []models.OutboundQuantity{{ItemID: 1, Quantity: 5}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя упаковать")
+}
+
+// TestOutboundSvc_ShipOutboundOrder - тесты для метода ShipOutboundOrder
+func TestOutboundSvc_ShipOutboundOrder_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		ShipOutboundOrder(mock.Anything, 3, 4, "TRK-1", []models.OutboundQuantity{
+			{ItemID: 2, Quantity: 2, Serials: []string{"SN-1", "SN-2"}},
+		}).
+		Return(7, models.OutboundPartiallyShipped, nil)
+	mockDB.EXPECT().
+		GetOutboundOrder(mock.Anything, 4).
+		Return(&models.OutboundOrder{ID: 4, Lines: []models.OutboundOrderLine{{ItemID: 2}}}, nil)
+	notifier.EXPECT().Notify(2).Return()
+
+	shipmentID, status, err := svc.ShipOutboundOrder(context.Background(), 3, 4, " TRK-1 ", []models.OutboundQuantity{
+		{ItemID: 2, Quantity: 2, Serials: []string{" SN-1", "SN-2 "}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, shipmentID)
+	assert.Equal(t, models.OutboundPartiallyShipped, status)
+}
+
+func TestOutboundSvc_ShipOutboundOrder_ErrDuplicateSerial(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, _, err := svc.ShipOutboundOrder(context.Background(), 3, 4, "", []models.OutboundQuantity{
+		{ItemID: 1, Quantity: 1, Serials: []string{"SN-1"}},
+		{ItemID: 2, Quantity: 1, Serials: []string{"SN-1"}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "указан дважды")
+}
+
+// TestOutboundSvc_CancelOutboundOrder - тесты для метода CancelOutboundOrder
+func TestOutboundSvc_CancelOutboundOrder_ErrShipped(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		CancelOutboundOrder(mock.Anything, 3, 4, "клиент отказался").
+		Return(fmt.Errorf("нельзя отменить заказ SO-000004 в статусе shipped"))
+
+	err := svc.CancelOutboundOrder(context.Background(), 3, 4, " клиент отказался ")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя отменить")
+}
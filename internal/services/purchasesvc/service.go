@@ -0,0 +1,234 @@
+package purchasesvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.PurchaseService = (*purchaseSvc)(nil)
+
+type purchaseSvc struct {
+	db        infra.Database
+	notifier  services.StockNotifier
+	tolerance int
+}
+
+// New - конструктор нового purchaseSvc.
+// notifier получает сигналы об изменении остатка, nil - без сигналов.
+// tolerance - допустимая приемка сверх заказанного в процентах от количества строки.
+func New(db infra.Database, notifier services.StockNotifier, tolerance int) services.PurchaseService {
+	return &purchaseSvc{db: db, notifier: notifier, tolerance: max(tolerance, 0)}
+}
+
+// CreatePurchaseOrder - метод для создания черновика заказа на закупку.
+// Цена строки без unit_cost берется из условий поставщика, ожидаемая дата - по сроку поставки.
+// Валюта заказа по умолчанию - валюта поставщика.
+func (s *purchaseSvc) CreatePurchaseOrder(ctx context.Context, userID int, po *models.PurchaseOrder) (int, string, error) {
+	if len(po.Lines) == 0 {
+		return 0, "", fmt.Errorf("некорректный заказ: нет строк")
+	}
+	seen := make(map[int]struct{}, len(po.Lines))
+	for _, line := range po.Lines {
+		if line.OrderedQty <= 0 {
+			return 0, "", fmt.Errorf("некорректное количество %d для item %d: должно быть больше 0", line.OrderedQty, line.ItemID)
+		}
+		if line.UnitCost < 0 {
+			return 0, "", fmt.Errorf("некорректная цена закупки %s для item %d", line.UnitCost, line.ItemID)
+		}
+		if _, ok := seen[line.ItemID]; ok {
+			return 0, "", fmt.Errorf("некорректный заказ: item %d указан дважды", line.ItemID)
+		}
+		seen[line.ItemID] = struct{}{}
+	}
+
+	sup, err := s.db.GetSupplierByID(ctx, po.SupplierID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return 0, "", err
+		}
+
+		return 0, "", fmt.Errorf("db.GetSupplierByID: %w", err)
+	}
+	if sup.Status != models.SupplierActive {
+		return 0, "", fmt.Errorf("нельзя создать заказ у поставщика %d в статусе %s", sup.ID, sup.Status)
+	}
+	if po.Currency == "" {
+		po.Currency = sup.Currency
+	} else if po.Currency, err = models.ParseCurrencyCode(po.Currency); err != nil {
+		return 0, "", err
+	}
+
+	terms, err := s.db.ListSupplierItems(ctx, sup.ID)
+	if err != nil {
+		return 0, "", fmt.Errorf("db.ListSupplierItems: %w", err)
+	}
+	costs := make(map[int]models.Money, len(terms))
+	for _, term := range terms {
+		costs[term.ItemID] = term.UnitCost
+	}
+	for i := range po.Lines {
+		if po.Lines[i].UnitCost != 0 {
+			continue
+		}
+		cost, ok := costs[po.Lines[i].ItemID]
+		if ok && cost != 0 && po.Currency != sup.Currency {
+			return 0, "", fmt.Errorf("нельзя подставить цену поставщика в %s в заказ в %s: укажите unit_cost для item %d",
+				sup.Currency, po.Currency, po.Lines[i].ItemID)
+		}
+		po.Lines[i].UnitCost = cost
+	}
+
+	if po.ExpectedAt == nil {
+		expected := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, sup.LeadTimeDays)
+		po.ExpectedAt = &expected
+	}
+	po.CreatedBy = userID
+
+	id, number, err := s.db.CreatePurchaseOrder(ctx, po)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return 0, "", err
+		}
+
+		return 0, "", fmt.Errorf("db.CreatePurchaseOrder: %w", err)
+	}
+
+	return id, number, nil
+}
+
+// GetPurchaseOrders - метод для получения заказов на закупку по фильтру.
+func (s *purchaseSvc) GetPurchaseOrders(ctx context.Context, filter models.PurchaseOrderFilter) ([]models.PurchaseOrder, error) {
+	if filter.Status != "" && !validStatus(filter.Status) {
+		return nil, fmt.Errorf("некорректный статус заказа %s", filter.Status)
+	}
+
+	orders, err := s.db.ListPurchaseOrders(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListPurchaseOrders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// GetPurchaseOrder - метод для получения заказа на закупку со строками и приемками.
+func (s *purchaseSvc) GetPurchaseOrder(ctx context.Context, id int) (*models.PurchaseOrder, error) {
+	po, err := s.db.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetPurchaseOrder: %w", err)
+	}
+
+	return po, nil
+}
+
+// SendPurchaseOrder - метод для отправки черновика заказа поставщику.
+func (s *purchaseSvc) SendPurchaseOrder(ctx context.Context, id int) error {
+	return s.updateStatus(ctx, id, []string{models.PODraft}, models.POSent)
+}
+
+// ClosePurchaseOrder - метод для закрытия заказа.
+// Закрытие частично принятого заказа означает, что недопоставка больше не ожидается.
+func (s *purchaseSvc) ClosePurchaseOrder(ctx context.Context, id int) error {
+	return s.updateStatus(ctx, id, []string{models.POSent, models.POPartiallyReceived, models.POReceived}, models.POClosed)
+}
+
+// DeletePurchaseOrder - метод для удаления черновика заказа.
+func (s *purchaseSvc) DeletePurchaseOrder(ctx context.Context, id int) error {
+	if err := s.db.DeletePurchaseOrder(ctx, id); err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "нельзя") {
+			return err
+		}
+
+		return fmt.Errorf("db.DeletePurchaseOrder: %w", err)
+	}
+
+	return nil
+}
+
+// ReceivePurchaseOrder - метод для приемки товара по заказу на закупку.
+// Возвращает новый статус заказа: partially_received или received.
+func (s *purchaseSvc) ReceivePurchaseOrder(ctx context.Context, userID, id int, lines []models.ReceiptLine, note string) (string, error) {
+	if len(lines) == 0 {
+		return "", fmt.Errorf("некорректная приемка: нет строк")
+	}
+	seen := make(map[int]struct{}, len(lines))
+	serials := make(map[string]struct{})
+	for i, line := range lines {
+		if line.Quantity <= 0 {
+			return "", fmt.Errorf("некорректное количество %d для item %d: должно быть больше 0", line.Quantity, line.ItemID)
+		}
+		if _, ok := seen[line.ItemID]; ok {
+			return "", fmt.Errorf("некорректная приемка: item %d указан дважды", line.ItemID)
+		}
+		seen[line.ItemID] = struct{}{}
+
+		for j, serial := range line.Serials {
+			serial = strings.TrimSpace(serial)
+			if serial == "" {
+				return "", fmt.Errorf("некорректная приемка: пустой серийный номер для item %d", line.ItemID)
+			}
+			if _, ok := serials[serial]; ok {
+				return "", fmt.Errorf("некорректная приемка: серийный номер %s указан дважды", serial)
+			}
+			serials[serial] = struct{}{}
+			lines[i].Serials[j] = serial
+		}
+	}
+
+	status, err := s.db.ReceivePurchaseOrder(ctx, userID, id, lines, strings.TrimSpace(note), s.tolerance)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") ||
+			strings.Contains(err.Error(), "нельзя") ||
+			strings.Contains(err.Error(), "некорректн") ||
+			strings.Contains(err.Error(), "уже существует") {
+			return "", err
+		}
+
+		return "", fmt.Errorf("db.ReceivePurchaseOrder: %w", err)
+	}
+
+	for _, line := range lines {
+		s.notifyStock(line.ItemID)
+	}
+
+	return status, nil
+}
+
+// updateStatus - перевод заказа в статус to из одного из статусов from.
+func (s *purchaseSvc) updateStatus(ctx context.Context, id int, from []string, to string) error {
+	if err := s.db.UpdatePurchaseOrderStatus(ctx, id, from, to); err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "нельзя") {
+			return err
+		}
+
+		return fmt.Errorf("db.UpdatePurchaseOrderStatus: %w", err)
+	}
+
+	return nil
+}
+
+// notifyStock - сигнал об изменении остатка item для проверки точки заказа.
+func (s *purchaseSvc) notifyStock(itemID int) {
+	if s.notifier != nil {
+		s.notifier.Notify(itemID)
+	}
+}
+
+// validStatus - проверка статуса заказа.
+func validStatus(status string) bool {
+	switch status {
+	case models.PODraft, models.POSent, models.POPartiallyReceived, models.POReceived, models.POClosed:
+		return true
+	}
+
+	return false
+}
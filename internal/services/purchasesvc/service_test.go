@@ -0,0 +1,252 @@
+package purchasesvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestPurchaseSvc_CreatePurchaseOrder - тесты для метода CreatePurchaseOrder
+func TestPurchaseSvc_CreatePurchaseOrder_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	mockDB.EXPECT().
+		GetSupplierByID(mock.Anything, 2).
+		Return(&models.Supplier{ID: 2, Status: models.SupplierActive, LeadTimeDays: 7}, nil)
+	mockDB.EXPECT().
+		ListSupplierItems(mock.Anything, 2).
+		Return([]models.ItemSupplier{{ItemID: 1, SupplierID: 2, UnitCost: 125000}}, nil)
+	mockDB.EXPECT().
+		CreatePurchaseOrder(mock.Anything, mock.MatchedBy(func(po *models.PurchaseOrder) bool {
+			return po.CreatedBy == 3 &&
+				po.ExpectedAt != nil &&
+				po.Lines[0].UnitCost == 125000 &&
+				po.Lines[1].UnitCost == 40000
+		})).
+		Return(5, "PO-000005", nil)
+
+	id, number, err := svc.CreatePurchaseOrder(context.Background(), 3, &models.PurchaseOrder{
+		SupplierID: 2,
+		Lines: []models.PurchaseOrderLine{
+			{ItemID: 1, OrderedQty: 10},
+			{ItemID: 2, OrderedQty: 5, UnitCost: 40000},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, id)
+	assert.Equal(t, "PO-000005", number)
+}
+
+func TestPurchaseSvc_CreatePurchaseOrder_ErrNoLines(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	_, _, err := svc.CreatePurchaseOrder(context.Background(), 3, &models.PurchaseOrder{SupplierID: 2})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нет строк")
+}
+
+func TestPurchaseSvc_CreatePurchaseOrder_ErrDuplicateItem(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	_, _, err := svc.CreatePurchaseOrder(context.Background(), 3, &models.PurchaseOrder{
+		SupplierID: 2,
+		Lines:      []models.PurchaseOrderLine{{ItemID: 1, OrderedQty: 1}, {ItemID: 1, OrderedQty: 2}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "указан дважды")
+}
+
+func TestPurchaseSvc_CreatePurchaseOrder_ErrInvalidQuantity(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	_, _, err := svc.CreatePurchaseOrder(context.Background(), 3, &models.PurchaseOrder{
+		SupplierID: 2,
+		Lines:      []models.PurchaseOrderLine{{ItemID: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректное количество")
+}
+
+func TestPurchaseSvc_CreatePurchaseOrder_ErrSupplierBlocked(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	mockDB.EXPECT().
+		GetSupplierByID(mock.Anything, 2).
+		Return(&models.Supplier{ID: 2, Status: models.SupplierBlocked}, nil)
+
+	_, _, err := svc.CreatePurchaseOrder(context.Background(), 3, &models.PurchaseOrder{
+		SupplierID: 2,
+		Lines:      []models.PurchaseOrderLine{{ItemID: 1, OrderedQty: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя создать заказ")
+}
+
+func TestPurchaseSvc_CreatePurchaseOrder_ErrItemNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	mockDB.EXPECT().
+		GetSupplierByID(mock.Anything, 2).
+		Return(&models.Supplier{ID: 2, Status: models.SupplierActive}, nil)
+	mockDB.EXPECT().
+		ListSupplierItems(mock.Anything, 2).
+		Return(nil, nil)
+	mockDB.EXPECT().
+		CreatePurchaseOrder(mock.Anything, mock.Anything).
+		Return(0, "", fmt.Errorf("item с id 9 не найден"))
+
+	_, _, err := svc.CreatePurchaseOrder(context.Background(), 3, &models.PurchaseOrder{
+		SupplierID: 2,
+		Lines:      []models.PurchaseOrderLine{{ItemID: 9, OrderedQty: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+// TestPurchaseSvc_GetPurchaseOrders - тесты для метода GetPurchaseOrders
+func TestPurchaseSvc_GetPurchaseOrders_ErrInvalidStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	orders, err := svc.GetPurchaseOrders(context.Background(), models.PurchaseOrderFilter{Status: "lost"})
+
+	assert.Error(t, err)
+	assert.Nil(t, orders)
+	assert.Contains(t, err.Error(), "некорректный статус")
+}
+
+// TestPurchaseSvc_SendPurchaseOrder - тесты для метода SendPurchaseOrder
+func TestPurchaseSvc_SendPurchaseOrder_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	mockDB.EXPECT().
+		UpdatePurchaseOrderStatus(mock.Anything, 5, []string{models.PODraft}, models.POSent).
+		Return(nil)
+
+	err := svc.SendPurchaseOrder(context.Background(), 5)
+
+	assert.NoError(t, err)
+}
+
+func TestPurchaseSvc_SendPurchaseOrder_ErrWrongStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	mockDB.EXPECT().
+		UpdatePurchaseOrderStatus(mock.Anything, 5, mock.Anything, models.POSent).
+		Return(fmt.Errorf("нельзя перевести заказ 5 из статуса closed в sent"))
+
+	err := svc.SendPurchaseOrder(context.Background(), 5)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя")
+}
+
+// TestPurchaseSvc_ClosePurchaseOrder - тесты для метода ClosePurchaseOrder
+func TestPurchaseSvc_ClosePurchaseOrder_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	mockDB.EXPECT().
+		UpdatePurchaseOrderStatus(mock.Anything, 5, mock.Anything, models.POClosed).
+		Return(fmt.Errorf("database error"))
+
+	err := svc.ClosePurchaseOrder(context.Background(), 5)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.UpdatePurchaseOrderStatus")
+}
+
+// TestPurchaseSvc_ReceivePurchaseOrder - тесты для метода ReceivePurchaseOrder
+func TestPurchaseSvc_ReceivePurchaseOrder_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier, 10)
+
+	lines := []models.ReceiptLine{{ItemID: 1, Quantity: 4}, {ItemID: 2, Quantity: 2, Serials: []string{" SN-1", "SN-2 "}}}
+
+	mockDB.EXPECT().
+		ReceivePurchaseOrder(mock.Anything, 3, 5, []models.ReceiptLine{
+			{ItemID: 1, Quantity: 4},
+			{ItemID: 2, Quantity: 2, Serials: []string{"SN-1", "SN-2"}},
+		}, "накладная 17", 10).
+		Return(models.POPartiallyReceived, nil)
+	notifier.EXPECT().Notify(1).Return()
+	notifier.EXPECT().Notify(2).Return()
+
+	status, err := svc.ReceivePurchaseOrder(context.Background(), 3, 5, lines, " накладная 17 ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.POPartiallyReceived, status)
+}
+
+func TestPurchaseSvc_ReceivePurchaseOrder_ErrDuplicateSerial(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	_, err := svc.ReceivePurchaseOrder(context.Background(), 3, 5, []models.ReceiptLine{
+		{ItemID: 1, Quantity: 1, Serials: []string{"SN-1"}},
+		{ItemID: 2, Quantity: 1, Serials: []string{"SN-1"}},
+	}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "указан дважды")
+}
+
+func TestPurchaseSvc_ReceivePurchaseOrder_ErrInvalidQuantity(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	_, err := svc.ReceivePurchaseOrder(context.Background(), 3, 5, []models.ReceiptLine{{ItemID: 1, Quantity: 0}}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректное количество")
+}
+
+func TestPurchaseSvc_ReceivePurchaseOrder_ErrOverReceipt(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier, 10)
+
+	mockDB.EXPECT().
+		ReceivePurchaseOrder(mock.Anything, 3, 5, mock.Anything, "", 10).
+		Return("", fmt.Errorf("нельзя принять 20 по item 1 заказа PO-000005: заказано 10, принято 0, допуск сверх заказа 10%%"))
+
+	_, err := svc.ReceivePurchaseOrder(context.Background(), 3, 5, []models.ReceiptLine{{ItemID: 1, Quantity: 20}}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя принять")
+}
+
+func TestPurchaseSvc_ReceivePurchaseOrder_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 10)
+
+	mockDB.EXPECT().
+		ReceivePurchaseOrder(mock.Anything, 3, 5, mock.Anything, "", 10).
+		Return("", fmt.Errorf("database error"))
+
+	_, err := svc.ReceivePurchaseOrder(context.Background(), 3, 5, []models.ReceiptLine{{ItemID: 1, Quantity: 1}}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.ReceivePurchaseOrder")
+}
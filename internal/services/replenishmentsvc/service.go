@@ -0,0 +1,181 @@
+package replenishmentsvc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const orderNotes = "создан по предложениям пополнения"
+
+var _ services.ReplenishmentService = (*replenishmentSvc)(nil)
+
+type replenishmentSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового replenishmentSvc.
+func New(db infra.Database) services.ReplenishmentService {
+	return &replenishmentSvc{db: db}
+}
+
+// GetSuggestions - метод для расчета предложений к заказу, сгруппированных по поставщикам.
+// Item предлагается, когда доступный остаток вместе с открытыми заказами на закупку достиг точки заказа,
+// количество - до максимального уровня, но не меньше минимальной партии поставщика.
+// Расход за срок поставки покрывается точкой заказа, срок поставки задает только ожидаемую дату поставки.
+// Items без активного поставщика собираются в группу с supplier_id 0 в конце списка.
+func (s *replenishmentSvc) GetSuggestions(ctx context.Context) ([]models.ReplenishmentSuggestion, error) {
+	candidates, err := s.db.ListReplenishmentCandidates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListReplenishmentCandidates: %w", err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	bySupplier := make(map[int]*models.ReplenishmentSuggestion)
+	for _, c := range candidates {
+		if !c.NeedsReorder() {
+			continue
+		}
+
+		supplierID := 0
+		if c.SupplierID != nil {
+			supplierID = *c.SupplierID
+		}
+		suggestion, ok := bySupplier[supplierID]
+		if !ok {
+			suggestion = &models.ReplenishmentSuggestion{
+				SupplierID:   supplierID,
+				SupplierName: c.SupplierName,
+				Currency:     c.Currency,
+				LeadTimeDays: c.LeadTimeDays,
+				ExpectedAt:   today.AddDate(0, 0, c.LeadTimeDays),
+			}
+			bySupplier[supplierID] = suggestion
+		}
+		suggestion.Lines = append(suggestion.Lines, models.ReplenishmentLine{
+			ItemID:       c.ItemID,
+			ItemName:     c.ItemName,
+			Available:    c.Available(),
+			OnOrder:      c.OnOrder,
+			ReorderPoint: c.ReorderPoint,
+			MaxQty:       c.MaxQty,
+			Quantity:     c.OrderQty(),
+			MinOrderQty:  c.MinOrderQty,
+			UnitCost:     c.UnitCost,
+		})
+	}
+
+	suggestions := make([]models.ReplenishmentSuggestion, 0, len(bySupplier))
+	for _, suggestion := range bySupplier {
+		suggestions = append(suggestions, *suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if (suggestions[i].SupplierID == 0) != (suggestions[j].SupplierID == 0) {
+			return suggestions[j].SupplierID == 0
+		}
+
+		return suggestions[i].SupplierName < suggestions[j].SupplierName
+	})
+
+	return suggestions, nil
+}
+
+// CreatePurchaseOrders - метод для создания черновиков заказов на закупку по принятым предложениям.
+// Строки одного поставщика попадают в один заказ, все заказы создаются в одной транзакции.
+func (s *replenishmentSvc) CreatePurchaseOrders(
+	ctx context.Context,
+	userID int,
+	accepted []models.AcceptedSuggestion,
+) ([]models.PurchaseOrder, error) {
+	if len(accepted) == 0 {
+		return nil, fmt.Errorf("некорректный запрос: нет принятых предложений")
+	}
+	seen := make(map[int]struct{}, len(accepted))
+	for _, a := range accepted {
+		if a.Quantity < 0 {
+			return nil, fmt.Errorf("некорректное количество %d для item %d: должно быть не меньше 0", a.Quantity, a.ItemID)
+		}
+		if _, ok := seen[a.ItemID]; ok {
+			return nil, fmt.Errorf("некорректный запрос: item %d указан дважды", a.ItemID)
+		}
+		seen[a.ItemID] = struct{}{}
+	}
+
+	suggestions, err := s.GetSuggestions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	type suggestionLine struct {
+		suggestion *models.ReplenishmentSuggestion
+		line       models.ReplenishmentLine
+	}
+	index := make(map[[2]int]suggestionLine)
+	for i := range suggestions {
+		if suggestions[i].SupplierID == 0 {
+			continue
+		}
+		for _, line := range suggestions[i].Lines {
+			index[[2]int{suggestions[i].SupplierID, line.ItemID}] = suggestionLine{&suggestions[i], line}
+		}
+	}
+
+	var orders []*models.PurchaseOrder
+	bySupplier := make(map[int]*models.PurchaseOrder)
+	for _, a := range accepted {
+		found, ok := index[[2]int{a.SupplierID, a.ItemID}]
+		if !ok {
+			return nil, fmt.Errorf("предложение заказать item %d у поставщика %d не найдено", a.ItemID, a.SupplierID)
+		}
+		quantity := found.line.Quantity
+		if a.Quantity > 0 {
+			quantity = a.Quantity
+		}
+		if quantity < found.line.MinOrderQty {
+			return nil, fmt.Errorf("некорректное количество %d для item %d: минимальная партия поставщика %d",
+				quantity, a.ItemID, found.line.MinOrderQty)
+		}
+
+		po, ok := bySupplier[a.SupplierID]
+		if !ok {
+			expectedAt := found.suggestion.ExpectedAt
+			po = &models.PurchaseOrder{
+				SupplierID:   a.SupplierID,
+				SupplierName: found.suggestion.SupplierName,
+				Status:       models.PODraft,
+				Currency:     found.suggestion.Currency,
+				ExpectedAt:   &expectedAt,
+				Notes:        orderNotes,
+				CreatedBy:    userID,
+			}
+			bySupplier[a.SupplierID] = po
+			orders = append(orders, po)
+		}
+		po.Lines = append(po.Lines, models.PurchaseOrderLine{
+			ItemID:     a.ItemID,
+			ItemName:   found.line.ItemName,
+			OrderedQty: quantity,
+			UnitCost:   found.line.UnitCost,
+		})
+	}
+
+	if err := s.db.CreatePurchaseOrders(ctx, orders); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.CreatePurchaseOrders: %w", err)
+	}
+
+	created := make([]models.PurchaseOrder, 0, len(orders))
+	for _, po := range orders {
+		created = append(created, *po)
+	}
+
+	return created, nil
+}
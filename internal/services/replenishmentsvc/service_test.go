@@ -0,0 +1,159 @@
+package replenishmentsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func candidate(itemID, quantity, onOrder int, supplierID *int, supplierName string, minOrderQty int) models.ReplenishmentCandidate {
+	return models.ReplenishmentCandidate{
+		StockPosition: models.StockPosition{
+			StockLevel: models.StockLevel{ItemID: itemID, MinQty: 5, ReorderPoint: 10, MaxQty: 50},
+			ItemName:   fmt.Sprintf("item %d", itemID),
+			Quantity:   quantity,
+		},
+		OnOrder:      onOrder,
+		SupplierID:   supplierID,
+		SupplierName: supplierName,
+		UnitCost:     25000,
+		MinOrderQty:  minOrderQty,
+		LeadTimeDays: 7,
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// TestReplenishmentSvc_GetSuggestions - тесты для метода GetSuggestions
+func TestReplenishmentSvc_GetSuggestions_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListReplenishmentCandidates(mock.Anything).
+		Return([]models.ReplenishmentCandidate{
+			candidate(1, 4, 0, intPtr(2), "Бета", 1),
+			candidate(2, 4, 20, intPtr(2), "Бета", 1),
+			candidate(3, 8, 0, intPtr(3), "Альфа", 60),
+			candidate(4, 0, 0, nil, "", 0),
+		}, nil)
+
+	suggestions, err := svc.GetSuggestions(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 3)
+
+	assert.Equal(t, 3, suggestions[0].SupplierID)
+	assert.Equal(t, 60, suggestions[0].Lines[0].Quantity)
+
+	assert.Equal(t, 2, suggestions[1].SupplierID)
+	assert.Len(t, suggestions[1].Lines, 1)
+	assert.Equal(t, 1, suggestions[1].Lines[0].ItemID)
+	assert.Equal(t, 46, suggestions[1].Lines[0].Quantity)
+	assert.Equal(t, models.Money(1150000), suggestions[1].Total())
+
+	assert.Equal(t, 0, suggestions[2].SupplierID)
+	assert.Equal(t, 4, suggestions[2].Lines[0].ItemID)
+}
+
+func TestReplenishmentSvc_GetSuggestions_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListReplenishmentCandidates(mock.Anything).
+		Return(nil, fmt.Errorf("database error"))
+
+	_, err := svc.GetSuggestions(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.ListReplenishmentCandidates")
+}
+
+// TestReplenishmentSvc_CreatePurchaseOrders - тесты для метода CreatePurchaseOrders
+func TestReplenishmentSvc_CreatePurchaseOrders_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListReplenishmentCandidates(mock.Anything).
+		Return([]models.ReplenishmentCandidate{
+			candidate(1, 4, 0, intPtr(2), "Бета", 1),
+			candidate(2, 0, 0, intPtr(2), "Бета", 1),
+			candidate(3, 8, 0, intPtr(3), "Альфа", 1),
+		}, nil)
+	mockDB.EXPECT().
+		CreatePurchaseOrders(mock.Anything, mock.MatchedBy(func(pos []*models.PurchaseOrder) bool {
+			return len(pos) == 2 &&
+				pos[0].SupplierID == 2 && len(pos[0].Lines) == 2 &&
+				pos[0].Lines[0].OrderedQty == 46 && pos[0].Lines[1].OrderedQty == 30 &&
+				pos[1].SupplierID == 3 && pos[1].CreatedBy == 5 && pos[1].ExpectedAt != nil
+		})).
+		RunAndReturn(func(_ context.Context, pos []*models.PurchaseOrder) error {
+			for i, po := range pos {
+				po.ID = i + 1
+				po.Number = fmt.Sprintf("PO-%06d", i+1)
+			}
+			return nil
+		})
+
+	orders, err := svc.CreatePurchaseOrders(context.Background(), 5, []models.AcceptedSuggestion{
+		{SupplierID: 2, ItemID: 1},
+		{SupplierID: 2, ItemID: 2, Quantity: 30},
+		{SupplierID: 3, ItemID: 3},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, orders, 2)
+	assert.Equal(t, "PO-000001", orders[0].Number)
+	assert.Equal(t, models.PODraft, orders[1].Status)
+}
+
+func TestReplenishmentSvc_CreatePurchaseOrders_ErrNotSuggested(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListReplenishmentCandidates(mock.Anything).
+		Return([]models.ReplenishmentCandidate{candidate(1, 40, 0, intPtr(2), "Бета", 1)}, nil)
+
+	_, err := svc.CreatePurchaseOrders(context.Background(), 5, []models.AcceptedSuggestion{{SupplierID: 2, ItemID: 1}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найдено")
+}
+
+func TestReplenishmentSvc_CreatePurchaseOrders_ErrBelowMinOrder(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		ListReplenishmentCandidates(mock.Anything).
+		Return([]models.ReplenishmentCandidate{candidate(1, 4, 0, intPtr(2), "Бета", 50)}, nil)
+
+	_, err := svc.CreatePurchaseOrders(context.Background(), 5, []models.AcceptedSuggestion{{SupplierID: 2, ItemID: 1, Quantity: 10}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "минимальная партия")
+}
+
+func TestReplenishmentSvc_CreatePurchaseOrders_ErrDuplicateItem(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	_, err := svc.CreatePurchaseOrders(context.Background(), 5, []models.AcceptedSuggestion{
+		{SupplierID: 2, ItemID: 1},
+		{SupplierID: 3, ItemID: 1},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "указан дважды")
+}
@@ -0,0 +1,123 @@
+package reportsvc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/internal/report"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// Колонки отчетов в порядке вывода.
+var (
+	movementColumns = []string{
+		"id", "item_id", "item_name", "movement_type", "quantity", "unit_cost", "total_cost",
+		"method", "source", "balance_qty", "balance_value", "user_id", "username", "created_at",
+	}
+	activityColumns = []string{
+		"id", "user_id", "username", "item_id", "item_name", "operation", "details",
+		"old_value", "new_value", "changed_at",
+	}
+	balanceColumns = []string{
+		"item_id", "item_name", "opening_qty", "opening_value", "in_qty", "in_value",
+		"out_qty", "out_value", "closing_qty", "closing_value",
+	}
+)
+
+var _ services.ReportService = (*reportSvc)(nil)
+
+type reportSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового reportSvc.
+func New(db infra.Database) services.ReportService {
+	return &reportSvc{db: db}
+}
+
+// WriteMovements - метод для записи в w отчета по стоимостным движениям items за период.
+func (s *reportSvc) WriteMovements(ctx context.Context, filter models.ReportFilter, format string, w io.Writer) error {
+	rw, err := s.open(filter, format, w, movementColumns)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.StreamMovements(ctx, filter, func(m models.MovementRecord) error {
+		return rw.WriteRow(
+			m.ID, m.ItemID, m.ItemName, m.Type, m.Quantity, m.UnitCost, m.TotalCost,
+			m.Method, m.Source, m.BalanceQty, m.BalanceValue, m.UserID, m.Username, m.CreatedAt,
+		)
+	}); err != nil {
+		return fmt.Errorf("db.StreamMovements: %w", err)
+	}
+
+	return rw.Close()
+}
+
+// WriteUserActivity - метод для записи в w отчета по изменениям items пользователями за период.
+func (s *reportSvc) WriteUserActivity(ctx context.Context, filter models.ReportFilter, format string, w io.Writer) error {
+	rw, err := s.open(filter, format, w, activityColumns)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.StreamUserActivity(ctx, filter, func(a models.ActivityRecord) error {
+		return rw.WriteRow(
+			a.ID, a.UserID, a.Username, a.ItemID, a.ItemName, a.Operation, a.Details,
+			a.OldValue, a.NewValue, a.ChangedAt,
+		)
+	}); err != nil {
+		return fmt.Errorf("db.StreamUserActivity: %w", err)
+	}
+
+	return rw.Close()
+}
+
+// WriteBalances - метод для записи в w оборотной ведомости за период:
+// остатки на начало и конец, поступление и расход в количестве и по себестоимости.
+func (s *reportSvc) WriteBalances(ctx context.Context, filter models.ReportFilter, format string, w io.Writer) error {
+	if filter.UserID != nil {
+		return fmt.Errorf("некорректный фильтр: оборотная ведомость не строится по пользователю")
+	}
+
+	rw, err := s.open(filter, format, w, balanceColumns)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.StreamBalances(ctx, filter, func(b models.BalanceRecord) error {
+		return rw.WriteRow(
+			b.ItemID, b.ItemName, b.OpeningQty, b.OpeningValue, b.InQty, b.InValue,
+			b.OutQty, b.OutValue, b.ClosingQty, b.ClosingValue,
+		)
+	}); err != nil {
+		return fmt.Errorf("db.StreamBalances: %w", err)
+	}
+
+	return rw.Close()
+}
+
+// open - проверка параметров и начало записи отчета.
+// Ошибки параметров возвращаются до записи первого байта в w.
+func (s *reportSvc) open(filter models.ReportFilter, format string, w io.Writer, columns []string) (report.Writer, error) {
+	if err := report.ValidateFormat(format); err != nil {
+		return nil, err
+	}
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return nil, fmt.Errorf("некорректный период: нужно указать начало и конец")
+	}
+	if filter.To.Before(filter.From) {
+		return nil, fmt.Errorf("некорректный период: начало позже конца")
+	}
+	if filter.ItemID != nil && *filter.ItemID <= 0 {
+		return nil, fmt.Errorf("некорректный item_id")
+	}
+	if filter.UserID != nil && *filter.UserID <= 0 {
+		return nil, fmt.Errorf("некорректный user_id")
+	}
+
+	return report.NewWriter(w, format, columns)
+}
@@ -0,0 +1,207 @@
+package reportsvc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/internal/report"
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var (
+	testFrom = time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	testTo   = time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+	testAt   = time.Date(2026, 8, 15, 12, 0, 0, 0, time.UTC)
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func strPtr(v string) *string {
+	return &v
+}
+
+// TestReportSvc_WriteMovements - тесты для метода WriteMovements
+func TestReportSvc_WriteMovements_OKCSV(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	filter := models.ReportFilter{From: testFrom, To: testTo, ItemID: intPtr(1)}
+	mockDB.EXPECT().
+		StreamMovements(mock.Anything, filter, mock.Anything).
+		Run(func(_ context.Context, _ models.ReportFilter, fn func(models.MovementRecord) error) {
+			fn(models.MovementRecord{
+				ID: 5, ItemID: 1, ItemName: "Болт", Type: "receipt", Quantity: 10, UnitCost: 24000, TotalCost: 240000,
+				Method: "fifo", Source: "PO-1", BalanceQty: 10, BalanceValue: 240000,
+				UserID: intPtr(2), Username: strPtr("manager123"), CreatedAt: testAt,
+			})
+			fn(models.MovementRecord{
+				ID: 6, ItemID: 1, ItemName: "Болт", Type: "issue", Quantity: 4, UnitCost: 24000, TotalCost: 96000,
+				Method: "fifo", BalanceQty: 6, BalanceValue: 144000, CreatedAt: testAt,
+			})
+		}).
+		Return(nil)
+
+	var buf bytes.Buffer
+	err := svc.WriteMovements(context.Background(), filter, report.FormatCSV, &buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "\xef\xbb\xbf"+
+		"id,item_id,item_name,movement_type,quantity,unit_cost,total_cost,method,source,balance_qty,balance_value,user_id,username,created_at\n"+
+		"5,1,Болт,receipt,10,2.40,24.00,fifo,PO-1,10,24.00,2,manager123,2026-08-15T12:00:00Z\n"+
+		"6,1,Болт,issue,4,2.40,9.60,fifo,,6,14.40,,,2026-08-15T12:00:00Z\n", buf.String())
+}
+
+func TestReportSvc_WriteMovements_ErrFormat(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	var buf bytes.Buffer
+	err := svc.WriteMovements(context.Background(), models.ReportFilter{From: testFrom, To: testTo}, "pdf", &buf)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный формат отчета")
+	assert.Zero(t, buf.Len())
+}
+
+func TestReportSvc_WriteMovements_ErrPeriod(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	var buf bytes.Buffer
+	err := svc.WriteMovements(context.Background(), models.ReportFilter{From: testTo, To: testFrom}, report.FormatJSON, &buf)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный период")
+	assert.Zero(t, buf.Len())
+}
+
+func TestReportSvc_WriteMovements_ErrDB(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		StreamMovements(mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("db error"))
+
+	var buf bytes.Buffer
+	err := svc.WriteMovements(context.Background(), models.ReportFilter{From: testFrom, To: testTo}, report.FormatJSON, &buf)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.StreamMovements")
+}
+
+// TestReportSvc_WriteUserActivity - тесты для метода WriteUserActivity
+func TestReportSvc_WriteUserActivity_OKJSON(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	filter := models.ReportFilter{From: testFrom, To: testTo, UserID: intPtr(2)}
+	mockDB.EXPECT().
+		StreamUserActivity(mock.Anything, filter, mock.Anything).
+		Run(func(_ context.Context, _ models.ReportFilter, fn func(models.ActivityRecord) error) {
+			fn(models.ActivityRecord{
+				ID: 7, UserID: 2, Username: "manager123", ItemID: 1, ItemName: "Болт", Operation: "UPDATE",
+				Details: strPtr("выдача"), OldValue: strPtr(`{"quantity":10}`), NewValue: strPtr(`{"quantity":6}`),
+				ChangedAt: testAt,
+			})
+		}).
+		Return(nil)
+
+	var buf bytes.Buffer
+	err := svc.WriteUserActivity(context.Background(), filter, report.FormatJSON, &buf)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{
+		"id": 7, "user_id": 2, "username": "manager123", "item_id": 1, "item_name": "Болт",
+		"operation": "UPDATE", "details": "выдача",
+		"old_value": "{\"quantity\":10}", "new_value": "{\"quantity\":6}",
+		"changed_at": "2026-08-15T12:00:00Z"
+	}]`, buf.String())
+}
+
+func TestReportSvc_WriteUserActivity_OKEmpty(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		StreamUserActivity(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	var buf bytes.Buffer
+	err := svc.WriteUserActivity(context.Background(), models.ReportFilter{From: testFrom, To: testFrom}, report.FormatJSON, &buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", buf.String())
+}
+
+func TestReportSvc_WriteUserActivity_ErrUserID(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	var buf bytes.Buffer
+	err := svc.WriteUserActivity(context.Background(), models.ReportFilter{From: testFrom, To: testTo, UserID: intPtr(0)}, report.FormatCSV, &buf)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный user_id")
+}
+
+// TestReportSvc_WriteBalances - тесты для метода WriteBalances
+func TestReportSvc_WriteBalances_OKXLSX(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		StreamBalances(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ models.ReportFilter, fn func(models.BalanceRecord) error) {
+			fn(models.BalanceRecord{
+				ItemID: 1, ItemName: "Болт", OpeningQty: 0, InQty: 10, InValue: 240000,
+				OutQty: 4, OutValue: 96000, ClosingQty: 6, ClosingValue: 144000,
+			})
+		}).
+		Return(nil)
+
+	var buf bytes.Buffer
+	err := svc.WriteBalances(context.Background(), models.ReportFilter{From: testFrom, To: testTo}, report.FormatXLSX, &buf)
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("PK")))
+}
+
+func TestReportSvc_WriteBalances_ErrUserFilter(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	var buf bytes.Buffer
+	err := svc.WriteBalances(context.Background(), models.ReportFilter{From: testFrom, To: testTo, UserID: intPtr(2)}, report.FormatCSV, &buf)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный фильтр")
+}
+
+func TestReportSvc_WriteBalances_ErrWriter(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	rowErr := errors.New("row error")
+	mockDB.EXPECT().
+		StreamBalances(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ models.ReportFilter, fn func(models.BalanceRecord) error) {
+			assert.NoError(t, fn(models.BalanceRecord{ItemID: 1}))
+		}).
+		Return(rowErr)
+
+	var buf bytes.Buffer
+	err := svc.WriteBalances(context.Background(), models.ReportFilter{From: testFrom, To: testTo}, report.FormatJSON, &buf)
+
+	assert.ErrorIs(t, err, rowErr)
+	assert.Contains(t, err.Error(), "db.StreamBalances")
+}
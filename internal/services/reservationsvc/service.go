@@ -0,0 +1,106 @@
+package reservationsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.ReservationService = (*reservationSvc)(nil)
+
+type reservationSvc struct {
+	db         infra.Database
+	defaultTTL time.Duration
+}
+
+// New - конструктор нового reservationSvc.
+// defaultTTL используется, если срок действия резерва не указан явно.
+func New(db infra.Database, defaultTTL time.Duration) services.ReservationService {
+	return &reservationSvc{db: db, defaultTTL: defaultTTL}
+}
+
+// Reserve - метод для резервирования остатка item под заказ.
+func (s *reservationSvc) Reserve(ctx context.Context, userID int, res *models.Reservation) (int, error) {
+	if res.Quantity <= 0 {
+		return 0, fmt.Errorf("quantity должно быть больше 0")
+	}
+
+	now := time.Now()
+	if res.ExpiresAt.IsZero() {
+		res.ExpiresAt = now.Add(s.defaultTTL)
+	}
+	if !res.ExpiresAt.After(now) {
+		return 0, fmt.Errorf("expires_at должно быть в будущем")
+	}
+
+	res.UserID = userID
+
+	id, err := s.db.CreateReservation(ctx, res)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "недостаточно") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.CreateReservation: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetItemReservations - метод для получения резервов конкретного item.
+func (s *reservationSvc) GetItemReservations(ctx context.Context, itemID int) ([]models.Reservation, error) {
+	reservations, err := s.db.GetReservationsByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("db.GetReservationsByItemID: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// Release - метод для снятия резерва без списания остатка.
+func (s *reservationSvc) Release(ctx context.Context, id int) error {
+	if err := s.db.ReleaseReservation(ctx, id); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return fmt.Errorf("активный резерв с id %d не найден", id)
+		}
+		if strings.Contains(err.Error(), "нельзя") {
+			return err
+		}
+
+		return fmt.Errorf("db.ReleaseReservation: %w", err)
+	}
+
+	return nil
+}
+
+// Confirm - метод для подтверждения резерва со списанием остатка.
+func (s *reservationSvc) Confirm(ctx context.Context, userID, id int) error {
+	if err := s.db.ConfirmReservation(ctx, userID, id); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return fmt.Errorf("активный резерв с id %d не найден", id)
+		}
+		if strings.Contains(err.Error(), "нельзя") {
+			return err
+		}
+
+		return fmt.Errorf("db.ConfirmReservation: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseExpired - метод для снятия всех истекших резервов.
+// Возвращает количество снятых резервов.
+func (s *reservationSvc) ReleaseExpired(ctx context.Context) (int, error) {
+	count, err := s.db.ExpireReservations(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("db.ExpireReservations: %w", err)
+	}
+
+	return count, nil
+}
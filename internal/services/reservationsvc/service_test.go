@@ -0,0 +1,209 @@
+package reservationsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const testTTL = time.Hour
+
+// TestReservationSvc_Reserve - тесты для метода Reserve
+func TestReservationSvc_Reserve_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	res := &models.Reservation{ItemID: 1, Quantity: 5, OrderRef: "SO-1"}
+
+	mockDB.EXPECT().
+		CreateReservation(mock.Anything, res).
+		Return(1, nil)
+
+	id, err := svc.Reserve(context.Background(), 2, res)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Equal(t, 2, res.UserID)
+	assert.WithinDuration(t, time.Now().Add(testTTL), res.ExpiresAt, time.Minute)
+}
+
+func TestReservationSvc_Reserve_OKExplicitExpiry(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	expiresAt := time.Now().Add(48 * time.Hour)
+	res := &models.Reservation{ItemID: 1, Quantity: 5, ExpiresAt: expiresAt}
+
+	mockDB.EXPECT().
+		CreateReservation(mock.Anything, res).
+		Return(1, nil)
+
+	_, err := svc.Reserve(context.Background(), 2, res)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expiresAt, res.ExpiresAt)
+}
+
+func TestReservationSvc_Reserve_ErrZeroQuantity(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	id, err := svc.Reserve(context.Background(), 1, &models.Reservation{ItemID: 1, Quantity: 0})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "quantity должно быть больше 0")
+}
+
+func TestReservationSvc_Reserve_ErrExpiryInPast(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	res := &models.Reservation{ItemID: 1, Quantity: 1, ExpiresAt: time.Now().Add(-time.Minute)}
+
+	id, err := svc.Reserve(context.Background(), 1, res)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "expires_at")
+}
+
+func TestReservationSvc_Reserve_ErrNotEnoughStock(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	res := &models.Reservation{ItemID: 1, Quantity: 50}
+
+	mockDB.EXPECT().
+		CreateReservation(mock.Anything, res).
+		Return(0, fmt.Errorf("недостаточно доступного остатка: запрошено 50, доступно 10"))
+
+	id, err := svc.Reserve(context.Background(), 1, res)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "недостаточно доступного остатка")
+}
+
+func TestReservationSvc_Reserve_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	res := &models.Reservation{ItemID: 1, Quantity: 1}
+
+	mockDB.EXPECT().
+		CreateReservation(mock.Anything, res).
+		Return(0, fmt.Errorf("database error"))
+
+	id, err := svc.Reserve(context.Background(), 1, res)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "db.CreateReservation")
+}
+
+// TestReservationSvc_Release - тесты для метода Release
+func TestReservationSvc_Release_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	mockDB.EXPECT().
+		ReleaseReservation(mock.Anything, 1).
+		Return(nil)
+
+	err := svc.Release(context.Background(), 1)
+
+	assert.NoError(t, err)
+}
+
+func TestReservationSvc_Release_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	mockDB.EXPECT().
+		ReleaseReservation(mock.Anything, 999).
+		Return(fmt.Errorf("активный резерв с id 999 не найден"))
+
+	err := svc.Release(context.Background(), 999)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestReservationSvc_Release_ErrOutboundOrder(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	mockDB.EXPECT().
+		ReleaseReservation(mock.Anything, 4).
+		Return(fmt.Errorf("нельзя снять резерв 4: он принадлежит заказу на отгрузку"))
+
+	err := svc.Release(context.Background(), 4)
+
+	assert.EqualError(t, err, "нельзя снять резерв 4: он принадлежит заказу на отгрузку")
+}
+
+// TestReservationSvc_Confirm - тесты для метода Confirm
+func TestReservationSvc_Confirm_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	mockDB.EXPECT().
+		ConfirmReservation(mock.Anything, 1, 3).
+		Return(nil)
+
+	err := svc.Confirm(context.Background(), 1, 3)
+
+	assert.NoError(t, err)
+}
+
+func TestReservationSvc_Confirm_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	mockDB.EXPECT().
+		ConfirmReservation(mock.Anything, 1, 3).
+		Return(fmt.Errorf("database error"))
+
+	err := svc.Confirm(context.Background(), 1, 3)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.ConfirmReservation")
+}
+
+// TestReservationSvc_ReleaseExpired - тесты для метода ReleaseExpired
+func TestReservationSvc_ReleaseExpired_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	mockDB.EXPECT().
+		ExpireReservations(mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(3, nil)
+
+	count, err := svc.ReleaseExpired(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestReservationSvc_ReleaseExpired_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, testTTL)
+
+	mockDB.EXPECT().
+		ExpireReservations(mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(0, fmt.Errorf("database error"))
+
+	count, err := svc.ReleaseExpired(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+	assert.Contains(t, err.Error(), "db.ExpireReservations")
+}
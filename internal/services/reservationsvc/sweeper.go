@@ -0,0 +1,47 @@
+package reservationsvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+)
+
+type sweeper struct {
+	svc      services.ReservationService
+	interval time.Duration
+}
+
+// NewSweeper - конструктор фонового процесса, снимающего истекшие резервы.
+func NewSweeper(svc services.ReservationService, interval time.Duration) *sweeper {
+	return &sweeper{svc: svc, interval: interval}
+}
+
+// Run - запуск периодического снятия истекших резервов до отмены контекста.
+func (s *sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			zlog.Logger.Info().Msg("Sweeper: остановлен")
+			return
+		case <-ticker.C:
+			count, err := s.svc.ReleaseExpired(ctx)
+			if err != nil {
+				zlog.Logger.Error().
+					Err(err).
+					Msg("Sweeper: не удалось снять истекшие резервы")
+				continue
+			}
+			if count > 0 {
+				zlog.Logger.Info().
+					Int("count", count).
+					Msg("Sweeper: истекшие резервы сняты")
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package returnsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.ReturnService = (*returnSvc)(nil)
+
+type returnSvc struct {
+	db       infra.Database
+	notifier services.StockNotifier
+}
+
+// New - конструктор нового returnSvc.
+// notifier получает сигналы об изменении доступного остатка, nil - без сигналов.
+func New(db infra.Database, notifier services.StockNotifier) services.ReturnService {
+	return &returnSvc{db: db, notifier: notifier}
+}
+
+// CreateReturn - метод для оформления возврата по заказу на отгрузку.
+func (s *returnSvc) CreateReturn(ctx context.Context, userID int, rma *models.ReturnAuthorization) (int, string, error) {
+	if len(rma.Lines) == 0 {
+		return 0, "", fmt.Errorf("некорректный возврат: нет строк")
+	}
+	seen := make(map[int]struct{}, len(rma.Lines))
+	for _, line := range rma.Lines {
+		if line.AuthorizedQty <= 0 {
+			return 0, "", fmt.Errorf("некорректное количество %d для item %d: должно быть больше 0", line.AuthorizedQty, line.ItemID)
+		}
+		if !validReason(line.Reason) {
+			return 0, "", fmt.Errorf("некорректная причина возврата %q для item %d", line.Reason, line.ItemID)
+		}
+		if _, ok := seen[line.ItemID]; ok {
+			return 0, "", fmt.Errorf("некорректный возврат: item %d указан дважды", line.ItemID)
+		}
+		seen[line.ItemID] = struct{}{}
+	}
+
+	rma.Note = strings.TrimSpace(rma.Note)
+	rma.CreatedBy = userID
+
+	id, number, err := s.db.CreateReturnAuthorization(ctx, rma)
+	if err != nil {
+		if knownError(err) {
+			return 0, "", err
+		}
+
+		return 0, "", fmt.Errorf("db.CreateReturnAuthorization: %w", err)
+	}
+
+	return id, number, nil
+}
+
+// GetReturns - метод для получения возвратов по статусу и заказу, orderID == 0 - по всем заказам.
+func (s *returnSvc) GetReturns(ctx context.Context, status string, orderID int) ([]models.ReturnAuthorization, error) {
+	switch status {
+	case "", models.ReturnAuthorized, models.ReturnPartiallyReceived, models.ReturnReceived, models.ReturnCancelled:
+	default:
+		return nil, fmt.Errorf("некорректный статус возврата %s", status)
+	}
+
+	rmas, err := s.db.ListReturnAuthorizations(ctx, status, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListReturnAuthorizations: %w", err)
+	}
+
+	return rmas, nil
+}
+
+// GetReturn - метод для получения возврата со строками и приемками.
+func (s *returnSvc) GetReturn(ctx context.Context, id int) (*models.ReturnAuthorization, error) {
+	rma, err := s.db.GetReturnAuthorization(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetReturnAuthorization: %w", err)
+	}
+
+	return rma, nil
+}
+
+// ReceiveReturn - метод для приемки возвращенного товара с результатом осмотра.
+// Один item можно принять несколькими строками с разными результатами осмотра.
+// Возвращает новый статус возврата.
+func (s *returnSvc) ReceiveReturn(ctx context.Context, userID, id int, inspections []models.ReturnInspection) (string, error) {
+	if len(inspections) == 0 {
+		return "", fmt.Errorf("некорректная приемка: нет строк")
+	}
+	serials := make(map[string]struct{})
+	for i, inspection := range inspections {
+		if inspection.Quantity <= 0 {
+			return "", fmt.Errorf("некорректное количество %d для item %d: должно быть больше 0", inspection.Quantity, inspection.ItemID)
+		}
+		if !validDisposition(inspection.Disposition) {
+			return "", fmt.Errorf("некорректный результат осмотра %q для item %d", inspection.Disposition, inspection.ItemID)
+		}
+
+		for j, serial := range inspection.Serials {
+			serial = strings.TrimSpace(serial)
+			if serial == "" {
+				return "", fmt.Errorf("некорректная приемка: пустой серийный номер для item %d", inspection.ItemID)
+			}
+			if _, ok := serials[serial]; ok {
+				return "", fmt.Errorf("некорректная приемка: серийный номер %s указан дважды", serial)
+			}
+			serials[serial] = struct{}{}
+			inspections[i].Serials[j] = serial
+		}
+		inspections[i].Note = strings.TrimSpace(inspection.Note)
+	}
+
+	status, err := s.db.ReceiveReturn(ctx, userID, id, inspections)
+	if err != nil {
+		if knownError(err) {
+			return "", err
+		}
+
+		return "", fmt.Errorf("db.ReceiveReturn: %w", err)
+	}
+
+	if s.notifier != nil {
+		for _, inspection := range inspections {
+			if inspection.Disposition == models.DispositionRestock {
+				s.notifier.Notify(inspection.ItemID)
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// CancelReturn - метод для отмены возврата, по которому еще ничего не принято.
+func (s *returnSvc) CancelReturn(ctx context.Context, userID, id int) error {
+	if err := s.db.CancelReturnAuthorization(ctx, userID, id); err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.CancelReturnAuthorization: %w", err)
+	}
+
+	return nil
+}
+
+// GetReturnReasonStats - метод для получения статистики возвратов по причинам за период.
+// Границы периода - даты оформления возврата включительно, nil не ограничивает границу.
+func (s *returnSvc) GetReturnReasonStats(ctx context.Context, from, to *time.Time) ([]models.ReturnReasonStat, error) {
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, fmt.Errorf("некорректный период: дата окончания раньше даты начала")
+	}
+
+	var until *time.Time
+	if to != nil {
+		next := to.AddDate(0, 0, 1)
+		until = &next
+	}
+
+	stats, err := s.db.ReturnReasonStats(ctx, from, until)
+	if err != nil {
+		return nil, fmt.Errorf("db.ReturnReasonStats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// knownError - ошибки, которые отдаются клиенту как есть.
+func knownError(err error) bool {
+	return strings.Contains(err.Error(), "не найден") ||
+		strings.Contains(err.Error(), "нельзя") ||
+		strings.Contains(err.Error(), "некорректн")
+}
+
+// validReason - проверка причины возврата.
+func validReason(reason string) bool {
+	switch reason {
+	case models.ReturnReasonDamaged, models.ReturnReasonDefective, models.ReturnReasonWrongItem,
+		models.ReturnReasonNotAsDescribed, models.ReturnReasonUnwanted, models.ReturnReasonOther:
+		return true
+	}
+
+	return false
+}
+
+// validDisposition - проверка результата осмотра.
+func validDisposition(disposition string) bool {
+	switch disposition {
+	case models.DispositionRestock, models.DispositionRefurbish, models.DispositionQuarantine, models.DispositionScrap:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,181 @@
+package returnsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestReturnSvc_CreateReturn - тесты для метода CreateReturn
+func TestReturnSvc_CreateReturn_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		CreateReturnAuthorization(mock.Anything, mock.MatchedBy(func(rma *models.ReturnAuthorization) bool {
+			return rma.CreatedBy == 3 && rma.OrderID == 4 && rma.Note == "брак" && len(rma.Lines) == 2
+		})).
+		Return(2, "RMA-000002", nil)
+
+	id, number, err := svc.CreateReturn(context.Background(), 3, &models.ReturnAuthorization{
+		OrderID: 4,
+		Note:    " брак ",
+		Lines: []models.ReturnLine{
+			{ItemID: 1, Reason: models.ReturnReasonDefective, AuthorizedQty: 2},
+			{ItemID: 2, Reason: models.ReturnReasonUnwanted, AuthorizedQty: 1},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, id)
+	assert.Equal(t, "RMA-000002", number)
+}
+
+func TestReturnSvc_CreateReturn_ErrReason(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, _, err := svc.CreateReturn(context.Background(), 3, &models.ReturnAuthorization{
+		OrderID: 4,
+		Lines:   []models.ReturnLine{{ItemID: 1, Reason: "bored", AuthorizedQty: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная причина")
+}
+
+func TestReturnSvc_CreateReturn_ErrOverShipped(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		CreateReturnAuthorization(mock.Anything, mock.Anything).
+		Return(0, "", fmt.Errorf("нельзя оформить возврат 5 по item 1 заказа SO-000004: отгружено 3, уже в возвратах 0"))
+
+	_, _, err := svc.CreateReturn(context.Background(), 3, &models.ReturnAuthorization{
+		OrderID: 4,
+		Lines:   []models.ReturnLine{{ItemID: 1, Reason: models.ReturnReasonDamaged, AuthorizedQty: 5}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя оформить возврат")
+}
+
+// TestReturnSvc_GetReturns - тесты для метода GetReturns
+func TestReturnSvc_GetReturns_ErrStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, err := svc.GetReturns(context.Background(), "lost", 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный статус")
+}
+
+// TestReturnSvc_ReceiveReturn - тесты для метода ReceiveReturn
+func TestReturnSvc_ReceiveReturn_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		ReceiveReturn(mock.Anything, 3, 2, []models.ReturnInspection{
+			{ItemID: 1, Quantity: 1, Disposition: models.DispositionRestock},
+			{ItemID: 1, Quantity: 1, Disposition: models.DispositionScrap, Note: "разбит"},
+			{ItemID: 5, Quantity: 1, Disposition: models.DispositionQuarantine, Serials: []string{"SN-1"}},
+		}).
+		Return(models.ReturnReceived, nil)
+	notifier.EXPECT().Notify(1).Return()
+
+	status, err := svc.ReceiveReturn(context.Background(), 3, 2, []models.ReturnInspection{
+		{ItemID: 1, Quantity: 1, Disposition: models.DispositionRestock},
+		{ItemID: 1, Quantity: 1, Disposition: models.DispositionScrap, Note: " разбит "},
+		{ItemID: 5, Quantity: 1, Disposition: models.DispositionQuarantine, Serials: []string{" SN-1 "}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReturnReceived, status)
+}
+
+func TestReturnSvc_ReceiveReturn_ErrDisposition(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	_, err := svc.ReceiveReturn(context.Background(), 3, 2, []models.ReturnInspection{
+		{ItemID: 1, Quantity: 1, Disposition: "resell"},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный результат осмотра")
+}
+
+func TestReturnSvc_ReceiveReturn_ErrOverAuthorized(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		ReceiveReturn(mock.Anything, 3, 2, mock.Anything).
+		Return("", fmt.Errorf("нельзя принять 3 по item 1 возврата RMA-000002: ожидается 2"))
+
+	_, err := svc.ReceiveReturn(context.Background(), 3, 2, []models.ReturnInspection{
+		{ItemID: 1, Quantity: 3, Disposition: models.DispositionRestock},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя принять")
+}
+
+// TestReturnSvc_CancelReturn - тесты для метода CancelReturn
+func TestReturnSvc_CancelReturn_ErrReceived(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		CancelReturnAuthorization(mock.Anything, 3, 2).
+		Return(fmt.Errorf("нельзя отменить возврат RMA-000002 в статусе received"))
+
+	err := svc.CancelReturn(context.Background(), 3, 2)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя отменить")
+}
+
+// TestReturnSvc_GetReturnReasonStats - тесты для метода GetReturnReasonStats
+func TestReturnSvc_GetReturnReasonStats_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	mockDB.EXPECT().
+		ReturnReasonStats(mock.Anything, &from, &until).
+		Return([]models.ReturnReasonStat{{Reason: models.ReturnReasonDefective, Returns: 2, AuthorizedQty: 3}}, nil)
+
+	stats, err := svc.GetReturnReasonStats(context.Background(), &from, &to)
+
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, models.ReturnReasonDefective, stats[0].Reason)
+}
+
+func TestReturnSvc_GetReturnReasonStats_ErrPeriod(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	from := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.GetReturnReasonStats(context.Background(), &from, &to)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный период")
+}
@@ -0,0 +1,123 @@
+package serialsvc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// transitions - допустимые переходы между статусами серийного номера.
+var transitions = map[string][]string{
+	models.SerialInStock:  {models.SerialReserved, models.SerialShipped, models.SerialScrapped},
+	models.SerialReserved: {models.SerialInStock, models.SerialShipped},
+	models.SerialShipped:  {models.SerialReturned},
+	models.SerialReturned: {models.SerialInStock, models.SerialScrapped},
+	models.SerialScrapped: {},
+}
+
+var _ services.SerialService = (*serialSvc)(nil)
+
+type serialSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового serialSvc.
+func New(db infra.Database) services.SerialService {
+	return &serialSvc{db: db}
+}
+
+// RegisterSerials - метод для регистрации серийных номеров серийного item.
+func (s *serialSvc) RegisterSerials(ctx context.Context, userID, itemID int, serialNumbers []string) error {
+	if len(serialNumbers) == 0 {
+		return fmt.Errorf("список серийных номеров не может быть пустым")
+	}
+
+	seen := make(map[string]struct{}, len(serialNumbers))
+	for i, serialNumber := range serialNumbers {
+		serialNumber = strings.TrimSpace(serialNumber)
+		if serialNumber == "" {
+			return fmt.Errorf("серийный номер не может быть пустым")
+		}
+		if _, ok := seen[serialNumber]; ok {
+			return fmt.Errorf("серийный номер %s указан несколько раз", serialNumber)
+		}
+		seen[serialNumber] = struct{}{}
+		serialNumbers[i] = serialNumber
+	}
+
+	if err := s.db.CreateSerials(ctx, userID, itemID, serialNumbers); err != nil {
+		if strings.Contains(err.Error(), "не найден") ||
+			strings.Contains(err.Error(), "уже существует") ||
+			strings.Contains(err.Error(), "не является серийным") {
+			return err
+		}
+
+		return fmt.Errorf("db.CreateSerials: %w", err)
+	}
+
+	return nil
+}
+
+// GetItemSerials - метод для получения серийных номеров item с необязательным фильтром по статусу.
+func (s *serialSvc) GetItemSerials(ctx context.Context, itemID int, status string) ([]models.Serial, error) {
+	if status != "" && !validStatus(status) {
+		return nil, fmt.Errorf("неизвестный статус серийного номера %s", status)
+	}
+
+	serials, err := s.db.GetSerialsByItemID(ctx, itemID, status)
+	if err != nil {
+		return nil, fmt.Errorf("db.GetSerialsByItemID: %w", err)
+	}
+
+	return serials, nil
+}
+
+// GetSerial - метод для поиска серийного номера.
+func (s *serialSvc) GetSerial(ctx context.Context, serialNumber string) (*models.Serial, error) {
+	serial, err := s.db.GetSerialByNumber(ctx, serialNumber)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, fmt.Errorf("серийный номер %s не найден", serialNumber)
+		}
+
+		return nil, fmt.Errorf("db.GetSerialByNumber: %w", err)
+	}
+
+	return serial, nil
+}
+
+// MoveSerial - метод для перевода серийного номера в новый статус.
+func (s *serialSvc) MoveSerial(ctx context.Context, userID int, serialNumber, status string) error {
+	if !validStatus(status) {
+		return fmt.Errorf("неизвестный статус серийного номера %s", status)
+	}
+
+	serial, err := s.GetSerial(ctx, serialNumber)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(transitions[serial.Status], status) {
+		return fmt.Errorf("переход серийного номера %s из статуса %s в %s недопустим", serialNumber, serial.Status, status)
+	}
+
+	if err := s.db.UpdateSerialStatus(ctx, userID, serial, status); err != nil {
+		if strings.Contains(err.Error(), "уже изменен") {
+			return err
+		}
+
+		return fmt.Errorf("db.UpdateSerialStatus: %w", err)
+	}
+
+	return nil
+}
+
+func validStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
@@ -0,0 +1,162 @@
+package serialsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestSerialSvc_RegisterSerials - тесты для метода RegisterSerials
+func TestSerialSvc_RegisterSerials_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateSerials(mock.Anything, 1, 10, []string{"SN-1", "SN-2"}).
+		Return(nil)
+
+	err := svc.RegisterSerials(context.Background(), 1, 10, []string{" SN-1 ", "SN-2"})
+
+	assert.NoError(t, err)
+}
+
+func TestSerialSvc_RegisterSerials_ErrEmptyList(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.RegisterSerials(context.Background(), 1, 10, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не может быть пустым")
+}
+
+func TestSerialSvc_RegisterSerials_ErrDuplicateInRequest(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.RegisterSerials(context.Background(), 1, 10, []string{"SN-1", "SN-1"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "указан несколько раз")
+}
+
+func TestSerialSvc_RegisterSerials_ErrAlreadyExists(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateSerials(mock.Anything, 1, 10, []string{"SN-1"}).
+		Return(fmt.Errorf("серийный номер SN-1 уже существует"))
+
+	err := svc.RegisterSerials(context.Background(), 1, 10, []string{"SN-1"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "уже существует")
+}
+
+func TestSerialSvc_RegisterSerials_ErrNotSerialized(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateSerials(mock.Anything, 1, 10, []string{"SN-1"}).
+		Return(fmt.Errorf("item с id 10 не является серийным"))
+
+	err := svc.RegisterSerials(context.Background(), 1, 10, []string{"SN-1"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не является серийным")
+}
+
+// TestSerialSvc_GetItemSerials - тесты для метода GetItemSerials
+func TestSerialSvc_GetItemSerials_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	expected := []models.Serial{{ID: 1, ItemID: 10, SerialNumber: "SN-1", Status: models.SerialInStock}}
+
+	mockDB.EXPECT().
+		GetSerialsByItemID(mock.Anything, 10, models.SerialInStock).
+		Return(expected, nil)
+
+	serials, err := svc.GetItemSerials(context.Background(), 10, models.SerialInStock)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, serials)
+}
+
+func TestSerialSvc_GetItemSerials_ErrUnknownStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	serials, err := svc.GetItemSerials(context.Background(), 10, "lost")
+
+	assert.Error(t, err)
+	assert.Nil(t, serials)
+	assert.Contains(t, err.Error(), "неизвестный статус")
+}
+
+// TestSerialSvc_MoveSerial - тесты для метода MoveSerial
+func TestSerialSvc_MoveSerial_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	serial := &models.Serial{ID: 1, ItemID: 10, SerialNumber: "SN-1", Status: models.SerialInStock}
+
+	mockDB.EXPECT().
+		GetSerialByNumber(mock.Anything, "SN-1").
+		Return(serial, nil)
+	mockDB.EXPECT().
+		UpdateSerialStatus(mock.Anything, 1, serial, models.SerialShipped).
+		Return(nil)
+
+	err := svc.MoveSerial(context.Background(), 1, "SN-1", models.SerialShipped)
+
+	assert.NoError(t, err)
+}
+
+func TestSerialSvc_MoveSerial_ErrForbiddenTransition(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	serial := &models.Serial{ID: 1, ItemID: 10, SerialNumber: "SN-1", Status: models.SerialScrapped}
+
+	mockDB.EXPECT().
+		GetSerialByNumber(mock.Anything, "SN-1").
+		Return(serial, nil)
+
+	err := svc.MoveSerial(context.Background(), 1, "SN-1", models.SerialInStock)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "недопустим")
+}
+
+func TestSerialSvc_MoveSerial_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetSerialByNumber(mock.Anything, "SN-404").
+		Return(nil, fmt.Errorf("серийный номер SN-404 не найден"))
+
+	err := svc.MoveSerial(context.Background(), 1, "SN-404", models.SerialShipped)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestSerialSvc_MoveSerial_ErrUnknownStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.MoveSerial(context.Background(), 1, "SN-1", "lost")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "неизвестный статус")
+}
@@ -0,0 +1,85 @@
+package stockstatussvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.StockStatusService = (*stockStatusSvc)(nil)
+
+type stockStatusSvc struct {
+	db       infra.Database
+	notifier services.StockNotifier
+}
+
+// New - конструктор нового stockStatusSvc.
+// notifier получает сигналы об изменении доступного остатка, nil - без сигналов.
+func New(db infra.Database, notifier services.StockNotifier) services.StockStatusService {
+	return &stockStatusSvc{db: db, notifier: notifier}
+}
+
+// ChangeStockStatus - метод для перевода запаса item между статусами.
+// Причина обязательна, ячейка относится к стороне вне доступного остатка.
+func (s *stockStatusSvc) ChangeStockStatus(ctx context.Context, userID int, change *models.StockStatusChange) error {
+	if !validStatus(change.FromStatus) {
+		return fmt.Errorf("некорректный статус запаса %q", change.FromStatus)
+	}
+	if !validStatus(change.ToStatus) {
+		return fmt.Errorf("некорректный статус запаса %q", change.ToStatus)
+	}
+	if change.FromStatus == change.ToStatus {
+		return fmt.Errorf("некорректный перевод: статусы %s совпадают", change.FromStatus)
+	}
+	if change.Quantity <= 0 {
+		return fmt.Errorf("некорректное количество %d: должно быть больше 0", change.Quantity)
+	}
+	change.Reason = strings.TrimSpace(change.Reason)
+	if change.Reason == "" {
+		return fmt.Errorf("некорректный перевод: не указана причина")
+	}
+
+	if err := s.db.ChangeStockStatus(ctx, userID, change); err != nil {
+		if strings.Contains(err.Error(), "не найден") ||
+			strings.Contains(err.Error(), "нельзя") ||
+			strings.Contains(err.Error(), "недостаточно") {
+			return err
+		}
+
+		return fmt.Errorf("db.ChangeStockStatus: %w", err)
+	}
+
+	if s.notifier != nil && (change.FromStatus == models.StockAvailable || change.ToStatus == models.StockAvailable) {
+		s.notifier.Notify(change.ItemID)
+	}
+
+	return nil
+}
+
+// GetItemStock - метод для получения запаса item по статусам и журнала смены статусов.
+func (s *stockStatusSvc) GetItemStock(ctx context.Context, itemID int) (*models.ItemStock, error) {
+	stock, err := s.db.GetItemStock(ctx, itemID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetItemStock: %w", err)
+	}
+
+	return stock, nil
+}
+
+// validStatus - проверка статуса запаса.
+func validStatus(status string) bool {
+	switch status {
+	case models.StockAvailable, models.StockQuarantine, models.StockDamaged, models.StockQCHold, models.StockBlocked:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package stockstatussvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestStockStatusSvc_ChangeStockStatus - тесты для метода ChangeStockStatus
+func TestStockStatusSvc_ChangeStockStatus_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	locationID := 3
+	mockDB.EXPECT().
+		ChangeStockStatus(mock.Anything, 1, mock.MatchedBy(func(change *models.StockStatusChange) bool {
+			return change.ItemID == 5 && change.Reason == "вмятины на коробках" && change.Quantity == 4
+		})).
+		Return(nil)
+	notifier.EXPECT().Notify(5).Return()
+
+	err := svc.ChangeStockStatus(context.Background(), 1, &models.StockStatusChange{
+		ItemID:     5,
+		LocationID: &locationID,
+		FromStatus: models.StockAvailable,
+		ToStatus:   models.StockDamaged,
+		Quantity:   4,
+		Reason:     " вмятины на коробках ",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestStockStatusSvc_ChangeStockStatus_OKBetweenHeld(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier)
+
+	mockDB.EXPECT().
+		ChangeStockStatus(mock.Anything, 1, mock.Anything).
+		Return(nil)
+
+	err := svc.ChangeStockStatus(context.Background(), 1, &models.StockStatusChange{
+		ItemID:     5,
+		FromStatus: models.StockQCHold,
+		ToStatus:   models.StockBlocked,
+		Quantity:   1,
+		Reason:     "не прошел входной контроль",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestStockStatusSvc_ChangeStockStatus_ErrStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	err := svc.ChangeStockStatus(context.Background(), 1, &models.StockStatusChange{
+		ItemID:     5,
+		FromStatus: models.StockAvailable,
+		ToStatus:   "lost",
+		Quantity:   1,
+		Reason:     "потерян",
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный статус запаса")
+}
+
+func TestStockStatusSvc_ChangeStockStatus_ErrSameStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	err := svc.ChangeStockStatus(context.Background(), 1, &models.StockStatusChange{
+		ItemID:     5,
+		FromStatus: models.StockQuarantine,
+		ToStatus:   models.StockQuarantine,
+		Quantity:   1,
+		Reason:     "повтор",
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "совпадают")
+}
+
+func TestStockStatusSvc_ChangeStockStatus_ErrNoReason(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	err := svc.ChangeStockStatus(context.Background(), 1, &models.StockStatusChange{
+		ItemID:     5,
+		FromStatus: models.StockAvailable,
+		ToStatus:   models.StockQuarantine,
+		Quantity:   1,
+		Reason:     "  ",
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не указана причина")
+}
+
+func TestStockStatusSvc_ChangeStockStatus_ErrReserved(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		ChangeStockStatus(mock.Anything, 1, mock.Anything).
+		Return(fmt.Errorf("недостаточно доступного незарезервированного остатка item 5 для перевода 10"))
+
+	err := svc.ChangeStockStatus(context.Background(), 1, &models.StockStatusChange{
+		ItemID:     5,
+		FromStatus: models.StockAvailable,
+		ToStatus:   models.StockBlocked,
+		Quantity:   10,
+		Reason:     "арест партии",
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "недостаточно")
+}
+
+// TestStockStatusSvc_GetItemStock - тесты для метода GetItemStock
+func TestStockStatusSvc_GetItemStock_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil)
+
+	mockDB.EXPECT().
+		GetItemStock(mock.Anything, 9).
+		Return(nil, fmt.Errorf("item с id 9 не найден"))
+
+	_, err := svc.GetItemStock(context.Background(), 9)
+
+	assert.EqualError(t, err, "item с id 9 не найден")
+}
@@ -0,0 +1,229 @@
+package suppliersvc
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	maxNameLength   = 255
+	maxPhoneLength  = 32
+	maxSupplierSKU  = 64
+	maxLeadTimeDays = 365
+)
+
+var _ services.SupplierService = (*supplierSvc)(nil)
+
+type supplierSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового supplierSvc.
+func New(db infra.Database) services.SupplierService {
+	return &supplierSvc{db: db}
+}
+
+// CreateSupplier - метод для создания поставщика, по умолчанию в статусе active.
+func (s *supplierSvc) CreateSupplier(ctx context.Context, sup *models.Supplier) (int, error) {
+	if sup.Status == "" {
+		sup.Status = models.SupplierActive
+	}
+	if err := normalizeSupplier(sup); err != nil {
+		return 0, err
+	}
+
+	id, err := s.db.CreateSupplier(ctx, sup)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже существует") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.CreateSupplier: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetSuppliers - метод для получения поставщиков, опционально по статусу.
+func (s *supplierSvc) GetSuppliers(ctx context.Context, status string) ([]models.Supplier, error) {
+	if status != "" && !validStatus(status) {
+		return nil, fmt.Errorf("некорректный статус поставщика %s", status)
+	}
+
+	suppliers, err := s.db.ListSuppliers(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListSuppliers: %w", err)
+	}
+
+	return suppliers, nil
+}
+
+// GetSupplier - метод для получения поставщика по id.
+func (s *supplierSvc) GetSupplier(ctx context.Context, id int) (*models.Supplier, error) {
+	sup, err := s.db.GetSupplierByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetSupplierByID: %w", err)
+	}
+
+	return sup, nil
+}
+
+// UpdateSupplier - метод для обновления карточки поставщика.
+func (s *supplierSvc) UpdateSupplier(ctx context.Context, sup *models.Supplier) error {
+	if err := normalizeSupplier(sup); err != nil {
+		return err
+	}
+
+	if err := s.db.UpdateSupplier(ctx, sup); err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже существует") {
+			return err
+		}
+
+		return fmt.Errorf("db.UpdateSupplier: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSupplier - метод для удаления поставщика.
+func (s *supplierSvc) DeleteSupplier(ctx context.Context, id int) error {
+	if err := s.db.DeleteSupplier(ctx, id); err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "нельзя") {
+			return err
+		}
+
+		return fmt.Errorf("db.DeleteSupplier: %w", err)
+	}
+
+	return nil
+}
+
+// SetItemSupplier - метод для привязки item к поставщику или обновления условий поставки.
+// Заблокированного поставщика привязать нельзя, основным может быть только активный.
+func (s *supplierSvc) SetItemSupplier(ctx context.Context, link *models.ItemSupplier) error {
+	link.SupplierSKU = strings.TrimSpace(link.SupplierSKU)
+	if utf8.RuneCountInString(link.SupplierSKU) > maxSupplierSKU {
+		return fmt.Errorf("некорректный артикул поставщика: не более %d символов", maxSupplierSKU)
+	}
+	if link.UnitCost < 0 {
+		return fmt.Errorf("некорректная цена закупки %s", link.UnitCost)
+	}
+	if link.MinOrderQty < 1 {
+		return fmt.Errorf("некорректная минимальная партия %d: должна быть больше 0", link.MinOrderQty)
+	}
+
+	sup, err := s.GetSupplier(ctx, link.SupplierID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case sup.Status == models.SupplierBlocked:
+		return fmt.Errorf("нельзя привязать item к заблокированному поставщику %d", sup.ID)
+	case link.Preferred && sup.Status != models.SupplierActive:
+		return fmt.Errorf("нельзя назначить основным неактивного поставщика %d", sup.ID)
+	}
+
+	if err := s.db.SetItemSupplier(ctx, link); err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже существует") {
+			return err
+		}
+
+		return fmt.Errorf("db.SetItemSupplier: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveItemSupplier - метод для отвязки item от поставщика.
+func (s *supplierSvc) RemoveItemSupplier(ctx context.Context, itemID, supplierID int) error {
+	if err := s.db.RemoveItemSupplier(ctx, itemID, supplierID); err != nil {
+		if strings.Contains(err.Error(), "не найдена") {
+			return err
+		}
+
+		return fmt.Errorf("db.RemoveItemSupplier: %w", err)
+	}
+
+	return nil
+}
+
+// GetItemSuppliers - метод для получения поставщиков item.
+func (s *supplierSvc) GetItemSuppliers(ctx context.Context, itemID int) ([]models.ItemSupplier, error) {
+	links, err := s.db.ListItemSuppliers(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListItemSuppliers: %w", err)
+	}
+
+	return links, nil
+}
+
+// GetSupplierItems - метод для получения items, поставляемых поставщиком.
+func (s *supplierSvc) GetSupplierItems(ctx context.Context, supplierID int) ([]models.ItemSupplier, error) {
+	links, err := s.db.ListSupplierItems(ctx, supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListSupplierItems: %w", err)
+	}
+
+	return links, nil
+}
+
+// normalizeSupplier - очистка и проверка полей карточки поставщика.
+func normalizeSupplier(sup *models.Supplier) error {
+	sup.Name = strings.TrimSpace(sup.Name)
+	sup.ContactName = strings.TrimSpace(sup.ContactName)
+	sup.Email = strings.TrimSpace(sup.Email)
+	sup.Phone = strings.TrimSpace(sup.Phone)
+	sup.Address = strings.TrimSpace(sup.Address)
+
+	if sup.Name == "" || utf8.RuneCountInString(sup.Name) > maxNameLength {
+		return fmt.Errorf("некорректное название поставщика: от 1 до %d символов", maxNameLength)
+	}
+	if utf8.RuneCountInString(sup.ContactName) > maxNameLength {
+		return fmt.Errorf("некорректное контактное лицо: не более %d символов", maxNameLength)
+	}
+	if sup.Email != "" {
+		addr, err := mail.ParseAddress(sup.Email)
+		if err != nil || addr.Address != sup.Email {
+			return fmt.Errorf("некорректный email поставщика %s", sup.Email)
+		}
+	}
+	if utf8.RuneCountInString(sup.Phone) > maxPhoneLength {
+		return fmt.Errorf("некорректный телефон поставщика: не более %d символов", maxPhoneLength)
+	}
+	if sup.LeadTimeDays < 0 || sup.LeadTimeDays > maxLeadTimeDays {
+		return fmt.Errorf("некорректный срок поставки %d: от 0 до %d дней", sup.LeadTimeDays, maxLeadTimeDays)
+	}
+	if !validStatus(sup.Status) {
+		return fmt.Errorf("некорректный статус поставщика %s", sup.Status)
+	}
+	if sup.Currency != "" {
+		code, err := models.ParseCurrencyCode(sup.Currency)
+		if err != nil {
+			return err
+		}
+		sup.Currency = code
+	}
+
+	return nil
+}
+
+// validStatus - проверка статуса поставщика.
+func validStatus(status string) bool {
+	switch status {
+	case models.SupplierActive, models.SupplierInactive, models.SupplierBlocked:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,314 @@
+package suppliersvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestSupplierSvc_CreateSupplier - тесты для метода CreateSupplier
+func TestSupplierSvc_CreateSupplier_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateSupplier(mock.Anything, &models.Supplier{
+			Name:         "ООО Ромашка",
+			Email:        "sales@romashka.ru",
+			LeadTimeDays: 7,
+			Status:       models.SupplierActive,
+		}).
+		Return(1, nil)
+
+	id, err := svc.CreateSupplier(context.Background(), &models.Supplier{
+		Name:         " ООО Ромашка ",
+		Email:        "sales@romashka.ru",
+		LeadTimeDays: 7,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestSupplierSvc_CreateSupplier_ErrEmptyName(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	id, err := svc.CreateSupplier(context.Background(), &models.Supplier{Name: "  "})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "некорректное название поставщика")
+}
+
+func TestSupplierSvc_CreateSupplier_ErrInvalidEmail(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	id, err := svc.CreateSupplier(context.Background(), &models.Supplier{Name: "ООО Ромашка", Email: "Sales <sales@romashka.ru>"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "некорректный email")
+}
+
+func TestSupplierSvc_CreateSupplier_ErrInvalidLeadTime(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	id, err := svc.CreateSupplier(context.Background(), &models.Supplier{Name: "ООО Ромашка", LeadTimeDays: 400})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "некорректный срок поставки")
+}
+
+func TestSupplierSvc_CreateSupplier_ErrInvalidStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	id, err := svc.CreateSupplier(context.Background(), &models.Supplier{Name: "ООО Ромашка", Status: "paused"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "некорректный статус поставщика")
+}
+
+func TestSupplierSvc_CreateSupplier_ErrAlreadyExists(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateSupplier(mock.Anything, mock.Anything).
+		Return(0, fmt.Errorf("поставщик ООО Ромашка уже существует"))
+
+	id, err := svc.CreateSupplier(context.Background(), &models.Supplier{Name: "ООО Ромашка"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "уже существует")
+}
+
+func TestSupplierSvc_CreateSupplier_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		CreateSupplier(mock.Anything, mock.Anything).
+		Return(0, fmt.Errorf("database error"))
+
+	id, err := svc.CreateSupplier(context.Background(), &models.Supplier{Name: "ООО Ромашка"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, id)
+	assert.Contains(t, err.Error(), "db.CreateSupplier")
+}
+
+// TestSupplierSvc_GetSuppliers - тесты для метода GetSuppliers
+func TestSupplierSvc_GetSuppliers_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	expected := []models.Supplier{{ID: 1, Name: "ООО Ромашка", Status: models.SupplierActive}}
+
+	mockDB.EXPECT().
+		ListSuppliers(mock.Anything, models.SupplierActive).
+		Return(expected, nil)
+
+	suppliers, err := svc.GetSuppliers(context.Background(), models.SupplierActive)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, suppliers)
+}
+
+func TestSupplierSvc_GetSuppliers_ErrInvalidStatus(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	suppliers, err := svc.GetSuppliers(context.Background(), "paused")
+
+	assert.Error(t, err)
+	assert.Nil(t, suppliers)
+	assert.Contains(t, err.Error(), "некорректный статус")
+}
+
+// TestSupplierSvc_UpdateSupplier - тесты для метода UpdateSupplier
+func TestSupplierSvc_UpdateSupplier_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	sup := &models.Supplier{ID: 1, Name: "ООО Ромашка", Status: models.SupplierBlocked}
+
+	mockDB.EXPECT().
+		UpdateSupplier(mock.Anything, sup).
+		Return(nil)
+
+	err := svc.UpdateSupplier(context.Background(), sup)
+
+	assert.NoError(t, err)
+}
+
+func TestSupplierSvc_UpdateSupplier_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		UpdateSupplier(mock.Anything, mock.Anything).
+		Return(fmt.Errorf("поставщик с id 1 не найден"))
+
+	err := svc.UpdateSupplier(context.Background(), &models.Supplier{ID: 1, Name: "ООО Ромашка", Status: models.SupplierActive})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+// TestSupplierSvc_DeleteSupplier - тесты для метода DeleteSupplier
+func TestSupplierSvc_DeleteSupplier_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		DeleteSupplier(mock.Anything, 1).
+		Return(fmt.Errorf("database error"))
+
+	err := svc.DeleteSupplier(context.Background(), 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.DeleteSupplier")
+}
+
+// TestSupplierSvc_SetItemSupplier - тесты для метода SetItemSupplier
+func TestSupplierSvc_SetItemSupplier_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	link := &models.ItemSupplier{ItemID: 1, SupplierID: 2, SupplierSKU: "RM-100", UnitCost: 125000, MinOrderQty: 10, Preferred: true}
+
+	mockDB.EXPECT().
+		GetSupplierByID(mock.Anything, 2).
+		Return(&models.Supplier{ID: 2, Status: models.SupplierActive}, nil)
+	mockDB.EXPECT().
+		SetItemSupplier(mock.Anything, link).
+		Return(nil)
+
+	err := svc.SetItemSupplier(context.Background(), link)
+
+	assert.NoError(t, err)
+}
+
+func TestSupplierSvc_SetItemSupplier_ErrInvalidCost(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.SetItemSupplier(context.Background(), &models.ItemSupplier{ItemID: 1, SupplierID: 2, UnitCost: -1, MinOrderQty: 1})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная цена закупки")
+}
+
+func TestSupplierSvc_SetItemSupplier_ErrInvalidMinOrder(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.SetItemSupplier(context.Background(), &models.ItemSupplier{ItemID: 1, SupplierID: 2})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная минимальная партия")
+}
+
+func TestSupplierSvc_SetItemSupplier_ErrSupplierNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetSupplierByID(mock.Anything, 2).
+		Return(nil, fmt.Errorf("поставщик с id 2 не найден"))
+
+	err := svc.SetItemSupplier(context.Background(), &models.ItemSupplier{ItemID: 1, SupplierID: 2, MinOrderQty: 1})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestSupplierSvc_SetItemSupplier_ErrBlockedSupplier(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetSupplierByID(mock.Anything, 2).
+		Return(&models.Supplier{ID: 2, Status: models.SupplierBlocked}, nil)
+
+	err := svc.SetItemSupplier(context.Background(), &models.ItemSupplier{ItemID: 1, SupplierID: 2, MinOrderQty: 1})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя привязать")
+}
+
+func TestSupplierSvc_SetItemSupplier_ErrPreferredInactive(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetSupplierByID(mock.Anything, 2).
+		Return(&models.Supplier{ID: 2, Status: models.SupplierInactive}, nil)
+
+	err := svc.SetItemSupplier(context.Background(), &models.ItemSupplier{ItemID: 1, SupplierID: 2, MinOrderQty: 1, Preferred: true})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя назначить основным")
+}
+
+func TestSupplierSvc_SetItemSupplier_ErrItemNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetSupplierByID(mock.Anything, 2).
+		Return(&models.Supplier{ID: 2, Status: models.SupplierActive}, nil)
+	mockDB.EXPECT().
+		SetItemSupplier(mock.Anything, mock.Anything).
+		Return(fmt.Errorf("item с id 1 не найден"))
+
+	err := svc.SetItemSupplier(context.Background(), &models.ItemSupplier{ItemID: 1, SupplierID: 2, MinOrderQty: 1})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+// TestSupplierSvc_RemoveItemSupplier - тесты для метода RemoveItemSupplier
+func TestSupplierSvc_RemoveItemSupplier_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		RemoveItemSupplier(mock.Anything, 1, 2).
+		Return(fmt.Errorf("связь item 1 с поставщиком 2 не найдена"))
+
+	err := svc.RemoveItemSupplier(context.Background(), 1, 2)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найдена")
+}
+
+// TestSupplierSvc_GetItemSuppliers - тесты для метода GetItemSuppliers
+func TestSupplierSvc_GetItemSuppliers_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	expected := []models.ItemSupplier{{ItemID: 1, SupplierID: 2, Preferred: true}}
+
+	mockDB.EXPECT().
+		ListItemSuppliers(mock.Anything, 1).
+		Return(expected, nil)
+
+	links, err := svc.GetItemSuppliers(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, links)
+}
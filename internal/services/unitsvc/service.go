@@ -0,0 +1,61 @@
+package unitsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/internal/uom"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.UnitService = (*unitSvc)(nil)
+
+type unitSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового unitSvc.
+func New(db infra.Database) services.UnitService {
+	return &unitSvc{db: db}
+}
+
+// SetItemUnits - метод для замены базовой единицы и уровней упаковки item.
+// Возвращает уровни упаковки с коэффициентами, пересчитанными в базовую единицу.
+func (s *unitSvc) SetItemUnits(ctx context.Context, userID, itemID int, baseUnit string, units []models.ItemUnit) ([]models.ItemUnit, error) {
+	baseUnit = strings.TrimSpace(baseUnit)
+	if baseUnit == "" {
+		baseUnit = models.DefaultBaseUnit
+	}
+
+	resolved, err := uom.Resolve(baseUnit, units)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.SetItemUnits(ctx, userID, itemID, baseUnit, resolved); err != nil {
+		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "нельзя") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.SetItemUnits: %w", err)
+	}
+
+	return resolved, nil
+}
+
+// ToBaseQuantity - метод для пересчета количества в единицах unit в базовые единицы item.
+func (s *unitSvc) ToBaseQuantity(ctx context.Context, itemID int, qty float64, unit string) (int, error) {
+	item, err := s.db.GetByID(ctx, itemID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return 0, fmt.Errorf("item с id %d не найден", itemID)
+		}
+
+		return 0, fmt.Errorf("db.GetByID: %w", err)
+	}
+
+	return uom.ToBase(item.BaseUnit, item.Units, qty, unit)
+}
@@ -0,0 +1,120 @@
+package unitsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestUnitSvc_SetItemUnits - тесты для метода SetItemUnits
+func TestUnitSvc_SetItemUnits_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	resolved := []models.ItemUnit{
+		{Code: "case", Factor: 12},
+		{Code: "pallet", Factor: 480},
+	}
+
+	mockDB.EXPECT().
+		SetItemUnits(mock.Anything, 1, 5, "each", resolved).
+		Return(nil)
+
+	units, err := svc.SetItemUnits(context.Background(), 1, 5, "", []models.ItemUnit{
+		{Code: "case", Factor: 12},
+		{Code: "pallet", Factor: 40, Of: "case"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resolved, units)
+}
+
+func TestUnitSvc_SetItemUnits_ErrInvalidUnit(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	units, err := svc.SetItemUnits(context.Background(), 1, 5, "each", []models.ItemUnit{
+		{Code: "Case", Factor: 12},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, units)
+	assert.Contains(t, err.Error(), "некорректная единица измерения Case")
+}
+
+func TestUnitSvc_SetItemUnits_ErrBaseUnitWithStock(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		SetItemUnits(mock.Anything, 1, 5, "g", []models.ItemUnit{{Code: "kg", Factor: 1000}}).
+		Return(fmt.Errorf("нельзя изменить базовую единицу item 5 с each на g при ненулевом остатке"))
+
+	_, err := svc.SetItemUnits(context.Background(), 1, 5, "g", []models.ItemUnit{{Code: "kg", Factor: 1000}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя изменить базовую единицу")
+}
+
+func TestUnitSvc_SetItemUnits_ErrDBFailed(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		SetItemUnits(mock.Anything, 1, 5, "each", []models.ItemUnit{}).
+		Return(fmt.Errorf("database error"))
+
+	_, err := svc.SetItemUnits(context.Background(), 1, 5, "each", nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.SetItemUnits")
+}
+
+// TestUnitSvc_ToBaseQuantity - тесты для метода ToBaseQuantity
+func TestUnitSvc_ToBaseQuantity_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 5).
+		Return(&models.Item{ID: 5, BaseUnit: "each", Units: []models.ItemUnit{{Code: "case", Factor: 12}}}, nil)
+
+	qty, err := svc.ToBaseQuantity(context.Background(), 5, 2.5, "case")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30, qty)
+}
+
+func TestUnitSvc_ToBaseQuantity_ErrUnknownUnit(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 5).
+		Return(&models.Item{ID: 5, BaseUnit: "each"}, nil)
+
+	_, err := svc.ToBaseQuantity(context.Background(), 5, 1, "pallet")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная единица измерения pallet")
+}
+
+func TestUnitSvc_ToBaseQuantity_ErrItemNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetByID(mock.Anything, 404).
+		Return(nil, fmt.Errorf("item с id 404 не найден"))
+
+	_, err := svc.ToBaseQuantity(context.Background(), 404, 1, "case")
+
+	assert.Error(t, err)
+	assert.Equal(t, "item с id 404 не найден", err.Error())
+}
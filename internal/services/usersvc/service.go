@@ -0,0 +1,86 @@
+package usersvc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.UserService = (*userSvc)(nil)
+
+type userSvc struct {
+	db     infra.Database
+	policy models.ApprovalPolicy
+}
+
+// New - конструктор нового userSvc.
+// policy определяет, ждет ли смена роли согласования вторым пользователем.
+func New(db infra.Database, policy models.ApprovalPolicy) services.UserService {
+	return &userSvc{db: db, policy: policy}
+}
+
+// ChangeUserRole - метод для смены роли пользователя id.
+// Собственную роль сменить нельзя. Если смена роли требует согласования, возвращается запрос на согласование.
+// Новая роль попадает в токен при следующем входе пользователя.
+func (s *userSvc) ChangeUserRole(ctx context.Context, userID, id int, role string) (*models.ChangeRequest, error) {
+	switch role {
+	case models.RoleAdmin, models.RoleManager, models.RoleViewer:
+	default:
+		return nil, fmt.Errorf("некорректная роль %q", role)
+	}
+	if id == userID {
+		return nil, fmt.Errorf("нельзя изменить собственную роль")
+	}
+
+	user, err := s.db.GetUserByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetUserByID: %w", err)
+	}
+	if user.Role == role {
+		return nil, fmt.Errorf("некорректная роль: у пользователя %s уже роль %s", user.Username, role)
+	}
+
+	if s.policy.Requires(models.OpUserRole) {
+		payload, err := json.Marshal(models.RoleChange{FromRole: user.Role, Role: role})
+		if err != nil {
+			return nil, fmt.Errorf("json.Marshal: %w", err)
+		}
+
+		cr := &models.ChangeRequest{
+			Operation:   models.OpUserRole,
+			TargetID:    id,
+			Payload:     payload,
+			Summary:     fmt.Sprintf("смена роли пользователя %s: %s → %s", user.Username, user.Role, role),
+			Status:      models.ChangeRequestPending,
+			RequestedBy: userID,
+		}
+		if cr.ID, err = s.db.CreateChangeRequest(ctx, cr); err != nil {
+			if strings.Contains(err.Error(), "нельзя") {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("db.CreateChangeRequest: %w", err)
+		}
+
+		return cr, nil
+	}
+
+	if err := s.db.SetUserRole(ctx, id, role); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.SetUserRole: %w", err)
+	}
+
+	return nil, nil
+}
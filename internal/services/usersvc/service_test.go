@@ -0,0 +1,84 @@
+package usersvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestUserSvc_ChangeUserRole - тесты для метода ChangeUserRole
+func TestUserSvc_ChangeUserRole_OKDirect(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, models.ApprovalPolicy{})
+
+	mockDB.EXPECT().
+		GetUserByID(mock.Anything, 3).
+		Return(&models.User{ID: 3, Username: "viewer", Role: models.RoleViewer}, nil)
+	mockDB.EXPECT().
+		SetUserRole(mock.Anything, 3, models.RoleManager).
+		Return(nil)
+
+	cr, err := svc.ChangeUserRole(context.Background(), 1, 3, models.RoleManager)
+
+	assert.NoError(t, err)
+	assert.Nil(t, cr)
+}
+
+func TestUserSvc_ChangeUserRole_OKPendingApproval(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, models.ApprovalPolicy{Operations: []string{models.OpUserRole}})
+
+	mockDB.EXPECT().
+		GetUserByID(mock.Anything, 3).
+		Return(&models.User{ID: 3, Username: "viewer", Role: models.RoleViewer}, nil)
+	mockDB.EXPECT().
+		CreateChangeRequest(mock.Anything, mock.MatchedBy(func(cr *models.ChangeRequest) bool {
+			return cr.Operation == models.OpUserRole && cr.TargetID == 3 && cr.RequestedBy == 1
+		})).
+		Return(6, nil)
+
+	cr, err := svc.ChangeUserRole(context.Background(), 1, 3, models.RoleAdmin)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, cr.ID)
+	assert.JSONEq(t, `{"FromRole":"viewer","Role":"admin"}`, string(cr.Payload))
+}
+
+func TestUserSvc_ChangeUserRole_ErrOwnRole(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, models.ApprovalPolicy{})
+
+	_, err := svc.ChangeUserRole(context.Background(), 1, 1, models.RoleViewer)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя изменить собственную роль")
+}
+
+func TestUserSvc_ChangeUserRole_ErrRole(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, models.ApprovalPolicy{})
+
+	_, err := svc.ChangeUserRole(context.Background(), 1, 3, "root")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная роль")
+}
+
+func TestUserSvc_ChangeUserRole_ErrSameRole(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, models.ApprovalPolicy{})
+
+	mockDB.EXPECT().
+		GetUserByID(mock.Anything, 3).
+		Return(&models.User{ID: 3, Username: "viewer", Role: models.RoleViewer}, nil)
+
+	_, err := svc.ChangeUserRole(context.Background(), 1, 3, models.RoleViewer)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "уже роль")
+}
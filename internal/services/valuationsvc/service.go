@@ -0,0 +1,169 @@
+package valuationsvc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.ValuationService = (*valuationSvc)(nil)
+
+type valuationSvc struct {
+	db infra.Database
+}
+
+// New - конструктор нового valuationSvc.
+func New(db infra.Database) services.ValuationService {
+	return &valuationSvc{db: db}
+}
+
+// GetDefaultMethod - метод для получения метода оценки запасов по умолчанию.
+func (s *valuationSvc) GetDefaultMethod(ctx context.Context) (string, error) {
+	method, err := s.db.GetDefaultValuationMethod(ctx)
+	if err != nil {
+		return "", fmt.Errorf("db.GetDefaultValuationMethod: %w", err)
+	}
+
+	return method, nil
+}
+
+// SetDefaultMethod - метод для смены метода оценки запасов по умолчанию.
+// Новый метод применяется к следующим расходам items без собственного метода.
+func (s *valuationSvc) SetDefaultMethod(ctx context.Context, method string) error {
+	if !validMethod(method) {
+		return fmt.Errorf("некорректный метод оценки %q", method)
+	}
+
+	if err := s.db.SetDefaultValuationMethod(ctx, method); err != nil {
+		return fmt.Errorf("db.SetDefaultValuationMethod: %w", err)
+	}
+
+	return nil
+}
+
+// SetItemMethod - метод для назначения метода оценки item, пустой метод - метод по умолчанию.
+func (s *valuationSvc) SetItemMethod(ctx context.Context, userID, itemID int, method string) error {
+	if method != "" && !validMethod(method) {
+		return fmt.Errorf("некорректный метод оценки %q", method)
+	}
+
+	if err := s.db.SetItemValuationMethod(ctx, userID, itemID, method); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return err
+		}
+
+		return fmt.Errorf("db.SetItemValuationMethod: %w", err)
+	}
+
+	return nil
+}
+
+// GetItemCost - метод для получения стоимостного учета item.
+func (s *valuationSvc) GetItemCost(ctx context.Context, itemID int) (*models.ItemCost, error) {
+	cost, err := s.db.GetItemCost(ctx, itemID)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetItemCost: %w", err)
+	}
+
+	return cost, nil
+}
+
+// GetValuation - метод для получения стоимости запаса на конец дня asOf всего и по категориям.
+// asOf == nil - на текущий момент. Items считаются по непосредственной категории.
+// Суммы пересчитываются из базовой валюты в currency по курсу на дату asOf, пустая валюта - базовая.
+func (s *valuationSvc) GetValuation(ctx context.Context, asOf *time.Time, currency string) (*models.ValuationReport, error) {
+	report := models.ValuationReport{AsOf: time.Now(), Rate: models.RateScale}
+	before := report.AsOf
+	if asOf != nil {
+		report.AsOf = *asOf
+		before = asOf.AddDate(0, 0, 1)
+	}
+
+	base, err := s.db.GetBaseCurrency(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db.GetBaseCurrency: %w", err)
+	}
+	report.Currency = base
+	if currency != "" {
+		if report.Currency, err = models.ParseCurrencyCode(currency); err != nil {
+			return nil, err
+		}
+	}
+	if report.Currency != base {
+		if report.Rate, err = s.db.GetCurrencyRate(ctx, report.Currency, report.AsOf); err != nil {
+			if strings.Contains(err.Error(), "не найден") {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("db.GetCurrencyRate: %w", err)
+		}
+	}
+
+	valuations, err := s.db.ListItemValuations(ctx, before)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListItemValuations: %w", err)
+	}
+
+	categories := make(map[int]*models.CategoryValuation)
+	for _, v := range valuations {
+		if v.Quantity == 0 && v.Value == 0 {
+			continue
+		}
+
+		key := 0
+		if v.CategoryID != nil {
+			key = *v.CategoryID
+		}
+		category, ok := categories[key]
+		if !ok {
+			category = &models.CategoryValuation{CategoryID: v.CategoryID, CategoryName: v.CategoryName}
+			categories[key] = category
+		}
+		category.Items++
+		category.Quantity += v.Quantity
+		category.Value += v.Value
+
+		report.Items++
+		report.Quantity += v.Quantity
+	}
+
+	// Итог - сумма пересчитанных категорий, чтобы округление не расходилось с разбивкой.
+	report.Categories = make([]models.CategoryValuation, 0, len(categories))
+	for _, category := range categories {
+		if report.Currency != base {
+			category.Value = category.Value.ConvertTo(report.Rate)
+		}
+		report.Value += category.Value
+		report.Categories = append(report.Categories, *category)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		a, b := report.Categories[i], report.Categories[j]
+		if a.Value != b.Value {
+			return a.Value > b.Value
+		}
+
+		return a.CategoryName < b.CategoryName
+	})
+
+	return &report, nil
+}
+
+// validMethod - проверка метода оценки запасов.
+func validMethod(method string) bool {
+	switch method {
+	case models.ValuationFIFO, models.ValuationLIFO, models.ValuationWAC:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,193 @@
+package valuationsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// TestValuationSvc_GetValuation - тесты для метода GetValuation
+func TestValuationSvc_GetValuation_OKByCategory(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	electronics, tools := 1, 2
+	asOf := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	mockDB.EXPECT().
+		GetBaseCurrency(mock.Anything).
+		Return("RUB", nil)
+	mockDB.EXPECT().
+		ListItemValuations(mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+			return before.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+		})).
+		Return([]models.ItemValuation{
+			{ItemID: 1, CategoryID: &electronics, CategoryName: "Электроника", Quantity: 3, Value: 1500005},
+			{ItemID: 2, CategoryID: &electronics, CategoryName: "Электроника", Quantity: 2, Value: 99995},
+			{ItemID: 3, CategoryID: &tools, CategoryName: "Инструменты", Quantity: 0, Value: 0},
+			{ItemID: 4, Quantity: 10, Value: 250000},
+		}, nil)
+
+	report, err := svc.GetValuation(context.Background(), &asOf, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, asOf, report.AsOf)
+	assert.Equal(t, "RUB", report.Currency)
+	assert.Equal(t, 3, report.Items)
+	assert.Equal(t, 15, report.Quantity)
+	assert.Equal(t, "185.00", report.Value.String())
+	assert.Len(t, report.Categories, 2)
+	assert.Equal(t, &electronics, report.Categories[0].CategoryID)
+	assert.Equal(t, 2, report.Categories[0].Items)
+	assert.Equal(t, "160.00", report.Categories[0].Value.String())
+	assert.Nil(t, report.Categories[1].CategoryID)
+	assert.Equal(t, "25.00", report.Categories[1].Value.String())
+}
+
+func TestValuationSvc_GetValuation_ErrDB(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetBaseCurrency(mock.Anything).
+		Return("RUB", nil)
+	mockDB.EXPECT().
+		ListItemValuations(mock.Anything, mock.Anything).
+		Return(nil, errors.New("db error"))
+
+	_, err := svc.GetValuation(context.Background(), nil, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db.ListItemValuations")
+}
+
+func TestValuationSvc_GetValuation_OKInCurrency(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	electronics := 1
+	asOf := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	mockDB.EXPECT().
+		GetBaseCurrency(mock.Anything).
+		Return("RUB", nil)
+	mockDB.EXPECT().
+		GetCurrencyRate(mock.Anything, "EUR", asOf).
+		Return(models.Rate(9850000000), nil)
+	mockDB.EXPECT().
+		ListItemValuations(mock.Anything, mock.Anything).
+		Return([]models.ItemValuation{
+			{ItemID: 1, CategoryID: &electronics, CategoryName: "Электроника", Quantity: 2, Value: 19700000},
+			{ItemID: 2, Quantity: 1, Value: 10000},
+		}, nil)
+
+	report, err := svc.GetValuation(context.Background(), &asOf, "eur")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", report.Currency)
+	assert.Equal(t, "98.5", report.Rate.String())
+	assert.Equal(t, "20.00", report.Categories[0].Value.String())
+	assert.Equal(t, "0.0102", report.Categories[1].Value.String())
+	assert.Equal(t, "20.0102", report.Value.String())
+}
+
+func TestValuationSvc_GetValuation_ErrNoRate(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetBaseCurrency(mock.Anything).
+		Return("RUB", nil)
+	mockDB.EXPECT().
+		GetCurrencyRate(mock.Anything, "CNY", mock.Anything).
+		Return(models.Rate(0), errors.New("курс валюты CNY на 2026-03-31 не найден"))
+
+	_, err := svc.GetValuation(context.Background(), nil, "CNY")
+
+	assert.Error(t, err)
+	assert.Equal(t, "курс валюты CNY на 2026-03-31 не найден", err.Error())
+}
+
+// TestValuationSvc_SetDefaultMethod - тесты для метода SetDefaultMethod
+func TestValuationSvc_SetDefaultMethod_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		SetDefaultValuationMethod(mock.Anything, models.ValuationWAC).
+		Return(nil)
+
+	err := svc.SetDefaultMethod(context.Background(), models.ValuationWAC)
+
+	assert.NoError(t, err)
+}
+
+func TestValuationSvc_SetDefaultMethod_ErrInvalidMethod(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.SetDefaultMethod(context.Background(), "avg")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный метод оценки")
+}
+
+// TestValuationSvc_SetItemMethod - тесты для метода SetItemMethod
+func TestValuationSvc_SetItemMethod_OKResetToDefault(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		SetItemValuationMethod(mock.Anything, 1, 5, "").
+		Return(nil)
+
+	err := svc.SetItemMethod(context.Background(), 1, 5, "")
+
+	assert.NoError(t, err)
+}
+
+func TestValuationSvc_SetItemMethod_ErrInvalidMethod(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	err := svc.SetItemMethod(context.Background(), 1, 5, "FIFO")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректный метод оценки")
+}
+
+func TestValuationSvc_SetItemMethod_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		SetItemValuationMethod(mock.Anything, 1, 999, models.ValuationLIFO).
+		Return(errors.New("item с id 999 не найден"))
+
+	err := svc.SetItemMethod(context.Background(), 1, 999, models.ValuationLIFO)
+
+	assert.Error(t, err)
+	assert.Equal(t, "item с id 999 не найден", err.Error())
+}
+
+// TestValuationSvc_GetItemCost - тесты для метода GetItemCost
+func TestValuationSvc_GetItemCost_ErrNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB)
+
+	mockDB.EXPECT().
+		GetItemCost(mock.Anything, 999).
+		Return(nil, errors.New("item с id 999 не найден"))
+
+	_, err := svc.GetItemCost(context.Background(), 999)
+
+	assert.Error(t, err)
+	assert.Equal(t, "item с id 999 не найден", err.Error())
+}
@@ -0,0 +1,270 @@
+package writeoffsvc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/sunr3d/warehouse-control/internal/interfaces/infra"
+	"github.com/sunr3d/warehouse-control/internal/interfaces/services"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+var _ services.WriteOffService = (*writeOffSvc)(nil)
+
+type writeOffSvc struct {
+	db            infra.Database
+	notifier      services.StockNotifier
+	approvalQty   int
+	approvalValue models.Money
+}
+
+// New - конструктор нового writeOffSvc.
+// Списание с количеством больше approvalQty или стоимостью больше approvalValue ждет согласования
+// вторым администратором. notifier получает сигналы об изменении доступного остатка, nil - без сигналов.
+func New(db infra.Database, notifier services.StockNotifier, approvalQty int, approvalValue models.Money) services.WriteOffService {
+	return &writeOffSvc{db: db, notifier: notifier, approvalQty: approvalQty, approvalValue: approvalValue}
+}
+
+// CreateWriteOff - метод для оформления списания.
+// Строки оцениваются по слоям себестоимости, списание в пределах порогов проводится сразу.
+func (s *writeOffSvc) CreateWriteOff(ctx context.Context, userID int, wo *models.WriteOff) (int, string, error) {
+	if !validReason(wo.Reason) {
+		return 0, "", fmt.Errorf("некорректная причина списания %q", wo.Reason)
+	}
+	if wo.SourceStatus == "" {
+		wo.SourceStatus = models.StockAvailable
+	}
+	if !validSourceStatus(wo.SourceStatus) {
+		return 0, "", fmt.Errorf("некорректный статус запаса %q", wo.SourceStatus)
+	}
+	if len(wo.Lines) == 0 {
+		return 0, "", fmt.Errorf("некорректное списание: нет строк")
+	}
+
+	type lineKey struct{ itemID, locationID int }
+	seen := make(map[lineKey]struct{}, len(wo.Lines))
+	serials := make(map[string]struct{})
+	for i, line := range wo.Lines {
+		if line.Quantity <= 0 {
+			return 0, "", fmt.Errorf("некорректное количество %d для item %d: должно быть больше 0", line.Quantity, line.ItemID)
+		}
+		if line.LocationID != nil && wo.SourceStatus == models.StockAvailable {
+			return 0, "", fmt.Errorf("некорректное списание: ячейка указывается только при списании из статуса запаса")
+		}
+
+		key := lineKey{itemID: line.ItemID}
+		if line.LocationID != nil {
+			key.locationID = *line.LocationID
+		}
+		if _, ok := seen[key]; ok {
+			return 0, "", fmt.Errorf("некорректное списание: item %d указан дважды", line.ItemID)
+		}
+		seen[key] = struct{}{}
+
+		for j, serial := range line.Serials {
+			serial = strings.TrimSpace(serial)
+			if serial == "" {
+				return 0, "", fmt.Errorf("некорректное списание: пустой серийный номер для item %d", line.ItemID)
+			}
+			if _, ok := serials[serial]; ok {
+				return 0, "", fmt.Errorf("некорректное списание: серийный номер %s указан дважды", serial)
+			}
+			serials[serial] = struct{}{}
+			wo.Lines[i].Serials[j] = serial
+		}
+	}
+
+	costs, err := s.db.ValueWriteOffLines(ctx, wo.Lines)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return 0, "", err
+		}
+
+		return 0, "", fmt.Errorf("db.ValueWriteOffLines: %w", err)
+	}
+	for i, cost := range costs {
+		wo.Lines[i].SetCost(cost)
+	}
+
+	wo.Note = strings.TrimSpace(wo.Note)
+	wo.CreatedBy = userID
+	wo.Status = models.WriteOffPosted
+	if s.needsApproval(wo) {
+		wo.Status = models.WriteOffPending
+	}
+
+	id, number, err := s.db.CreateWriteOff(ctx, wo)
+	if err != nil {
+		if knownError(err) {
+			return 0, "", err
+		}
+
+		return 0, "", fmt.Errorf("db.CreateWriteOff: %w", err)
+	}
+
+	if wo.Status == models.WriteOffPosted {
+		s.notify(wo)
+	}
+
+	return id, number, nil
+}
+
+// GetWriteOffs - метод для получения списаний по статусу, пустой статус - все.
+func (s *writeOffSvc) GetWriteOffs(ctx context.Context, status string) ([]models.WriteOff, error) {
+	switch status {
+	case "", models.WriteOffPending, models.WriteOffPosted, models.WriteOffRejected:
+	default:
+		return nil, fmt.Errorf("некорректный статус списания %s", status)
+	}
+
+	wos, err := s.db.ListWriteOffs(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("db.ListWriteOffs: %w", err)
+	}
+
+	return wos, nil
+}
+
+// GetWriteOff - метод для получения списания со строками и подтверждающими файлами.
+func (s *writeOffSvc) GetWriteOff(ctx context.Context, id int) (*models.WriteOff, error) {
+	wo, err := s.db.GetWriteOff(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetWriteOff: %w", err)
+	}
+
+	return wo, nil
+}
+
+// ApproveWriteOff - метод для согласования и проведения списания пользователем, не являющимся автором.
+func (s *writeOffSvc) ApproveWriteOff(ctx context.Context, userID, id int, note string) error {
+	wo, err := s.db.ApproveWriteOff(ctx, userID, id, strings.TrimSpace(note))
+	if err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.ApproveWriteOff: %w", err)
+	}
+
+	s.notify(wo)
+
+	return nil
+}
+
+// RejectWriteOff - метод для отклонения списания, причина обязательна.
+func (s *writeOffSvc) RejectWriteOff(ctx context.Context, userID, id int, note string) error {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return fmt.Errorf("некорректное отклонение: не указана причина")
+	}
+
+	if err := s.db.RejectWriteOff(ctx, userID, id, note); err != nil {
+		if knownError(err) {
+			return err
+		}
+
+		return fmt.Errorf("db.RejectWriteOff: %w", err)
+	}
+
+	return nil
+}
+
+// AddEvidence - метод для прикрепления подтверждающего файла к списанию.
+// Принимаются изображения JPEG, PNG и PDF, тип определяется по содержимому.
+func (s *writeOffSvc) AddEvidence(ctx context.Context, userID int, evidence *models.WriteOffEvidence) (int, error) {
+	if len(evidence.Data) == 0 {
+		return 0, fmt.Errorf("некорректный файл: файл пустой")
+	}
+	if len(evidence.Data) > models.WriteOffEvidenceMaxSize {
+		return 0, fmt.Errorf("некорректный файл: размер больше %d байт", models.WriteOffEvidenceMaxSize)
+	}
+
+	contentType := http.DetectContentType(evidence.Data)
+	switch contentType {
+	case "image/jpeg", "image/png", "application/pdf":
+	default:
+		return 0, fmt.Errorf("некорректный файл: тип %s не поддерживается, допустимы JPEG, PNG и PDF", contentType)
+	}
+	evidence.ContentType = contentType
+
+	evidence.FileName = filepath.Base(strings.TrimSpace(evidence.FileName))
+	if evidence.FileName == "." || evidence.FileName == string(filepath.Separator) {
+		evidence.FileName = "evidence"
+	}
+	evidence.UploadedBy = &userID
+
+	id, err := s.db.AddWriteOffEvidence(ctx, evidence)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("db.AddWriteOffEvidence: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetEvidence - метод для получения подтверждающего файла списания с содержимым.
+func (s *writeOffSvc) GetEvidence(ctx context.Context, writeOffID, id int) (*models.WriteOffEvidence, error) {
+	evidence, err := s.db.GetWriteOffEvidence(ctx, writeOffID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("db.GetWriteOffEvidence: %w", err)
+	}
+
+	return evidence, nil
+}
+
+// needsApproval - превышает ли списание пороги количества или стоимости.
+func (s *writeOffSvc) needsApproval(wo *models.WriteOff) bool {
+	return wo.TotalQty() > s.approvalQty || wo.TotalValue() > s.approvalValue
+}
+
+// notify - сигнал об изменении доступного остатка по items проведенного списания.
+func (s *writeOffSvc) notify(wo *models.WriteOff) {
+	if s.notifier == nil || wo.SourceStatus != models.StockAvailable {
+		return
+	}
+	for _, line := range wo.Lines {
+		s.notifier.Notify(line.ItemID)
+	}
+}
+
+// knownError - ошибки, которые отдаются клиенту как есть.
+func knownError(err error) bool {
+	return strings.Contains(err.Error(), "не найден") ||
+		strings.Contains(err.Error(), "нельзя") ||
+		strings.Contains(err.Error(), "недостаточно") ||
+		strings.Contains(err.Error(), "некорректн")
+}
+
+// validReason - проверка причины списания.
+func validReason(reason string) bool {
+	switch reason {
+	case models.WriteOffDamaged, models.WriteOffExpired, models.WriteOffTheft, models.WriteOffLost:
+		return true
+	}
+
+	return false
+}
+
+// validSourceStatus - проверка статуса запаса, из которого списывается item.
+func validSourceStatus(status string) bool {
+	switch status {
+	case models.StockAvailable, models.StockQuarantine, models.StockDamaged, models.StockQCHold, models.StockBlocked:
+		return true
+	}
+
+	return false
+}
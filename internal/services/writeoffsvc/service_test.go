@@ -0,0 +1,213 @@
+package writeoffsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/warehouse-control/mocks"
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+// approvalValue - порог стоимости списания 10000 в базовой валюте.
+const approvalValue = 10000 * models.MoneyScale
+
+// TestWriteOffSvc_CreateWriteOff - тесты для метода CreateWriteOff
+func TestWriteOffSvc_CreateWriteOff_OKPosted(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier, 20, approvalValue)
+
+	mockDB.EXPECT().
+		ValueWriteOffLines(mock.Anything, []models.WriteOffLine{{ItemID: 5, Quantity: 3}}).
+		Return([]models.Money{4500000}, nil)
+	mockDB.EXPECT().
+		CreateWriteOff(mock.Anything, mock.MatchedBy(func(wo *models.WriteOff) bool {
+			return wo.Status == models.WriteOffPosted && wo.CreatedBy == 1 &&
+				wo.SourceStatus == models.StockAvailable && wo.Lines[0].UnitCost == 1500000 && wo.Lines[0].TotalCost == 4500000
+		})).
+		Return(7, "WO-000007", nil)
+	notifier.EXPECT().Notify(5).Return()
+
+	id, number, err := svc.CreateWriteOff(context.Background(), 1, &models.WriteOff{
+		Reason: models.WriteOffDamaged,
+		Lines:  []models.WriteOffLine{{ItemID: 5, Quantity: 3}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, id)
+	assert.Equal(t, "WO-000007", number)
+}
+
+func TestWriteOffSvc_CreateWriteOff_OKPendingByValue(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, mocks.NewStockNotifier(t), 20, approvalValue)
+
+	mockDB.EXPECT().
+		ValueWriteOffLines(mock.Anything, mock.Anything).
+		Return([]models.Money{120000000, 100000}, nil)
+	mockDB.EXPECT().
+		CreateWriteOff(mock.Anything, mock.MatchedBy(func(wo *models.WriteOff) bool {
+			return wo.Status == models.WriteOffPending && wo.TotalValue() == 120100000
+		})).
+		Return(8, "WO-000008", nil)
+
+	_, _, err := svc.CreateWriteOff(context.Background(), 1, &models.WriteOff{
+		Reason: models.WriteOffTheft,
+		Lines: []models.WriteOffLine{
+			{ItemID: 5, Quantity: 3},
+			{ItemID: 6, Quantity: 1},
+		},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestWriteOffSvc_CreateWriteOff_OKPendingByQty(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 20, approvalValue)
+
+	mockDB.EXPECT().
+		ValueWriteOffLines(mock.Anything, mock.Anything).
+		Return([]models.Money{0}, nil)
+	mockDB.EXPECT().
+		CreateWriteOff(mock.Anything, mock.MatchedBy(func(wo *models.WriteOff) bool {
+			return wo.Status == models.WriteOffPending
+		})).
+		Return(9, "WO-000009", nil)
+
+	_, _, err := svc.CreateWriteOff(context.Background(), 1, &models.WriteOff{
+		Reason: models.WriteOffExpired,
+		Lines:  []models.WriteOffLine{{ItemID: 5, Quantity: 21}},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestWriteOffSvc_CreateWriteOff_ErrReason(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 20, approvalValue)
+
+	_, _, err := svc.CreateWriteOff(context.Background(), 1, &models.WriteOff{
+		Reason: "shrinkage",
+		Lines:  []models.WriteOffLine{{ItemID: 5, Quantity: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "некорректная причина списания")
+}
+
+func TestWriteOffSvc_CreateWriteOff_ErrLocationForAvailable(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 20, approvalValue)
+
+	locationID := 3
+	_, _, err := svc.CreateWriteOff(context.Background(), 1, &models.WriteOff{
+		Reason: models.WriteOffLost,
+		Lines:  []models.WriteOffLine{{ItemID: 5, LocationID: &locationID, Quantity: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ячейка указывается только")
+}
+
+func TestWriteOffSvc_CreateWriteOff_ErrItemNotFound(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 20, approvalValue)
+
+	mockDB.EXPECT().
+		ValueWriteOffLines(mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("item с id %d не найден", 404))
+
+	_, _, err := svc.CreateWriteOff(context.Background(), 1, &models.WriteOff{
+		Reason: models.WriteOffLost,
+		Lines:  []models.WriteOffLine{{ItemID: 404, Quantity: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+// TestWriteOffSvc_ApproveWriteOff - тесты для метода ApproveWriteOff
+func TestWriteOffSvc_ApproveWriteOff_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	notifier := mocks.NewStockNotifier(t)
+	svc := New(mockDB, notifier, 20, approvalValue)
+
+	mockDB.EXPECT().
+		ApproveWriteOff(mock.Anything, 2, 7, "акт проверен").
+		Return(&models.WriteOff{
+			ID:           7,
+			SourceStatus: models.StockAvailable,
+			Lines:        []models.WriteOffLine{{ItemID: 5, Quantity: 30}},
+		}, nil)
+	notifier.EXPECT().Notify(5).Return()
+
+	err := svc.ApproveWriteOff(context.Background(), 2, 7, " акт проверен ")
+
+	assert.NoError(t, err)
+}
+
+func TestWriteOffSvc_ApproveWriteOff_ErrOwnDocument(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 20, approvalValue)
+
+	mockDB.EXPECT().
+		ApproveWriteOff(mock.Anything, 1, 7, "").
+		Return(nil, fmt.Errorf("нельзя согласовать списание WO-000007: автор не может согласовать собственный документ"))
+
+	err := svc.ApproveWriteOff(context.Background(), 1, 7, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нельзя согласовать")
+}
+
+// TestWriteOffSvc_RejectWriteOff - тесты для метода RejectWriteOff
+func TestWriteOffSvc_RejectWriteOff_ErrNoReason(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 20, approvalValue)
+
+	err := svc.RejectWriteOff(context.Background(), 2, 7, "  ")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не указана причина")
+}
+
+// TestWriteOffSvc_AddEvidence - тесты для метода AddEvidence
+func TestWriteOffSvc_AddEvidence_OK(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 20, approvalValue)
+
+	mockDB.EXPECT().
+		AddWriteOffEvidence(mock.Anything, mock.MatchedBy(func(evidence *models.WriteOffEvidence) bool {
+			return evidence.ContentType == "application/pdf" && evidence.FileName == "act.pdf" &&
+				evidence.UploadedBy != nil && *evidence.UploadedBy == 1
+		})).
+		Return(3, nil)
+
+	id, err := svc.AddEvidence(context.Background(), 1, &models.WriteOffEvidence{
+		WriteOffID: 7,
+		FileName:   "../act.pdf",
+		Data:       []byte("%PDF-1.4 акт"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+}
+
+func TestWriteOffSvc_AddEvidence_ErrType(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	svc := New(mockDB, nil, 20, approvalValue)
+
+	_, err := svc.AddEvidence(context.Background(), 1, &models.WriteOffEvidence{
+		WriteOffID: 7,
+		FileName:   "notes.txt",
+		Data:       []byte("просто текст"),
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не поддерживается")
+}
@@ -0,0 +1,156 @@
+// Package uom - единицы измерения item и пересчет количества между уровнями упаковки.
+package uom
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+const (
+	// factorPrecision - знаков после запятой в коэффициенте пересчета, как в item_units.factor.
+	factorPrecision = 6
+	// tolerance - допустимая погрешность float при проверке целого количества базовых единиц.
+	tolerance = 1e-6
+)
+
+var codePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,15}$`)
+
+// ValidateCode - проверка кода единицы измерения.
+func ValidateCode(code string) error {
+	if !codePattern.MatchString(code) {
+		return fmt.Errorf("некорректная единица измерения %s: допускаются строчные латинские буквы, цифры и _ длиной до 16", code)
+	}
+
+	return nil
+}
+
+// Resolve - проверка уровней упаковки и пересчет коэффициентов в базовые единицы.
+// Единица может быть задана через другую единицу (Of), например pallet = 40 case, case = 12 each.
+func Resolve(baseUnit string, units []models.ItemUnit) ([]models.ItemUnit, error) {
+	if err := ValidateCode(baseUnit); err != nil {
+		return nil, err
+	}
+
+	defs := make(map[string]models.ItemUnit, len(units))
+	for _, u := range units {
+		if err := ValidateCode(u.Code); err != nil {
+			return nil, err
+		}
+		if u.Code == baseUnit {
+			return nil, fmt.Errorf("некорректная единица измерения %s: совпадает с базовой", u.Code)
+		}
+		if _, ok := defs[u.Code]; ok {
+			return nil, fmt.Errorf("некорректная единица измерения %s: указана несколько раз", u.Code)
+		}
+		if u.Factor <= 0 || math.IsInf(u.Factor, 0) || math.IsNaN(u.Factor) {
+			return nil, fmt.Errorf("некорректный коэффициент единицы %s: должен быть больше 0", u.Code)
+		}
+		defs[u.Code] = u
+	}
+
+	resolved := make(map[string]float64, len(defs))
+	var factorOf func(code string, path map[string]bool) (float64, error)
+	factorOf = func(code string, path map[string]bool) (float64, error) {
+		if code == "" || code == baseUnit {
+			return 1, nil
+		}
+		if f, ok := resolved[code]; ok {
+			return f, nil
+		}
+		def, ok := defs[code]
+		if !ok {
+			return 0, fmt.Errorf("некорректная единица измерения %s: не определена", code)
+		}
+		if path[code] {
+			return 0, fmt.Errorf("некорректная единица измерения %s: циклическое определение", code)
+		}
+		path[code] = true
+
+		parent, err := factorOf(def.Of, path)
+		if err != nil {
+			return 0, err
+		}
+		f := round(def.Factor*parent, factorPrecision)
+		if f == 0 {
+			return 0, fmt.Errorf("некорректный коэффициент единицы %s: меньше %s %s", code, strconv.FormatFloat(math.Pow10(-factorPrecision), 'f', -1, 64), baseUnit)
+		}
+		resolved[code] = f
+
+		return f, nil
+	}
+
+	result := make([]models.ItemUnit, 0, len(units))
+	for _, u := range units {
+		f, err := factorOf(u.Code, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, models.ItemUnit{Code: u.Code, Factor: f})
+	}
+
+	return result, nil
+}
+
+// Factor - количество базовых единиц в одной единице unit, пустая unit - базовая.
+func Factor(baseUnit string, units []models.ItemUnit, unit string) (float64, error) {
+	if unit == "" || unit == baseUnit {
+		return 1, nil
+	}
+	for _, u := range units {
+		if u.Code == unit {
+			return u.Factor, nil
+		}
+	}
+
+	return 0, fmt.Errorf("некорректная единица измерения %s: не определена для item, базовая - %s", unit, baseUnit)
+}
+
+// ToBase - пересчет количества qty в единицах unit в целое количество базовых единиц.
+// Количество, не выражающееся целым числом базовых единиц, отклоняется.
+func ToBase(baseUnit string, units []models.ItemUnit, qty float64, unit string) (int, error) {
+	f, err := Factor(baseUnit, units, unit)
+	if err != nil {
+		return 0, err
+	}
+
+	base := qty * f
+	rounded := math.Round(base)
+	if math.Abs(base-rounded) > tolerance*math.Max(1, math.Abs(base)) {
+		return 0, fmt.Errorf("некорректное количество: %s %s не выражается целым числом %s",
+			strconv.FormatFloat(qty, 'f', -1, 64), unitName(unit, baseUnit), baseUnit)
+	}
+	if rounded > math.MaxInt32 || rounded < math.MinInt32 {
+		return 0, fmt.Errorf("некорректное количество: %s %s превышает допустимое",
+			strconv.FormatFloat(qty, 'f', -1, 64), unitName(unit, baseUnit))
+	}
+
+	return int(rounded), nil
+}
+
+// FromBase - пересчет количества базовых единиц в единицы unit с округлением до 6 знаков.
+func FromBase(baseUnit string, units []models.ItemUnit, qty int, unit string) (float64, error) {
+	f, err := Factor(baseUnit, units, unit)
+	if err != nil {
+		return 0, err
+	}
+
+	return round(float64(qty)/f, factorPrecision), nil
+}
+
+func unitName(unit, baseUnit string) string {
+	if unit == "" {
+		return baseUnit
+	}
+
+	return unit
+}
+
+func round(v float64, precision int) float64 {
+	p := math.Pow10(precision)
+
+	return math.Round(v*p) / p
+}
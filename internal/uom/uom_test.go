@@ -0,0 +1,106 @@
+package uom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/warehouse-control/models"
+)
+
+func TestResolve_Chain(t *testing.T) {
+	units, err := Resolve("each", []models.ItemUnit{
+		{Code: "pallet", Factor: 40, Of: "case"},
+		{Code: "case", Factor: 12},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ItemUnit{
+		{Code: "pallet", Factor: 480},
+		{Code: "case", Factor: 12},
+	}, units)
+}
+
+func TestResolve_ErrCycle(t *testing.T) {
+	_, err := Resolve("each", []models.ItemUnit{
+		{Code: "box", Factor: 2, Of: "pack"},
+		{Code: "pack", Factor: 3, Of: "box"},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "циклическое определение")
+}
+
+func TestResolve_ErrUnknownOf(t *testing.T) {
+	_, err := Resolve("each", []models.ItemUnit{{Code: "pallet", Factor: 40, Of: "case"}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "case: не определена")
+}
+
+func TestResolve_ErrSameAsBase(t *testing.T) {
+	_, err := Resolve("each", []models.ItemUnit{{Code: "each", Factor: 1}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "совпадает с базовой")
+}
+
+func TestResolve_ErrFactor(t *testing.T) {
+	_, err := Resolve("each", []models.ItemUnit{{Code: "case", Factor: 0}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "должен быть больше 0")
+}
+
+func TestToBase(t *testing.T) {
+	packs := []models.ItemUnit{{Code: "case", Factor: 12}, {Code: "pallet", Factor: 480}}
+	weight := []models.ItemUnit{{Code: "kg", Factor: 1000}}
+
+	cases := []struct {
+		name     string
+		baseUnit string
+		units    []models.ItemUnit
+		qty      float64
+		unit     string
+		want     int
+	}{
+		{"базовая по умолчанию", "each", packs, 7, "", 7},
+		{"базовая явно", "each", packs, 7, "each", 7},
+		{"паллеты", "each", packs, 2, "pallet", 960},
+		{"половина коробки", "each", packs, 0.5, "case", 6},
+		{"дробные кг", "g", weight, 1.2, "kg", 1200},
+		{"граммовая точность", "g", weight, 0.001, "kg", 1},
+	}
+
+	for _, tc := range cases {
+		got, err := ToBase(tc.baseUnit, tc.units, tc.qty, tc.unit)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+}
+
+func TestToBase_ErrFraction(t *testing.T) {
+	_, err := ToBase("each", []models.ItemUnit{{Code: "case", Factor: 12}}, 0.3, "case")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "0.3 case не выражается целым числом each")
+}
+
+func TestToBase_ErrUnknownUnit(t *testing.T) {
+	_, err := ToBase("each", nil, 1, "box")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не определена для item")
+}
+
+func TestFromBase(t *testing.T) {
+	units := []models.ItemUnit{{Code: "case", Factor: 12}, {Code: "pallet", Factor: 480}}
+
+	got, err := FromBase("each", units, 600, "pallet")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.25, got)
+
+	got, err = FromBase("each", units, 10, "case")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.833333, got)
+}
@@ -0,0 +1,23 @@
+BEGIN;
+-- Резервы товаров под заказы
+CREATE TABLE IF NOT EXISTS reservations (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    order_ref VARCHAR(255),
+    reservation_status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (reservation_status IN ('active', 'released', 'confirmed', 'expired')),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_reservations_item_id ON reservations(item_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_active_expires_at ON reservations(expires_at) WHERE reservation_status = 'active';
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -0,0 +1,45 @@
+BEGIN;
+-- Партии товаров со сроками годности
+CREATE TABLE IF NOT EXISTS item_lots (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    manufactured_at DATE,
+    expires_at DATE,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, lot_number)
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_item_lots_item_id ON item_lots(item_id);
+CREATE INDEX IF NOT EXISTS idx_item_lots_expires_at ON item_lots(expires_at) WHERE quantity > 0;
+
+-- Детали операции в истории изменений (например, списанные партии)
+ALTER TABLE items_history ADD COLUMN IF NOT EXISTS details TEXT;
+
+CREATE OR REPLACE FUNCTION log_item_changes()
+RETURNS TRIGGER AS $$
+DECLARE
+    current_user_id INTEGER;
+    current_details TEXT;
+BEGIN
+    current_user_id := COALESCE(current_setting('warehouse.user_id', TRUE)::INTEGER, 0);
+    current_details := NULLIF(current_setting('warehouse.details', TRUE), '');
+
+    IF (TG_OP = 'INSERT') THEN
+        INSERT INTO items_history (item_id, user_id, operation, old_value, new_value, details, changed_at)
+        VALUES (NEW.id, current_user_id, 'INSERT', NULL, row_to_json(NEW)::TEXT, current_details, CURRENT_TIMESTAMP);
+    ELSIF (TG_OP = 'UPDATE') THEN
+        INSERT INTO items_history (item_id, user_id, operation, old_value, new_value, details, changed_at)
+        VALUES (NEW.id, current_user_id, 'UPDATE', row_to_json(OLD)::TEXT, row_to_json(NEW)::TEXT, current_details, CURRENT_TIMESTAMP);
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
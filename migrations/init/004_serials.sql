@@ -0,0 +1,45 @@
+BEGIN;
+-- Серийный учет товаров
+ALTER TABLE items ADD COLUMN IF NOT EXISTS serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS item_serials (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    serial_number VARCHAR(100) UNIQUE NOT NULL,
+    serial_status VARCHAR(20) NOT NULL DEFAULT 'in_stock' CHECK (serial_status IN ('in_stock', 'reserved', 'shipped', 'returned', 'scrapped')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_item_serials_item_id_status ON item_serials(item_id, serial_status);
+
+-- Количество серийного товара всегда равно числу его серийных номеров на складе
+CREATE OR REPLACE FUNCTION check_serialized_quantity()
+RETURNS TRIGGER AS $$
+DECLARE
+    in_stock_count INTEGER;
+BEGIN
+    IF NEW.serialized THEN
+        SELECT COUNT(*) INTO in_stock_count
+        FROM item_serials
+        WHERE item_id = NEW.id AND serial_status = 'in_stock';
+
+        IF NEW.quantity <> in_stock_count THEN
+            RAISE EXCEPTION 'quantity серийного item % должно совпадать с количеством серийных номеров на складе (%)', NEW.id, in_stock_count
+                USING ERRCODE = 'check_violation';
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER item_serialized_quantity_trigger
+BEFORE INSERT OR UPDATE ON items
+FOR EACH ROW EXECUTE FUNCTION check_serialized_quantity();
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+-- Идентификаторы товаров: артикул и штрихкоды
+ALTER TABLE items ADD COLUMN IF NOT EXISTS sku VARCHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_items_sku ON items (sku);
+
+CREATE TABLE IF NOT EXISTS item_barcodes (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    code VARCHAR(64) NOT NULL,
+    symbology VARCHAR(16) NOT NULL CHECK (symbology IN ('ean13', 'upca', 'code128')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_item_barcodes_code UNIQUE (code)
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_item_barcodes_item_id ON item_barcodes(item_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
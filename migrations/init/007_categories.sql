@@ -0,0 +1,25 @@
+BEGIN;
+-- Дерево категорий товаров
+CREATE TABLE IF NOT EXISTS categories (
+    id SERIAL PRIMARY KEY,
+    parent_id INTEGER REFERENCES categories(id) ON DELETE RESTRICT,
+    category_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS category_id INTEGER
+    CONSTRAINT fk_items_category_id REFERENCES categories(id) ON DELETE SET NULL;
+
+-- Индексы
+-- Название категории уникально среди соседей без учета регистра
+CREATE UNIQUE INDEX IF NOT EXISTS uq_categories_parent_name ON categories(COALESCE(parent_id, 0), LOWER(category_name));
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+CREATE INDEX IF NOT EXISTS idx_items_category_id ON items(category_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
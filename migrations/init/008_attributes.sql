@@ -0,0 +1,26 @@
+BEGIN;
+-- Схема атрибутов товаров, задается для категории и наследуется подкатегориями
+CREATE TABLE IF NOT EXISTS attribute_definitions (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    attr_code VARCHAR(64) NOT NULL,
+    attr_name VARCHAR(255) NOT NULL,
+    attr_type VARCHAR(16) NOT NULL CHECK (attr_type IN ('string', 'number', 'boolean', 'enum')),
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    allowed_values JSONB NOT NULL DEFAULT '[]',
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_attribute_definitions_category_code UNIQUE (category_id, attr_code)
+);
+
+-- Значения атрибутов товара, проверяются по схеме категории
+ALTER TABLE items ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_items_attributes ON items USING GIN (attributes);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+-- Базовая единица учета item, quantity и все движения хранятся в ней
+ALTER TABLE items ADD COLUMN IF NOT EXISTS base_unit VARCHAR(16) NOT NULL DEFAULT 'each';
+
+-- Уровни упаковки item: factor - количество базовых единиц в одной единице unit_code
+CREATE TABLE IF NOT EXISTS item_units (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    unit_code VARCHAR(16) NOT NULL,
+    factor NUMERIC(18, 6) NOT NULL CHECK (factor > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_item_units_item_code UNIQUE (item_id, unit_code)
+);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
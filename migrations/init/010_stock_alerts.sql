@@ -0,0 +1,43 @@
+BEGIN;
+-- Уровни запаса item в базовых единицах: минимум, точка заказа и максимум.
+-- Строка без ячейки задает уровни на весь склад, с ячейкой - на ячейку подбора item
+CREATE TABLE IF NOT EXISTS stock_levels (
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    location_id INTEGER REFERENCES locations(id) ON DELETE CASCADE,
+    min_qty INTEGER NOT NULL CHECK (min_qty >= 0),
+    reorder_point INTEGER NOT NULL,
+    max_qty INTEGER NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_stock_levels_order CHECK (min_qty <= reorder_point AND reorder_point < max_qty)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_stock_levels_item_location
+    ON stock_levels (item_id, (COALESCE(location_id, 0)));
+
+-- Оповещения о пересечении точки заказа
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    location_id INTEGER REFERENCES locations(id) ON DELETE CASCADE,
+    available INTEGER NOT NULL,
+    reorder_point INTEGER NOT NULL,
+    alert_status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (alert_status IN ('open', 'acknowledged', 'resolved')),
+    acknowledged_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    acknowledged_at TIMESTAMP,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Не более одного активного оповещения на item и ячейку, пока остаток не вернется выше точки заказа
+CREATE UNIQUE INDEX IF NOT EXISTS uq_stock_alerts_active_item_location
+    ON stock_alerts (item_id, (COALESCE(location_id, 0)))
+    WHERE alert_status IN ('open', 'acknowledged');
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_status ON stock_alerts (alert_status);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
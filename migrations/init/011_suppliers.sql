@@ -0,0 +1,43 @@
+BEGIN;
+-- Поставщики
+CREATE TABLE IF NOT EXISTS suppliers (
+    id SERIAL PRIMARY KEY,
+    supplier_name VARCHAR(255) NOT NULL,
+    contact_name VARCHAR(255) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    lead_time_days INTEGER NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0),
+    supplier_status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (supplier_status IN ('active', 'inactive', 'blocked')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Названия поставщиков уникальны без учета регистра
+CREATE UNIQUE INDEX IF NOT EXISTS uq_suppliers_name ON suppliers (LOWER(supplier_name));
+
+-- Связи items с поставщиками: артикул поставщика, цена закупки и минимальная партия
+CREATE TABLE IF NOT EXISTS item_suppliers (
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    supplier_id INTEGER NOT NULL REFERENCES suppliers(id) ON DELETE CASCADE,
+    supplier_sku VARCHAR(64) NOT NULL DEFAULT '',
+    unit_cost NUMERIC(14,4) NOT NULL DEFAULT 0 CHECK (unit_cost >= 0),
+    min_order_qty INTEGER NOT NULL DEFAULT 1 CHECK (min_order_qty > 0),
+    preferred BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, supplier_id)
+);
+
+-- Не более одного основного поставщика на item
+CREATE UNIQUE INDEX IF NOT EXISTS uq_item_suppliers_preferred ON item_suppliers (item_id)
+    WHERE preferred;
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_item_suppliers_supplier_id ON item_suppliers (supplier_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
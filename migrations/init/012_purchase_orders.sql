@@ -0,0 +1,52 @@
+BEGIN;
+-- Номера заказов на закупку вида PO-000001
+CREATE SEQUENCE IF NOT EXISTS purchase_order_number_seq;
+
+-- Заказы на закупку
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id SERIAL PRIMARY KEY,
+    po_number VARCHAR(32) NOT NULL UNIQUE DEFAULT 'PO-' || LPAD(nextval('purchase_order_number_seq')::TEXT, 6, '0'),
+    supplier_id INTEGER NOT NULL REFERENCES suppliers(id) ON DELETE RESTRICT,
+    po_status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (po_status IN ('draft', 'sent', 'partially_received', 'received', 'closed')),
+    expected_at DATE,
+    notes TEXT NOT NULL DEFAULT '',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    sent_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Строки заказов в базовых единицах items
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id SERIAL PRIMARY KEY,
+    po_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE RESTRICT,
+    ordered_qty INTEGER NOT NULL CHECK (ordered_qty > 0),
+    received_qty INTEGER NOT NULL DEFAULT 0 CHECK (received_qty >= 0),
+    unit_cost NUMERIC(14,4) NOT NULL DEFAULT 0 CHECK (unit_cost >= 0),
+    CONSTRAINT uq_purchase_order_lines_item UNIQUE (po_id, item_id)
+);
+
+-- Приемки по строкам заказов
+CREATE TABLE IF NOT EXISTS purchase_receipts (
+    id SERIAL PRIMARY KEY,
+    po_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    line_id INTEGER NOT NULL REFERENCES purchase_order_lines(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    received_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_status ON purchase_orders (po_status);
+CREATE INDEX IF NOT EXISTS idx_purchase_order_lines_item_id ON purchase_order_lines (item_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_receipts_po_id ON purchase_receipts (po_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
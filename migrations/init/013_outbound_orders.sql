@@ -0,0 +1,81 @@
+BEGIN;
+-- Основная ячейка подбора item
+ALTER TABLE items ADD COLUMN IF NOT EXISTS pick_location_id INTEGER
+    CONSTRAINT fk_items_pick_location_id REFERENCES locations(id) ON DELETE SET NULL;
+
+-- Номера заказов на отгрузку вида SO-000001
+CREATE SEQUENCE IF NOT EXISTS outbound_order_number_seq;
+
+-- Заказы на отгрузку
+CREATE TABLE IF NOT EXISTS outbound_orders (
+    id SERIAL PRIMARY KEY,
+    order_number VARCHAR(32) NOT NULL UNIQUE DEFAULT 'SO-' || LPAD(nextval('outbound_order_number_seq')::TEXT, 6, '0'),
+    customer_ref VARCHAR(255) NOT NULL DEFAULT '',
+    ship_to TEXT NOT NULL DEFAULT '',
+    order_status VARCHAR(20) NOT NULL DEFAULT 'new' CHECK (order_status IN (
+        'new', 'partially_allocated', 'allocated', 'picking', 'packed', 'partially_shipped', 'shipped', 'cancelled'
+    )),
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Строки заказов в базовых единицах items: заказано >= размещено >= упаковано >= отгружено
+CREATE TABLE IF NOT EXISTS outbound_order_lines (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES outbound_orders(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE RESTRICT,
+    ordered_qty INTEGER NOT NULL CHECK (ordered_qty > 0),
+    allocated_qty INTEGER NOT NULL DEFAULT 0,
+    packed_qty INTEGER NOT NULL DEFAULT 0,
+    shipped_qty INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT uq_outbound_order_lines_item UNIQUE (order_id, item_id),
+    CONSTRAINT chk_outbound_order_lines_qty CHECK (
+        ordered_qty >= allocated_qty AND allocated_qty >= packed_qty AND packed_qty >= shipped_qty AND shipped_qty >= 0
+    )
+);
+
+-- Резервы под строки заказов на отгрузку не истекают
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS outbound_line_id INTEGER
+    REFERENCES outbound_order_lines(id) ON DELETE CASCADE;
+ALTER TABLE reservations ALTER COLUMN expires_at DROP NOT NULL;
+
+-- Отгрузки по заказам
+CREATE TABLE IF NOT EXISTS shipments (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES outbound_orders(id) ON DELETE CASCADE,
+    tracking_ref VARCHAR(255) NOT NULL DEFAULT '',
+    shipped_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    shipped_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS shipment_lines (
+    shipment_id INTEGER NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    line_id INTEGER NOT NULL REFERENCES outbound_order_lines(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (shipment_id, line_id)
+);
+
+-- История статусов заказов на отгрузку
+CREATE TABLE IF NOT EXISTS outbound_order_events (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES outbound_orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_outbound_orders_status ON outbound_orders (order_status);
+CREATE INDEX IF NOT EXISTS idx_outbound_order_lines_item_id ON outbound_order_lines (item_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_outbound_line_id ON reservations (outbound_line_id) WHERE outbound_line_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_shipments_order_id ON shipments (order_id);
+CREATE INDEX IF NOT EXISTS idx_outbound_order_events_order_id ON outbound_order_events (order_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -0,0 +1,53 @@
+BEGIN;
+-- Номера сессий инвентаризации вида CC-000001
+CREATE SEQUENCE IF NOT EXISTS count_session_number_seq;
+
+-- Сессии инвентаризации по ячейке подбора, категории или всему складу
+CREATE TABLE IF NOT EXISTS count_sessions (
+    id SERIAL PRIMARY KEY,
+    session_number VARCHAR(32) NOT NULL UNIQUE DEFAULT 'CC-' || LPAD(nextval('count_session_number_seq')::TEXT, 6, '0'),
+    location_id INTEGER REFERENCES locations(id) ON DELETE SET NULL,
+    category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+    blind BOOLEAN NOT NULL DEFAULT FALSE,
+    recount_threshold INTEGER NOT NULL DEFAULT 0 CHECK (recount_threshold >= 0),
+    session_status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (session_status IN ('open', 'review', 'approved', 'cancelled')),
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    approved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    approved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Строки подсчета: ожидаемый остаток на момент открытия сессии, первый и итоговый подсчет.
+-- Ожидаемый остаток включает запас в статусах held_qty, который лежит на полке вместе с доступным
+CREATE TABLE IF NOT EXISTS count_lines (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES count_sessions(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    expected_qty INTEGER NOT NULL CHECK (expected_qty >= 0),
+    held_qty INTEGER NOT NULL DEFAULT 0 CHECK (held_qty >= 0),
+    first_counted_qty INTEGER CHECK (first_counted_qty >= 0),
+    counted_qty INTEGER CHECK (counted_qty >= 0),
+    count_round INTEGER NOT NULL DEFAULT 0,
+    recount_required BOOLEAN NOT NULL DEFAULT FALSE,
+    counted_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    counted_at TIMESTAMP,
+    CONSTRAINT uq_count_lines_item UNIQUE (session_id, item_id)
+);
+
+-- Пользователи, записывавшие подсчеты в сессию: они не могут ее согласовать
+CREATE TABLE IF NOT EXISTS count_session_counters (
+    session_id INTEGER NOT NULL REFERENCES count_sessions(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (session_id, user_id)
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_count_sessions_status ON count_sessions (session_status);
+CREATE INDEX IF NOT EXISTS idx_count_lines_item_id ON count_lines (item_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
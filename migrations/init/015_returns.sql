@@ -0,0 +1,56 @@
+BEGIN;
+-- Номера возвратов вида RMA-000001
+CREATE SEQUENCE IF NOT EXISTS return_number_seq;
+
+-- Разрешения на возврат по заказам на отгрузку
+CREATE TABLE IF NOT EXISTS return_authorizations (
+    id SERIAL PRIMARY KEY,
+    rma_number VARCHAR(32) NOT NULL UNIQUE DEFAULT 'RMA-' || LPAD(nextval('return_number_seq')::TEXT, 6, '0'),
+    order_id INTEGER NOT NULL REFERENCES outbound_orders(id) ON DELETE RESTRICT,
+    rma_status VARCHAR(20) NOT NULL DEFAULT 'authorized' CHECK (rma_status IN (
+        'authorized', 'partially_received', 'received', 'cancelled'
+    )),
+    note TEXT NOT NULL DEFAULT '',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Строки возврата в базовых единицах items: разрешено >= принято
+CREATE TABLE IF NOT EXISTS return_lines (
+    id SERIAL PRIMARY KEY,
+    rma_id INTEGER NOT NULL REFERENCES return_authorizations(id) ON DELETE CASCADE,
+    order_line_id INTEGER NOT NULL REFERENCES outbound_order_lines(id) ON DELETE RESTRICT,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN (
+        'damaged', 'defective', 'wrong_item', 'not_as_described', 'unwanted', 'other'
+    )),
+    authorized_qty INTEGER NOT NULL CHECK (authorized_qty > 0),
+    received_qty INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT uq_return_lines_order_line UNIQUE (rma_id, order_line_id),
+    CONSTRAINT chk_return_lines_qty CHECK (authorized_qty >= received_qty AND received_qty >= 0)
+);
+
+-- Приемки возвратов с результатом осмотра
+CREATE TABLE IF NOT EXISTS return_receipts (
+    id SERIAL PRIMARY KEY,
+    rma_id INTEGER NOT NULL REFERENCES return_authorizations(id) ON DELETE CASCADE,
+    line_id INTEGER NOT NULL REFERENCES return_lines(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    disposition VARCHAR(20) NOT NULL CHECK (disposition IN ('restock', 'refurbish', 'quarantine', 'scrap')),
+    serial_numbers TEXT[] NOT NULL DEFAULT '{}',
+    note TEXT NOT NULL DEFAULT '',
+    received_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_order_id ON return_authorizations (order_id);
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_status ON return_authorizations (rma_status);
+CREATE INDEX IF NOT EXISTS idx_return_lines_order_line_id ON return_lines (order_line_id);
+CREATE INDEX IF NOT EXISTS idx_return_receipts_rma_id ON return_receipts (rma_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -0,0 +1,38 @@
+BEGIN;
+-- Запас items вне доступного остатка по статусам и ячейкам.
+-- Доступный запас (available) - это items.quantity, здесь хранятся только остальные статусы.
+CREATE TABLE IF NOT EXISTS item_stock_statuses (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    location_id INTEGER REFERENCES locations(id) ON DELETE RESTRICT,
+    stock_status VARCHAR(20) NOT NULL CHECK (stock_status IN ('quarantine', 'damaged', 'qc_hold', 'blocked')),
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Запас без ячейки хранится одной строкой на item и статус
+CREATE UNIQUE INDEX IF NOT EXISTS uq_item_stock_statuses
+    ON item_stock_statuses (item_id, (COALESCE(location_id, 0)), stock_status);
+
+-- Журнал смены статусов запаса, пустой from_status - поступление (например, по возврату)
+CREATE TABLE IF NOT EXISTS stock_status_changes (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    location_id INTEGER REFERENCES locations(id) ON DELETE SET NULL,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    reason TEXT NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_item_stock_statuses_status ON item_stock_statuses (stock_status) WHERE quantity > 0;
+CREATE INDEX IF NOT EXISTS idx_stock_status_changes_item_id ON stock_status_changes (item_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -0,0 +1,55 @@
+BEGIN;
+-- Номера документов списания вида WO-000001
+CREATE SEQUENCE IF NOT EXISTS write_off_number_seq;
+
+-- Документы списания. Списание выше порога ждет согласования вторым администратором.
+CREATE TABLE IF NOT EXISTS write_offs (
+    id SERIAL PRIMARY KEY,
+    wo_number VARCHAR(32) NOT NULL UNIQUE DEFAULT 'WO-' || LPAD(nextval('write_off_number_seq')::TEXT, 6, '0'),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('damaged', 'expired', 'theft', 'lost')),
+    source_status VARCHAR(20) NOT NULL DEFAULT 'available' CHECK (source_status IN (
+        'available', 'quarantine', 'damaged', 'qc_hold', 'blocked'
+    )),
+    note TEXT NOT NULL DEFAULT '',
+    wo_status VARCHAR(20) NOT NULL CHECK (wo_status IN ('pending', 'posted', 'rejected')),
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    decided_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    decision_note TEXT NOT NULL DEFAULT '',
+    decided_at TIMESTAMP,
+    posted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Строки списания в базовых единицах items с себестоимостью единицы на момент оформления
+CREATE TABLE IF NOT EXISTS write_off_lines (
+    id SERIAL PRIMARY KEY,
+    write_off_id INTEGER NOT NULL REFERENCES write_offs(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE RESTRICT,
+    location_id INTEGER REFERENCES locations(id) ON DELETE SET NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost NUMERIC(14,4) NOT NULL DEFAULT 0 CHECK (unit_cost >= 0),
+    serial_numbers TEXT[] NOT NULL DEFAULT '{}'
+);
+
+-- Подтверждающие файлы (фото, акты)
+CREATE TABLE IF NOT EXISTS write_off_evidence (
+    id SERIAL PRIMARY KEY,
+    write_off_id INTEGER NOT NULL REFERENCES write_offs(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    data BYTEA NOT NULL,
+    uploaded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_write_offs_status ON write_offs (wo_status);
+CREATE INDEX IF NOT EXISTS idx_write_off_lines_write_off_id ON write_off_lines (write_off_id);
+CREATE INDEX IF NOT EXISTS idx_write_off_lines_item_id ON write_off_lines (item_id);
+CREATE INDEX IF NOT EXISTS idx_write_off_evidence_write_off_id ON write_off_evidence (write_off_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
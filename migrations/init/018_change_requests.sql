@@ -0,0 +1,28 @@
+BEGIN;
+-- Запросы на согласование чувствительных операций (принцип четырех глаз).
+-- Операция выполняется при согласовании другим пользователем в той же транзакции.
+CREATE TABLE IF NOT EXISTS change_requests (
+    id SERIAL PRIMARY KEY,
+    operation VARCHAR(30) NOT NULL CHECK (operation IN ('item_delete', 'item_adjustment', 'user_role')),
+    target_id INTEGER NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    summary TEXT NOT NULL DEFAULT '',
+    cr_status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (cr_status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    requested_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    decided_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    decision_note TEXT NOT NULL DEFAULT '',
+    decided_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+-- Один ожидающий запрос на операцию с объектом
+CREATE UNIQUE INDEX IF NOT EXISTS uq_change_requests_pending
+    ON change_requests (operation, target_id) WHERE cr_status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_change_requests_status ON change_requests (cr_status);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
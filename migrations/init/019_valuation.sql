@@ -0,0 +1,91 @@
+BEGIN;
+-- Метод оценки запасов по умолчанию, единственная строка.
+CREATE TABLE IF NOT EXISTS valuation_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    default_method VARCHAR(10) NOT NULL DEFAULT 'fifo' CHECK (default_method IN ('fifo', 'lifo', 'wac')),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO valuation_settings (id) VALUES (TRUE) ON CONFLICT DO NOTHING;
+
+-- Метод оценки товара, NULL - метод по умолчанию.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS valuation_method VARCHAR(10)
+    CHECK (valuation_method IN ('fifo', 'lifo', 'wac'));
+
+-- Себестоимость строки списания: оценка по слоям при оформлении, себестоимость расхода после проведения.
+ALTER TABLE write_off_lines ADD COLUMN IF NOT EXISTS total_cost NUMERIC(18, 4) NOT NULL DEFAULT 0;
+
+-- Слои себестоимости: каждый приход создает слой, расход уменьшает остаток слоев.
+CREATE TABLE IF NOT EXISTS cost_layers (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    received_qty INTEGER NOT NULL CHECK (received_qty > 0),
+    remaining_qty INTEGER NOT NULL CHECK (remaining_qty >= 0 AND remaining_qty <= received_qty),
+    unit_cost NUMERIC(14, 4) NOT NULL CHECK (unit_cost >= 0),
+    source TEXT NOT NULL DEFAULT '',
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Стоимостной журнал: приходы и расходы с себестоимостью и остатком после движения.
+-- Остаток включает запас в статусах, поэтому смена статуса в журнал не попадает.
+CREATE TABLE IF NOT EXISTS cost_movements (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    movement_type VARCHAR(10) NOT NULL CHECK (movement_type IN ('receipt', 'issue')),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost NUMERIC(14, 4) NOT NULL,
+    total_cost NUMERIC(18, 4) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    source TEXT NOT NULL DEFAULT '',
+    balance_qty INTEGER NOT NULL CHECK (balance_qty >= 0),
+    balance_value NUMERIC(18, 4) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Начальные слои по текущему остатку: цена последней приемки, иначе основного или самого дешевого поставщика.
+WITH opening AS (
+    SELECT i.id AS item_id,
+        i.quantity + COALESCE((
+            SELECT SUM(s.quantity)
+            FROM item_stock_statuses s
+            WHERE s.item_id = i.id
+        ), 0) AS quantity,
+        COALESCE(
+            (SELECT l.unit_cost
+            FROM purchase_receipts pr
+            JOIN purchase_order_lines l ON l.id = pr.line_id
+            WHERE l.item_id = i.id
+            ORDER BY pr.received_at DESC, pr.id DESC
+            LIMIT 1),
+            (SELECT s.unit_cost
+            FROM item_suppliers s
+            WHERE s.item_id = i.id
+            ORDER BY s.preferred DESC, s.unit_cost
+            LIMIT 1),
+            0) AS unit_cost
+    FROM items i
+    WHERE NOT EXISTS (SELECT 1 FROM cost_movements m WHERE m.item_id = i.id)
+), layers AS (
+    INSERT INTO cost_layers (item_id, received_qty, remaining_qty, unit_cost, source)
+    SELECT item_id, quantity, quantity, unit_cost, 'начальный остаток'
+    FROM opening
+    WHERE quantity > 0
+    RETURNING item_id, received_qty, unit_cost
+)
+INSERT INTO cost_movements (item_id, movement_type, quantity, unit_cost, total_cost, method, source, balance_qty, balance_value)
+SELECT l.item_id, 'receipt', l.received_qty, l.unit_cost, l.unit_cost * l.received_qty,
+    COALESCE(i.valuation_method, (SELECT default_method FROM valuation_settings)), 'начальный остаток', l.received_qty, l.unit_cost * l.received_qty
+FROM layers l
+JOIN items i ON i.id = l.item_id;
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_cost_layers_open ON cost_layers (item_id, id) WHERE remaining_qty > 0;
+CREATE INDEX IF NOT EXISTS idx_cost_movements_item_id ON cost_movements (item_id, id);
+CREATE INDEX IF NOT EXISTS idx_cost_movements_created_at ON cost_movements (created_at);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -0,0 +1,66 @@
+BEGIN;
+-- Валюты, ровно одна из них базовая: в ней ведется стоимостной учет и отчеты.
+CREATE TABLE IF NOT EXISTS currencies (
+    code CHAR(3) PRIMARY KEY CHECK (code ~ '^[A-Z]{3}$'),
+    currency_name VARCHAR(100) NOT NULL,
+    is_base BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_currencies_base ON currencies (is_base) WHERE is_base;
+
+INSERT INTO currencies (code, currency_name, is_base) VALUES
+    ('RUB', 'Российский рубль', TRUE),
+    ('CNY', 'Китайский юань', FALSE),
+    ('EUR', 'Евро', FALSE)
+ON CONFLICT DO NOTHING;
+
+-- Курсы валют: сколько единиц базовой валюты стоит единица валюты, действует с rate_date до следующего курса.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id SERIAL PRIMARY KEY,
+    currency_code CHAR(3) NOT NULL REFERENCES currencies(code) ON DELETE CASCADE,
+    rate_date DATE NOT NULL,
+    rate NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_exchange_rates_currency_date UNIQUE (currency_code, rate_date)
+);
+
+-- Курс валюты на дату: 1 для базовой, иначе последний курс не позже даты, NULL если курса нет.
+CREATE OR REPLACE FUNCTION currency_rate(p_code CHAR(3), p_date DATE)
+RETURNS NUMERIC AS $$
+    SELECT CASE
+        WHEN (SELECT is_base FROM currencies WHERE code = p_code) THEN 1::NUMERIC
+        ELSE (
+            SELECT rate
+            FROM exchange_rates
+            WHERE currency_code = p_code AND rate_date <= p_date
+            ORDER BY rate_date DESC
+            LIMIT 1)
+    END;
+$$ LANGUAGE sql STABLE;
+
+-- Валюта поставщика: в ней указаны цены его товаров и заказы на закупку.
+-- Существующие цены считаются в базовой валюте.
+ALTER TABLE suppliers ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB'
+    CONSTRAINT suppliers_currency_fkey REFERENCES currencies(code);
+
+ALTER TABLE purchase_orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB'
+    CONSTRAINT purchase_orders_currency_fkey REFERENCES currencies(code);
+
+-- Курс пересчета цены строки в базовую валюту на дату приемки.
+ALTER TABLE purchase_receipts ADD COLUMN IF NOT EXISTS exchange_rate NUMERIC(18, 8) NOT NULL DEFAULT 1
+    CHECK (exchange_rate > 0);
+
+-- Себестоимость списаний в базовой валюте на момент оформления.
+ALTER TABLE write_offs ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB'
+    REFERENCES currencies(code);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_date ON exchange_rates (rate_date);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -0,0 +1,46 @@
+BEGIN;
+-- Номера документов сборки и разборки комплектов вида KA-000001
+CREATE SEQUENCE IF NOT EXISTS kit_assembly_number_seq;
+
+-- Состав комплектов: компоненты и их количество на один комплект в базовых единицах
+CREATE TABLE IF NOT EXISTS kit_components (
+    kit_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    component_id INTEGER NOT NULL REFERENCES items(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (kit_id, component_id),
+    CHECK (kit_id <> component_id)
+);
+
+-- Документы сборки и разборки комплектов с себестоимостью единицы комплекта
+CREATE TABLE IF NOT EXISTS kit_assemblies (
+    id SERIAL PRIMARY KEY,
+    assembly_number VARCHAR(32) NOT NULL UNIQUE DEFAULT 'KA-' || LPAD(nextval('kit_assembly_number_seq')::TEXT, 6, '0'),
+    kit_id INTEGER NOT NULL REFERENCES items(id) ON DELETE RESTRICT,
+    operation VARCHAR(20) NOT NULL CHECK (operation IN ('assemble', 'disassemble')),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost NUMERIC(14,4) NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Строки документа: израсходованные при сборке или полученные при разборке компоненты
+CREATE TABLE IF NOT EXISTS kit_assembly_lines (
+    id SERIAL PRIMARY KEY,
+    assembly_id INTEGER NOT NULL REFERENCES kit_assemblies(id) ON DELETE CASCADE,
+    component_id INTEGER NOT NULL REFERENCES items(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost NUMERIC(14,4) NOT NULL DEFAULT 0
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_kit_components_component_id ON kit_components (component_id);
+CREATE INDEX IF NOT EXISTS idx_kit_assemblies_kit_id ON kit_assemblies (kit_id);
+CREATE INDEX IF NOT EXISTS idx_kit_assembly_lines_assembly_id ON kit_assembly_lines (assembly_id);
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
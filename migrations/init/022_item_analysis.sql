@@ -0,0 +1,29 @@
+BEGIN;
+-- Результаты периодического анализа запаса: ABC по стоимости расхода, XYZ по вариативности спроса
+-- и давность последнего движения. Таблица пересчитывается целиком при каждом анализе.
+CREATE TABLE IF NOT EXISTS item_analysis (
+    item_id INTEGER PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    abc_class CHAR(1) NOT NULL CHECK (abc_class IN ('A', 'B', 'C')),
+    xyz_class CHAR(1) NOT NULL CHECK (xyz_class IN ('X', 'Y', 'Z')),
+    consumption_qty INTEGER NOT NULL DEFAULT 0,
+    consumption_value NUMERIC(18, 4) NOT NULL DEFAULT 0,
+    value_share NUMERIC(7, 4) NOT NULL DEFAULT 0,
+    demand_cv NUMERIC(10, 4),
+    on_hand INTEGER NOT NULL DEFAULT 0,
+    last_movement_at TIMESTAMP NOT NULL,
+    movement_status VARCHAR(10) CHECK (movement_status IN ('active', 'slow', 'dead')),
+    period_from DATE NOT NULL,
+    period_to DATE NOT NULL,
+    analyzed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индексы
+CREATE INDEX IF NOT EXISTS idx_item_analysis_classes ON item_analysis (abc_class, xyz_class);
+CREATE INDEX IF NOT EXISTS idx_item_analysis_movement_status ON item_analysis (movement_status);
+CREATE INDEX IF NOT EXISTS idx_cost_movements_issue ON cost_movements (created_at, item_id) WHERE movement_type = 'issue';
+
+-- Права доступа
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO warehouse_control_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO warehouse_control_user;
+
+COMMIT;
@@ -3,6 +3,174 @@ BEGIN;
 REVOKE ALL PRIVILEGES ON ALL TABLES IN SCHEMA public FROM warehouse_control_user;
 REVOKE ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public FROM warehouse_control_user;
 
+DROP INDEX IF EXISTS idx_cost_movements_issue;
+DROP INDEX IF EXISTS idx_item_analysis_movement_status;
+DROP INDEX IF EXISTS idx_item_analysis_classes;
+
+DROP TABLE IF EXISTS item_analysis;
+
+DROP INDEX IF EXISTS idx_kit_assembly_lines_assembly_id;
+DROP INDEX IF EXISTS idx_kit_assemblies_kit_id;
+DROP INDEX IF EXISTS idx_kit_components_component_id;
+
+DROP TABLE IF EXISTS kit_assembly_lines;
+DROP TABLE IF EXISTS kit_assemblies;
+DROP TABLE IF EXISTS kit_components;
+
+DROP SEQUENCE IF EXISTS kit_assembly_number_seq;
+
+ALTER TABLE IF EXISTS write_offs DROP COLUMN IF EXISTS currency;
+ALTER TABLE IF EXISTS purchase_receipts DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE IF EXISTS purchase_orders DROP COLUMN IF EXISTS currency;
+ALTER TABLE IF EXISTS suppliers DROP COLUMN IF EXISTS currency;
+
+DROP FUNCTION IF EXISTS currency_rate(CHAR(3), DATE);
+
+DROP INDEX IF EXISTS idx_exchange_rates_date;
+DROP INDEX IF EXISTS uq_currencies_base;
+
+DROP TABLE IF EXISTS exchange_rates;
+DROP TABLE IF EXISTS currencies;
+
+DROP INDEX IF EXISTS idx_cost_movements_created_at;
+DROP INDEX IF EXISTS idx_cost_movements_item_id;
+DROP INDEX IF EXISTS idx_cost_layers_open;
+
+DROP TABLE IF EXISTS cost_movements;
+DROP TABLE IF EXISTS cost_layers;
+DROP TABLE IF EXISTS valuation_settings;
+
+ALTER TABLE IF EXISTS write_off_lines DROP COLUMN IF EXISTS total_cost;
+ALTER TABLE IF EXISTS items DROP COLUMN IF EXISTS valuation_method;
+
+DROP INDEX IF EXISTS idx_change_requests_status;
+DROP INDEX IF EXISTS uq_change_requests_pending;
+
+DROP TABLE IF EXISTS change_requests;
+
+DROP INDEX IF EXISTS idx_write_off_evidence_write_off_id;
+DROP INDEX IF EXISTS idx_write_off_lines_item_id;
+DROP INDEX IF EXISTS idx_write_off_lines_write_off_id;
+DROP INDEX IF EXISTS idx_write_offs_status;
+
+DROP TABLE IF EXISTS write_off_evidence;
+DROP TABLE IF EXISTS write_off_lines;
+DROP TABLE IF EXISTS write_offs;
+
+DROP SEQUENCE IF EXISTS write_off_number_seq;
+
+DROP INDEX IF EXISTS idx_stock_status_changes_item_id;
+DROP INDEX IF EXISTS idx_item_stock_statuses_status;
+DROP INDEX IF EXISTS uq_item_stock_statuses;
+
+DROP TABLE IF EXISTS stock_status_changes;
+DROP TABLE IF EXISTS item_stock_statuses;
+
+DROP INDEX IF EXISTS idx_return_receipts_rma_id;
+DROP INDEX IF EXISTS idx_return_lines_order_line_id;
+DROP INDEX IF EXISTS idx_return_authorizations_status;
+DROP INDEX IF EXISTS idx_return_authorizations_order_id;
+
+DROP TABLE IF EXISTS return_receipts;
+DROP TABLE IF EXISTS return_lines;
+DROP TABLE IF EXISTS return_authorizations;
+
+DROP SEQUENCE IF EXISTS return_number_seq;
+
+DROP INDEX IF EXISTS idx_count_lines_item_id;
+DROP INDEX IF EXISTS idx_count_sessions_status;
+
+DROP TABLE IF EXISTS count_session_counters;
+DROP TABLE IF EXISTS count_lines;
+DROP TABLE IF EXISTS count_sessions;
+
+DROP SEQUENCE IF EXISTS count_session_number_seq;
+
+DROP INDEX IF EXISTS idx_outbound_order_events_order_id;
+DROP INDEX IF EXISTS idx_shipments_order_id;
+DROP INDEX IF EXISTS idx_reservations_outbound_line_id;
+DROP INDEX IF EXISTS idx_outbound_order_lines_item_id;
+DROP INDEX IF EXISTS idx_outbound_orders_status;
+
+DROP TABLE IF EXISTS outbound_order_events;
+DROP TABLE IF EXISTS shipment_lines;
+DROP TABLE IF EXISTS shipments;
+
+ALTER TABLE IF EXISTS reservations DROP COLUMN IF EXISTS outbound_line_id;
+
+DROP TABLE IF EXISTS outbound_order_lines;
+DROP TABLE IF EXISTS outbound_orders;
+
+DROP SEQUENCE IF EXISTS outbound_order_number_seq;
+
+ALTER TABLE IF EXISTS items DROP COLUMN IF EXISTS pick_location_id;
+
+DROP INDEX IF EXISTS idx_purchase_receipts_po_id;
+DROP INDEX IF EXISTS idx_purchase_order_lines_item_id;
+DROP INDEX IF EXISTS idx_purchase_orders_status;
+DROP INDEX IF EXISTS idx_purchase_orders_supplier_id;
+
+DROP TABLE IF EXISTS purchase_receipts;
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP TABLE IF EXISTS purchase_orders;
+
+DROP SEQUENCE IF EXISTS purchase_order_number_seq;
+
+DROP INDEX IF EXISTS idx_item_suppliers_supplier_id;
+DROP INDEX IF EXISTS uq_item_suppliers_preferred;
+DROP INDEX IF EXISTS uq_suppliers_name;
+
+DROP TABLE IF EXISTS item_suppliers;
+DROP TABLE IF EXISTS suppliers;
+
+DROP INDEX IF EXISTS idx_stock_alerts_status;
+DROP INDEX IF EXISTS uq_stock_alerts_active_item_location;
+DROP INDEX IF EXISTS uq_stock_levels_item_location;
+
+DROP TABLE IF EXISTS stock_alerts;
+DROP TABLE IF EXISTS stock_levels;
+
+DROP TABLE IF EXISTS item_units;
+
+ALTER TABLE IF EXISTS items DROP COLUMN IF EXISTS base_unit;
+
+DROP INDEX IF EXISTS idx_items_attributes;
+
+ALTER TABLE IF EXISTS items DROP COLUMN IF EXISTS attributes;
+
+DROP TABLE IF EXISTS attribute_definitions;
+
+DROP INDEX IF EXISTS idx_items_category_id;
+DROP INDEX IF EXISTS idx_categories_parent_id;
+DROP INDEX IF EXISTS uq_categories_parent_name;
+
+ALTER TABLE IF EXISTS items DROP COLUMN IF EXISTS category_id;
+DROP TABLE IF EXISTS categories;
+
+DROP TABLE IF EXISTS locations;
+
+DROP INDEX IF EXISTS idx_item_barcodes_item_id;
+
+DROP TABLE IF EXISTS item_barcodes;
+
+DROP INDEX IF EXISTS uq_items_sku;
+
+ALTER TABLE IF EXISTS items DROP COLUMN IF EXISTS sku;
+
+DROP INDEX IF EXISTS idx_item_serials_item_id_status;
+
+DROP TABLE IF EXISTS item_serials;
+
+DROP INDEX IF EXISTS idx_item_lots_item_id;
+DROP INDEX IF EXISTS idx_item_lots_expires_at;
+
+DROP TABLE IF EXISTS item_lots;
+
+DROP INDEX IF EXISTS idx_reservations_item_id;
+DROP INDEX IF EXISTS idx_reservations_active_expires_at;
+
+DROP TABLE IF EXISTS reservations;
+
 DROP INDEX IF EXISTS idx_items_history_item_id;
 DROP INDEX IF EXISTS idx_items_history_user_id;
 DROP INDEX IF EXISTS idx_items_history_changed_at;
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Классы ABC по доле в стоимости расхода.
+const (
+	ABCClassA = "A"
+	ABCClassB = "B"
+	ABCClassC = "C"
+)
+
+// Классы XYZ по вариативности месячного спроса.
+const (
+	XYZClassX = "X"
+	XYZClassY = "Y"
+	XYZClassZ = "Z"
+)
+
+// Статусы движения запаса по давности последнего движения.
+const (
+	MovementActive = "active"
+	MovementSlow   = "slow"
+	MovementDead   = "dead"
+)
+
+// ItemDemand - исходные данные анализа item: запас, последнее движение и расход по месяцам периода.
+type ItemDemand struct {
+	ItemID         int
+	ItemName       string
+	OnHand         int
+	CreatedAt      time.Time
+	LastMovementAt time.Time
+	Periods        []DemandPeriod
+}
+
+// DemandPeriod - расход item за месяц Month в базовых единицах и по себестоимости.
+type DemandPeriod struct {
+	Month    time.Time
+	Quantity int
+	Value    Money
+}
+
+// ItemAnalysis - результат анализа item за период.
+// ValueShare - доля item в стоимости расхода всех items в процентах.
+// DemandCV - коэффициент вариации месячного спроса, nil если расхода не было.
+// MovementStatus пустой для item без запаса.
+// PeriodFrom и PeriodTo - первый и последний день периода расхода включительно.
+type ItemAnalysis struct {
+	ItemID           int
+	ItemName         string
+	ABCClass         string
+	XYZClass         string
+	ConsumptionQty   int
+	ConsumptionValue Money
+	ValueShare       float64
+	DemandCV         *float64
+	OnHand           int
+	LastMovementAt   time.Time
+	MovementStatus   string
+	PeriodFrom       time.Time
+	PeriodTo         time.Time
+	AnalyzedAt       time.Time
+}
+
+// DaysSinceMovement - число полных дней от последнего движения до now.
+func (a ItemAnalysis) DaysSinceMovement(now time.Time) int {
+	return DaysSince(a.LastMovementAt, now)
+}
+
+// DaysSince - число полных дней от t до now, не меньше 0.
+func DaysSince(t, now time.Time) int {
+	return max(int(math.Floor(now.Sub(t).Hours()/24)), 0)
+}
+
+// AnalysisFilter - фильтр результатов анализа, пустые поля не ограничивают выборку.
+type AnalysisFilter struct {
+	ABCClass       string
+	XYZClass       string
+	MovementStatus string
+}
+
+// ABCReport - отчет ABC/XYZ: сводка по классам и items по последнему анализу.
+// AnalyzedAt nil, если анализ еще не выполнялся.
+type ABCReport struct {
+	AnalyzedAt *time.Time
+	PeriodFrom *time.Time
+	PeriodTo   *time.Time
+	Classes    []ABCClassSummary
+	Matrix     map[string]int
+	Items      []ItemAnalysis
+}
+
+// ABCClassSummary - число items класса ABC, их стоимость расхода и доля в процентах.
+type ABCClassSummary struct {
+	Class      string
+	Items      int
+	Value      Money
+	ValueShare float64
+}
+
+// Validate - проверка значений фильтра.
+func (f AnalysisFilter) Validate() error {
+	switch f.ABCClass {
+	case "", ABCClassA, ABCClassB, ABCClassC:
+	default:
+		return fmt.Errorf("некорректный класс ABC %q", f.ABCClass)
+	}
+	switch f.XYZClass {
+	case "", XYZClassX, XYZClassY, XYZClassZ:
+	default:
+		return fmt.Errorf("некорректный класс XYZ %q", f.XYZClass)
+	}
+	switch f.MovementStatus {
+	case "", MovementActive, MovementSlow, MovementDead:
+	default:
+		return fmt.Errorf("некорректный статус движения %q", f.MovementStatus)
+	}
+
+	return nil
+}
+
+// Match - проверка результата анализа на соответствие фильтру.
+func (f AnalysisFilter) Match(a ItemAnalysis) bool {
+	return (f.ABCClass == "" || a.ABCClass == f.ABCClass) &&
+		(f.XYZClass == "" || a.XYZClass == f.XYZClass) &&
+		(f.MovementStatus == "" || a.MovementStatus == f.MovementStatus)
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	AttrTypeString  = "string"
+	AttrTypeNumber  = "number"
+	AttrTypeBoolean = "boolean"
+	AttrTypeEnum    = "enum"
+)
+
+type AttributeDefinition struct {
+	ID            int
+	CategoryID    int
+	Code          string
+	Name          string
+	Type          string
+	Required      bool
+	AllowedValues []string
+	Unit          string
+	CreatedAt     time.Time
+}
@@ -0,0 +1,12 @@
+package models
+
+const (
+	SymbologyEAN13   = "ean13"
+	SymbologyUPCA    = "upca"
+	SymbologyCode128 = "code128"
+)
+
+type Barcode struct {
+	Code      string
+	Symbology string
+}
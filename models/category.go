@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Category struct {
+	ID         int
+	ParentID   *int
+	Name       string
+	ItemCount  int
+	TotalCount int
+	Children   []Category
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
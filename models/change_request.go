@@ -0,0 +1,77 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	ChangeRequestPending   = "pending"
+	ChangeRequestApproved  = "approved"
+	ChangeRequestRejected  = "rejected"
+	ChangeRequestCancelled = "cancelled"
+)
+
+// Операции, которые могут требовать согласования вторым пользователем.
+const (
+	OpItemDelete     = "item_delete"
+	OpItemAdjustment = "item_adjustment"
+	OpUserRole       = "user_role"
+)
+
+// ChangeRequest - запрос на согласование операции над объектом TargetID.
+// Payload - параметры операции в JSON, операция выполняется при согласовании.
+type ChangeRequest struct {
+	ID           int
+	Operation    string
+	TargetID     int
+	Payload      []byte
+	Summary      string
+	Status       string
+	RequestedBy  int
+	DecidedBy    *int
+	DecisionNote string
+	CreatedAt    time.Time
+	DecidedAt    *time.Time
+}
+
+// QuantityChange - параметры изменения остатка item, FromQuantity - остаток на момент запроса.
+type QuantityChange struct {
+	FromQuantity int
+	ToQuantity   int
+}
+
+// RoleChange - параметры смены роли пользователя, FromRole - роль на момент запроса.
+type RoleChange struct {
+	FromRole string
+	Role     string
+}
+
+// ApprovalPolicy - операции, требующие согласования, и порог изменения остатка в базовых единицах.
+type ApprovalPolicy struct {
+	Operations          []string
+	AdjustmentThreshold int
+}
+
+// Requires - требует ли операция согласования.
+func (p ApprovalPolicy) Requires(operation string) bool {
+	return slices.Contains(p.Operations, operation)
+}
+
+// RequiresAdjustment - требует ли согласования изменение остатка item с from на to.
+// Обнуление остатка согласуется всегда, остальные изменения - при превышении порога.
+func (p ApprovalPolicy) RequiresAdjustment(from, to int) bool {
+	if !p.Requires(OpItemAdjustment) || from == to {
+		return false
+	}
+	if to == 0 {
+		return true
+	}
+
+	delta := to - from
+	if delta < 0 {
+		delta = -delta
+	}
+
+	return delta > p.AdjustmentThreshold
+}
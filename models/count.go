@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+const (
+	CountOpen      = "open"
+	CountReview    = "review"
+	CountApproved  = "approved"
+	CountCancelled = "cancelled"
+)
+
+// CountSession - сессия инвентаризации. Без LocationID и CategoryID охватывает все несерийные items.
+type CountSession struct {
+	ID               int
+	Number           string
+	LocationID       *int
+	CategoryID       *int
+	Blind            bool
+	RecountThreshold int
+	Status           string
+	CreatedBy        int
+	ApprovedBy       *int
+	ApprovedAt       *time.Time
+	Lines            []CountLine
+	ItemsTotal       int
+	ItemsCounted     int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// HidesExpected - признак того, что ожидаемый остаток скрыт от счетчиков до завершения подсчета.
+func (s CountSession) HidesExpected() bool {
+	return s.Blind && s.Status == CountOpen
+}
+
+// CountLine - строка подсчета item в базовых единицах.
+// ExpectedQty включает запас в статусах HeldQty: счетчики считают весь запас item на полке.
+type CountLine struct {
+	ID              int
+	SessionID       int
+	ItemID          int
+	ItemName        string
+	ExpectedQty     int
+	HeldQty         int
+	FirstCountedQty *int
+	CountedQty      *int
+	Round           int
+	RecountRequired bool
+	CountedBy       *int
+	CountedAt       *time.Time
+}
+
+// Counted - признак того, что item посчитан и пересчет не ожидается.
+func (l CountLine) Counted() bool {
+	return l.CountedQty != nil && !l.RecountRequired
+}
+
+// Variance - расхождение подсчета с ожидаемым остатком, 0 для непосчитанной строки.
+func (l CountLine) Variance() int {
+	if l.CountedQty == nil {
+		return 0
+	}
+
+	return *l.CountedQty - l.ExpectedQty
+}
+
+// ExceedsThreshold - признак того, что расхождение больше threshold процентов от ожидаемого остатка.
+// При нулевом ожидаемом остатке любое расхождение превышает порог.
+func (l CountLine) ExceedsThreshold(threshold int) bool {
+	variance := l.Variance()
+	if variance < 0 {
+		variance = -variance
+	}
+
+	return variance*100 > threshold*l.ExpectedQty
+}
+
+// CountEntry - результат подсчета item.
+type CountEntry struct {
+	ItemID   int
+	Quantity int
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Currency - валюта, Base - базовая валюта стоимостного учета и отчетов.
+type Currency struct {
+	Code      string
+	Name      string
+	Base      bool
+	CreatedAt time.Time
+}
+
+// ExchangeRate - курс валюты, действующий с даты Date до следующего курса.
+// Rate - стоимость единицы валюты в базовой валюте.
+type ExchangeRate struct {
+	ID        int
+	Currency  string
+	Date      time.Time
+	Rate      Rate
+	CreatedBy *int
+	UpdatedAt time.Time
+}
+
+// ExchangeRateImportMaxSize - максимальный размер CSV файла курсов в байтах.
+const ExchangeRateImportMaxSize = 1 << 20
+
+// ExchangeRateFilter - фильтр списка курсов, пустые поля не ограничивают выборку.
+type ExchangeRateFilter struct {
+	Currency string
+	From     *time.Time
+	To       *time.Time
+}
+
+// ParseCurrencyCode - разбор трехбуквенного кода валюты ISO 4217, регистр не важен.
+func ParseCurrencyCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", fmt.Errorf("некорректный код валюты %q: нужно 3 латинские буквы", code)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("некорректный код валюты %q: нужно 3 латинские буквы", code)
+		}
+	}
+
+	return code, nil
+}
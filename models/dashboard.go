@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// Dashboard - сводные показатели склада.
+// Full - полный набор для admin и manager, для viewer стоимость запаса,
+// активность пользователей и последние изменения не заполняются.
+type Dashboard struct {
+	GeneratedAt   time.Time
+	Full          bool
+	SKUs          int
+	Units         int
+	Value         Money
+	StockOuts     int
+	LowStock      int
+	Today         DashboardFlow
+	TopMovers     []TopMover
+	ActiveUsers   []ActiveUser
+	RecentChanges []RecentChange
+}
+
+// DashboardFlow - поступление и расход за сегодня: единицы и число стоимостных движений.
+type DashboardFlow struct {
+	InboundQty        int
+	InboundMovements  int
+	OutboundQty       int
+	OutboundMovements int
+}
+
+// TopMover - item с наибольшим расходом за период.
+type TopMover struct {
+	ItemID   int
+	ItemName string
+	Quantity int
+}
+
+// ActiveUser - пользователь с наибольшим числом изменений items за период.
+type ActiveUser struct {
+	UserID   int
+	Username string
+	Changes  int
+}
+
+// RecentChange - запись истории изменений item с названием item и именем пользователя.
+type RecentChange struct {
+	ID        int
+	ItemID    int
+	ItemName  string
+	UserID    int
+	Username  string
+	Operation string
+	Details   *string
+	ChangedAt time.Time
+}
+
+// Restricted - копия показателей без данных, недоступных viewer.
+func (d Dashboard) Restricted() Dashboard {
+	d.Full = false
+	d.Value = 0
+	d.ActiveUsers = nil
+	d.RecentChanges = nil
+
+	return d
+}
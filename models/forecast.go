@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// Методы прогноза спроса.
+const (
+	ForecastSES             = "ses"
+	ForecastMovingAverage   = "moving_average"
+	ForecastSeasonalAverage = "seasonal_moving_average"
+)
+
+// Forecast - прогноз месячного спроса item и рекомендованные уровни запаса.
+// Method - метод с наименьшей средней абсолютной ошибкой на истории, MAE и RMSE - его ошибки.
+// Current - действующие уровни запаса на весь склад, nil если они не заданы.
+type Forecast struct {
+	ItemID         int
+	ItemName       string
+	Method         string
+	History        []DemandPeriod
+	Periods        []ForecastPeriod
+	MAE            float64
+	RMSE           float64
+	LeadTimeDays   int
+	ServiceLevel   float64
+	Recommendation StockRecommendation
+	Current        *StockLevel
+}
+
+// HasDemand - признак того, что за период истории был расход.
+func (f Forecast) HasDemand() bool {
+	for _, p := range f.History {
+		if p.Quantity > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ForecastPeriod - прогноз расхода за месяц Month в базовых единицах.
+type ForecastPeriod struct {
+	Month    time.Time
+	Quantity float64
+}
+
+// StockRecommendation - рекомендованные по прогнозу уровни запаса.
+// SafetyStock покрывает отклонение спроса за срок поставки с заданным уровнем сервиса,
+// ReorderPoint - спрос за срок поставки вместе со страховым запасом, MaxQty - точка заказа и месячный спрос.
+type StockRecommendation struct {
+	DailyDemand  float64
+	SafetyStock  int
+	ReorderPoint int
+	MaxQty       int
+}
+
+// StockLevel - рекомендация в виде уровней запаса item.
+func (r StockRecommendation) StockLevel(itemID int) StockLevel {
+	return StockLevel{
+		ItemID:       itemID,
+		MinQty:       r.SafetyStock,
+		ReorderPoint: r.ReorderPoint,
+		MaxQty:       r.MaxQty,
+	}
+}
@@ -3,10 +3,66 @@ package models
 import "time"
 
 type Item struct {
-	ID          int
-	Quantity    int
-	Name        string
-	Description string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           int
+	Quantity     int
+	Reserved     int
+	Held         map[string]int
+	BaseUnit     string
+	Units        []ItemUnit
+	Serialized   bool
+	SKU          string
+	Barcodes     []Barcode
+	CategoryID   *int
+	Attributes   map[string]any
+	Name         string
+	Description  string
+	UnitCost     *Money
+	CostCurrency string
+	Kit          *Kit
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	// ABCClass, XYZClass и MovementStatus - результаты последнего анализа запаса, пустые до первого анализа.
+	ABCClass       string
+	XYZClass       string
+	MovementStatus string
+	LastMovementAt *time.Time
+}
+
+// Available - доступный остаток: количество на складе за вычетом активных резервов.
+func (i Item) Available() int {
+	return i.Quantity - i.Reserved
+}
+
+// OnHand - весь запас item на складе: доступный остаток и запас в остальных статусах.
+func (i Item) OnHand() int {
+	total := i.Quantity
+	for _, quantity := range i.Held {
+		total += quantity
+	}
+
+	return total
+}
+
+// StatusQuantity - запас item в статусе, для available - количество на складе вместе с резервами.
+func (i Item) StatusQuantity(status string) int {
+	if status == StockAvailable {
+		return i.Quantity
+	}
+
+	return i.Held[status]
+}
+
+// ItemFilter - фильтр списка items, пустые поля не ограничивают выборку.
+type ItemFilter struct {
+	// CategoryID - категория, включая все ее подкатегории.
+	CategoryID *int
+	// Attributes - точные значения атрибутов в текстовом виде.
+	Attributes map[string]string
+	// StockStatus - только items с запасом в этом статусе.
+	StockStatus string
+	// ABCClass, XYZClass и MovementStatus - классы и статус движения по последнему анализу запаса.
+	ABCClass       string
+	XYZClass       string
+	MovementStatus string
 }
@@ -9,5 +9,6 @@ type ItemHistory struct {
 	Operation string
 	OldValue  *string
 	NewValue  *string
+	Details   *string
 	ChangedAt time.Time
 }
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Операции с комплектами.
+const (
+	KitAssemble    = "assemble"
+	KitDisassemble = "disassemble"
+)
+
+// Kit - состав комплекта: компоненты и их количество на один комплект.
+type Kit struct {
+	ItemID     int
+	Components []KitComponent
+}
+
+// AvailableToBuild - сколько комплектов можно собрать из доступного незарезервированного остатка компонентов.
+func (k Kit) AvailableToBuild() int {
+	if len(k.Components) == 0 {
+		return 0
+	}
+
+	build := -1
+	for _, c := range k.Components {
+		n := max(c.Available, 0) / c.Quantity
+		if build < 0 || n < build {
+			build = n
+		}
+	}
+
+	return build
+}
+
+// KitComponent - компонент комплекта, Quantity - количество на один комплект в базовых единицах item.
+// Available - доступный незарезервированный остаток компонента.
+type KitComponent struct {
+	ItemID    int
+	ItemName  string
+	BaseUnit  string
+	Quantity  int
+	Available int
+	UpdatedAt time.Time
+}
+
+// KitAssembly - документ сборки или разборки комплектов.
+// Сборка расходует компоненты и приходует комплекты, разборка - наоборот.
+// UnitCost - себестоимость одного комплекта в базовой валюте.
+type KitAssembly struct {
+	ID        int
+	Number    string
+	KitID     int
+	KitName   string
+	Operation string
+	Quantity  int
+	UnitCost  Money
+	Note      string
+	CreatedBy int
+	Lines     []KitAssemblyLine
+	CreatedAt time.Time
+}
+
+// KitAssemblyLine - компонент, израсходованный при сборке или полученный при разборке, в базовых единицах.
+type KitAssemblyLine struct {
+	ItemID   int
+	ItemName string
+	Quantity int
+	UnitCost Money
+}
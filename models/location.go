@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Location struct {
+	ID        int
+	Code      string
+	Name      string
+	CreatedAt time.Time
+}
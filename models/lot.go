@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type Lot struct {
+	ID             int
+	ItemID         int
+	LotNumber      string
+	ManufacturedAt *time.Time
+	ExpiresAt      *time.Time
+	Quantity       int
+	UnitCost       *Money
+	CostCurrency   string
+	CreatedAt      time.Time
+}
+
+// Expired - признак того, что срок годности партии истек к дате day.
+func (l Lot) Expired(day time.Time) bool {
+	return l.ExpiresAt != nil && l.ExpiresAt.Before(day)
+}
+
+// LotPick - количество, списываемое из конкретной партии.
+type LotPick struct {
+	LotID     int
+	LotNumber string
+	Quantity  int
+}
+
+type ExpiringLot struct {
+	Lot
+	ItemName string
+	DaysLeft int
+}
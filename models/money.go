@@ -0,0 +1,330 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MoneyScale - число долей в единице Money, точность 4 знака после запятой как у NUMERIC(14, 4).
+const MoneyScale = 10000
+
+// maxMoneyDigits - максимальное число цифр целой части суммы, чтобы она помещалась в int64.
+const maxMoneyDigits = 14
+
+// Money - денежная сумма в десятитысячных долях.
+// Суммы складываются и умножаются на количество без потери точности, в отличие от float64.
+type Money int64
+
+// ParseMoney - разбор десятичной записи суммы, например "12.5" или "-0.0001".
+func ParseMoney(s string) (Money, error) {
+	v, err := parseDecimal(s, 4, maxMoneyDigits)
+	if err != nil {
+		return 0, decimalError("некорректная сумма", s, err)
+	}
+
+	return Money(v), nil
+}
+
+// errBadDecimal - запись не является десятичным числом.
+var errBadDecimal = errors.New("не десятичное число")
+
+// parseDecimal - разбор десятичной записи в целое число долей с scale знаками после запятой,
+// целая часть не длиннее maxDigits цифр.
+func parseDecimal(s string, scale, maxDigits int) (int64, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, errBadDecimal
+	}
+	if len(intPart) > maxDigits {
+		return 0, errors.New("слишком большое значение")
+	}
+	if len(fracPart) > scale {
+		if strings.Trim(fracPart[scale:], "0") != "" {
+			return 0, fmt.Errorf("больше %d знаков после запятой", scale)
+		}
+		fracPart = fracPart[:scale]
+	}
+	fracPart += strings.Repeat("0", scale-len(fracPart))
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	units, err := strconv.ParseUint(intPart, 10, 64)
+	if err != nil {
+		return 0, errBadDecimal
+	}
+	frac, err := strconv.ParseUint(fracPart, 10, 64)
+	if err != nil {
+		return 0, errBadDecimal
+	}
+
+	v := int64(units)*pow10(scale) + int64(frac)
+	if negative {
+		v = -v
+	}
+
+	return v, nil
+}
+
+// decimalError - ошибка разбора десятичного значения s с сообщением msg.
+func decimalError(msg, s string, err error) error {
+	s = strings.TrimSpace(s)
+	if errors.Is(err, errBadDecimal) {
+		return fmt.Errorf("%s %q", msg, s)
+	}
+
+	return fmt.Errorf("%s %q: %w", msg, s, err)
+}
+
+// pow10 - 10 в степени n.
+func pow10(n int) int64 {
+	v := int64(1)
+	for range n {
+		v *= 10
+	}
+
+	return v
+}
+
+// Mul - стоимость quantity единиц по цене m.
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Div - цена единицы при стоимости m за quantity единиц, округление до 4 знаков половиной от нуля.
+func (m Money) Div(quantity int) Money {
+	if quantity == 0 {
+		return 0
+	}
+	q := Money(quantity)
+	if (m < 0) != (q < 0) {
+		return (m - q/2) / q
+	}
+
+	return (m + q/2) / q
+}
+
+// Share - доля part из whole от суммы m, округление до 4 знаков половиной от нуля.
+// Считается без промежуточного переполнения, например стоимость части запаса по средней цене.
+func (m Money) Share(part, whole int) Money {
+	if whole == 0 {
+		return 0
+	}
+
+	num := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(part)))
+	den := big.NewInt(int64(whole))
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+	half := new(big.Int).Quo(den, big.NewInt(2))
+	if num.Sign() < 0 {
+		num.Sub(num, half)
+	} else {
+		num.Add(num, half)
+	}
+
+	return Money(num.Quo(num, den).Int64())
+}
+
+// String - десятичная запись суммы, минимум 2 знака после запятой.
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	digits := fmt.Sprintf("%04d", v%MoneyScale)
+	frac := strings.TrimRight(digits, "0")
+	if len(frac) < 2 {
+		frac = digits[:2]
+	}
+
+	return fmt.Sprintf("%s%d.%s", sign, v/MoneyScale, frac)
+}
+
+// MarshalJSON - сумма в JSON как число в десятичной записи без двоичного округления.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON - разбор суммы из JSON числа или строки.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("некорректная сумма %s", s)
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+
+	return nil
+}
+
+// Scan - чтение суммы из NUMERIC столбца.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		parsed, err := ParseMoney(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case string:
+		parsed, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case int64:
+		*m = Money(v) * MoneyScale
+	case float64:
+		parsed, err := ParseMoney(strconv.FormatFloat(v, 'f', 4, 64))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	default:
+		return fmt.Errorf("неподдерживаемый тип суммы %T", src)
+	}
+
+	return nil
+}
+
+// Value - запись суммы в NUMERIC столбец десятичной строкой.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// RateScale - число долей в единице Rate, точность 8 знаков после запятой как у NUMERIC(18, 8).
+const RateScale = 100000000
+
+// maxRateDigits - максимальное число цифр целой части курса.
+const maxRateDigits = 10
+
+// Rate - курс валюты в стомиллионных долях: стоимость единицы валюты в базовой валюте.
+type Rate int64
+
+// ParseRate - разбор десятичной записи курса, например "12.4537".
+func ParseRate(s string) (Rate, error) {
+	v, err := parseDecimal(s, 8, maxRateDigits)
+	if err != nil {
+		return 0, decimalError("некорректный курс", s, err)
+	}
+
+	return Rate(v), nil
+}
+
+// Convert - сумма m в валюте с курсом rate, пересчитанная в базовую валюту.
+func (m Money) Convert(rate Rate) Money {
+	return m.Share(int(rate), RateScale)
+}
+
+// ConvertTo - сумма m в базовой валюте, пересчитанная в валюту с курсом rate.
+func (m Money) ConvertTo(rate Rate) Money {
+	return m.Share(RateScale, int(rate))
+}
+
+// String - десятичная запись курса без незначащих нулей, минимум 1 знак после запятой.
+func (r Rate) String() string {
+	sign := ""
+	v := int64(r)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	frac := strings.TrimRight(fmt.Sprintf("%08d", v%RateScale), "0")
+	if frac == "" {
+		frac = "0"
+	}
+
+	return fmt.Sprintf("%s%d.%s", sign, v/RateScale, frac)
+}
+
+// MarshalJSON - курс в JSON как число в десятичной записи.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON - разбор курса из JSON числа или строки.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("некорректный курс %s", s)
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+
+	return nil
+}
+
+// Scan - чтение курса из NUMERIC столбца.
+func (r *Rate) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return r.scanString(string(v))
+	case string:
+		return r.scanString(v)
+	case int64:
+		*r = Rate(v) * RateScale
+		return nil
+	default:
+		return fmt.Errorf("неподдерживаемый тип курса %T", src)
+	}
+}
+
+// scanString - чтение курса из десятичной строки, NUMERIC может вернуть больше 8 знаков после запятой.
+func (r *Rate) scanString(s string) error {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if len(fracPart) > 8 {
+		fracPart = fracPart[:8]
+	}
+
+	parsed, err := ParseRate(intPart + "." + fracPart)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+
+	return nil
+}
+
+// Value - запись курса в NUMERIC столбец десятичной строкой.
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
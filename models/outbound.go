@@ -0,0 +1,135 @@
+package models
+
+import "time"
+
+const (
+	OutboundNew                = "new"
+	OutboundPartiallyAllocated = "partially_allocated"
+	OutboundAllocated          = "allocated"
+	OutboundPicking            = "picking"
+	OutboundPacked             = "packed"
+	OutboundPartiallyShipped   = "partially_shipped"
+	OutboundShipped            = "shipped"
+	OutboundCancelled          = "cancelled"
+)
+
+type OutboundOrder struct {
+	ID          int
+	Number      string
+	CustomerRef string
+	ShipTo      string
+	Status      string
+	CreatedBy   int
+	Lines       []OutboundOrderLine
+	Shipments   []Shipment
+	History     []OutboundOrderEvent
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NextStatus - статус заказа, соответствующий количествам по строкам.
+// Статус picking сохраняется до начала упаковки, отмененный заказ не меняет статус.
+func (o OutboundOrder) NextStatus() string {
+	if o.Status == OutboundCancelled {
+		return OutboundCancelled
+	}
+
+	allShipped, allPacked, allAllocated := true, true, true
+	anyShipped, anyPacked, anyAllocated := false, false, false
+	for _, line := range o.Lines {
+		allShipped = allShipped && line.ShippedQty >= line.OrderedQty
+		allPacked = allPacked && line.PackedQty >= line.OrderedQty
+		allAllocated = allAllocated && line.AllocatedQty >= line.OrderedQty
+		anyShipped = anyShipped || line.ShippedQty > 0
+		anyPacked = anyPacked || line.PackedQty > 0
+		anyAllocated = anyAllocated || line.AllocatedQty > 0
+	}
+
+	switch {
+	case allShipped:
+		return OutboundShipped
+	case anyShipped:
+		return OutboundPartiallyShipped
+	case allPacked:
+		return OutboundPacked
+	case anyPacked || o.Status == OutboundPicking:
+		return OutboundPicking
+	case allAllocated:
+		return OutboundAllocated
+	case anyAllocated:
+		return OutboundPartiallyAllocated
+	}
+
+	return OutboundNew
+}
+
+// OutboundOrderLine - строка заказа на отгрузку, количества в базовых единицах item.
+type OutboundOrderLine struct {
+	ID           int
+	OrderID      int
+	ItemID       int
+	ItemName     string
+	OrderedQty   int
+	AllocatedQty int
+	PackedQty    int
+	ShippedQty   int
+}
+
+// Unallocated - количество, под которое еще нет резерва.
+func (l OutboundOrderLine) Unallocated() int {
+	return l.OrderedQty - l.AllocatedQty
+}
+
+// ToPick - зарезервированное количество, которое еще не упаковано.
+func (l OutboundOrderLine) ToPick() int {
+	return l.AllocatedQty - l.PackedQty
+}
+
+// ToShip - упакованное количество, которое еще не отгружено.
+func (l OutboundOrderLine) ToShip() int {
+	return l.PackedQty - l.ShippedQty
+}
+
+type Shipment struct {
+	ID          int
+	OrderID     int
+	TrackingRef string
+	ShippedBy   *int
+	Lines       []ShipmentLine
+	ShippedAt   time.Time
+}
+
+type ShipmentLine struct {
+	LineID   int
+	ItemID   int
+	Quantity int
+}
+
+type OutboundOrderEvent struct {
+	ID         int
+	OrderID    int
+	FromStatus string
+	ToStatus   string
+	UserID     *int
+	Note       string
+	CreatedAt  time.Time
+}
+
+// OutboundQuantity - количество item по заказу на отгрузку в базовых единицах.
+// Serials - серийные номера для отгрузки серийного item, их число должно совпадать с Quantity.
+type OutboundQuantity struct {
+	ItemID   int
+	Quantity int
+	Serials  []string
+}
+
+// PickListEntry - позиция листа подбора.
+// LocationID == nil - у item не задана ячейка подбора.
+type PickListEntry struct {
+	LocationID   *int
+	LocationCode string
+	ItemID       int
+	ItemName     string
+	SKU          string
+	Quantity     int
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+const (
+	PODraft             = "draft"
+	POSent              = "sent"
+	POPartiallyReceived = "partially_received"
+	POReceived          = "received"
+	POClosed            = "closed"
+)
+
+type PurchaseOrder struct {
+	ID           int
+	Number       string
+	SupplierID   int
+	SupplierName string
+	Status       string
+	Currency     string
+	ExpectedAt   *time.Time
+	Notes        string
+	CreatedBy    int
+	Lines        []PurchaseOrderLine
+	Receipts     []PurchaseReceipt
+	SentAt       *time.Time
+	ClosedAt     *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Total - стоимость заказа по заказанному количеству в валюте заказа.
+func (o PurchaseOrder) Total() Money {
+	var total Money
+	for _, line := range o.Lines {
+		total += line.UnitCost.Mul(line.OrderedQty)
+	}
+
+	return total
+}
+
+// PurchaseOrderLine - строка заказа на закупку, количества в базовых единицах item.
+type PurchaseOrderLine struct {
+	ID          int
+	OrderID     int
+	ItemID      int
+	ItemName    string
+	OrderedQty  int
+	ReceivedQty int
+	UnitCost    Money
+}
+
+// Outstanding - количество, которое еще ожидается от поставщика.
+func (l PurchaseOrderLine) Outstanding() int {
+	return max(l.OrderedQty-l.ReceivedQty, 0)
+}
+
+// PurchaseReceipt - приемка по строке заказа.
+// ExchangeRate - курс валюты заказа на дату приемки, по нему цена строки пересчитана в базовую валюту.
+type PurchaseReceipt struct {
+	ID           int
+	OrderID      int
+	LineID       int
+	ItemID       int
+	Quantity     int
+	ExchangeRate Rate
+	ReceivedBy   *int
+	Note         string
+	ReceivedAt   time.Time
+}
+
+// ReceiptLine - принимаемое количество item по заказу в базовых единицах.
+// Serials - серийные номера для серийного item, их число должно совпадать с Quantity.
+type ReceiptLine struct {
+	ItemID   int
+	Quantity int
+	Serials  []string
+}
+
+// PurchaseOrderFilter - фильтр списка заказов на закупку, пустые поля не ограничивают выборку.
+type PurchaseOrderFilter struct {
+	Status     string
+	SupplierID *int
+}
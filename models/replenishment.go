@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// ReplenishmentCandidate - item с уровнями запаса, количеством в открытых заказах на закупку
+// и условиями поставщика, у которого его следует заказывать.
+type ReplenishmentCandidate struct {
+	StockPosition
+	OnOrder      int
+	SupplierID   *int
+	SupplierName string
+	UnitCost     Money
+	Currency     string
+	MinOrderQty  int
+	LeadTimeDays int
+}
+
+// Projected - ожидаемый доступный остаток с учетом еще не принятых заказов на закупку.
+func (c ReplenishmentCandidate) Projected() int {
+	return c.Available() + c.OnOrder
+}
+
+// NeedsReorder - признак того, что ожидаемый остаток достиг точки заказа.
+func (c ReplenishmentCandidate) NeedsReorder() bool {
+	return c.Projected() <= c.ReorderPoint
+}
+
+// OrderQty - количество к заказу до максимального уровня, не меньше минимальной партии поставщика.
+func (c ReplenishmentCandidate) OrderQty() int {
+	return max(c.MaxQty-c.Projected(), c.MinOrderQty, 0)
+}
+
+// ReplenishmentLine - предложение заказать item.
+type ReplenishmentLine struct {
+	ItemID       int
+	ItemName     string
+	Available    int
+	OnOrder      int
+	ReorderPoint int
+	MaxQty       int
+	Quantity     int
+	MinOrderQty  int
+	UnitCost     Money
+}
+
+// ReplenishmentSuggestion - предложения к заказу у одного поставщика.
+// SupplierID равен 0 для items, у которых нет активного поставщика.
+type ReplenishmentSuggestion struct {
+	SupplierID   int
+	SupplierName string
+	Currency     string
+	LeadTimeDays int
+	ExpectedAt   time.Time
+	Lines        []ReplenishmentLine
+}
+
+// Total - сумма предложения по ценам поставщика в его валюте.
+func (s ReplenishmentSuggestion) Total() Money {
+	var total Money
+	for _, line := range s.Lines {
+		total += line.UnitCost.Mul(line.Quantity)
+	}
+
+	return total
+}
+
+// AcceptedSuggestion - принятая строка предложения, Quantity 0 оставляет предложенное количество.
+type AcceptedSuggestion struct {
+	SupplierID int
+	ItemID     int
+	Quantity   int
+}
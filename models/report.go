@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// ReportFilter - параметры отчета: период From - To включительно по дням,
+// ItemID и UserID nil не ограничивают выборку.
+type ReportFilter struct {
+	From   time.Time
+	To     time.Time
+	ItemID *int
+	UserID *int
+}
+
+// Before - граница периода, не входящая в него: начало дня после To.
+func (f ReportFilter) Before() time.Time {
+	return f.To.AddDate(0, 0, 1)
+}
+
+// MovementRecord - строка отчета по движениям: стоимостное движение item и остаток после него.
+// UserID и Username nil для движений без автора.
+type MovementRecord struct {
+	ID           int
+	ItemID       int
+	ItemName     string
+	Type         string
+	Quantity     int
+	UnitCost     Money
+	TotalCost    Money
+	Method       string
+	Source       string
+	BalanceQty   int
+	BalanceValue Money
+	UserID       *int
+	Username     *string
+	CreatedAt    time.Time
+}
+
+// ActivityRecord - строка отчета по активности пользователей: изменение item из истории.
+// Username пустой, если пользователь не найден.
+type ActivityRecord struct {
+	ID        int
+	UserID    int
+	Username  string
+	ItemID    int
+	ItemName  string
+	Operation string
+	Details   *string
+	OldValue  *string
+	NewValue  *string
+	ChangedAt time.Time
+}
+
+// BalanceRecord - строка оборотной ведомости: остаток item на начало периода,
+// поступление и расход за период и остаток на конец в количестве и по себестоимости.
+type BalanceRecord struct {
+	ItemID       int
+	ItemName     string
+	OpeningQty   int
+	OpeningValue Money
+	InQty        int
+	InValue      Money
+	OutQty       int
+	OutValue     Money
+	ClosingQty   int
+	ClosingValue Money
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	ReservationActive    = "active"
+	ReservationReleased  = "released"
+	ReservationConfirmed = "confirmed"
+	ReservationExpired   = "expired"
+)
+
+type Reservation struct {
+	ID        int
+	ItemID    int
+	UserID    int
+	Quantity  int
+	OrderRef  string
+	Status    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
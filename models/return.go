@@ -0,0 +1,120 @@
+package models
+
+import "time"
+
+const (
+	ReturnAuthorized        = "authorized"
+	ReturnPartiallyReceived = "partially_received"
+	ReturnReceived          = "received"
+	ReturnCancelled         = "cancelled"
+)
+
+// Причины возврата.
+const (
+	ReturnReasonDamaged        = "damaged"
+	ReturnReasonDefective      = "defective"
+	ReturnReasonWrongItem      = "wrong_item"
+	ReturnReasonNotAsDescribed = "not_as_described"
+	ReturnReasonUnwanted       = "unwanted"
+	ReturnReasonOther          = "other"
+)
+
+// Результаты осмотра возвращенного товара.
+// Restock возвращает товар в доступный остаток, refurbish и quarantine переводят его
+// в статусы запаса damaged и quarantine, scrap списывает.
+const (
+	DispositionRestock    = "restock"
+	DispositionRefurbish  = "refurbish"
+	DispositionQuarantine = "quarantine"
+	DispositionScrap      = "scrap"
+)
+
+// ReturnAuthorization - разрешение на возврат (RMA) по заказу на отгрузку.
+type ReturnAuthorization struct {
+	ID          int
+	Number      string
+	OrderID     int
+	OrderNumber string
+	Status      string
+	Note        string
+	CreatedBy   int
+	Lines       []ReturnLine
+	Receipts    []ReturnReceipt
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NextStatus - статус возврата, соответствующий принятым количествам, отмененный возврат не меняет статус.
+func (r ReturnAuthorization) NextStatus() string {
+	if r.Status == ReturnCancelled {
+		return ReturnCancelled
+	}
+
+	allReceived, anyReceived := true, false
+	for _, line := range r.Lines {
+		allReceived = allReceived && line.ReceivedQty >= line.AuthorizedQty
+		anyReceived = anyReceived || line.ReceivedQty > 0
+	}
+
+	switch {
+	case allReceived:
+		return ReturnReceived
+	case anyReceived:
+		return ReturnPartiallyReceived
+	}
+
+	return ReturnAuthorized
+}
+
+// ReturnLine - строка возврата, количества в базовых единицах item.
+type ReturnLine struct {
+	ID            int
+	RMAID         int
+	OrderLineID   int
+	ItemID        int
+	ItemName      string
+	Reason        string
+	AuthorizedQty int
+	ReceivedQty   int
+}
+
+// Outstanding - разрешенное к возврату количество, которое еще не принято.
+func (l ReturnLine) Outstanding() int {
+	return l.AuthorizedQty - l.ReceivedQty
+}
+
+// ReturnReceipt - приемка возвращенного item с результатом осмотра.
+type ReturnReceipt struct {
+	ID          int
+	RMAID       int
+	LineID      int
+	ItemID      int
+	Quantity    int
+	Disposition string
+	Serials     []string
+	Note        string
+	ReceivedBy  *int
+	ReceivedAt  time.Time
+}
+
+// ReturnInspection - результат осмотра принимаемого item в базовых единицах.
+// Serials - серийные номера возвращенного серийного item, их число должно совпадать с Quantity.
+type ReturnInspection struct {
+	ItemID      int
+	Quantity    int
+	Disposition string
+	Serials     []string
+	Note        string
+}
+
+// ReturnReasonStat - статистика возвратов по причине.
+type ReturnReasonStat struct {
+	Reason        string
+	Returns       int
+	AuthorizedQty int
+	ReceivedQty   int
+	Restocked     int
+	Refurbished   int
+	Quarantined   int
+	Scrapped      int
+}
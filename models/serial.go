@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	SerialInStock  = "in_stock"
+	SerialReserved = "reserved"
+	SerialShipped  = "shipped"
+	SerialReturned = "returned"
+	SerialScrapped = "scrapped"
+)
+
+type Serial struct {
+	ID           int
+	ItemID       int
+	SerialNumber string
+	Status       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const (
+	AlertOpen         = "open"
+	AlertAcknowledged = "acknowledged"
+	AlertResolved     = "resolved"
+)
+
+// StockLevel - уровни запаса item в базовых единицах.
+// LocationID == nil - уровни на весь склад, иначе - на ячейку.
+type StockLevel struct {
+	ItemID       int
+	LocationID   *int
+	MinQty       int
+	ReorderPoint int
+	MaxQty       int
+	UpdatedAt    time.Time
+}
+
+// StockPosition - текущий остаток item вместе с его уровнями запаса.
+// Для уровней ячейки Quantity и Reserved - доступный запас и резервы в ней:
+// доступный запас хранится в ячейке подбора item, в остальных ячейках он нулевой.
+type StockPosition struct {
+	StockLevel
+	LocationCode string
+	ItemName     string
+	Quantity     int
+	Reserved     int
+}
+
+// Available - доступный остаток: количество на складе за вычетом активных резервов.
+func (p StockPosition) Available() int {
+	return p.Quantity - p.Reserved
+}
+
+// BelowReorderPoint - признак того, что доступный остаток достиг точки заказа.
+func (p StockPosition) BelowReorderPoint() bool {
+	return p.Available() <= p.ReorderPoint
+}
+
+// SuggestedOrder - количество для пополнения до максимального уровня.
+func (p StockPosition) SuggestedOrder() int {
+	return max(p.MaxQty-p.Available(), 0)
+}
+
+// StockAlert - оповещение о пересечении точки заказа item на складе или в ячейке LocationID.
+type StockAlert struct {
+	ID             int
+	ItemID         int
+	ItemName       string
+	LocationID     *int
+	LocationCode   string
+	Available      int
+	ReorderPoint   int
+	Status         string
+	AcknowledgedBy *int
+	AcknowledgedAt *time.Time
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Статусы запаса. Доступный запас - это Item.Quantity, остальные статусы держат запас вне него.
+const (
+	StockAvailable  = "available"
+	StockQuarantine = "quarantine"
+	StockDamaged    = "damaged"
+	StockQCHold     = "qc_hold"
+	StockBlocked    = "blocked"
+)
+
+// StockStatusQty - запас item в статусе вне доступного остатка, LocationID == nil - без ячейки.
+type StockStatusQty struct {
+	ItemID       int
+	LocationID   *int
+	LocationCode string
+	Status       string
+	Quantity     int
+	UpdatedAt    time.Time
+}
+
+// StockStatusChange - перевод запаса item между статусами.
+// LocationID относится к стороне вне доступного остатка, пустой FromStatus - поступление, например по возврату,
+// пустой ToStatus - списание.
+type StockStatusChange struct {
+	ID         int
+	ItemID     int
+	LocationID *int
+	FromStatus string
+	ToStatus   string
+	Quantity   int
+	Reason     string
+	UserID     *int
+	CreatedAt  time.Time
+}
+
+// ItemStock - разбивка запаса item по статусам и журнал их смены.
+type ItemStock struct {
+	ItemID    int
+	Available int
+	Held      []StockStatusQty
+	Changes   []StockStatusChange
+}
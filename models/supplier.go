@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	SupplierActive   = "active"
+	SupplierInactive = "inactive"
+	SupplierBlocked  = "blocked"
+)
+
+type Supplier struct {
+	ID           int
+	Name         string
+	ContactName  string
+	Email        string
+	Phone        string
+	Address      string
+	LeadTimeDays int
+	Status       string
+	Currency     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ItemSupplier - связь item с поставщиком.
+// UnitCost - цена закупки за базовую единицу item в валюте поставщика Currency, MinOrderQty - минимальная партия в базовых единицах.
+type ItemSupplier struct {
+	ItemID       int
+	ItemName     string
+	SupplierID   int
+	SupplierName string
+	SupplierSKU  string
+	UnitCost     Money
+	Currency     string
+	MinOrderQty  int
+	Preferred    bool
+	LeadTimeDays int
+	UpdatedAt    time.Time
+}
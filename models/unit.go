@@ -0,0 +1,13 @@
+package models
+
+// DefaultBaseUnit - базовая единица учета item по умолчанию.
+const DefaultBaseUnit = "each"
+
+// ItemUnit - уровень упаковки item.
+// Factor - количество базовых единиц в одной единице Code.
+// Of - единица, в которой задан Factor при описании, пустая - базовая.
+type ItemUnit struct {
+	Code   string
+	Factor float64
+	Of     string
+}
@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+// Методы оценки запасов.
+const (
+	ValuationFIFO = "fifo"
+	ValuationLIFO = "lifo"
+	ValuationWAC  = "wac"
+)
+
+// Типы движений стоимостного журнала.
+const (
+	CostReceipt = "receipt"
+	CostIssue   = "issue"
+)
+
+// CostLayer - слой себестоимости item: количество одного прихода по одной цене.
+// Расход по FIFO уменьшает самые старые слои, по LIFO - самые новые.
+type CostLayer struct {
+	ID           int
+	ItemID       int
+	ReceivedQty  int
+	RemainingQty int
+	UnitCost     Money
+	Source       string
+	ReceivedAt   time.Time
+}
+
+// CostMovement - движение стоимостного журнала item.
+// Для расхода TotalCost - себестоимость списанного количества (COGS).
+// BalanceQty и BalanceValue - количество и стоимость запаса после движения.
+type CostMovement struct {
+	ID           int
+	ItemID       int
+	Type         string
+	Quantity     int
+	UnitCost     Money
+	TotalCost    Money
+	Method       string
+	Source       string
+	BalanceQty   int
+	BalanceValue Money
+	CreatedBy    *int
+	CreatedAt    time.Time
+}
+
+// ItemCost - стоимостной учет item: метод оценки, открытые слои и журнал движений.
+// ItemMethod пустой, если item оценивается методом по умолчанию.
+// Суммы указаны в базовой валюте Currency.
+type ItemCost struct {
+	ItemID       int
+	Currency     string
+	Method       string
+	ItemMethod   string
+	BalanceQty   int
+	BalanceValue Money
+	Layers       []CostLayer
+	Movements    []CostMovement
+}
+
+// AvgCost - средняя себестоимость единицы запаса.
+func (c ItemCost) AvgCost() Money {
+	return c.BalanceValue.Div(c.BalanceQty)
+}
+
+// ItemValuation - количество и стоимость запаса item на дату.
+type ItemValuation struct {
+	ItemID       int
+	ItemName     string
+	CategoryID   *int
+	CategoryName string
+	Quantity     int
+	Value        Money
+}
+
+// CategoryValuation - стоимость запаса категории, CategoryID == nil - товары без категории.
+type CategoryValuation struct {
+	CategoryID   *int
+	CategoryName string
+	Items        int
+	Quantity     int
+	Value        Money
+}
+
+// ValuationReport - стоимость запаса на дату AsOf всего и по категориям в валюте Currency.
+// Rate - курс валюты отчета на дату AsOf, по нему пересчитаны суммы из базовой валюты.
+type ValuationReport struct {
+	AsOf       time.Time
+	Currency   string
+	Rate       Rate
+	Items      int
+	Quantity   int
+	Value      Money
+	Categories []CategoryValuation
+}
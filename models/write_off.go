@@ -0,0 +1,94 @@
+package models
+
+import "time"
+
+const (
+	WriteOffPending  = "pending"
+	WriteOffPosted   = "posted"
+	WriteOffRejected = "rejected"
+)
+
+// Причины списания.
+const (
+	WriteOffDamaged = "damaged"
+	WriteOffExpired = "expired"
+	WriteOffTheft   = "theft"
+	WriteOffLost    = "lost"
+)
+
+// WriteOff - документ списания запаса из доступного остатка или статуса запаса SourceStatus.
+// Остаток уменьшается только при проведении, списание выше порога ждет согласования.
+// Себестоимость строк указана в базовой валюте Currency: до проведения - оценка по слоям себестоимости
+// на момент оформления, после проведения - фактическая себестоимость расхода.
+type WriteOff struct {
+	ID           int
+	Number       string
+	Reason       string
+	SourceStatus string
+	Note         string
+	Status       string
+	Currency     string
+	CreatedBy    int
+	DecidedBy    *int
+	DecisionNote string
+	Lines        []WriteOffLine
+	Evidence     []WriteOffEvidence
+	DecidedAt    *time.Time
+	PostedAt     *time.Time
+	CreatedAt    time.Time
+}
+
+// TotalQty - списываемое количество по всем строкам в базовых единицах.
+func (w WriteOff) TotalQty() int {
+	var total int
+	for _, line := range w.Lines {
+		total += line.Quantity
+	}
+
+	return total
+}
+
+// TotalValue - стоимость списания по себестоимости строк.
+func (w WriteOff) TotalValue() Money {
+	var total Money
+	for _, line := range w.Lines {
+		total += line.TotalCost
+	}
+
+	return total
+}
+
+// WriteOffLine - строка списания в базовых единицах item.
+// LocationID относится к запасу в статусе SourceStatus, для доступного остатка не указывается.
+type WriteOffLine struct {
+	ID         int
+	WriteOffID int
+	ItemID     int
+	ItemName   string
+	LocationID *int
+	Quantity   int
+	UnitCost   Money
+	TotalCost  Money
+	Serials    []string
+}
+
+// SetCost - себестоимость строки total и цена единицы по ней.
+func (l *WriteOffLine) SetCost(total Money) {
+	l.TotalCost = total
+	l.UnitCost = total.Div(l.Quantity)
+}
+
+// WriteOffEvidenceMaxSize - максимальный размер подтверждающего файла в байтах.
+const WriteOffEvidenceMaxSize = 5 << 20
+
+// WriteOffEvidence - подтверждающий файл списания, Data заполняется только при скачивании.
+type WriteOffEvidence struct {
+	ID          int
+	WriteOffID  int
+	FileName    string
+	ContentType string
+	Size        int
+	Data        []byte
+	UploadedBy  *int
+	CreatedAt   time.Time
+}
@@ -1,6 +1,7 @@
 let currentToken = '';
 let currentUser = '';
 let currentRole = '';
+let loadedItems = {};
 
 // Проверить токен при загрузке
 async function validateToken() {
@@ -160,16 +161,20 @@ function displayItems(items) {
     
     // Заголовки
     const header = table.insertRow();
-    header.innerHTML = '<th>ID</th><th>Название</th><th>Описание</th><th>Количество</th><th>Создан</th><th>Обновлен</th><th>Действия</th>';
+    header.innerHTML = '<th>ID</th><th>SKU</th><th>Название</th><th>Описание</th><th>Количество</th><th>В резерве</th><th>Доступно</th><th>Создан</th><th>Обновлен</th><th>Действия</th>';
     
     // Строки товаров
     items.forEach(item => {
+        loadedItems[item.id] = item;
         const row = table.insertRow();
         row.innerHTML = `
             <td>${item.id}</td>
+            <td>${item.sku || ''}</td>
             <td>${item.name}</td>
             <td>${item.description || ''}</td>
             <td>${item.quantity}</td>
+            <td>${item.reserved}</td>
+            <td>${item.available}</td>
             <td>${new Date(item.created_at).toLocaleString('ru-RU')}</td>
             <td>${new Date(item.updated_at).toLocaleString('ru-RU')}</td>
             <td>
@@ -177,7 +182,7 @@ function displayItems(items) {
                     <button onclick="showHistory(${item.id}, '${item.name}')">История</button>
                 ` : ''}
                 ${currentRole === 'admin' || currentRole === 'manager' ? `
-                    <button onclick="editItem(${item.id}, '${item.name}', '${item.description || ''}', ${item.quantity})">Изменить</button>
+                    <button onclick="editItem(${item.id}, '${item.name}', '${item.description || ''}', ${item.quantity}, ${item.serialized}, '${item.sku || ''}')">Изменить</button>
                 ` : ''}
                 ${currentRole === 'admin' ? `
                     <button onclick="deleteItem(${item.id})">Удалить</button>
@@ -228,7 +233,7 @@ async function addItem() {
 }
 
 // Редактировать товар
-async function editItem(id, currentName, currentDescription, currentQuantity) {
+async function editItem(id, currentName, currentDescription, currentQuantity, serialized, currentSku) {
     const newName = prompt('Название:', currentName);
     if (newName === null) return;
     
@@ -244,6 +249,12 @@ async function editItem(id, currentName, currentDescription, currentQuantity) {
         return;
     }
 
+    const sku = prompt('SKU:', currentSku);
+    if (sku === null) return;
+    const barcodes = loadedItems[id].barcodes || [];
+    const category_id = loadedItems[id].category_id;
+    const attributes = loadedItems[id].attributes || {};
+
     try {
         const response = await fetch(`/items/${id}`, {
             method: 'PUT',
@@ -251,10 +262,13 @@ async function editItem(id, currentName, currentDescription, currentQuantity) {
                 'Content-Type': 'application/json',
                 'Authorization': `Bearer ${currentToken}`
             },
-            body: JSON.stringify({ name: newName, description: newDescription, quantity })
+            body: JSON.stringify({ name: newName, description: newDescription, quantity, serialized, sku, barcodes, category_id, attributes })
         });
 
-        if (response.ok) {
+        if (response.status === 202) {
+            const data = await response.json();
+            alert(`${data.message}: запрос #${data.change_request_id}`);
+        } else if (response.ok) {
             loadItems();
         } else {
             const error = await response.json();
@@ -277,7 +291,10 @@ async function deleteItem(id) {
             }
         });
 
-        if (response.ok) {
+        if (response.status === 202) {
+            const data = await response.json();
+            alert(`${data.message}: запрос #${data.change_request_id}`);
+        } else if (response.ok) {
             loadItems();
         } else {
             const error = await response.json();